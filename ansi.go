@@ -0,0 +1,66 @@
+package webflow
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ParseANSI strips ANSI escape sequences from a line of subprocess output and
+// maps its SGR foreground color to the closest LogStyle: red to LogError,
+// yellow to LogWarning, green to LogSuccess, and bright black or faint to LogDim.
+// The first mapped color in the line wins; lines without one are LogNormal.
+//
+// LogWriter.WriteLine applies this automatically, so tool output can be piped
+// into a log view unchanged.
+func ParseANSI(line string) (string, LogStyle) {
+	if !strings.Contains(line, "\x1b") {
+		return line, LogNormal
+	}
+
+	var buf strings.Builder
+	style := LogNormal
+	for i := 0; i < len(line); i++ {
+		if line[i] != '\x1b' {
+			buf.WriteByte(line[i])
+			continue
+		}
+		if i+1 >= len(line) || line[i+1] != '[' {
+			// Lone ESC or non-CSI sequence: drop ESC and the following byte
+			i++
+			continue
+		}
+
+		// CSI sequence: parameters up to a final byte in 0x40-0x7E
+		j := i + 2
+		for j < len(line) && (line[j] < 0x40 || line[j] > 0x7E) {
+			j++
+		}
+		if j < len(line) && line[j] == 'm' && style == LogNormal {
+			style = sgrStyle(line[i+2 : j])
+		}
+		i = j
+	}
+	return buf.String(), style
+}
+
+// sgrStyle maps the parameters of an SGR sequence (e.g. "1;31") to a LogStyle.
+func sgrStyle(params string) LogStyle {
+	style := LogNormal
+	for _, p := range strings.Split(params, ";") {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			continue
+		}
+		switch n {
+		case 31, 91:
+			return LogError
+		case 33, 93:
+			return LogWarning
+		case 32, 92:
+			return LogSuccess
+		case 2, 90:
+			style = LogDim
+		}
+	}
+	return style
+}
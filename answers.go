@@ -0,0 +1,92 @@
+package webflow
+
+import "maps"
+
+// PageAnswer is a response supplied for a page without showing it
+// (see WithAnswers).
+type PageAnswer struct {
+	Button string         // Button to click (default: Next)
+	Data   map[string]any // Form values and selections, merged over the page defaults
+	Close  bool           // Close the window instead, which aborts most wizards
+}
+
+// WithAnswers answers pages from fn instead of waiting for the user, for
+// unattended installs. fn receives the page ID (see WithPageID) and the page;
+// returning false shows the page as usual. Answered pages are never displayed.
+// Progress and log pages don't wait for the user and run as usual.
+//
+// Answer data uses the same keys the page would send: field IDs for forms,
+// "_selected_index" for ShowChoice and ShowMenu, "_selected_indices" for
+// ShowMultiChoice, with numbers as float64 as they decode from JSON. Fields
+// the answer leaves out keep their defaults.
+// installer.LoadAnswers builds fn from an answers file.
+func WithAnswers(fn func(id string, page Page) (PageAnswer, bool)) Option {
+	return func(c *Config) {
+		c.Answers = fn
+	}
+}
+
+// answerPage returns the configured answer for a page as if the user had
+// clicked it, or false if the page should be shown.
+func (f *Flow) answerPage(page Page) (messageResponse, bool) {
+	if f.config.Answers == nil {
+		return messageResponse{}, false
+	}
+	answer, ok := f.config.Answers(pageID(page), page)
+	if !ok {
+		return messageResponse{}, false
+	}
+
+	var msg messageResponse
+	if answer.Close {
+		f.closed.Store(true)
+		msg = messageResponse{Type: "window_close", Button: ButtonClose}
+	} else {
+		data := pageDefaults(page)
+		maps.Copy(data, answer.Data)
+		button := answer.Button
+		if button == "" {
+			button = ButtonNext
+		}
+		msg = messageResponse{Type: "button_click", Button: button, Data: data}
+	}
+	f.recordPage(page, msg)
+	return msg, true
+}
+
+// pageDefaults returns the data a page sends when the user accepts it
+// unchanged. Numbers are float64, as they are when decoded from the page.
+func pageDefaults(page Page) map[string]any {
+	data := make(map[string]any)
+	switch c := page.Content.(type) {
+	case []FormField:
+		for _, field := range c {
+			switch field.Type {
+			case FieldCheckbox:
+				checked, _ := field.Default.(bool)
+				data[field.ID] = checked
+			case FieldInfo:
+			default:
+				if field.Default != nil {
+					data[field.ID] = field.Default
+				} else if field.Type == FieldSelect && len(field.Options) > 0 {
+					data[field.ID] = field.Options[0]
+				} else {
+					data[field.ID] = ""
+				}
+			}
+		}
+	case []Choice:
+		if len(c) > 0 {
+			data["_selected_index"] = float64(0)
+			data["_selected_choice"] = choiceValue(c[0])
+		}
+	case MultiChoice:
+		indices := make([]any, len(c.Selected))
+		for i, idx := range c.Selected {
+			indices[i] = float64(idx)
+		}
+		data["_selected_indices"] = indices
+	}
+	return data
+}
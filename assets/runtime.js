@@ -50,6 +50,27 @@
                 window.saveReviewContent();
                 return;
             }
+            if (buttonId === 'log_copy') {
+                window.copyLogContent();
+                return;
+            }
+            if (buttonId === 'log_save') {
+                sendMessage('log_save', {
+                    data: { content: logText() }
+                });
+                return;
+            }
+
+            // Buttons with a Go callback stay on the page while it runs
+            if (button.hasAttribute('data-action')) {
+                if (button.disabled) return;
+                window.setButtonLoading(buttonId, true);
+                sendMessage('button_action', {
+                    button: buttonId,
+                    data: collectFormData()
+                });
+                return;
+            }
 
             // Collect form data if present
             var formData = collectFormData();
@@ -61,6 +82,144 @@
         }
     });
 
+    // Button loading state and badges, set from Go
+    function findButton(id) {
+        return document.querySelector('[data-button="' + CSS.escape(id) + '"]');
+    }
+
+    window.setButtonLoading = function(id, loading) {
+        var button = findButton(id);
+        if (!button) return;
+        var spinner = button.querySelector('.btn-spinner');
+        if (loading) {
+            if (!button.classList.contains('btn-loading')) {
+                button.setAttribute('data-was-disabled', button.disabled ? 'true' : 'false');
+            }
+            button.classList.add('btn-loading', 'btn-disabled');
+            button.disabled = true;
+            button.setAttribute('aria-busy', 'true');
+            if (!spinner) {
+                spinner = document.createElement('span');
+                spinner.className = 'btn-spinner';
+                spinner.setAttribute('aria-hidden', 'true');
+                button.insertBefore(spinner, button.firstChild);
+            }
+        } else {
+            button.classList.remove('btn-loading');
+            button.removeAttribute('aria-busy');
+            if (spinner) spinner.remove();
+            if (button.getAttribute('data-was-disabled') !== 'true') {
+                button.classList.remove('btn-disabled');
+                button.disabled = false;
+            }
+            button.removeAttribute('data-was-disabled');
+        }
+    };
+
+    window.setButtonBadge = function(id, badge) {
+        var button = findButton(id);
+        if (!button) return;
+        var el = button.querySelector('.btn-badge');
+        if (!badge) {
+            if (el) el.remove();
+            return;
+        }
+        if (!el) {
+            el = document.createElement('span');
+            el.className = 'btn-badge';
+            button.appendChild(el);
+        }
+        el.textContent = badge;
+    };
+
+    // Field updates from FormField.OnSuffix callbacks (see FormAction in Go)
+    window.setFieldValue = function(id, value) {
+        var input = document.getElementById(id);
+        if (!input) return;
+        if (input.type === 'checkbox') {
+            input.checked = !!value;
+        } else {
+            input.value = value == null ? '' : String(value);
+        }
+    };
+
+    window.showFieldAlert = function(id, type, message, iconSvg) {
+        var input = document.getElementById(id);
+        var group = input && input.closest('.form-group, .form-group-inline');
+        if (!group) return;
+        window.clearFieldAlert(id);
+        var alert = document.createElement('div');
+        alert.className = 'summary-alert summary-alert-' + type + ' form-field-info form-action-alert';
+        alert.setAttribute('data-for', id);
+        alert.setAttribute('role', type === 'error' ? 'alert' : 'status');
+        var icon = document.createElement('span');
+        icon.className = 'summary-alert-icon';
+        icon.innerHTML = iconSvg;
+        var text = document.createElement('span');
+        text.className = 'summary-alert-text';
+        text.textContent = message;
+        alert.appendChild(icon);
+        alert.appendChild(text);
+        group.insertAdjacentElement('afterend', alert);
+    };
+
+    window.clearFieldAlert = function(id) {
+        document.querySelectorAll('.form-action-alert[data-for="' + CSS.escape(id) + '"]').forEach(function(el) {
+            el.remove();
+        });
+    };
+
+    function isTextInput(el) {
+        return el.tagName === 'INPUT' && ['text', 'password', 'email', 'url'].indexOf(el.type) >= 0;
+    }
+
+    // Live field changes for pages with WithOnFieldChange: text fields report
+    // after a pause in typing, other inputs immediately
+    var fieldChangeTimers = {};
+    var fieldChangeSent = {};
+
+    function sendFieldChange(target) {
+        if (!document.documentElement.hasAttribute('data-field-change')) return;
+        if (!target.closest('.flow-content')) return;
+        var field = target.id;
+        if (target.closest('.choice-list, .choice-list-multi')) {
+            field = '_choice';
+        }
+        if (!field) return;
+        var isText = target.tagName === 'TEXTAREA' || isTextInput(target);
+        if (isText) {
+            clearTimeout(fieldChangeTimers[field]);
+            if (fieldChangeSent[field] === target.value) return;
+            fieldChangeSent[field] = target.value;
+        }
+        sendMessage('field_change', { button: field, data: collectFormData() });
+    }
+
+    document.addEventListener('input', function(e) {
+        var target = e.target;
+        if (target.tagName !== 'TEXTAREA' && !isTextInput(target)) return;
+        clearTimeout(fieldChangeTimers[target.id]);
+        fieldChangeTimers[target.id] = setTimeout(function() {
+            sendFieldChange(target);
+        }, 300);
+    });
+
+    // Also covers text fields on blur and values set by the Browse button
+    document.addEventListener('change', function(e) {
+        sendFieldChange(e.target);
+    });
+
+    // Links never navigate the webview; Go opens them in the system browser
+    document.addEventListener('click', function(e) {
+        const link = e.target.closest('a[href]');
+        if (link) {
+            e.preventDefault();
+            sendMessage('open_link', {
+                data: { url: link.getAttribute('href') }
+            });
+        }
+    });
+
     // Handle menu item clicks
     document.addEventListener('click', function(e) {
         const menuItem = e.target.closest('.menu-item');
@@ -81,8 +240,8 @@
     function collectFormData() {
         const data = {};
 
-        // Text inputs, password inputs, textareas
-        document.querySelectorAll('input[type="text"], input[type="password"], textarea').forEach(function(input) {
+        // Text inputs, password inputs, sliders, textareas
+        document.querySelectorAll('input[type="text"], input[type="password"], input[type="email"], input[type="url"], input[type="range"], textarea').forEach(function(input) {
             if (input.id) {
                 data[input.id] = input.value;
             }
@@ -102,6 +261,12 @@
             }
         });
 
+        // Selected settings tab, so the page reopens on it
+        const settingsTab = document.querySelector('.settings-tab[aria-selected="true"]');
+        if (settingsTab) {
+            data['_settings_tab'] = parseInt(settingsTab.getAttribute('data-tab'), 10);
+        }
+
         // Selected radio (single choice)
         const selectedRadio = document.querySelector('.choice-list input[type="radio"]:checked');
         if (selectedRadio) {
@@ -125,18 +290,266 @@
         return data;
     }
 
+    // Screen reader announcements through the live regions at the end of the
+    // page. Progress can change many times a second, far faster than speech,
+    // so polite messages are spaced out and only the newest waiting one is
+    // read; urgent ones (errors) are read at once.
+    var announceInterval = 4000;
+    var lastAnnounced = 0;
+    var pendingAnnouncement = '';
+    var announceTimer = null;
+
+    function announce(text, urgent) {
+        if (!text) return;
+        if (urgent) {
+            setLiveRegion('live-assertive', text);
+            return;
+        }
+        pendingAnnouncement = text;
+        if (announceTimer) return;
+        var wait = Math.max(0, lastAnnounced + announceInterval - Date.now());
+        announceTimer = setTimeout(function() {
+            announceTimer = null;
+            lastAnnounced = Date.now();
+            setLiveRegion('live-polite', pendingAnnouncement);
+            pendingAnnouncement = '';
+        }, wait);
+    }
+
+    function setLiveRegion(id, text) {
+        var region = document.getElementById(id);
+        if (!region) return;
+        // Clear first so repeating the same text is announced again
+        region.textContent = '';
+        setTimeout(function() { region.textContent = text; }, 50);
+    }
+
+    // Progress announcements: status changes and every tenth of the way
+    var announcedStatus = '';
+    var announcedStep = -1;
+
+    function announceProgress(percent, status) {
+        var step = Math.floor(percent / 10);
+        if (status === announcedStatus && step === announcedStep) return;
+        announcedStatus = status;
+        announcedStep = step;
+        announce(Math.round(percent) + '%' + (status ? ' — ' + status : ''));
+    }
+
+    function setProgressValue(bar, percent) {
+        var wrapper = bar.parentElement;
+        if (wrapper && wrapper.getAttribute('role') === 'progressbar') {
+            wrapper.setAttribute('aria-valuenow', String(Math.round(percent)));
+        }
+    }
+
     // Update progress bar (called from Go)
     // Status text arrives fully translated from the backend.
     window.updateProgress = function(percent, status) {
         const bar = document.querySelector('.progress-bar');
         const statusEl = document.querySelector('.progress-status');
 
+        if (bar) {
+            bar.style.width = percent + '%';
+            setProgressValue(bar, percent);
+        }
+        if (statusEl && status) {
+            statusEl.textContent = status;
+        }
+        announceProgress(percent, status || (statusEl ? statusEl.textContent : ''));
+    };
+
+    // Update elapsed/remaining time and speed sparkline (called from Go).
+    // samples are per-second rates, oldest first: bytes per second when the
+    // work reports bytes (speed is then the current one, formatted),
+    // otherwise percent per second.
+    window.updateProgressTime = function(elapsed, remaining, samples, speed) {
+        const elapsedEl = document.querySelector('.progress-elapsed');
+        const remainingEl = document.querySelector('.progress-remaining');
+        const speedEl = document.querySelector('.progress-speed');
+        const line = document.querySelector('.progress-sparkline polyline');
+
+        if (elapsedEl) {
+            elapsedEl.textContent = elapsed;
+        }
+        if (remainingEl) {
+            remainingEl.textContent = remaining;
+        }
+        if (speedEl) {
+            speedEl.textContent = speed || '';
+        }
+        if (line && samples && samples.length > 1) {
+            const peak = Math.max.apply(null, samples) || 1;
+            const step = 100 / (samples.length - 1);
+            const points = samples.map(function(r, i) {
+                return (i * step).toFixed(1) + ',' + (19 - (r / peak) * 18).toFixed(1);
+            });
+            line.setAttribute('points', points.join(' '));
+        }
+    };
+
+    // Multi-progress functions (called from Go).
+    window.multiProgressUpdate = function(name, percent, status, overall) {
+        const list = document.getElementById('multiprogress-list');
+        if (!list) return;
+
+        const item = Array.prototype.find.call(list.children, function(el) {
+            return el.dataset.name === name;
+        });
+        if (!item) return;
+
+        const bar = item.querySelector('.progress-bar');
         if (bar) {
             bar.style.width = percent + '%';
         }
+        const statusEl = item.querySelector('.multiprogress-item-status');
         if (statusEl && status) {
             statusEl.textContent = status;
         }
+
+        const overallBar = document.getElementById('multiprogress-overall-bar');
+        if (overallBar) {
+            overallBar.style.width = overall + '%';
+            setProgressValue(overallBar, overall);
+        }
+        const overallStatus = document.getElementById('multiprogress-status');
+        announceProgress(overall, overallStatus ? overallStatus.textContent : '');
+    };
+
+    window.multiProgressSetStatus = function(status) {
+        const statusEl = document.getElementById('multiprogress-status');
+        if (statusEl) {
+            statusEl.textContent = status;
+        }
+        announce(status);
+    };
+
+    // Exclusive accordions: opening a section closes its siblings.
+    // The toggle event does not bubble, so listen in the capture phase.
+    document.addEventListener('toggle', function(e) {
+        const section = e.target;
+        if (!section.classList || !section.classList.contains('accordion-section') || !section.open) return;
+        const accordion = section.closest('.accordion');
+        if (!accordion || accordion.getAttribute('data-exclusive') !== 'true') return;
+        accordion.querySelectorAll(':scope > .accordion-section[open]').forEach(function(other) {
+            if (other !== section) {
+                other.open = false;
+            }
+        });
+    }, true);
+
+    // Settings pages: tabs switch panels, and Apply is enabled while a value
+    // differs from the last saved one. Tabs with unsaved changes are marked.
+    var settingsSaved = null;
+
+    function selectSettingsTab(tab, focus) {
+        tab.parentElement.querySelectorAll('.settings-tab').forEach(function(t) {
+            const selected = t === tab;
+            t.setAttribute('aria-selected', selected ? 'true' : 'false');
+            t.tabIndex = selected ? 0 : -1;
+            document.getElementById(t.getAttribute('aria-controls')).hidden = !selected;
+        });
+        if (focus) tab.focus();
+    }
+
+    document.addEventListener('click', function(e) {
+        const tab = e.target.closest('.settings-tab');
+        if (tab) selectSettingsTab(tab, false);
+    });
+
+    document.addEventListener('keydown', function(e) {
+        const tab = e.target.closest && e.target.closest('.settings-tab');
+        if (!tab) return;
+        const tabs = Array.from(tab.parentElement.querySelectorAll('.settings-tab'));
+        const step = document.documentElement.dir === 'rtl' ? -1 : 1;
+        let i = tabs.indexOf(tab);
+        if (e.key === 'ArrowRight') {
+            i += step;
+        } else if (e.key === 'ArrowLeft') {
+            i -= step;
+        } else if (e.key === 'Home') {
+            i = 0;
+        } else if (e.key === 'End') {
+            i = tabs.length - 1;
+        } else {
+            return;
+        }
+        e.preventDefault();
+        selectSettingsTab(tabs[(i + tabs.length) % tabs.length], true);
+    });
+
+    function settingsPanelValues(panel) {
+        const values = {};
+        panel.querySelectorAll('input, select, textarea').forEach(function(input) {
+            if (input.id) {
+                values[input.id] = input.type === 'checkbox' ? input.checked : input.value;
+            }
+        });
+        return values;
+    }
+
+    function initSettings() {
+        const settings = document.querySelector('.settings');
+        if (!settings) return;
+        settingsSaved = {};
+        settings.querySelectorAll('.settings-panel').forEach(function(panel) {
+            Object.assign(settingsSaved, settingsPanelValues(panel));
+        });
+        // After a failed OK the page shows the user's edits; compare them
+        // with the values from before
+        const saved = settings.getAttribute('data-saved');
+        if (saved) Object.assign(settingsSaved, JSON.parse(saved));
+        updateSettingsDirty();
+    }
+
+    function updateSettingsDirty() {
+        const settings = document.querySelector('.settings');
+        if (!settings || !settingsSaved) return;
+        let dirty = false;
+        settings.querySelectorAll('.settings-panel').forEach(function(panel) {
+            const values = settingsPanelValues(panel);
+            const changed = Object.keys(values).some(function(id) {
+                return id in settingsSaved && values[id] !== settingsSaved[id];
+            });
+            document.getElementById(panel.getAttribute('aria-labelledby')).classList.toggle('settings-tab-dirty', changed);
+            dirty = dirty || changed;
+        });
+        const apply = findButton('settings_apply');
+        if (!apply) return;
+        if (apply.classList.contains('btn-loading')) {
+            // setButtonLoading restores this state when Apply returns
+            apply.setAttribute('data-was-disabled', dirty ? 'false' : 'true');
+            return;
+        }
+        apply.disabled = !dirty;
+        apply.classList.toggle('btn-disabled', !dirty);
+    }
+
+    document.addEventListener('input', updateSettingsDirty);
+    document.addEventListener('change', updateSettingsDirty);
+
+    // Apply results (called from Go)
+    window.settingsApplied = function() {
+        const settings = document.querySelector('.settings');
+        if (settings) settings.removeAttribute('data-saved');
+        document.getElementById('settings-alert').replaceChildren();
+        initSettings();
+        updateSelectAll();
+    };
+
+    window.settingsApplyFailed = function(message, iconSvg) {
+        const alert = document.createElement('div');
+        alert.className = 'summary-alert summary-alert-error form-field-info';
+        alert.setAttribute('role', 'alert');
+        const icon = document.createElement('span');
+        icon.className = 'summary-alert-icon';
+        icon.innerHTML = iconSvg;
+        const text = document.createElement('span');
+        text.className = 'summary-alert-text';
+        text.textContent = message;
+        alert.appendChild(icon);
+        alert.appendChild(text);
+        document.getElementById('settings-alert').replaceChildren(alert);
     };
 
     // Log view functions (called from Go)
@@ -147,10 +560,66 @@
         const line = document.createElement('div');
         line.className = 'log-line' + (styleClass ? ' ' + styleClass : '');
         line.textContent = text;
+        line.hidden = !logLineVisible(line);
         logContent.appendChild(line);
 
         // Auto-scroll to bottom
         logContent.scrollTop = logContent.scrollHeight;
+
+        announce(text, styleClass === 'log-error');
+    };
+
+    // Log filtering: text filter plus severity toggles. When any severity
+    // toggle is active, only lines of the active severities are shown.
+    function logLineVisible(line) {
+        const filterEl = document.getElementById('log-filter');
+        const query = filterEl ? filterEl.value.trim().toLowerCase() : '';
+        if (query && line.textContent.toLowerCase().indexOf(query) === -1) {
+            return false;
+        }
+        const active = document.querySelectorAll('.log-severity[aria-pressed="true"]');
+        if (active.length === 0) {
+            return true;
+        }
+        return Array.prototype.some.call(active, function(btn) {
+            return line.classList.contains(btn.getAttribute('data-severity'));
+        });
+    }
+
+    function applyLogFilter() {
+        document.querySelectorAll('#log-content .log-line').forEach(function(line) {
+            line.hidden = !logLineVisible(line);
+        });
+    }
+
+    document.addEventListener('input', function(e) {
+        if (e.target.id === 'log-filter') {
+            applyLogFilter();
+        }
+    });
+
+    document.addEventListener('click', function(e) {
+        const btn = e.target.closest('.log-severity');
+        if (btn) {
+            const pressed = btn.getAttribute('aria-pressed') === 'true';
+            btn.setAttribute('aria-pressed', pressed ? 'false' : 'true');
+            applyLogFilter();
+        }
+    });
+
+    // Full log text, regardless of the current filter
+    function logText() {
+        const lines = document.querySelectorAll('#log-content .log-line');
+        return Array.prototype.map.call(lines, function(line) {
+            return line.textContent;
+        }).join('\n');
+    }
+
+    window.copyLogContent = function() {
+        var btn = document.querySelector('[data-button="log_copy"]');
+        copyToClipboard(logText(), function() {
+            showCopySuccess(btn);
+        });
     };
 
     window.logClear = function() {
@@ -231,6 +700,7 @@
         if (statusEl) {
             statusEl.textContent = status;
         }
+        announce(status);
     };
 
     // Review functions - icons for swap animation
@@ -287,10 +757,24 @@
     window.copyReviewContent = function() {
         var content = document.querySelector('.review-content');
         if (!content) return;
-
-        var text = content.textContent;
         var btn = document.querySelector('[data-button="review_copy"]');
 
+        // Chunked content is only partly in the page; Go copies it
+        if (content.querySelector('.review-virtual')) {
+            showCopySuccess(btn);
+            sendMessage('button_click', {
+                button: 'review_copy',
+                data: {}
+            });
+            return;
+        }
+
+        // Code views hold one element per line
+        var lines = content.querySelectorAll('.review-line');
+        var text = lines.length ? Array.prototype.map.call(lines, function(line) {
+            return line.textContent;
+        }).join('\n') : content.textContent;
+
         copyToClipboard(text, function() {
             showCopySuccess(btn);
             // Notify Go that copy was clicked
@@ -301,6 +785,341 @@
         });
     };
 
+    // Word wrap toggle of code review pages
+    document.addEventListener('click', function(e) {
+        var btn = e.target.closest('.review-wrap');
+        var content = document.querySelector('.review-code');
+        if (btn && content) {
+            var wrap = btn.getAttribute('aria-pressed') !== 'true';
+            btn.setAttribute('aria-pressed', wrap ? 'true' : 'false');
+            content.classList.toggle('review-nowrap', !wrap);
+        }
+    });
+
+    // Virtual scrolling of large review pages: only the lines around the
+    // viewport are in the page. Go sends them in chunks on request (see
+    // handleReviewChunk); chunks far from the viewport are dropped again.
+    var REVIEW_MAX_HEIGHT = 10000000; // Below the browsers' element height limits
+    var reviewChunks = {};
+    var reviewLineHeight = 0;
+
+    function initReviewVirtual() {
+        var virt = document.querySelector('.review-virtual');
+        if (!virt) return;
+        var scroller = virt.closest('.review-content');
+        var win = virt.querySelector('.review-window');
+
+        // Measure one line to size the spacer
+        win.innerHTML = '<span class="review-line">0</span>';
+        reviewLineHeight = win.firstChild.getBoundingClientRect().height || 20;
+        win.innerHTML = '';
+
+        var total = parseInt(virt.getAttribute('data-lines'), 10) || 0;
+        virt.querySelector('.review-spacer').style.height =
+            Math.min(total * reviewLineHeight, REVIEW_MAX_HEIGHT) + 'px';
+        reviewChunks = {};
+        scroller.addEventListener('scroll', renderReviewWindow);
+        window.addEventListener('resize', renderReviewWindow);
+        renderReviewWindow();
+    }
+
+    function renderReviewWindow() {
+        var virt = document.querySelector('.review-virtual');
+        if (!virt) return;
+        var scroller = virt.closest('.review-content');
+        var win = virt.querySelector('.review-window');
+        var total = parseInt(virt.getAttribute('data-lines'), 10) || 0;
+        var chunkLines = parseInt(virt.getAttribute('data-chunk'), 10) || 500;
+        var visible = Math.ceil(scroller.clientHeight / reviewLineHeight) + 1;
+
+        // Beyond the height limit, the scroll position maps to a line
+        // proportionally and the lines follow the viewport
+        var first, top;
+        var maxScroll = scroller.scrollHeight - scroller.clientHeight;
+        if (total * reviewLineHeight <= REVIEW_MAX_HEIGHT || maxScroll <= 0) {
+            first = Math.floor(scroller.scrollTop / reviewLineHeight);
+            top = first * reviewLineHeight;
+        } else {
+            first = Math.round(scroller.scrollTop / maxScroll * Math.max(0, total - visible));
+            top = scroller.scrollTop;
+        }
+        first = Math.max(0, Math.min(first, total - 1));
+        var last = Math.min(total, first + visible);
+
+        var firstChunk = Math.floor(first / chunkLines);
+        var lastChunk = Math.floor((last - 1) / chunkLines);
+        var html = [];
+        for (var i = first; i < last; i++) {
+            var chunk = reviewChunks[Math.floor(i / chunkLines)];
+            var line = chunk && chunk !== 'pending' ? chunk[i % chunkLines] : '';
+            html.push('<span class="review-line">' + (line || '') + '</span>');
+        }
+        win.style.transform = 'translateY(' + top + 'px)';
+        win.style.counterReset = 'review-line ' + first;
+        win.setAttribute('data-first', first);
+        win.innerHTML = html.join('');
+        highlightFindMatches();
+
+        // Request missing chunks, including the next one, and drop far ones
+        for (var c = firstChunk; c <= lastChunk + 1 && c * chunkLines < total; c++) {
+            if (!reviewChunks[c]) {
+                reviewChunks[c] = 'pending';
+                sendMessage('review_chunk', { data: { chunk: c } });
+            }
+        }
+        Object.keys(reviewChunks).forEach(function(key) {
+            if (key < firstChunk - 4 || key > lastChunk + 4) {
+                delete reviewChunks[key];
+            }
+        });
+    }
+
+    // Called by Go with the (highlighted) lines of a chunk
+    window.reviewChunk = function(index, lines) {
+        reviewChunks[index] = lines;
+        renderReviewWindow();
+    };
+
+    // Scrolls a chunked review page so that line is in view
+    function scrollToReviewLine(line) {
+        var virt = document.querySelector('.review-virtual');
+        var scroller = virt.closest('.review-content');
+        var total = parseInt(virt.getAttribute('data-lines'), 10) || 0;
+        var maxScroll = scroller.scrollHeight - scroller.clientHeight;
+        if (total * reviewLineHeight <= REVIEW_MAX_HEIGHT) {
+            scroller.scrollTop = line * reviewLineHeight - scroller.clientHeight / 3;
+        } else {
+            var visible = Math.ceil(scroller.clientHeight / reviewLineHeight) + 1;
+            scroller.scrollTop = line / Math.max(1, total - visible) * maxScroll;
+        }
+        renderReviewWindow();
+    }
+
+    // Find in page (Ctrl+F) for license and review content. Matches are
+    // marked with the CSS Custom Highlight API where the WebView has it, else
+    // the current match is selected. Chunked review content is searched by
+    // Go (see handleReviewFind), one matching line at a time.
+    var findMatches = [];   // Ranges of the matches in the page
+    var findIndex = -1;     // Current match in findMatches
+    var findLine = -1;      // Current matching line of chunked review content
+    var findTimer = null;
+
+    function findBar() {
+        return document.querySelector('.find-bar');
+    }
+
+    function findTarget() {
+        return document.querySelector('.review-content, .license-content');
+    }
+
+    function findQuery() {
+        var bar = findBar();
+        return bar && !bar.hidden ? bar.querySelector('.find-input').value.toLowerCase() : '';
+    }
+
+    function openFind() {
+        var bar = findBar();
+        if (!bar || !findTarget()) return false;
+        bar.hidden = false;
+        var input = bar.querySelector('.find-input');
+        input.focus();
+        input.select();
+        return true;
+    }
+
+    function closeFind() {
+        var bar = findBar();
+        if (!bar) return;
+        bar.hidden = true;
+        findLine = -1;
+        highlightFindMatches();
+        setFindCount(0, 0, false);
+    }
+
+    function setFindCount(current, total, searched) {
+        var count = findBar().querySelector('.find-count');
+        if (!searched) {
+            count.textContent = '';
+        } else if (total === 0) {
+            count.textContent = count.getAttribute('data-none');
+        } else {
+            count.textContent = count.getAttribute('data-format')
+                .replace('{0}', current).replace('{1}', total);
+        }
+    }
+
+    // Collects the ranges of all matches of the query in the target's text.
+    // Matches may span elements, e.g. the highlighted parts of a line.
+    function highlightFindMatches() {
+        var target = findTarget();
+        var query = findQuery();
+        findMatches = [];
+        if (target && query) {
+            var nodes = [];
+            var text = '';
+            var lastLine = null;
+            var walker = document.createTreeWalker(target, NodeFilter.SHOW_TEXT);
+            while (walker.nextNode()) {
+                // Separate the lines of code views so matches don't span them
+                var lineEl = walker.currentNode.parentNode.closest('.review-line');
+                if (lineEl !== lastLine && text) {
+                    text += '\n';
+                }
+                lastLine = lineEl;
+                nodes.push({ node: walker.currentNode, start: text.length });
+                text += walker.currentNode.nodeValue;
+            }
+            text = text.toLowerCase();
+            var position = function(offset) {
+                var i = nodes.length - 1;
+                while (i > 0 && nodes[i].start > offset) i--;
+                return { node: nodes[i].node, offset: offset - nodes[i].start };
+            };
+            for (var at = text.indexOf(query); at >= 0; at = text.indexOf(query, at + query.length)) {
+                var start = position(at);
+                var end = position(at + query.length - 1);
+                var range = document.createRange();
+                range.setStart(start.node, start.offset);
+                range.setEnd(end.node, end.offset + 1);
+                findMatches.push(range);
+            }
+        }
+
+        // On chunked content, the current match is the first on findLine
+        if (document.querySelector('.review-virtual')) {
+            findIndex = -1;
+            var win = document.querySelector('.review-window');
+            var first = parseInt(win.getAttribute('data-first'), 10) || 0;
+            findMatches.some(function(range, i) {
+                var el = range.startContainer.parentNode.closest('.review-line');
+                var index = Array.prototype.indexOf.call(el.parentNode.children, el);
+                if (first + index === findLine) {
+                    findIndex = i;
+                    return true;
+                }
+                return false;
+            });
+        } else if (findIndex >= findMatches.length) {
+            findIndex = findMatches.length - 1;
+        }
+
+        if (window.CSS && CSS.highlights && window.Highlight) {
+            CSS.highlights.set('find-match', new Highlight(...findMatches));
+            var current = findMatches[findIndex];
+            CSS.highlights.set('find-current', current ? new Highlight(current) : new Highlight());
+        }
+    }
+
+    // Shows the current match: highlighted, selected as a fallback, and
+    // scrolled into view
+    function showFindMatch() {
+        highlightFindMatches();
+        var range = findMatches[findIndex];
+        if (!range) return;
+        if (!(window.CSS && CSS.highlights)) {
+            var selection = window.getSelection();
+            selection.removeAllRanges();
+            selection.addRange(range);
+        }
+        var target = findTarget();
+        var rect = range.getBoundingClientRect();
+        var box = target.getBoundingClientRect();
+        if (rect.top < box.top || rect.bottom > box.bottom) {
+            target.scrollTop += rect.top - box.top - box.height / 3;
+        }
+        if (rect.left < box.left || rect.right > box.right) {
+            target.scrollLeft += rect.left - box.left - box.width / 3;
+        }
+    }
+
+    // Searches for the query from the start, or moves to the next (1) or
+    // previous (-1) match
+    function runFind(step) {
+        var query = findQuery();
+        if (document.querySelector('.review-virtual')) {
+            if (!query) {
+                findLine = -1;
+                highlightFindMatches();
+                setFindCount(0, 0, false);
+                return;
+            }
+            sendMessage('review_find', { data: {
+                query: query,
+                line: step ? findLine : -1,
+                backward: step < 0
+            }});
+            return;
+        }
+
+        if (step === 0) {
+            findIndex = 0;
+            highlightFindMatches();
+        } else if (findMatches.length) {
+            findIndex = (findIndex + step + findMatches.length) % findMatches.length;
+        }
+        showFindMatch();
+        setFindCount(findIndex + 1, findMatches.length, query !== '');
+    }
+
+    // Called by Go with the result of a search of chunked review content
+    window.reviewFound = function(query, line, current, total) {
+        if (query !== findQuery()) return;
+        findLine = line;
+        setFindCount(current, total, true);
+        if (line >= 0) {
+            scrollToReviewLine(line);
+        } else {
+            highlightFindMatches();
+        }
+    };
+
+    document.addEventListener('input', function(e) {
+        if (e.target.classList && e.target.classList.contains('find-input')) {
+            clearTimeout(findTimer);
+            findTimer = setTimeout(function() { runFind(0); },
+                document.querySelector('.review-virtual') ? 250 : 0);
+        }
+    });
+
+    document.addEventListener('click', function(e) {
+        if (!e.target.closest) return;
+        if (e.target.closest('.find-next')) {
+            runFind(1);
+        } else if (e.target.closest('.find-prev')) {
+            runFind(-1);
+        } else if (e.target.closest('.find-close')) {
+            closeFind();
+        }
+    });
+
+    // Ctrl+F opens the find bar, F3 and Enter move between matches, Escape
+    // closes it. Handled before the page's own Enter and Escape keys.
+    document.addEventListener('keydown', function(e) {
+        var key = (e.key || '').toLowerCase();
+        var inBar = e.target.closest && e.target.closest('.find-bar');
+        if ((e.ctrlKey || e.metaKey) && !e.altKey && key === 'f') {
+            if (openFind()) {
+                e.preventDefault();
+                e.stopPropagation();
+            }
+        } else if (key === 'f3' || (inBar && key === 'enter' && e.target.classList.contains('find-input'))) {
+            var bar = findBar();
+            if (!bar || !findTarget()) return;
+            e.preventDefault();
+            e.stopPropagation();
+            if (bar.hidden) {
+                openFind();
+            } else {
+                runFind(e.shiftKey ? -1 : 1);
+            }
+        } else if (inBar && key === 'escape') {
+            e.preventDefault();
+            e.stopPropagation();
+            closeFind();
+            e.target.blur();
+        }
+    }, true);
+
     window.saveReviewContent = function() {
         // Notify Go to handle save (Go will show file dialog)
         sendMessage('button_click', {
@@ -321,6 +1140,517 @@
         });
     };
 
+    // Password strength from 0 to 4. Must match PasswordStrength in Go.
+    function passwordStrength(password) {
+        var classes = 0;
+        var chars = Array.from(password);
+        if (chars.some(function(c) { return /\p{Ll}/u.test(c); })) classes++;
+        if (chars.some(function(c) { return /\p{Lu}/u.test(c); })) classes++;
+        if (chars.some(function(c) { return /\p{Nd}/u.test(c); })) classes++;
+        if (chars.some(function(c) { return !/[\p{Ll}\p{Lu}\p{Nd}]/u.test(c); })) classes++;
+        var length = chars.length;
+        if (length < 6) return 0;
+        var score = 0;
+        if (length >= 8) score++;
+        if (length >= 12) score++;
+        if (classes >= 3) score++;
+        if (classes === 4 || length >= 16) score++;
+        return score;
+    }
+
+    function updatePasswordStrength(input) {
+        var meter = document.querySelector('.password-strength[data-strength-for="' + CSS.escape(input.id) + '"]');
+        if (!meter) return;
+        var labels = JSON.parse(meter.getAttribute('data-labels') || '[]');
+        var bar = meter.querySelector('.password-strength-bar span');
+        var label = meter.querySelector('.password-strength-label');
+        if (!input.value) {
+            meter.removeAttribute('data-strength');
+            bar.style.width = '0';
+            label.textContent = '';
+            return;
+        }
+        var score = passwordStrength(input.value);
+        meter.setAttribute('data-strength', String(score));
+        bar.style.width = ((score + 1) * 20) + '%';
+        label.textContent = labels[score] || '';
+    }
+
+    // Confirm fields must repeat their password; Next is disabled until they do
+    function updatePasswordConfirm() {
+        var mismatch = false;
+        document.querySelectorAll('input[data-confirms]').forEach(function(confirm) {
+            var password = document.getElementById(confirm.getAttribute('data-confirms'));
+            if (!password) return;
+            var differs = confirm.value !== password.value;
+            var message = document.querySelector('.password-mismatch[data-mismatch-for="' + CSS.escape(confirm.id) + '"]');
+            if (message) message.hidden = !differs || confirm.value === '';
+            confirm.setAttribute('aria-invalid', differs && confirm.value !== '' ? 'true' : 'false');
+            if (differs) mismatch = true;
+        });
+        blockNext('password', mismatch);
+    }
+
+    // Next is disabled while any form check fails, e.g. a password mismatch
+    // and an invalid email address at the same time
+    var nextBlocks = {};
+
+    function blockNext(reason, blocked) {
+        if (blocked) {
+            nextBlocks[reason] = true;
+        } else {
+            delete nextBlocks[reason];
+        }
+        var nextBtn = document.querySelector('.btn-primary[data-button="next"]');
+        if (nextBtn) {
+            var disabled = Object.keys(nextBlocks).length > 0;
+            nextBtn.classList.toggle('btn-disabled', disabled);
+            nextBtn.disabled = disabled;
+        }
+    }
+
+    // Email and URL formats. Must match ValidEmail and ValidURL in Go.
+    var emailPattern = /^[^\s@]+@[^\s@.]+(\.[^\s@.]+)+$/;
+
+    function validURL(value) {
+        try {
+            var url = new URL(value);
+            return (url.protocol === 'http:' || url.protocol === 'https:') && url.hostname !== '';
+        } catch (e) {
+            return false;
+        }
+    }
+
+    // Host name or IP address with optional port. Must match ValidHost in Go.
+    var hostnamePattern = /^[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?(\.[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?)*$/;
+
+    function validHost(value) {
+        value = value.trim();
+        var host = value;
+        var port = null;
+        var bracket = /^\[([^\]]*)\](?::(.*))?$/.exec(value);
+        if (bracket) {
+            host = bracket[1];
+            port = bracket[2] === undefined ? null : bracket[2];
+        } else if (value.split(':').length === 2) {
+            host = value.split(':')[0];
+            port = value.split(':')[1];
+        }
+        if (port !== null && !(/^[0-9]+$/.test(port) && Number(port) >= 1 && Number(port) <= 65535)) {
+            return false;
+        }
+        if (host.indexOf(':') >= 0) {
+            try {
+                new URL('http://[' + host + ']');
+                return true;
+            } catch (e) {
+                return false;
+            }
+        }
+        if (/^[0-9.]+$/.test(host)) {
+            var parts = host.split('.');
+            return parts.length === 4 && parts.every(function(part) {
+                return /^[0-9]{1,3}$/.test(part) && Number(part) <= 255;
+            });
+        }
+        return host.length <= 253 && hostnamePattern.test(host);
+    }
+
+    // Input masks (FormField.InputMask). Must match InputMask in Go. Template
+    // masks use 9 for a digit, a for a letter and * for either.
+    var maskSlots = { '9': /[0-9]/, 'a': /\p{L}/u, '*': /[\p{L}0-9]/u };
+
+    // Restricts and formats a value as typed with mask
+    function applyMask(mask, value) {
+        switch (mask) {
+        case 'digits':
+            return value.replace(/[^0-9]/g, '');
+        case 'phone':
+            return (/^\s*\+/.test(value) ? '+' : '') + value.replace(/[^0-9 ().-]/g, '');
+        case 'ipv4':
+            return value.replace(/[^0-9.]/g, '').split('.').slice(0, 4).map(function(part) {
+                return part.slice(0, 3);
+            }).join('.');
+        case 'mac':
+            return value.replace(/[^0-9a-fA-F]/g, '').toUpperCase().slice(0, 12).replace(/(..)(?=.)/g, '$1:');
+        }
+        // Template: fill the slots with the typed letters and digits, inserting
+        // the literals between them
+        var chars = Array.from(value);
+        var slots = Array.from(mask);
+        var out = '';
+        var filled = 0;
+        for (var k = 0, i = 0; k < slots.length && i < chars.length; k++) {
+            var slot = maskSlots[slots[k]];
+            if (!slot) {
+                out += slots[k];
+                if (chars[i] === slots[k]) i++;
+                continue;
+            }
+            while (i < chars.length && !slot.test(chars[i])) i++;
+            if (i === chars.length) break;
+            out += chars[i++];
+            filled = out.length;
+        }
+        return out.slice(0, filled);
+    }
+
+    function maskComplete(mask, value) {
+        switch (mask) {
+        case 'digits':
+            return true;
+        case 'phone':
+            var digits = value.replace(/[^0-9]/g, '').length;
+            return digits >= 7 && digits <= 15;
+        case 'ipv4':
+            var parts = value.split('.');
+            return parts.length === 4 && parts.every(function(part) {
+                return /^[0-9]{1,3}$/.test(part) && Number(part) <= 255;
+            });
+        case 'mac':
+            return value.length === 17;
+        }
+        return Array.from(value).length === Array.from(mask).length;
+    }
+
+    // Formats a masked field, keeping the caret after the same number of
+    // letters and digits
+    function maskInput(input) {
+        var value = applyMask(input.getAttribute('data-mask'), input.value);
+        if (value === input.value) return;
+        var isChar = /[\p{L}\p{N}]/u;
+        var before = Array.from(input.value.slice(0, input.selectionStart || 0)).filter(function(c) {
+            return isChar.test(c);
+        }).length;
+        input.value = value;
+        var pos = 0;
+        for (var seen = 0; pos < value.length && seen < before; pos++) {
+            if (isChar.test(value[pos])) seen++;
+        }
+        if (document.activeElement === input) input.setSelectionRange(pos, pos);
+    }
+
+    function formatValid(input) {
+        if (input.value === '') return true;
+        if (input.type === 'email' && !emailPattern.test(input.value)) return false;
+        if (input.type === 'url' && !validURL(input.value)) return false;
+        if (input.hasAttribute('data-host') && !validHost(input.value)) return false;
+        if (input.hasAttribute('data-color') && !colorPattern.test(input.value.trim())) return false;
+        var mask = input.getAttribute('data-mask');
+        if (mask && !maskComplete(mask, input.value)) return false;
+        var pattern = input.getAttribute('data-pattern');
+        if (pattern) {
+            try {
+                if (!new RegExp('^(?:' + pattern + ')$', 'u').test(input.value)) return false;
+            } catch (e) {
+                // An invalid pattern doesn't block the form
+            }
+        }
+        return true;
+    }
+
+    function showFieldError(input, message) {
+        var el = document.querySelector('.field-error[data-error-for="' + CSS.escape(input.id) + '"]');
+        if (el) {
+            el.textContent = message;
+            el.hidden = !message;
+        }
+        input.setAttribute('aria-invalid', message ? 'true' : 'false');
+    }
+
+    // Checks an email, URL or verified field. Errors are shown once the user
+    // leaves the field (or at once if shown already), while Next is disabled
+    // as soon as the value is invalid. Fields with FormField.Verify are sent
+    // to Go when their format is valid.
+    function checkField(input, done) {
+        var valid = formatValid(input);
+        blockNext('format:' + input.id, !valid);
+        if (!valid) {
+            if (done || input.getAttribute('aria-invalid') === 'true') {
+                showFieldError(input, input.getAttribute('data-invalid-message') || '');
+            }
+            blockNext('verify:' + input.id, false);
+            return;
+        }
+        showFieldError(input, '');
+        if (!input.hasAttribute('data-verify')) return;
+        if (input.value === '') {
+            blockNext('verify:' + input.id, false);
+            return;
+        }
+        blockNext('verify:' + input.id, true);
+        if (done && input.getAttribute('data-verified') !== input.value) {
+            input.setAttribute('data-verified', input.value);
+            input.setAttribute('aria-busy', 'true');
+            sendMessage('field_verify', { button: input.id, data: { value: input.value } });
+        }
+    }
+
+    // Result of FormField.Verify; ignored if the value has changed since
+    window.fieldVerified = function(id, value, message) {
+        var input = document.getElementById(id);
+        if (!input || input.value !== value) return;
+        input.removeAttribute('aria-busy');
+        showFieldError(input, message);
+        blockNext('verify:' + id, !!message);
+    };
+
+    function isCheckedField(el) {
+        return el.tagName === 'INPUT' && (el.type === 'email' || el.type === 'url' ||
+            el.hasAttribute('data-mask') || el.hasAttribute('data-pattern') || el.hasAttribute('data-host') ||
+            el.hasAttribute('data-color') || el.hasAttribute('data-verify'));
+    }
+
+    // Color fields: the swatch and the hex text input follow each other.
+    // Must match NormalizeColor in Go.
+    var colorPattern = /^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$/;
+
+    function updateColorField(input) {
+        if (input.type === 'color') {
+            var text = document.getElementById(input.getAttribute('data-color-for'));
+            if (!text) return;
+            text.value = input.value;
+            text.dispatchEvent(new Event('input', { bubbles: true }));
+            text.dispatchEvent(new Event('change', { bubbles: true }));
+            return;
+        }
+        var value = input.value.trim();
+        if (!colorPattern.test(value)) return;
+        if (value.length === 4) {
+            value = '#' + value[1] + value[1] + value[2] + value[2] + value[3] + value[3];
+        }
+        var swatch = document.querySelector('.form-color-swatch[data-color-for="' + CSS.escape(input.id) + '"]');
+        if (swatch) swatch.value = value.toLowerCase();
+    }
+
+    // FieldGroup rows. New rows copy the group's template, numbered after the
+    // rows so far; Go collects the rows in order of their numbers.
+    function updateGroupButtons(group) {
+        var rows = group.querySelectorAll(':scope > .repeater-rows > .repeater-row');
+        var maxRows = parseInt(group.getAttribute('data-max-rows'), 10) || 0;
+        var add = group.querySelector(':scope > .repeater-add');
+        if (add) add.disabled = maxRows > 0 && rows.length >= maxRows;
+        var keepOne = group.getAttribute('data-required') === 'true' && rows.length <= 1;
+        rows.forEach(function(row) {
+            row.querySelector('.repeater-remove').disabled = keepOne;
+        });
+    }
+
+    window.addGroupRow = function(button) {
+        var group = button.closest('.form-repeater');
+        var n = parseInt(group.getAttribute('data-next-row'), 10) || 0;
+        group.setAttribute('data-next-row', String(n + 1));
+        var row = group.querySelector(':scope > .repeater-template').content.cloneNode(true);
+        row.querySelectorAll('*').forEach(function(el) {
+            Array.from(el.attributes).forEach(function(attr) {
+                if (attr.value.indexOf('.__row__.') >= 0) {
+                    el.setAttribute(attr.name, attr.value.split('.__row__.').join('.' + n + '.'));
+                }
+            });
+        });
+        var first = row.querySelector('input, select, textarea');
+        group.querySelector(':scope > .repeater-rows').appendChild(row);
+        updateGroupButtons(group);
+        if (first) first.focus();
+        updateSettingsDirty();
+    };
+
+    window.removeGroupRow = function(button) {
+        var group = button.closest('.form-repeater');
+        var row = button.closest('.repeater-row');
+        // The row's checks no longer hold Next back
+        row.querySelectorAll('input').forEach(function(input) {
+            blockNext('format:' + input.id, false);
+            blockNext('verify:' + input.id, false);
+        });
+        row.remove();
+        updatePasswordConfirm();
+        updateGroupButtons(group);
+        group.querySelector(':scope > .repeater-add').focus();
+        updateSettingsDirty();
+    };
+
+    // FieldFileDrop: pages can't see the paths of dropped files, so dropped
+    // files are checked here, then read and sent to Go, which saves them and
+    // calls fileDropped with the path of the copy. Browse asks Go for a file
+    // dialog. The field's textarea holds the paths, one per line.
+    function dropPaths(input) {
+        return input.value ? input.value.split('\n') : [];
+    }
+
+    function renderDropList(input) {
+        var list = document.querySelector('.file-drop-list[data-list-for="' + CSS.escape(input.id) + '"]');
+        if (!list) return;
+        var removeLabel = list.getAttribute('data-remove-label');
+        list.replaceChildren();
+        dropPaths(input).forEach(function(path, i) {
+            var item = document.createElement('li');
+            item.className = 'file-drop-item';
+            var name = document.createElement('span');
+            name.className = 'file-drop-name';
+            name.textContent = path.split(/[\\/]/).pop();
+            name.title = path;
+            var remove = document.createElement('button');
+            remove.type = 'button';
+            remove.className = 'btn btn-default btn-icon file-drop-remove';
+            remove.title = removeLabel;
+            remove.setAttribute('aria-label', removeLabel + ' ' + name.textContent);
+            remove.innerHTML = list.getAttribute('data-remove-icon');
+            remove.addEventListener('click', function() {
+                var paths = dropPaths(input);
+                paths.splice(i, 1);
+                setDropPaths(input, paths);
+                var browse = input.parentElement.querySelector('.file-drop-browse');
+                if (browse) browse.focus();
+            });
+            item.append(name, remove);
+            list.appendChild(item);
+        });
+    }
+
+    function setDropPaths(input, paths) {
+        input.value = paths.join('\n');
+        renderDropList(input);
+        input.dispatchEvent(new Event('input', { bubbles: true }));
+        input.dispatchEvent(new Event('change', { bubbles: true }));
+    }
+
+    window.fileDropped = function(id, path, message) {
+        var input = document.getElementById(id);
+        if (!input) return;
+        showFieldError(input, message);
+        if (!path) return;
+        var zone = document.querySelector('.file-drop[data-drop-for="' + CSS.escape(id) + '"]');
+        var paths = zone && zone.hasAttribute('data-multiple') ? dropPaths(input) : [];
+        if (paths.indexOf(path) < 0) paths.push(path);
+        setDropPaths(input, paths);
+    };
+
+    function dropConfig(zone) {
+        var extensions = zone.getAttribute('data-extensions');
+        return {
+            extensions: extensions ? extensions.split(',') : [],
+            maxSize: Number(zone.getAttribute('data-max-size')) || 0,
+            multiple: zone.hasAttribute('data-multiple')
+        };
+    }
+
+    function dropFiles(zone, files) {
+        var id = zone.getAttribute('data-drop-for');
+        var input = document.getElementById(id);
+        var config = dropConfig(zone);
+        if (!input) return;
+        if (!config.multiple) files = files.slice(0, 1);
+        files.forEach(function(file) {
+            var dot = file.name.lastIndexOf('.');
+            var ext = dot > 0 ? file.name.slice(dot).toLowerCase() : '';
+            if (config.extensions.length && config.extensions.indexOf(ext) < 0) {
+                showFieldError(input, zone.getAttribute('data-wrong-type').replace('{0}', file.name));
+                return;
+            }
+            if (config.maxSize && file.size > config.maxSize) {
+                showFieldError(input, zone.getAttribute('data-too-large').replace('{0}', file.name));
+                return;
+            }
+            var reader = new FileReader();
+            reader.onload = function() {
+                var content = String(reader.result);
+                sendMessage('file_drop', {
+                    button: id,
+                    data: { name: file.name, content: content.slice(content.indexOf(',') + 1) }
+                });
+            };
+            reader.onerror = function() {
+                showFieldError(input, String(reader.error));
+            };
+            reader.readAsDataURL(file);
+        });
+    }
+
+    function dropZone(e) {
+        return e.target.closest ? e.target.closest('.file-drop') : null;
+    }
+
+    document.addEventListener('dragover', function(e) {
+        var zone = dropZone(e);
+        if (!zone) return;
+        e.preventDefault();
+        e.dataTransfer.dropEffect = 'copy';
+        zone.classList.add('file-drop-over');
+    });
+
+    document.addEventListener('dragleave', function(e) {
+        var zone = dropZone(e);
+        if (zone && !zone.contains(e.relatedTarget)) zone.classList.remove('file-drop-over');
+    });
+
+    document.addEventListener('drop', function(e) {
+        var zone = dropZone(e);
+        if (!zone) return;
+        e.preventDefault();
+        zone.classList.remove('file-drop-over');
+        dropFiles(zone, Array.from(e.dataTransfer.files));
+    });
+
+    // Clicking anywhere on the area browses, like its Browse button
+    document.addEventListener('click', function(e) {
+        var zone = dropZone(e);
+        if (!zone) return;
+        sendMessage('file_drop_browse', {
+            button: zone.getAttribute('data-drop-for'),
+            data: dropConfig(zone)
+        });
+    });
+
+    // Sliders show their value next to them
+    function updateSliderValue(input) {
+        var output = input.parentElement.querySelector('.form-slider-value');
+        if (!output) return;
+        var unit = input.getAttribute('data-unit');
+        var text = unit ? input.value + ' ' + unit : input.value;
+        output.textContent = text;
+        input.setAttribute('aria-valuetext', text);
+    }
+
+    document.addEventListener('input', function(e) {
+        if (e.target.tagName !== 'INPUT') return;
+        if (e.target.type === 'range') updateSliderValue(e.target);
+        if (e.target.type === 'color' || e.target.hasAttribute('data-color')) updateColorField(e.target);
+        updatePasswordStrength(e.target);
+        if (document.querySelector('input[data-confirms]')) {
+            updatePasswordConfirm();
+        }
+        if (e.target.hasAttribute('data-mask')) maskInput(e.target);
+        if (isCheckedField(e.target)) {
+            e.target.removeAttribute('data-verified');
+            e.target.removeAttribute('aria-busy');
+            checkField(e.target, false);
+        }
+    });
+
+    document.addEventListener('change', function(e) {
+        if (isCheckedField(e.target)) checkField(e.target, true);
+    });
+
+    function initFormFields() {
+        document.querySelectorAll('.password-strength').forEach(function(meter) {
+            var input = document.getElementById(meter.getAttribute('data-strength-for'));
+            if (input) updatePasswordStrength(input);
+        });
+        if (document.querySelector('input[data-confirms]')) {
+            updatePasswordConfirm();
+        }
+        document.querySelectorAll('.form-repeater').forEach(updateGroupButtons);
+        document.querySelectorAll('.file-drop').forEach(function(zone) {
+            var input = document.getElementById(zone.getAttribute('data-drop-for'));
+            if (input) renderDropList(input);
+        });
+        document.querySelectorAll('.flow-content input').forEach(function(input) {
+            if (input.hasAttribute('data-mask')) maskInput(input);
+            if (isCheckedField(input)) checkField(input, input.value !== '');
+        });
+    }
+
     // Toggle a password field between hidden and visible. JS-only — does not
     // submit the form. Used by the FormField.RevealToggle eye-icon button.
     window.toggleReveal = function(btn) {
@@ -385,6 +1715,27 @@
         }
     };
 
+    // License with RequireScroll: "I Agree" stays disabled until the text
+    // has been scrolled to the end (or fits without scrolling)
+    function initLicenseScroll() {
+        var content = document.querySelector('.license-content[data-require-scroll]');
+        if (!content) return;
+        var check = function() {
+            if (content.scrollTop + content.clientHeight < content.scrollHeight - 2) {
+                window.updateConfirmButton(false);
+                return;
+            }
+            window.updateConfirmButton(true);
+            var hint = document.querySelector('.license-scroll-hint');
+            if (hint) hint.hidden = true;
+            content.removeEventListener('scroll', check);
+            window.removeEventListener('resize', check);
+        };
+        content.addEventListener('scroll', check);
+        window.addEventListener('resize', check);
+        check();
+    }
+
     // Update Install button based on summary checkboxes (for Summary page with required checkboxes)
     window.updateSummaryCheckboxes = function() {
         // Handle exclusive groups: when a checkbox with data-exclusive-group is checked,
@@ -496,6 +1847,118 @@
         }
     });
 
+    // Multi-choice dependency rules (data-requires / data-excludes hold
+    // newline-separated choice values). Mirrors ResolveChoices in Go.
+    function choiceRules(input, attr) {
+        var v = input.getAttribute(attr);
+        return v ? v.split('\n') : [];
+    }
+
+    function applyChoiceRules(list, changed) {
+        var inputs = Array.from(list.querySelectorAll('input[type="checkbox"]'));
+        var byValue = {};
+        inputs.forEach(function(input) { byValue[input.value] = input; });
+
+        var queue = [changed];
+        while (queue.length > 0) {
+            var input = queue.shift();
+            if (input.checked) {
+                // Select requirements, deselect anything in conflict
+                choiceRules(input, 'data-requires').forEach(function(v) {
+                    var dep = byValue[v];
+                    if (dep && !dep.checked) {
+                        dep.checked = true;
+                        queue.push(dep);
+                    }
+                });
+                inputs.forEach(function(other) {
+                    if (other !== input && other.checked &&
+                        (choiceRules(input, 'data-excludes').indexOf(other.value) !== -1 ||
+                         choiceRules(other, 'data-excludes').indexOf(input.value) !== -1)) {
+                        other.checked = false;
+                        queue.push(other);
+                    }
+                });
+            } else {
+                // Deselect choices that depend on this one
+                inputs.forEach(function(other) {
+                    if (other.checked && choiceRules(other, 'data-requires').indexOf(input.value) !== -1) {
+                        other.checked = false;
+                        queue.push(other);
+                    }
+                });
+            }
+        }
+    }
+
+    // Format a byte count with binary units in the page language.
+    // Must match formatBytes in Go; units come from the element's data-units.
+    function formatSize(bytes, units) {
+        var lang = document.documentElement.lang || 'en';
+        function number(value, decimals) {
+            return value.toLocaleString(lang, { minimumFractionDigits: decimals, maximumFractionDigits: decimals });
+        }
+        if (bytes < 1024) {
+            return number(bytes, 0) + ' ' + units[0];
+        }
+        var value = bytes / 1024;
+        var i = 1;
+        while (value >= 1024 && i < units.length - 1) {
+            value /= 1024;
+            i++;
+        }
+        return number(value, value < 10 ? 1 : 0) + ' ' + units[i];
+    }
+
+    // Update the "space required" total below a multi-choice list
+    function updateChoiceTotal(list) {
+        var totalEl = document.getElementById('choice-total-size');
+        if (!totalEl) {
+            return;
+        }
+        var total = 0;
+        list.querySelectorAll('input[type="checkbox"]:checked').forEach(function(input) {
+            var size = input.closest('.choice-item').querySelector('.choice-size');
+            if (size) {
+                total += parseInt(size.getAttribute('data-size'), 10) || 0;
+            }
+        });
+        var units = (totalEl.getAttribute('data-units') || 'B,KB,MB,GB,TB').split(',');
+        totalEl.textContent = formatSize(total, units);
+    }
+
+    document.addEventListener('change', function(e) {
+        var list = e.target.closest && e.target.closest('.choice-list-multi');
+        if (list && e.target.type === 'checkbox') {
+            applyChoiceRules(list, e.target);
+            updateChoiceTotal(list);
+        }
+    });
+
+    // Item lists: "Select all" checks or clears every item and shows a
+    // partial selection as indeterminate
+    function updateSelectAll() {
+        const all = document.getElementById('item-select-all');
+        const list = document.querySelector('.item-list');
+        if (!all || !list) return;
+        const inputs = getChoiceInputs(list);
+        const checked = inputs.filter(function(input) { return input.checked; }).length;
+        all.checked = inputs.length > 0 && checked === inputs.length;
+        all.indeterminate = checked > 0 && checked < inputs.length;
+    }
+
+    document.addEventListener('change', function(e) {
+        if (e.target.id === 'item-select-all') {
+            const list = document.querySelector('.item-list');
+            getChoiceInputs(list).forEach(function(input) {
+                input.checked = e.target.checked;
+            });
+            updateChoiceTotal(list);
+        } else if (e.target.closest && e.target.closest('.item-list')) {
+            updateSelectAll();
+        }
+    });
+
     // Focus management on page load
     // If page has focusable content, focus first content element
     // If page has no focusable content, focus the primary/default button
@@ -528,13 +1991,46 @@
     // Initialize page (focus and UI state)
     // Translation is done by the backend - HTML arrives fully translated.
     // Language selector options are also rendered by the backend.
+    // Report the WebView's renderer once per window, so Go can reduce
+    // animations when there is no GPU acceleration (see Flow.RenderingInfo)
+    function checkRenderer() {
+        var root = document.documentElement;
+        if (root.hasAttribute('data-renderer-checked')) return;
+        var renderer = '';
+        var software = true;
+        try {
+            var canvas = document.createElement('canvas');
+            var gl = canvas.getContext('webgl') || canvas.getContext('experimental-webgl');
+            if (gl) {
+                var ext = gl.getExtension('WEBGL_debug_renderer_info');
+                renderer = String(gl.getParameter(ext ? ext.UNMASKED_RENDERER_WEBGL : gl.RENDERER) || '');
+                software = /swiftshader|llvmpipe|softpipe|software|basic render/i.test(renderer);
+                var lose = gl.getExtension('WEBGL_lose_context');
+                if (lose) lose.loseContext();
+            }
+        } catch (e) {
+            // No WebGL: treat as software rendering
+        }
+        if (software) {
+            root.setAttribute('data-reduced-motion', '');
+        }
+        root.setAttribute('data-renderer-checked', '');
+        sendMessage('rendering_info', { data: { renderer: renderer, software: software } });
+    }
+
     function initPage() {
         // Initialize summary checkboxes if present (disables Install button until checked)
         if (window._summaryHasRequiredCheckboxes) {
             window.updateSummaryCheckboxes();
         }
+        // Strength meters, confirm fields and format checks reflect default values
+        initFormFields();
+        initSettings();
+        initReviewVirtual();
+        initLicenseScroll();
         // Set up focus
         initFocus();
+        checkRenderer();
         // Notify Go that page is ready
         sendMessage('page_ready', {});
     }
@@ -559,6 +2055,136 @@
         return Array.from(choiceList.querySelectorAll('input[type="radio"], input[type="checkbox"]'));
     }
 
+    // Context menu: the browser's (default), none ("off") or the page's own ("custom")
+    var contextMenu = document.getElementById('context-menu');
+    var contextTarget = null;
+
+    function hideContextMenu() {
+        if (contextMenu && !contextMenu.hidden) {
+            contextMenu.hidden = true;
+        }
+    }
+
+    document.addEventListener('contextmenu', function(e) {
+        var mode = document.documentElement.getAttribute('data-context-menu');
+        if (!mode) {
+            return;
+        }
+        e.preventDefault();
+        if (mode !== 'custom' || !contextMenu) {
+            return;
+        }
+        contextTarget = e.target;
+        contextMenu.hidden = false;
+        // Keep the menu inside the window
+        var x = Math.min(e.clientX, window.innerWidth - contextMenu.offsetWidth - 4);
+        var y = Math.min(e.clientY, window.innerHeight - contextMenu.offsetHeight - 4);
+        contextMenu.style.left = Math.max(0, x) + 'px';
+        contextMenu.style.top = Math.max(0, y) + 'px';
+        var first = contextMenu.querySelector('.context-menu-item');
+        if (first) {
+            first.focus();
+        }
+    });
+
+    if (contextMenu) {
+        // Keep the selection when clicking a menu item
+        contextMenu.addEventListener('mousedown', function(e) {
+            e.preventDefault();
+        });
+
+        contextMenu.addEventListener('click', function(e) {
+            var item = e.target.closest('.context-menu-item');
+            if (!item) {
+                return;
+            }
+            hideContextMenu();
+            switch (item.getAttribute('data-context-action')) {
+                case 'copy':
+                    document.execCommand('copy');
+                    break;
+                case 'selectAll':
+                    if (contextTarget && (contextTarget.tagName === 'INPUT' || contextTarget.tagName === 'TEXTAREA')) {
+                        contextTarget.focus();
+                        contextTarget.select();
+                    } else {
+                        document.getSelection().selectAllChildren(document.querySelector('.flow-content') || document.body);
+                    }
+                    break;
+                case 'toggleTheme':
+                    sendMessage('toggle_theme', {});
+                    break;
+                default:
+                    sendMessage('context_menu', { data: { index: parseInt(item.getAttribute('data-index'), 10) } });
+            }
+        });
+
+        document.addEventListener('mousedown', function(e) {
+            if (!contextMenu.contains(e.target)) {
+                hideContextMenu();
+            }
+        });
+        window.addEventListener('blur', hideContextMenu);
+        window.addEventListener('resize', hideContextMenu);
+        document.addEventListener('scroll', hideContextMenu, true);
+
+        // Escape closes the menu instead of the page; arrows move between items
+        document.addEventListener('keydown', function(e) {
+            if (contextMenu.hidden) {
+                return;
+            }
+            var items = Array.from(contextMenu.querySelectorAll('.context-menu-item'));
+            var index = items.indexOf(document.activeElement);
+            if (e.key === 'Escape') {
+                hideContextMenu();
+            } else if (e.key === 'ArrowDown') {
+                items[(index + 1) % items.length].focus();
+            } else if (e.key === 'ArrowUp') {
+                items[(index - 1 + items.length) % items.length].focus();
+            } else if (e.key === 'Enter' && index >= 0) {
+                items[index].click();
+            } else {
+                return;
+            }
+            e.preventDefault();
+            e.stopPropagation();
+        }, true);
+    }
+
+    // Kiosk mode: no reload, developer tools or print dialog
+    document.addEventListener('keydown', function(e) {
+        if (document.documentElement.getAttribute('data-window-mode') !== 'kiosk') return;
+        var key = e.key.toLowerCase();
+        var ctrl = e.ctrlKey || e.metaKey;
+        if (key === 'f5' || key === 'f12' || (ctrl && (key === 'r' || key === 'p')) ||
+            (ctrl && e.shiftKey && (key === 'i' || key === 'j' || key === 'c'))) {
+            e.preventDefault();
+            e.stopPropagation();
+        }
+    }, true);
+
+    // Text size: Ctrl+Plus/Minus zoom, Ctrl+0 resets to the base scale.
+    // The scale is reported to Go so the next page keeps it.
+    document.addEventListener('keydown', function(e) {
+        if (!(e.ctrlKey || e.metaKey) || e.altKey) return;
+        var root = document.documentElement;
+        var current = parseFloat(getComputedStyle(root).getPropertyValue('--font-scale')) || 1;
+        var scale;
+        if (e.key === '+' || e.key === '=') {
+            scale = current + 0.1;
+        } else if (e.key === '-') {
+            scale = current - 0.1;
+        } else if (e.key === '0') {
+            scale = parseFloat(root.getAttribute('data-base-font-scale')) || 1;
+        } else {
+            return;
+        }
+        e.preventDefault();
+        scale = Math.round(Math.min(2, Math.max(0.7, scale)) * 100) / 100;
+        root.style.setProperty('--font-scale', String(scale));
+        sendMessage('font_scale', { data: { scale: scale } });
+    });
+
     // Keyboard shortcuts
     document.addEventListener('keydown', function(e) {
         // Arrow Up/Down for choice list navigation
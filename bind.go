@@ -0,0 +1,206 @@
+package webflow
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNoFormData is returned by BindForm when the response is a navigation
+// (Back, Close, a custom button) rather than submitted form values.
+var ErrNoFormData = errors.New("response has no form data")
+
+// BindError reports the fields BindForm could not fill, keyed by field ID.
+type BindError struct {
+	Fields map[string]error
+}
+
+func (e *BindError) Error() string {
+	ids := slices.Sorted(maps.Keys(e.Fields))
+	msgs := make([]string, len(ids))
+	for i, id := range ids {
+		msgs[i] = id + ": " + e.Fields[id].Error()
+	}
+	return "invalid form values: " + strings.Join(msgs, "; ")
+}
+
+// BindForm copies the values of a form response (from ShowForm, ShowPage or
+// FormAction.Values) into a struct. Struct fields name their form field with
+// a `flow:"field_id"` tag, default to the Go field name, and are skipped with
+// `flow:"-"`; `flow:"field_id,required"` rejects a missing or empty value.
+//
+// Values are converted to the field's type: strings, bools, integers,
+// floats, time.Duration, []string, SecretString for passwords, pointers to
+// these, and types implementing encoding.TextUnmarshaler. Fields that fail
+// are reported together in a *BindError; the others are still filled.
+//
+// Example:
+//
+//	type settings struct {
+//	    Server   string `flow:"server,required"`
+//	    Port     int    `flow:"port"`
+//	    Shortcut bool   `flow:"create_shortcut"`
+//	}
+//
+//	resp := f.ShowForm("Settings", fields)
+//	if webflow.IsBack(resp) {
+//	    return
+//	}
+//	cfg, err := webflow.BindForm[settings](resp)
+func BindForm[T any](resp any) (T, error) {
+	var out T
+	data, ok := resp.(map[string]any)
+	if !ok {
+		return out, ErrNoFormData
+	}
+
+	if err := bindValues(data, reflect.ValueOf(&out).Elem()); err != nil {
+		return out, err
+	}
+	return out, nil
+}
+
+// bindValues copies form values into the struct v, as BindForm does. Fields
+// without a value keep theirs; fields with an empty value are cleared,
+// except secrets, which the page never fills in (see structValues).
+func bindValues(data map[string]any, v reflect.Value) error {
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("bind form: %s is not a struct", v.Type())
+	}
+
+	errs := make(map[string]error)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		id, opts, _ := strings.Cut(sf.Tag.Get("flow"), ",")
+		if id == "-" {
+			continue
+		}
+		if id == "" {
+			id = sf.Name
+		}
+		required := slices.Contains(strings.Split(opts, ","), "required")
+
+		value, ok := data[id]
+		if !ok || value == nil || value == "" {
+			if required {
+				errs[id] = errors.New("required")
+			} else if ok && sf.Type != secretType {
+				v.Field(i).SetZero()
+			}
+			continue
+		}
+		if err := setField(v.Field(i), value); err != nil {
+			errs[id] = err
+		}
+	}
+
+	if len(errs) > 0 {
+		return &BindError{Fields: errs}
+	}
+	return nil
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// setField converts a form value to the type of dst and stores it.
+func setField(dst reflect.Value, value any) error {
+	if dst.Kind() == reflect.Pointer {
+		ptr := reflect.New(dst.Type().Elem())
+		if err := setField(ptr.Elem(), value); err != nil {
+			return err
+		}
+		dst.Set(ptr)
+		return nil
+	}
+
+	if u, ok := dst.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(argString(value)))
+	}
+
+	if dst.Type() == durationType {
+		d, err := time.ParseDuration(strings.TrimSpace(argString(value)))
+		if err != nil {
+			return fmt.Errorf("invalid duration %q", argString(value))
+		}
+		dst.SetInt(int64(d))
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.String:
+		dst.SetString(argString(value))
+
+	case reflect.Bool:
+		switch b := value.(type) {
+		case bool:
+			dst.SetBool(b)
+		default:
+			parsed, err := strconv.ParseBool(strings.TrimSpace(argString(value)))
+			if err != nil {
+				return fmt.Errorf("invalid boolean %q", argString(value))
+			}
+			dst.SetBool(parsed)
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(numberText(value), 10, dst.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid whole number %q", argString(value))
+		}
+		dst.SetInt(n)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(numberText(value), 10, dst.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid whole number %q", argString(value))
+		}
+		dst.SetUint(n)
+
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(numberText(value), dst.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid number %q", argString(value))
+		}
+		dst.SetFloat(n)
+
+	case reflect.Slice:
+		if dst.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported type %s", dst.Type())
+		}
+		var items []string
+		switch s := value.(type) {
+		case []any:
+			for _, item := range s {
+				items = append(items, argString(item))
+			}
+		case []string:
+			items = s
+		default:
+			items = []string{argString(value)}
+		}
+		dst.Set(reflect.ValueOf(items).Convert(dst.Type()))
+
+	default:
+		return fmt.Errorf("unsupported type %s", dst.Type())
+	}
+	return nil
+}
+
+// numberText returns a form value as text for number parsing. JSON numbers
+// arrive as float64 and are written without exponent or fraction when whole.
+func numberText(value any) string {
+	if n, ok := value.(float64); ok {
+		return strconv.FormatFloat(n, 'f', -1, 64)
+	}
+	return strings.TrimSpace(argString(value))
+}
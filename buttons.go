@@ -0,0 +1,145 @@
+package webflow
+
+import "strconv"
+
+// setButtonActions records the callbacks of a page's buttons (OnClick,
+// FormField.OnSuffix and the Apply button of a settings page), so clicks on
+// them run the callback instead of completing the page. It also records the
+// page's FormField.Verify checks and the limits of its FieldFileDrops.
+func (f *Flow) setButtonActions(page Page) {
+	actions := make(map[string]func(values map[string]any))
+	add := func(btn *Button) {
+		addButtonAction(actions, btn)
+	}
+
+	bb := page.ButtonBar
+	add(bb.Back)
+	add(bb.Next)
+	add(bb.Close)
+	add(bb.Left)
+	for _, btn := range bb.Actions {
+		add(btn)
+	}
+	for i := range page.Buttons {
+		add(&page.Buttons[i])
+	}
+	fields := pageFields(page)
+	if settings, ok := page.Content.(SettingsConfig); ok {
+		if settings.OnApply != nil {
+			actions[settingsApply] = func(values map[string]any) {
+				if values == nil {
+					values = make(map[string]any)
+				}
+				f.applySettings(settings, values)
+			}
+		}
+	}
+	verify := make(map[string]func(value string) error)
+	f.addFieldActions(actions, verify, fields)
+	var groups []FormField
+	drops := make(map[string]FormField)
+	for _, field := range fields {
+		switch field.Type {
+		case FieldGroup:
+			groups = append(groups, field)
+		case FieldFileDrop:
+			drops[field.ID] = field
+		}
+	}
+
+	f.mu.Lock()
+	f.buttonActions = actions
+	f.fieldChange = page.OnFieldChange
+	f.fieldVerify = verify
+	f.fieldGroups = groups
+	f.dropFields = drops
+	f.mu.Unlock()
+}
+
+// pageFields returns the form fields of a page: those of a form, or of all
+// tabs of a settings page.
+func pageFields(page Page) []FormField {
+	switch c := page.Content.(type) {
+	case []FormField:
+		return c
+	case SettingsConfig:
+		var fields []FormField
+		for _, tab := range c.Tabs {
+			fields = append(fields, tab.Fields...)
+		}
+		return fields
+	}
+	return nil
+}
+
+// addButtonAction records the OnClick callback of btn, if any.
+func addButtonAction(actions map[string]func(values map[string]any), btn *Button) {
+	if btn != nil && btn.OnClick != nil {
+		onClick := btn.OnClick
+		actions[btn.ID] = func(map[string]any) { onClick() }
+	}
+}
+
+// addFieldActions records the Suffix callbacks and Verify checks of fields.
+func (f *Flow) addFieldActions(actions map[string]func(values map[string]any), verify map[string]func(value string) error, fields []FormField) {
+	for _, field := range fields {
+		field = field.withHostTest()
+		if field.Verify != nil {
+			verify[field.ID] = field.Verify
+		}
+		if field.Suffix != nil && field.OnSuffix != nil {
+			actions[field.Suffix.ID] = func(values map[string]any) {
+				if values == nil {
+					values = make(map[string]any)
+				}
+				field.OnSuffix(&FormAction{flow: f, field: field.ID, values: values})
+			}
+			continue
+		}
+		addButtonAction(actions, field.Suffix)
+	}
+}
+
+// rowActions returns the Suffix callbacks and Verify checks of the FieldGroup
+// row that the button or field id belongs to. Rows are added on the page, so
+// their callbacks are looked up when used.
+func (f *Flow) rowActions(id string) (map[string]func(values map[string]any), map[string]func(value string) error) {
+	f.mu.Lock()
+	groups := f.fieldGroups
+	f.mu.Unlock()
+	actions := make(map[string]func(values map[string]any))
+	verify := make(map[string]func(value string) error)
+	f.addFieldActions(actions, verify, groupRowFields(groups, id))
+	return actions, verify
+}
+
+// handleButtonAction runs the OnClick callback of a clicked button and
+// clears its loading state when the callback returns.
+func (f *Flow) handleButtonAction(resp messageResponse) {
+	f.mu.Lock()
+	action := f.buttonActions[resp.Button]
+	f.mu.Unlock()
+	if action == nil {
+		actions, _ := f.rowActions(resp.Button)
+		action = actions[resp.Button]
+	}
+
+	go func() {
+		if action != nil {
+			action(resp.Data)
+		}
+		f.SetButtonLoading(resp.Button, false)
+	}()
+}
+
+// SetButtonLoading shows or hides the spinner of a button on the current page
+// and disables it while loading, e.g. while a check started from the page runs.
+func (f *Flow) SetButtonLoading(id string, loading bool) {
+	f.wv.EvaluateScript(`window.setButtonLoading(` + jsonString(id) + `, ` + strconv.FormatBool(loading) + `);`)
+}
+
+// SetButtonBadge changes the badge of a button on the current page; an empty
+// badge removes it.
+func (f *Flow) SetButtonBadge(id, badge string) {
+	f.wv.EvaluateScript(`window.setButtonBadge(` + jsonString(id) + `, ` + jsonString(badge) + `);`)
+}
@@ -0,0 +1,145 @@
+package webflow
+
+import (
+	"path/filepath"
+
+	"github.com/crafted-tech/webflow/platform"
+)
+
+// certificateExtensions are the certificate files ShowCertificateImport offers.
+var certificateExtensions = []string{".crt", ".cer", ".pem", ".der"}
+
+// CertificateConfig is the content of ShowCertificateImport.
+type CertificateConfig struct {
+	Message string                  // Text above the file picker (default: a standard explanation)
+	Path    string                  // Certificate to import; if set, the page previews it without asking for a file
+	Import  func(path string) error // Imports the certificate (default: platform.ImportCertificate)
+}
+
+// ShowCertificateImport asks for a certificate file, shows its subject,
+// issuer, validity and fingerprint, and imports it into the system trust
+// store when the user clicks Import. Import failures are shown on the page.
+// The picker uses WizardMiddle() if no ButtonBar is provided; the preview
+// uses the same buttons with Import as Next.
+//
+// Returns:
+//   - *platform.Certificate that was imported if user clicked Import
+//   - Navigation (Back/Close) for navigation
+//
+// Example:
+//
+//	resp := f.ShowCertificateImport("Company CA", webflow.CertificateConfig{})
+//	if cert, ok := resp.(*platform.Certificate); ok {
+//	    log.Printf("Trusted %s (%s)", cert.Subject, cert.Fingerprint)
+//	}
+func (f *Flow) ShowCertificateImport(title string, cfg CertificateConfig, opts ...PageOption) any {
+	bar := WizardMiddle()
+	for _, opt := range opts {
+		pcfg := PageConfig{}
+		opt(&pcfg)
+		if pcfg.ButtonBar != nil {
+			bar = *pcfg.ButtonBar
+		}
+	}
+	if cfg.Message == "" {
+		cfg.Message = f.T("certificate.message")
+	}
+	if cfg.Import == nil {
+		cfg.Import = platform.ImportCertificate
+	}
+
+	path := cfg.Path
+	alert := ""
+	for {
+		if path == "" {
+			fields := []FormField{{Type: FieldInfo, Label: cfg.Message}}
+			if alert != "" {
+				fields = append(fields, FormField{Type: FieldInfo, Label: alert, AlertType: AlertError})
+			}
+			fields = append(fields, FormField{
+				ID:         "certificate",
+				Type:       FieldFileDrop,
+				Label:      f.T("certificate.file"),
+				Extensions: certificateExtensions,
+			})
+			resp := f.ShowForm(title, fields, append(opts, WithButtonBar(bar))...)
+			values, ok := resp.(map[string]any)
+			if !ok {
+				return resp
+			}
+			if path, _ = values["certificate"].(string); path == "" {
+				alert = f.T("certificate.noFile")
+				continue
+			}
+		}
+
+		cert, err := platform.ReadCertificate(path)
+		if err != nil {
+			if cfg.Path != "" {
+				f.ShowAlertError(title, f.TF("certificate.unreadable", filepath.Base(path)))
+				return Close
+			}
+			alert = f.TF("certificate.unreadable", filepath.Base(path))
+			path = ""
+			continue
+		}
+
+		preview := bar
+		preview.Next = NewButton(f.T("certificate.import"), ButtonNext).WithPrimary()
+		if cfg.Path == "" {
+			preview.Back = NewButton(f.T("button.back"), ButtonBack)
+		}
+		page := applyPageConfig(title, f.certificateSummary(cert, alert), append(opts, WithButtonBar(preview)))
+		msg := f.showPageInternal(page)
+		alert = ""
+
+		switch msg.Button {
+		case ButtonNext:
+			if err := cfg.Import(path); err != nil {
+				alert = f.TF("certificate.importFailed", err.Error())
+				continue
+			}
+			return cert
+		case ButtonBack:
+			if cfg.Path != "" {
+				return Back
+			}
+			path = ""
+		case ButtonClose, ButtonCancel, "":
+			return Close
+		default:
+			return Navigation(msg.Button)
+		}
+	}
+}
+
+// certificateSummary returns the preview of a certificate, with alert as an
+// error above it if set.
+func (f *Flow) certificateSummary(cert *platform.Certificate, alert string) SummaryConfig {
+	kind := "certificate.typeCertificate"
+	switch {
+	case cert.IsCA && cert.SelfSigned:
+		kind = "certificate.typeRoot"
+	case cert.IsCA:
+		kind = "certificate.typeIntermediate"
+	case cert.SelfSigned:
+		kind = "certificate.typeSelfSigned"
+	}
+
+	var items []SummaryItem
+	if alert != "" {
+		items = append(items, SummaryItem{Value: alert, AlertType: AlertError})
+	}
+	items = append(items,
+		SummaryItem{Label: f.T("certificate.subject"), Value: cert.Subject},
+		SummaryItem{Label: f.T("certificate.issuer"), Value: cert.Issuer},
+		SummaryItem{Label: f.T("certificate.type"), Value: f.T(kind)},
+		SummaryItem{Label: f.T("certificate.validity"), Value: f.TF("certificate.validityRange", cert.NotBefore, cert.NotAfter)},
+		SummaryItem{Label: f.T("certificate.fingerprint"), Value: cert.Fingerprint},
+	)
+	if cert.Expired() {
+		items = append(items, SummaryItem{Value: f.T("certificate.expired"), AlertType: AlertWarning})
+	}
+	items = append(items, SummaryItem{Value: f.T("certificate.trustWarning"), AlertType: AlertWarning})
+	return SummaryConfig{Items: items}
+}
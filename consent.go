@@ -0,0 +1,187 @@
+package webflow
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// IDs of the standard consent items (see StandardConsentItems).
+const (
+	ConsentAnalytics    = "consent_analytics"
+	ConsentCrashReports = "consent_crash_reports"
+	ConsentMarketing    = "consent_marketing"
+)
+
+// ConsentItem is one independent toggle of a consent page.
+type ConsentItem struct {
+	ID          string // Key of the choice in ConsentResult.Choices
+	Label       string // Toggle label, e.g. "Send usage statistics"
+	Description string // What is collected and why
+	PolicyURL   string // Optional link to the policy covering this item
+	Default     bool   // Initially on; leave off where the user must opt in
+}
+
+// ConsentConfig is the content of ShowConsent.
+type ConsentConfig struct {
+	Message   string        // Text above the toggles (default: a standard privacy notice)
+	Items     []ConsentItem // Toggles (default: StandardConsentItems(PolicyURL))
+	PolicyURL string        // Privacy policy linked from the standard items
+	Version   int           // Version of the consent text, recorded in the result; bump it when the wording changes
+}
+
+// ConsentResult is what the user agreed to on a consent page.
+type ConsentResult struct {
+	Analytics    bool            `json:"analytics"`    // ConsentAnalytics was on
+	CrashReports bool            `json:"crashReports"` // ConsentCrashReports was on
+	Marketing    bool            `json:"marketing"`    // ConsentMarketing was on
+	Choices      map[string]bool `json:"choices"`      // Every item by ID, including the standard ones
+	Version      int             `json:"version"`      // ConsentConfig.Version shown
+	Time         time.Time       `json:"time"`         // When the user confirmed
+}
+
+// Granted reports whether the item with id was on.
+func (r ConsentResult) Granted(id string) bool {
+	return r.Choices[id]
+}
+
+// StandardConsentItems returns toggles for usage analytics, crash reports and
+// marketing emails, all off so the user opts in, each linking to policyURL.
+func StandardConsentItems(policyURL string) []ConsentItem {
+	return []ConsentItem{
+		{ID: ConsentAnalytics, Label: TKey("consent.analytics"), Description: TKey("consent.analyticsDescription"), PolicyURL: policyURL},
+		{ID: ConsentCrashReports, Label: TKey("consent.crashReports"), Description: TKey("consent.crashReportsDescription"), PolicyURL: policyURL},
+		{ID: ConsentMarketing, Label: TKey("consent.marketing"), Description: TKey("consent.marketingDescription"), PolicyURL: policyURL},
+	}
+}
+
+// ShowConsent displays a privacy consent page with an independent toggle per
+// item, each with a description and a link to its policy.
+// Default is WizardMiddle() if no ButtonBar is provided.
+//
+// Returns:
+//   - ConsentResult if user clicked Next
+//   - Navigation (Back/Close) for navigation
+//
+// Example:
+//
+//	resp := f.ShowConsent("Privacy", webflow.ConsentConfig{
+//	    PolicyURL: "https://acme.example/privacy",
+//	    Version:   1,
+//	})
+//	if consent, ok := resp.(webflow.ConsentResult); ok {
+//	    webflow.SaveConsent("Acme", consent)
+//	}
+func (f *Flow) ShowConsent(title string, cfg ConsentConfig, opts ...PageOption) any {
+	hasButtonBar := false
+	for _, opt := range opts {
+		pcfg := PageConfig{}
+		opt(&pcfg)
+		if pcfg.ButtonBar != nil {
+			hasButtonBar = true
+			break
+		}
+	}
+	if !hasButtonBar {
+		opts = append(opts, WithButtonBar(WizardMiddle()))
+	}
+	if cfg.Message == "" {
+		cfg.Message = f.T("consent.message")
+	}
+	if len(cfg.Items) == 0 {
+		cfg.Items = StandardConsentItems(cfg.PolicyURL)
+	}
+
+	page := applyPageConfig(title, cfg, opts)
+	msg := f.showPageInternal(page)
+
+	switch msg.Button {
+	case ButtonBack:
+		return Back
+	case ButtonNext:
+		return cfg.result(msg.Data)
+	case ButtonClose, ButtonCancel, "":
+		if msg.Button == "" && msg.Type != "window_close" && msg.Data != nil {
+			return cfg.result(msg.Data)
+		}
+		return Close
+	default:
+		return Navigation(msg.Button)
+	}
+}
+
+// result returns the ConsentResult for the form values of a consent page.
+func (cfg ConsentConfig) result(data map[string]any) ConsentResult {
+	r := ConsentResult{
+		Choices: make(map[string]bool, len(cfg.Items)),
+		Version: cfg.Version,
+		Time:    time.Now().UTC(),
+	}
+	for _, item := range cfg.Items {
+		r.Choices[item.ID] = data[item.ID] == true
+	}
+	r.Analytics = r.Choices[ConsentAnalytics]
+	r.CrashReports = r.Choices[ConsentCrashReports]
+	r.Marketing = r.Choices[ConsentMarketing]
+	return r
+}
+
+// ConsentPath returns where SaveConsent stores an app's consent: a file in
+// the user's config folder.
+func ConsentPath(app string) (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, app, "consent.json"), nil
+}
+
+// SaveConsent stores the user's consent for app, so the installed app can
+// read it with LoadConsent.
+func SaveConsent(app string, r ConsentResult) error {
+	path, err := ConsentPath(app)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// LoadConsent returns the consent stored for app by SaveConsent. It reports
+// false, with a zero result that grants nothing, if none was stored.
+//
+// Example:
+//
+//	consent, ok, _ := webflow.LoadConsent("Acme")
+//	if !ok || consent.Version < currentConsentVersion {
+//	    // Ask again
+//	}
+//	if consent.CrashReports {
+//	    enableCrashReporting()
+//	}
+func LoadConsent(app string) (ConsentResult, bool, error) {
+	path, err := ConsentPath(app)
+	if err != nil {
+		return ConsentResult{}, false, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return ConsentResult{}, false, nil
+	}
+	if err != nil {
+		return ConsentResult{}, false, err
+	}
+	var r ConsentResult
+	if err := json.Unmarshal(data, &r); err != nil {
+		return ConsentResult{}, false, err
+	}
+	return r, true, nil
+}
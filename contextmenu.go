@@ -0,0 +1,105 @@
+package webflow
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+)
+
+// ContextMenuItem is an entry of the custom right-click menu (see WithContextMenu).
+type ContextMenuItem struct {
+	Label  string // Menu text
+	Action func() // Called when the item is chosen
+
+	builtin string // Action handled in the page: "copy", "selectAll" or "toggleTheme"
+}
+
+// ContextMenuCopy copies the selected text.
+func ContextMenuCopy() ContextMenuItem {
+	return ContextMenuItem{builtin: "copy"}
+}
+
+// ContextMenuSelectAll selects the text of the focused field, or of the page.
+func ContextMenuSelectAll() ContextMenuItem {
+	return ContextMenuItem{builtin: "selectAll"}
+}
+
+// ContextMenuToggleTheme switches between light and dark mode.
+func ContextMenuToggleTheme() ContextMenuItem {
+	return ContextMenuItem{builtin: "toggleTheme"}
+}
+
+// WithContextMenu replaces the browser's right-click menu (with its Inspect
+// and Reload entries) by the given items. Without items, right-click does
+// nothing, as kiosk-style installers usually want.
+//
+// Actions run on their own goroutine and may call Flow methods that update
+// the current page, but must not show another page.
+//
+// Example:
+//
+//	webflow.WithContextMenu(
+//	    webflow.ContextMenuCopy(),
+//	    webflow.ContextMenuSelectAll(),
+//	    webflow.ContextMenuItem{Label: "Open log folder", Action: openLogFolder},
+//	)
+func WithContextMenu(items ...ContextMenuItem) Option {
+	return func(c *Config) {
+		c.ContextMenu = append([]ContextMenuItem{}, items...)
+	}
+}
+
+// contextMenuController is an optional interface for turning off the
+// backend's native context menu (e.g. WebView2 default context menus).
+type contextMenuController interface {
+	SetContextMenuEnabled(enabled bool)
+}
+
+// contextMenuMode returns the data-context-menu value for the page:
+// "" for the browser menu, "off" or "custom".
+func contextMenuMode(items []ContextMenuItem) string {
+	switch {
+	case items == nil:
+		return ""
+	case len(items) == 0:
+		return "off"
+	default:
+		return "custom"
+	}
+}
+
+// renderContextMenu renders the hidden custom context menu, shown by runtime.js.
+func renderContextMenu(items []ContextMenuItem, tr translator) string {
+	if len(items) == 0 {
+		return ""
+	}
+	var buf bytes.Buffer
+	buf.WriteString(`    <div class="context-menu" id="context-menu" role="menu" hidden>
+`)
+	for i, item := range items {
+		label := item.Label
+		action := item.builtin
+		if action != "" && label == "" {
+			label = tr.T("contextMenu." + action)
+		}
+		if action == "" {
+			action = "custom"
+		}
+		buf.WriteString(fmt.Sprintf(`        <button type="button" class="context-menu-item" role="menuitem" data-context-action="%s" data-index="%d">%s</button>
+`, action, i, html.EscapeString(label)))
+	}
+	buf.WriteString(`    </div>
+`)
+	return buf.String()
+}
+
+// handleContextMenu runs the action of a custom context menu item.
+func (f *Flow) handleContextMenu(resp messageResponse) {
+	index, ok := resp.Data["index"].(float64)
+	if !ok || int(index) < 0 || int(index) >= len(f.config.ContextMenu) {
+		return
+	}
+	if action := f.config.ContextMenu[int(index)].Action; action != nil {
+		go action()
+	}
+}
@@ -0,0 +1,193 @@
+package webflow
+
+import "strings"
+
+// maxDiffCells limits the line-by-line comparison of ShowDiff; larger changes
+// are shown as all old lines removed and all new lines added.
+const maxDiffCells = 1 << 22
+
+// DiffConfig is the content of ShowDiff: a file before and after a change.
+type DiffConfig struct {
+	Before      string // Current content
+	After       string // Content after the change
+	BeforeLabel string // Label of the current content, e.g. a path
+	AfterLabel  string // Label of the new content (default: BeforeLabel)
+
+	// Language is LangJSON, LangYAML or LangINI for syntax highlighting, or
+	// "" to pick it by the labels' file extensions.
+	Language string
+
+	SideBySide bool // Show old and new content in two columns instead of one
+	Context    int  // Unchanged lines shown around each change (0 = 3, negative = all)
+}
+
+// ShowDiff displays the changes from cfg.Before to cfg.After, e.g. to show the
+// configuration changes an upgrade will make before the user confirms.
+// Removed and added lines are marked, with line numbers and syntax
+// highlighting; long runs of unchanged lines are collapsed.
+// Default is WizardMiddle() if no ButtonBar is provided.
+//
+// Returns:
+//   - true if user clicked Next
+//   - Navigation (Back/Close) for navigation
+//
+// Example:
+//
+//	resp := f.ShowDiff("Configuration Changes", webflow.DiffConfig{
+//	    Before:      string(oldConfig),
+//	    After:       string(newConfig),
+//	    BeforeLabel: configPath,
+//	})
+//	if resp == true {
+//	    os.WriteFile(configPath, newConfig, 0644)
+//	}
+func (f *Flow) ShowDiff(title string, cfg DiffConfig, opts ...PageOption) any {
+	hasButtonBar := false
+	for _, opt := range opts {
+		pcfg := PageConfig{}
+		opt(&pcfg)
+		if pcfg.ButtonBar != nil {
+			hasButtonBar = true
+			break
+		}
+	}
+	if !hasButtonBar {
+		opts = append(opts, WithButtonBar(WizardMiddle()))
+	}
+
+	page := applyPageConfig(title, cfg, opts)
+	msg := f.showPageInternal(page)
+
+	switch msg.Button {
+	case ButtonBack:
+		return Back
+	case ButtonNext:
+		return true
+	case ButtonClose, ButtonCancel, "":
+		if msg.Button == "" && msg.Type != "window_close" {
+			return true
+		}
+		return Close
+	default:
+		return Navigation(msg.Button)
+	}
+}
+
+// language returns the highlighting language of the diff.
+func (cfg DiffConfig) language() string {
+	if cfg.Language != "" {
+		return cfg.Language
+	}
+	if lang := LanguageForPath(cfg.AfterLabel); lang != "" {
+		return lang
+	}
+	return LanguageForPath(cfg.BeforeLabel)
+}
+
+// diffLine is a line of a diff. Op is '=' for an unchanged line, '-' for a
+// removed and '+' for an added one; Old and New are 1-based line numbers in
+// Before and After, or 0 where the line doesn't exist.
+type diffLine struct {
+	Op       byte
+	Old, New int
+	Text     string
+}
+
+// splitLines splits text into lines, ignoring a final newline.
+func splitLines(text string) []string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.TrimSuffix(text, "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
+
+// diffLines compares two texts line by line, using the longest common
+// subsequence of the lines between their common prefix and suffix.
+func diffLines(before, after string) []diffLine {
+	a, b := splitLines(before), splitLines(after)
+
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	lines := make([]diffLine, 0, len(a)+len(b))
+	for i := 0; i < prefix; i++ {
+		lines = append(lines, diffLine{Op: '=', Old: i + 1, New: i + 1, Text: a[i]})
+	}
+
+	am, bm := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	n, m := len(am), len(bm)
+	if n*m > maxDiffCells {
+		for i, text := range am {
+			lines = append(lines, diffLine{Op: '-', Old: prefix + i + 1, Text: text})
+		}
+		for j, text := range bm {
+			lines = append(lines, diffLine{Op: '+', New: prefix + j + 1, Text: text})
+		}
+	} else {
+		// lcs[i][j] is the length of the longest common subsequence of
+		// am[i:] and bm[j:].
+		lcs := make([][]int32, n+1)
+		for i := range lcs {
+			lcs[i] = make([]int32, m+1)
+		}
+		for i := n - 1; i >= 0; i-- {
+			for j := m - 1; j >= 0; j-- {
+				if am[i] == bm[j] {
+					lcs[i][j] = lcs[i+1][j+1] + 1
+				} else {
+					lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+				}
+			}
+		}
+		i, j := 0, 0
+		for i < n || j < m {
+			switch {
+			case i < n && j < m && am[i] == bm[j]:
+				lines = append(lines, diffLine{Op: '=', Old: prefix + i + 1, New: prefix + j + 1, Text: am[i]})
+				i++
+				j++
+			case j == m || i < n && lcs[i+1][j] >= lcs[i][j+1]:
+				lines = append(lines, diffLine{Op: '-', Old: prefix + i + 1, Text: am[i]})
+				i++
+			default:
+				lines = append(lines, diffLine{Op: '+', New: prefix + j + 1, Text: bm[j]})
+				j++
+			}
+		}
+	}
+
+	for k := 0; k < suffix; k++ {
+		lines = append(lines, diffLine{Op: '=', Old: len(a) - suffix + k + 1, New: len(b) - suffix + k + 1, Text: a[len(a)-suffix+k]})
+	}
+	return lines
+}
+
+// visibleDiffLines marks the lines to show: all changes and up to context
+// unchanged lines before and after each. A negative context shows all lines.
+// A single hidden line is shown too, as collapsing it saves no space.
+func visibleDiffLines(lines []diffLine, context int) []bool {
+	visible := make([]bool, len(lines))
+	for i, line := range lines {
+		if context < 0 || line.Op == '=' {
+			visible[i] = context < 0
+			continue
+		}
+		for k := max(0, i-context); k <= min(len(lines)-1, i+context); k++ {
+			visible[k] = true
+		}
+	}
+	for i := range visible {
+		if !visible[i] && (i == 0 || visible[i-1]) && (i == len(visible)-1 || visible[i+1]) {
+			visible[i] = true
+		}
+	}
+	return visible
+}
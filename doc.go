@@ -58,8 +58,15 @@ The package provides several methods for different page types:
   - ShowMessage: Display text with configurable buttons
   - ShowChoice: Display single-selection with Choice structs (labels + optional descriptions)
   - ShowMultiChoice: Display multi-selection with Choice structs (labels + optional descriptions)
+  - ShowItemList: Display found items with checkboxes, sizes and details, e.g. for cleanup
   - ShowForm: Display a form with various input types
-  - ShowProgress: Display a progress bar with cancellation support
+  - ShowProgress: Display a progress bar with cancellation support and optional time estimates
+  - ShowMultiProgress: Display several concurrent progress bars with an overall bar
+  - ShowSettings: Display a tabbed preferences page with Apply, OK and Cancel
+  - ShowDiff: Display before/after changes to a file, with JSON, YAML and INI highlighting
+  - ShowConsent: Display privacy consent toggles (analytics, crash reports, marketing) with policy links
+  - ShowCertificateImport: Preview a certificate's subject, issuer, expiry and fingerprint, then import it into the system trust store
+  - ShowFeedback: Ask for a rating, a comment and an optional email address, e.g. when the user cancels, and submit them through a callback
   - ShowPage: Display a fully custom page (advanced use)
 
 # Form Fields
@@ -68,11 +75,28 @@ Forms support the following field types:
 
   - FieldText: Single-line text input
   - FieldPassword: Password input (masked)
+  - FieldEmail: Email address input, checked as the user types
+  - FieldURL: http or https URL input, checked as the user types
+  - FieldHost: Host name or IP address with optional port, with a Test button
+  - FieldSlider: Slider from Min to Max showing the current value
+  - FieldColor: Color picker returning a hex string such as "#1a73e8"
+  - FieldGroup: Rows of sub-fields the user can add and remove, returned as []map[string]any
+  - FieldFileDrop: Area to drop files on or browse for them; returns the path, or []string if Multiple
   - FieldCheckbox: Boolean checkbox
   - FieldSelect: Dropdown selection
   - FieldPath: File/directory path with browse button
   - FieldTextArea: Multi-line text input
 
+Text fields can restrict what is typed with FormField.InputMask (digits,
+phone numbers, IP and MAC addresses, or a template such as "99999-9999") and
+FormField.Pattern; Next stays disabled until the value is complete.
+
+# Onboarding
+
+For a first-run flow in an app rather than an installer, NewOnboardingFlow
+shows a list of pages once per user, resuming where the user left off if the
+window was closed midway (see OnboardingFlow).
+
 # Styling
 
 The UI uses a modern, shadcn-inspired design with automatic dark/light mode
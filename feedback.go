@@ -0,0 +1,148 @@
+package webflow
+
+import (
+	"slices"
+	"strings"
+)
+
+// IDs of the fields of a feedback page.
+const (
+	feedbackRating      = "feedback_rating"
+	feedbackComment     = "feedback_comment"
+	feedbackEmail       = "feedback_email"
+	feedbackDiagnostics = "feedback_diagnostics"
+)
+
+// FeedbackConfig is the content of ShowFeedback.
+type FeedbackConfig struct {
+	Message     string               // Text above the fields (default: asks why the user is leaving)
+	NoEmail     bool                 // Hide the optional email field
+	Diagnostics bool                 // Offer an "Include diagnostics" checkbox (see Feedback.IncludeDiagnostics)
+	Submit      func(Feedback) error // Sends the feedback; its error is shown on the page so the user can retry or skip
+}
+
+// Feedback is what the user entered on a feedback page.
+type Feedback struct {
+	Rating             int    // 1 (very poor) to 5 (very good), or 0 if not rated
+	Comment            string // Free text
+	Email              string // Address to reply to, or "" if not given
+	IncludeDiagnostics bool   // The user agreed to attach diagnostics
+}
+
+// ShowFeedback asks the user for a rating, a comment and optionally an email
+// address, e.g. after they cancel an install, and passes the answer to
+// cfg.Submit when they click Send. Submit errors are shown on the page.
+// Default buttons are Skip (Close) and Send (Next) if no ButtonBar is
+// provided.
+//
+// Returns:
+//   - Feedback that was submitted if user clicked Send
+//   - Navigation (Back/Close) for navigation
+//
+// Example:
+//
+//	f.ShowFeedback("Why are you leaving?", webflow.FeedbackConfig{
+//	    Diagnostics: true,
+//	    Submit: func(fb webflow.Feedback) error {
+//	        if fb.IncludeDiagnostics {
+//	            installer.CollectDiagnostics(zipPath, app.Diagnostics())
+//	        }
+//	        return sendFeedback(fb, zipPath)
+//	    },
+//	})
+func (f *Flow) ShowFeedback(title string, cfg FeedbackConfig, opts ...PageOption) any {
+	hasButtonBar := false
+	for _, opt := range opts {
+		pcfg := PageConfig{}
+		opt(&pcfg)
+		if pcfg.ButtonBar != nil {
+			hasButtonBar = true
+			break
+		}
+	}
+	if !hasButtonBar {
+		opts = append(opts, WithButtonBar(ButtonBar{
+			Close: NewButton(f.T("feedback.skip"), ButtonClose),
+			Next:  NewButton(f.T("feedback.send"), ButtonNext).WithPrimary(),
+		}))
+	}
+	if cfg.Message == "" {
+		cfg.Message = f.T("feedback.message")
+	}
+
+	// Best rating first, after the "not rated" choice
+	ratings := []string{
+		f.T("feedback.notRated"),
+		f.T("feedback.rating5"),
+		f.T("feedback.rating4"),
+		f.T("feedback.rating3"),
+		f.T("feedback.rating2"),
+		f.T("feedback.rating1"),
+	}
+
+	var fb Feedback
+	alert := ""
+	for {
+		fields := []FormField{{Type: FieldInfo, Label: cfg.Message}}
+		if alert != "" {
+			fields = append(fields, FormField{Type: FieldInfo, Label: alert, AlertType: AlertError})
+		}
+		rating := ratings[0]
+		if fb.Rating > 0 {
+			rating = ratings[6-fb.Rating]
+		}
+		fields = append(fields,
+			FormField{ID: feedbackRating, Type: FieldSelect, Label: f.T("feedback.rating"), Options: ratings, Default: rating},
+			FormField{ID: feedbackComment, Type: FieldTextArea, Label: f.T("feedback.comment"), Default: fb.Comment, Focus: true},
+		)
+		if !cfg.NoEmail {
+			fields = append(fields, FormField{
+				ID:          feedbackEmail,
+				Type:        FieldEmail,
+				Label:       f.T("feedback.email"),
+				Placeholder: f.T("feedback.emailPlaceholder"),
+				Default:     fb.Email,
+			})
+		}
+		if cfg.Diagnostics {
+			fields = append(fields, FormField{
+				ID:      feedbackDiagnostics,
+				Type:    FieldCheckbox,
+				Label:   f.T("feedback.includeDiagnostics"),
+				Default: fb.IncludeDiagnostics,
+			})
+		}
+
+		resp := f.ShowForm(title, fields, opts...)
+		values, ok := resp.(map[string]any)
+		if !ok {
+			return resp
+		}
+		if button, _ := values["_button"].(string); button != "" {
+			return Navigation(button)
+		}
+
+		fb = Feedback{IncludeDiagnostics: values[feedbackDiagnostics] == true}
+		if s, _ := values[feedbackRating].(string); s != "" {
+			if i := slices.Index(ratings, s); i > 0 {
+				fb.Rating = 6 - i
+			}
+		}
+		fb.Comment, _ = values[feedbackComment].(string)
+		fb.Comment = strings.TrimSpace(fb.Comment)
+		fb.Email, _ = values[feedbackEmail].(string)
+		fb.Email = strings.TrimSpace(fb.Email)
+
+		if fb.Rating == 0 && fb.Comment == "" {
+			alert = f.T("feedback.empty")
+			continue
+		}
+		if cfg.Submit != nil {
+			if err := cfg.Submit(fb); err != nil {
+				alert = f.TF("feedback.submitFailed", err.Error())
+				continue
+			}
+		}
+		return fb
+	}
+}
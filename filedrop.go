@@ -0,0 +1,211 @@
+package webflow
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// defaultDropMaxSize is the size limit of FieldFileDrop files if MaxSize is
+// not set. Dropped files are sent to Go through the page, so keep it small.
+const defaultDropMaxSize = 10 << 20
+
+// dropMaxSize returns the size limit of a FieldFileDrop in bytes.
+func (field FormField) dropMaxSize() int64 {
+	if field.MaxSize > 0 {
+		return field.MaxSize
+	}
+	return defaultDropMaxSize
+}
+
+// dropPaths returns the initial paths of a FieldFileDrop from its Default, a
+// string or []string.
+func (field FormField) dropPaths() []string {
+	switch v := field.Default.(type) {
+	case string:
+		if v != "" {
+			return []string{v}
+		}
+	case []string:
+		return v
+	}
+	return nil
+}
+
+// dropValue converts the value a FieldFileDrop reports, its paths one per
+// line, to a path, or to []string if the field takes several files.
+func (field FormField) dropValue(value string) any {
+	var paths []string
+	for _, path := range strings.Split(value, "\n") {
+		if path != "" {
+			paths = append(paths, path)
+		}
+	}
+	if field.Multiple {
+		if paths == nil {
+			paths = []string{}
+		}
+		return paths
+	}
+	if len(paths) == 0 {
+		return ""
+	}
+	return paths[0]
+}
+
+// dropExtensionAllowed reports whether a file name has one of the extensions,
+// compared without case. Any name is allowed if extensions is empty.
+func dropExtensionAllowed(name string, extensions []string) bool {
+	if len(extensions) == 0 {
+		return true
+	}
+	ext := strings.ToLower(filepath.Ext(name))
+	return slices.ContainsFunc(extensions, func(e string) bool {
+		return strings.ToLower(e) == ext
+	})
+}
+
+// handleFileDrop saves a file dropped on a FieldFileDrop, which the page
+// sends as base64, and adds the copy to the field. Pages have no access to the
+// paths of dropped files, so the field returns the path of the copy, in a
+// temporary folder removed by Close. The page checks the field's extensions
+// and size limit before reading the file; they are checked again here.
+func (f *Flow) handleFileDrop(resp messageResponse) {
+	name, _ := resp.Data["name"].(string)
+	content, _ := resp.Data["content"].(string)
+	name = filepath.Base(name)
+	if name == "." || name == ".." || name == string(filepath.Separator) {
+		return
+	}
+
+	field, ok := f.dropField(resp.Button)
+	if !ok {
+		return
+	}
+	if !dropExtensionAllowed(name, field.Extensions) {
+		f.fileDropped(resp.Button, "", f.TF("fileDrop.wrongType", name))
+		return
+	}
+	// Padding makes the decoded length up to 2 bytes shorter than DecodedLen
+	maxSize := field.dropMaxSize()
+	if int64(base64.StdEncoding.DecodedLen(len(content)))-2 > maxSize {
+		f.fileDropped(resp.Button, "", f.TF("fileDrop.tooLarge", name))
+		return
+	}
+
+	go func() {
+		data, err := base64.StdEncoding.DecodeString(content)
+		if err != nil {
+			f.fileDropped(resp.Button, "", err.Error())
+			return
+		}
+		if int64(len(data)) > maxSize {
+			f.fileDropped(resp.Button, "", f.TF("fileDrop.tooLarge", name))
+			return
+		}
+		dir, err := f.dropFolder()
+		if err != nil {
+			f.fileDropped(resp.Button, "", err.Error())
+			return
+		}
+		// Each file gets its own folder, so files of the same name don't collide
+		fileDir, err := os.MkdirTemp(dir, "file-*")
+		if err != nil {
+			f.fileDropped(resp.Button, "", err.Error())
+			return
+		}
+		path := filepath.Join(fileDir, name)
+		if err := os.WriteFile(path, data, 0600); err != nil {
+			f.fileDropped(resp.Button, "", err.Error())
+			return
+		}
+		f.fileDropped(resp.Button, path, "")
+	}()
+}
+
+// handleFileDropBrowse shows a file dialog for a FieldFileDrop and adds the
+// selected files, checked against the field's extensions and size limit.
+func (f *Flow) handleFileDropBrowse(resp messageResponse) {
+	var extensions []string
+	if list, ok := resp.Data["extensions"].([]any); ok {
+		for _, e := range list {
+			if s, ok := e.(string); ok {
+				extensions = append(extensions, s)
+			}
+		}
+	}
+	maxSize, _ := resp.Data["maxSize"].(float64)
+	multiple, _ := resp.Data["multiple"].(bool)
+
+	opts := []DialogOption{DialogTitle(f.T("fileDrop.browseTitle"))}
+	if len(extensions) > 0 {
+		patterns := make([]string, len(extensions))
+		for i, e := range extensions {
+			patterns[i] = "*" + e
+		}
+		opts = append(opts, DialogFilters(FileFilter{Name: strings.Join(extensions, ", "), Patterns: patterns}))
+	}
+
+	var paths []string
+	if multiple {
+		paths, _ = f.OpenFiles(opts...)
+	} else if path, ok := f.OpenFile(opts...); ok {
+		paths = []string{path}
+	}
+	for _, path := range paths {
+		if !dropExtensionAllowed(path, extensions) {
+			f.fileDropped(resp.Button, "", f.TF("fileDrop.wrongType", filepath.Base(path)))
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			f.fileDropped(resp.Button, "", err.Error())
+			continue
+		}
+		if maxSize > 0 && info.Size() > int64(maxSize) {
+			f.fileDropped(resp.Button, "", f.TF("fileDrop.tooLarge", filepath.Base(path)))
+			continue
+		}
+		f.fileDropped(resp.Button, path, "")
+	}
+}
+
+// dropField returns the FieldFileDrop id of the current page, which may be
+// in a FieldGroup row.
+func (f *Flow) dropField(id string) (FormField, bool) {
+	f.mu.Lock()
+	field, ok := f.dropFields[id]
+	groups := f.fieldGroups
+	f.mu.Unlock()
+	if ok {
+		return field, true
+	}
+	for _, field := range groupRowFields(groups, id) {
+		if field.ID == id && field.Type == FieldFileDrop {
+			return field, true
+		}
+	}
+	return FormField{}, false
+}
+
+// fileDropped adds path to the FieldFileDrop id, or shows message below it.
+func (f *Flow) fileDropped(id, path, message string) {
+	f.wv.EvaluateScriptAsync(`window.fileDropped(` + jsonString(id) + `, ` + jsonString(path) + `, ` + jsonString(message) + `);`)
+}
+
+// dropFolder returns the temporary folder for dropped files, creating it on
+// first use.
+func (f *Flow) dropFolder() (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.dropDir == "" {
+		dir, err := os.MkdirTemp("", "webflow-drop-*")
+		if err != nil {
+			return "", err
+		}
+		f.dropDir = dir
+	}
+	return f.dropDir, nil
+}
@@ -3,10 +3,13 @@ package webflow
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"sync"
 	"sync/atomic"
+	"time"
 
+	"github.com/crafted-tech/webflow/platform"
 	"github.com/crafted-tech/webframe"
 	"github.com/crafted-tech/webframe/types"
 )
@@ -29,13 +32,37 @@ type Flow struct {
 	quitOnMsg         bool // Whether to quit the event loop when a message is received
 	primaryColorLight string
 	primaryColorDark  string
-	language          string // Current language code (e.g., "en", "es", "de")
+	language          string  // Current language code (e.g., "en", "es", "de")
+	fontScale         float64 // Text size factor: base scale × OS text size × user zoom
 
 	// Progress control
 	progressCancelled atomic.Bool
 
 	// Window state
 	closed atomic.Bool // Set when window X button is clicked; prevents further event loops
+
+	windowMode    WindowMode                             // Current presentation (see SetWindowMode)
+	buttonActions map[string]func(values map[string]any) // Callbacks of the current page's buttons, by ID
+	fieldChange   func(form *FormAction)                 // OnFieldChange of the current page
+	fieldChangeMu sync.Mutex                             // Runs OnFieldChange calls one at a time
+	fieldVerify   map[string]func(value string) error    // FormField.Verify of the current page's fields, by ID
+	fieldGroups   []FormField                            // FieldGroups of the current page, whose rows have their own callbacks
+	dropFields    map[string]FormField                   // FieldFileDrops of the current page, by ID, for their limits
+	dropDir       string                                 // Temporary folder for files dropped on FieldFileDrops
+	review        *reviewSource                          // Content of the current review page, if loaded in chunks
+
+	rendering        RenderingInfo // See RenderingInfo
+	rendererReported bool          // Set once a page has reported the WebView's renderer
+
+	// Secondary windows (see NewWindow)
+	parent   *Flow   // Non-nil for secondary windows; the parent's event loop drives this window
+	children []*Flow // Secondary windows created from this Flow, closed along with it
+
+	session Session // Pages answered so far, when recording (see WithRecording)
+
+	// Translations from code, kept to re-merge reloaded files over (see WithTranslationDir)
+	codeTranslations map[string]map[string]string
+	translationStamp time.Time
 }
 
 // messageResponse represents a message received from JavaScript.
@@ -45,20 +72,53 @@ type messageResponse struct {
 	Data   map[string]any `json:"data"`
 }
 
+// NonInteractiveError is returned by New when the process runs where no user
+// could see the window, such as a SYSTEM deployment from SCCM or Intune, a
+// service in session 0 or an SSH session (see platform.IsNonInteractiveSession).
+// Creating a window there would hang the install; run it silently instead.
+type NonInteractiveError struct {
+	Reason string // Why the session is non-interactive, e.g. "running as SYSTEM"
+}
+
+func (e *NonInteractiveError) Error() string {
+	return fmt.Sprintf("cannot show a window in a non-interactive session (%s); use silent mode", e.Reason)
+}
+
 // New creates a new Flow with the given options.
 // The Flow manages a window for displaying wizard-like UIs.
+//
+// New fails fast with *NonInteractiveError when no user could see the window,
+// unless pages are answered (see WithAnswers) or a custom backend is set.
 func New(opts ...Option) (*Flow, error) {
 	cfg := defaultConfig()
 	for _, opt := range opts {
 		opt(&cfg)
 	}
 
+	// A custom backend (e.g. webflowtest) needs no desktop, and unattended
+	// runs (e.g. deployment tools in session 0) need no one to see the window
+	if cfg.Backend == nil && cfg.Answers == nil {
+		if reason := platform.NonInteractiveReason(); reason != "" {
+			return nil, &NonInteractiveError{Reason: reason}
+		}
+	}
+
 	f := &Flow{
 		config:            cfg,
 		responseCh:        make(chan messageResponse, 1),
 		primaryColorLight: cfg.PrimaryColorLight,
 		primaryColorDark:  cfg.PrimaryColorDark,
-		language:          "en", // Default language
+		fontScale:         baseFontScale(cfg),
+		rendering:         detectRendering(),
+	}
+
+	// Merge translation files over the app translations before anything is rendered
+	if cfg.TranslationDir != "" {
+		f.codeTranslations = cfg.AppTranslations
+		if err := f.applyTranslationDir(); err != nil {
+			return nil, err
+		}
+		cfg.AppTranslations = f.config.AppTranslations
 	}
 
 	// Create webview
@@ -74,6 +134,14 @@ func New(opts ...Option) (*Flow, error) {
 		UserDataFolder: cfg.UserDataFolder,
 		StartHidden:    true,
 		OnClose: func() {
+			// Kiosk windows are only closed by the app
+			if f.WindowMode() == WindowKiosk {
+				return
+			}
+			// Let the app veto the close (e.g. during a critical phase)
+			if cfg.OnCloseRequest != nil && !cfg.OnCloseRequest() {
+				return
+			}
 			// Mark flow as closed so no further event loops are entered
 			f.closed.Store(true)
 			// Send a close message when window X button is clicked
@@ -84,13 +152,24 @@ func New(opts ...Option) (*Flow, error) {
 		},
 	}
 
-	wv, err := webframe.New(wvConfig)
+	newBackend := cfg.Backend
+	if newBackend == nil {
+		newBackend = func(c types.Config) (types.WebFrame, error) {
+			return webframe.New(c)
+		}
+	}
+	wv, err := newBackend(wvConfig)
 	if err != nil {
 		return nil, err
 	}
 
 	f.wv = wv
 
+	f.windowMode = cfg.WindowMode
+	if cfg.WindowMode != WindowNormal {
+		f.applyWindowMode(cfg.WindowMode)
+	}
+
 	// Determine dark mode based on theme setting
 	switch {
 	case cfg.Theme == nil, *cfg.Theme == ThemeSystem:
@@ -101,6 +180,15 @@ func New(opts ...Option) (*Flow, error) {
 		f.darkMode = false
 	}
 
+	// The page draws its own context menu, if any
+	if cfg.WindowMode == WindowKiosk && cfg.ContextMenu == nil {
+		cfg.ContextMenu = []ContextMenuItem{}
+		f.config.ContextMenu = cfg.ContextMenu
+	}
+	if cm, ok := wv.(contextMenuController); ok && cfg.ContextMenu != nil {
+		cm.SetContextMenuEnabled(false)
+	}
+
 	// Set initial frame appearance using system headerbar colors
 	frameColor := wv.GetHeaderBarColor()
 	backdropFrameColor := wv.GetBackdropHeaderBarColor()
@@ -146,6 +234,70 @@ func New(opts ...Option) (*Flow, error) {
 			return
 		}
 
+		if resp.Type == "log_save" {
+			f.handleLogSave(resp)
+			return
+		}
+
+		if resp.Type == "open_link" {
+			f.handleOpenLink(resp)
+			return
+		}
+
+		if resp.Type == "button_action" {
+			f.handleButtonAction(resp)
+			return
+		}
+
+		if resp.Type == "review_chunk" {
+			f.handleReviewChunk(resp)
+			return
+		}
+
+		if resp.Type == "review_find" {
+			f.handleReviewFind(resp)
+			return
+		}
+
+		if resp.Type == "field_change" {
+			f.handleFieldChange(resp)
+			return
+		}
+
+		if resp.Type == "field_verify" {
+			f.handleFieldVerify(resp)
+			return
+		}
+
+		if resp.Type == "file_drop" {
+			f.handleFileDrop(resp)
+			return
+		}
+
+		if resp.Type == "file_drop_browse" {
+			f.handleFileDropBrowse(resp)
+			return
+		}
+
+		if resp.Type == "rendering_info" {
+			f.handleRenderingInfo(resp)
+			return
+		}
+
+		if resp.Type == "font_scale" {
+			if scale, ok := resp.Data["scale"].(float64); ok && scale > 0 {
+				f.mu.Lock()
+				f.fontScale = scale
+				f.mu.Unlock()
+			}
+			return
+		}
+
+		if resp.Type == "context_menu" {
+			f.handleContextMenu(resp)
+			return
+		}
+
 		if resp.Type == "toggle_theme" {
 			f.darkMode = !f.darkMode
 
@@ -178,7 +330,7 @@ func New(opts ...Option) (*Flow, error) {
 				f.mu.Lock()
 				f.language = lang
 				f.mu.Unlock()
-				SetLanguage(lang, f.config.AppTranslations) // Update global state so T()/TF() use new language immediately
+				setCurrentTranslator(f.translator()) // Update global state so T()/TF() use new language immediately
 
 				// Send response so ShowPage returns and caller can rebuild page
 				select {
@@ -199,42 +351,67 @@ func New(opts ...Option) (*Flow, error) {
 			return
 		}
 
-		select {
-		case f.responseCh <- resp:
-			// If we should quit on message, do so
-			f.mu.Lock()
-			shouldQuit := f.quitOnMsg
-			f.mu.Unlock()
-			if shouldQuit {
-				f.wv.Quit()
-			}
-		default:
-		}
+		f.deliver(resp)
 	})
 
-	// Auto-initialize translations if app translations were provided
-	if cfg.AppTranslations != nil {
-		lang := cfg.InitialLanguage
-		if lang == "" {
-			lang = "en"
-		}
-		SetLanguage(lang, cfg.AppTranslations)
-		f.language = lang
+	// Start in the configured language, else the OS display language
+	lang := cfg.InitialLanguage
+	if lang == "" {
+		lang = DetectSystemLanguage()
 	}
+	f.language = lang
+	setCurrentTranslator(f.translator())
 
 	return f, nil
 }
 
+// deliver passes resp to the page waiting for the user and ends the event
+// loop if the page asked for that.
+func (f *Flow) deliver(resp messageResponse) {
+	select {
+	case f.responseCh <- resp:
+		// If we should quit on message, do so
+		f.mu.Lock()
+		shouldQuit := f.quitOnMsg
+		f.mu.Unlock()
+		if shouldQuit {
+			f.wv.Quit()
+		}
+	default:
+	}
+}
+
 // Close releases the Flow's resources and closes the window.
+// Secondary windows created with NewWindow are closed as well.
 func (f *Flow) Close() {
+	f.mu.Lock()
+	children := f.children
+	f.children = nil
+	f.mu.Unlock()
+	for _, child := range children {
+		child.Close()
+	}
+
 	if f.wv != nil {
 		f.wv.Destroy()
 	}
+
+	f.mu.Lock()
+	dropDir := f.dropDir
+	f.dropDir = ""
+	f.mu.Unlock()
+	if dropDir != "" {
+		os.RemoveAll(dropDir)
+	}
 }
 
 // Run starts the event loop. This must be called after all Show* methods complete
-// if you want to keep the window open.
+// if you want to keep the window open. It is a no-op for secondary windows,
+// which are driven by their parent's event loop.
 func (f *Flow) Run() {
+	if f.parent != nil {
+		return
+	}
 	f.wv.Run()
 }
 
@@ -246,13 +423,16 @@ func (f *Flow) showPageInternal(page Page) messageResponse {
 		return messageResponse{Type: "window_close", Button: "close"}
 	}
 
-	f.mu.Lock()
-	lang := f.language
-	f.mu.Unlock()
+	if f.config.OnPageShown != nil {
+		f.config.OnPageShown(pageID(page))
+	}
+
+	// Unattended runs answer the page without showing it
+	if msg, ok := f.answerPage(page); ok {
+		return msg
+	}
 
-	// Set language for T()/TF() to translate immediately
-	SetLanguage(lang, f.config.AppTranslations)
-	html := renderPage(page, f.darkMode, f.primaryColorLight, f.primaryColorDark)
+	html := f.renderPage(page)
 
 	f.wv.LoadHTML(html)
 	f.wv.Show()
@@ -271,7 +451,9 @@ func (f *Flow) showPageInternal(page Page) messageResponse {
 	f.mu.Unlock()
 
 	// Get response from channel
-	return <-f.responseCh
+	msg := <-f.responseCh
+	f.recordPage(page, msg)
+	return msg
 }
 
 // ShowPage displays a custom page and waits for user interaction.
@@ -326,6 +508,7 @@ func applyPageConfig(title string, content any, opts []PageOption) Page {
 	}
 
 	page := Page{
+		ID:         cfg.ID,
 		Title:      title,
 		Content:    content,
 		Icon:       cfg.Icon,
@@ -335,6 +518,8 @@ func applyPageConfig(title string, content any, opts []PageOption) Page {
 		LogoHeight:  cfg.LogoHeight,
 		LogoAlign:   cfg.LogoAlign,
 		CenterTitle: cfg.CenterTitle,
+
+		OnFieldChange: cfg.OnFieldChange,
 	}
 
 	if cfg.ButtonBar != nil {
@@ -465,6 +650,7 @@ func (f *Flow) ShowForm(title string, fields []FormField, opts ...PageOption) an
 
 	page := applyPageConfig(title, fields, opts)
 	msg := f.showPageInternal(page)
+	normalizeValues(fields, msg.Data)
 
 	switch msg.Button {
 	case ButtonBack:
@@ -582,22 +768,21 @@ func (f *Flow) ShowErrorDetails(title, message, detailsContent string, onCopy fu
 	}
 
 	// Create button bar with Details button
-	detailsBtn := NewButton(T("button.details"), "details")
+	detailsBtn := NewButton(f.T("button.details"), "details")
 	buttonBar := ButtonBar{
 		Left:  detailsBtn,
-		Close: NewButton(T("button.ok"), ButtonClose).WithPrimary(),
+		Close: NewButton(f.T("button.ok"), ButtonClose).WithPrimary(),
 	}
 
 	page := Page{
 		Content:   AlertConfig{Type: AlertError, Title: title, Message: message},
 		ButtonBar: buttonBar,
 	}
+	if _, ok := f.answerPage(page); ok {
+		return
+	}
 
-	f.mu.Lock()
-	lang := f.language
-	f.mu.Unlock()
-	SetLanguage(lang, f.config.AppTranslations)
-	html := renderPage(page, f.darkMode, f.primaryColorLight, f.primaryColorDark)
+	html := f.renderPage(page)
 	f.wv.LoadHTML(html)
 	f.wv.Show()
 
@@ -618,9 +803,9 @@ func (f *Flow) ShowErrorDetails(title, message, detailsContent string, onCopy fu
 			if msg.Button == "details" {
 				// Show details in review dialog with Copy and optional Save
 				if len(onSave) > 0 && onSave[0] != nil {
-					f.ShowReviewWithSave(T("log.title"), detailsContent, onCopy, onSave[0])
+					f.ShowReviewWithSave(f.T("log.title"), detailsContent, onCopy, onSave[0])
 				} else {
-					f.ShowReview(T("log.title"), detailsContent, onCopy)
+					f.ShowReview(f.T("log.title"), detailsContent, onCopy)
 				}
 				// Re-render and continue showing error
 				f.wv.LoadHTML(html)
@@ -694,7 +879,11 @@ func (f *Flow) ShowLicense(cfg LicenseConfig, opts ...PageOption) any {
 		}
 	}
 	if !hasButtonBar {
-		opts = append(opts, WithButtonBar(WizardLicense()))
+		bb := WizardLicense()
+		if cfg.RequireScroll {
+			bb.Next = bb.Next.Disabled() // Enabled by runtime.js at the end of the text
+		}
+		opts = append(opts, WithButtonBar(bb))
 	}
 
 	page := applyPageConfig(cfg.Title, cfg, opts)
@@ -895,6 +1084,10 @@ func (f *Flow) ShowTextInput(title, label, defaultValue string, opts ...PageOpti
 // Use WithButtonBar option to set navigation buttons.
 // Default is WizardMiddle() if no ButtonBar is provided.
 //
+// Choices may declare Requires and Excludes rules, which are enforced while
+// the user edits the selection and applied again to the result (see
+// ResolveChoices).
+//
 // Returns:
 //   - []int (selected indices, 0-based) if user clicked Next
 //   - Navigation (Back/Close) for navigation
@@ -940,11 +1133,11 @@ func (f *Flow) ShowMultiChoice(title string, choices []Choice, opts ...PageOptio
 		return Back
 	case ButtonClose, ButtonCancel, "":
 		if msg.Button == "" && msg.Type != "window_close" && msg.Data != nil {
-			return extractIndices(msg.Data)
+			return ResolveChoices(choices, extractIndices(msg.Data))
 		}
 		return Close
 	case ButtonNext:
-		return extractIndices(msg.Data)
+		return ResolveChoices(choices, extractIndices(msg.Data))
 	default:
 		return Navigation(msg.Button)
 	}
@@ -1004,17 +1197,20 @@ func (f *Flow) ShowLog(title string, work func(log LogWriter)) {
 	}
 	f.progressCancelled.Store(false)
 
+	// Copy and Save act on the full log and are handled without leaving the page
+	buttonBar := WizardProgress()
+	buttonBar.Actions = []*Button{
+		NewButton(f.T("button.copyToClipboard"), "log_copy").WithIcon(IconCopy).AsIconOnly(),
+		NewButton(f.T("button.saveToFile"), "log_save").WithIcon(IconDownload).AsIconOnly(),
+	}
+
 	page := Page{
 		Title:     title,
 		Content:   LogConfig{Work: work},
-		ButtonBar: WizardProgress(),
+		ButtonBar: buttonBar,
 	}
 
-	f.mu.Lock()
-	lang := f.language
-	f.mu.Unlock()
-	SetLanguage(lang, f.config.AppTranslations)
-	html := renderPage(page, f.darkMode, f.primaryColorLight, f.primaryColorDark)
+	html := f.renderPage(page)
 	f.wv.LoadHTML(html)
 	f.wv.Show()
 
@@ -1026,9 +1222,11 @@ func (f *Flow) ShowLog(title string, work func(log LogWriter)) {
 	// Track whether work completed
 	workDone := make(chan struct{})
 
-	// Run work in goroutine
+	// Run work in goroutine, keeping the computer awake until it returns
+	allowSleep := keepAwake(title)
 	go func() {
 		work(logWriter)
+		allowSleep()
 		close(workDone)
 		if !f.progressCancelled.Load() {
 			f.wv.Quit()
@@ -1064,10 +1262,13 @@ type logWriterImpl struct {
 }
 
 func (l *logWriterImpl) WriteLine(text string) {
-	l.WriteLineStyled(text, LogNormal)
+	l.WriteLineStyled(ParseANSI(text))
 }
 
 func (l *logWriterImpl) WriteLineStyled(text string, style LogStyle) {
+	// Never show raw escape codes, even when the caller picked the style
+	text, _ = ParseANSI(text)
+
 	styleClass := ""
 	switch style {
 	case LogSuccess:
@@ -1128,11 +1329,7 @@ func (f *Flow) ShowFileProgress(title string, work func(files FileList)) {
 		ButtonBar: WizardProgress(),
 	}
 
-	f.mu.Lock()
-	lang := f.language
-	f.mu.Unlock()
-	SetLanguage(lang, f.config.AppTranslations)
-	html := renderPage(page, f.darkMode, f.primaryColorLight, f.primaryColorDark)
+	html := f.renderPage(page)
 	f.wv.LoadHTML(html)
 	f.wv.Show()
 
@@ -1144,9 +1341,11 @@ func (f *Flow) ShowFileProgress(title string, work func(files FileList)) {
 	// Track whether work completed
 	workDone := make(chan struct{})
 
-	// Run work in goroutine
+	// Run work in goroutine, keeping the computer awake until it returns
+	allowSleep := keepAwake(title)
 	go func() {
 		work(fileList)
+		allowSleep()
 		close(workDone)
 		if !f.progressCancelled.Load() {
 			f.wv.Quit()
@@ -1269,8 +1468,9 @@ func (fl *fileListImpl) Cancelled() bool {
 // The onCopy callback is invoked when user clicks Copy (view stays open).
 // The onSave callback is invoked when user clicks Save (view stays open).
 // Returns when user closes the dialog.
+// Content of more than a few thousand lines is loaded as the user scrolls.
 func (f *Flow) ShowReview(title, content string, onCopy func(), opts ...PageOption) string {
-	return f.showReviewInternal(title, content, onCopy, nil, opts...)
+	return f.showReviewInternal(title, content, newReviewSource(content), onCopy, nil, opts...)
 }
 
 // ShowReviewWithSave displays text content with Copy and Save buttons.
@@ -1278,15 +1478,41 @@ func (f *Flow) ShowReview(title, content string, onCopy func(), opts ...PageOpti
 // Returns the button ID that closed the dialog (e.g. ButtonBack, ButtonClose,
 // or a custom button value). Callers can ignore the return value.
 func (f *Flow) ShowReviewWithSave(title, content string, onCopy, onSave func(), opts ...PageOption) string {
-	return f.showReviewInternal(title, content, onCopy, onSave, opts...)
+	return f.showReviewInternal(title, content, newReviewSource(content), onCopy, onSave, opts...)
+}
+
+// ShowReviewReader displays the text read from r like ShowReviewWithSave, for
+// content too large to hold in a string, such as multi-gigabyte logs. The text
+// is copied to a temporary file and the page loads the lines around its
+// viewport as the user scrolls. The Save button is shown if onSave is not nil.
+// It returns an error if r cannot be read.
+//
+// Example:
+//
+//	file, _ := os.Open(logPath)
+//	defer file.Close()
+//	f.ShowReviewReader("Service Log", file, nil, nil, webflow.WithSubtitle(logPath))
+func (f *Flow) ShowReviewReader(title string, r io.Reader, onCopy, onSave func(), opts ...PageOption) (string, error) {
+	if f.closed.Load() {
+		return ButtonClose, nil
+	}
+	src, err := readReviewSource(r)
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+	return f.showReviewInternal(title, "", src, onCopy, onSave, opts...), nil
 }
 
-func (f *Flow) showReviewInternal(title, content string, onCopy, onSave func(), opts ...PageOption) string {
+// showReviewInternal shows a review page. If src is not nil, the content is
+// read from src in chunks instead of being embedded in the page.
+func (f *Flow) showReviewInternal(title, content string, src *reviewSource, onCopy, onSave func(), opts ...PageOption) string {
 	if f.closed.Load() {
 		return ButtonClose
 	}
 	// Extract options
 	subtitle := ""
+	language := ""
 	var userButtonBar *ButtonBar
 	var saveDialogOpts []DialogOption
 	for _, opt := range opts {
@@ -1301,6 +1527,9 @@ func (f *Flow) showReviewInternal(title, content string, onCopy, onSave func(),
 		if len(cfg.SaveDialogOpts) > 0 {
 			saveDialogOpts = cfg.SaveDialogOpts
 		}
+		if cfg.Language != "" {
+			language = cfg.Language
+		}
 	}
 
 	// Build action buttons for copy/save
@@ -1331,6 +1560,26 @@ func (f *Flow) showReviewInternal(title, content string, onCopy, onSave func(),
 		OnCopy:   onCopy,
 		OnSave:   onSave,
 		Subtitle: subtitle,
+		Language: language,
+	}
+	if src != nil {
+		// Chunks are shown as code, which gives every line the same height
+		if language == "" {
+			language = LangText
+		}
+		src.language = language
+		reviewCfg.Content = ""
+		reviewCfg.Language = language
+		reviewCfg.lines = src.Len()
+
+		f.mu.Lock()
+		f.review = src
+		f.mu.Unlock()
+		defer func() {
+			f.mu.Lock()
+			f.review = nil
+			f.mu.Unlock()
+		}()
 	}
 
 	page := Page{
@@ -1338,13 +1587,12 @@ func (f *Flow) showReviewInternal(title, content string, onCopy, onSave func(),
 		Content:   reviewCfg,
 		ButtonBar: buttonBar,
 	}
+	if msg, ok := f.answerPage(page); ok {
+		return msg.Button
+	}
 
 	// Render page once
-	f.mu.Lock()
-	lang := f.language
-	f.mu.Unlock()
-	SetLanguage(lang, f.config.AppTranslations)
-	html := renderPage(page, f.darkMode, f.primaryColorLight, f.primaryColorDark)
+	html := f.renderPage(page)
 	f.wv.LoadHTML(html)
 	f.wv.Show()
 
@@ -1368,6 +1616,14 @@ func (f *Flow) showReviewInternal(title, content string, onCopy, onSave func(),
 		case msg := <-f.responseCh:
 			switch msg.Button {
 			case "review_copy":
+				// The page holds only some of the lines of chunked content
+				if src != nil {
+					text := content
+					if src.file != nil {
+						text = src.String()
+					}
+					platform.CopyToClipboard(text)
+				}
 				if onCopy != nil {
 					onCopy()
 				}
@@ -1390,7 +1646,7 @@ func (f *Flow) showReviewInternal(title, content string, onCopy, onSave func(),
 				path, ok := f.SaveFile(dialogOpts...)
 				if ok && path != "" {
 					// Write the content to the file
-					if err := os.WriteFile(path, []byte(content), 0644); err == nil {
+					if err := saveReview(path, content, src); err == nil {
 						if onSave != nil {
 							onSave()
 						}
@@ -1409,41 +1665,118 @@ func (f *Flow) showReviewInternal(title, content string, onCopy, onSave func(),
 // ShowProgress displays a progress bar and executes the provided work function.
 // The work function receives a Progress interface to report progress.
 // This method blocks until the work is complete or cancelled.
+// Use WithProgressTime to show elapsed/remaining time and a speed graph.
+// The Progress passed to work is also a ByteProgress.
 //
 // Returns:
 //   - nil if work completed successfully
 //   - Navigation (Cancel/Close) if user cancelled
-func (f *Flow) ShowProgress(title string, work func(p Progress)) any {
+func (f *Flow) ShowProgress(title string, work func(p Progress), opts ...PageOption) any {
 	if f.closed.Load() {
 		return Close
 	}
-	f.progressCancelled.Store(false)
 
-	page := Page{
-		Title:     title,
-		Content:   ProgressConfig{Work: work},
-		ButtonBar: WizardProgress(),
+	cfg := PageConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
 	}
+	page := applyPageConfig(title, ProgressConfig{Work: work, ShowTime: cfg.ProgressTime}, opts)
 
-	f.mu.Lock()
-	lang := f.language
-	f.mu.Unlock()
-	SetLanguage(lang, f.config.AppTranslations)
-	html := renderPage(page, f.darkMode, f.primaryColorLight, f.primaryColorDark)
+	progress := &progressImpl{progressTracker: newProgressTracker(f, title)}
+	return f.runProgress(page, cfg, progress.progressTracker, func() { work(progress) })
+}
+
+// ShowMultiProgress displays several named progress bars plus an overall bar and
+// executes the provided work function. Bars are updated independently, so the
+// work function may report from multiple goroutines. WithProgressTime and
+// WithTrayIcon follow the overall bar.
+// This method blocks until the work is complete or cancelled.
+//
+// Example:
+//
+//	f.ShowMultiProgress("Downloading", []string{"runtime.zip", "data.zip"}, func(p webflow.MultiProgress) {
+//	    var wg sync.WaitGroup
+//	    for _, name := range files {
+//	        wg.Add(1)
+//	        go func() {
+//	            defer wg.Done()
+//	            download(name, func(pct float64) { p.Update(name, pct, "") })
+//	        }()
+//	    }
+//	    wg.Wait()
+//	})
+//
+// Returns:
+//   - nil if work completed successfully
+//   - Navigation (Cancel/Close) if user cancelled
+func (f *Flow) ShowMultiProgress(title string, bars []string, work func(p MultiProgress), opts ...PageOption) any {
+	if f.closed.Load() {
+		return Close
+	}
+
+	cfg := PageConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	page := applyPageConfig(title, MultiProgressConfig{Bars: bars, Work: work, ShowTime: cfg.ProgressTime}, opts)
+
+	progress := &multiProgressImpl{
+		progressTracker: newProgressTracker(f, title),
+		percent:         make(map[string]float64, len(bars)),
+	}
+	for _, name := range bars {
+		progress.percent[name] = 0
+	}
+	return f.runProgress(page, cfg, progress.progressTracker, func() { work(progress) })
+}
+
+// runProgress shows a progress page and runs work in a goroutine, with the
+// time display and tray icon cfg asks for, until work returns or the user
+// cancels. The computer is kept awake while work runs. It returns nil when
+// the work completed and Cancel when it was cancelled.
+func (f *Flow) runProgress(page Page, cfg PageConfig, t *progressTracker, work func()) any {
+	f.progressCancelled.Store(false)
+	if cfg.ButtonBar == nil {
+		page.ButtonBar = WizardProgress()
+	}
+
+	html := f.renderPage(page)
 	f.wv.LoadHTML(html)
 	f.wv.Show()
 
-	// Create progress reporter
-	progress := &progressImpl{
-		flow: f,
+	stopTray := func() {}
+	if cfg.TrayIcon {
+		t.tray, stopTray = f.progressTray(t.title)
 	}
 
 	// Track whether work completed
 	workDone := make(chan struct{})
 
-	// Run work in goroutine
+	// Refresh the time display once a second until the work finishes or is cancelled
+	if cfg.ProgressTime {
+		stopTimer := make(chan struct{})
+		defer close(stopTimer)
+		go func() {
+			ticker := time.NewTicker(time.Second)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					t.tick()
+				case <-workDone:
+					return
+				case <-stopTimer:
+					return
+				}
+			}
+		}()
+	}
+
+	// Run work in goroutine, keeping the computer awake until it returns
+	allowSleep := keepAwake(t.title)
 	go func() {
-		work(progress)
+		work()
+		allowSleep()
 		close(workDone)
 		// Only quit the event loop if we weren't cancelled
 		// (if cancelled, the message handler already called Quit)
@@ -1459,6 +1792,7 @@ func (f *Flow) ShowProgress(title string, work func(p Progress)) any {
 
 	// Run event loop until work completes or cancel is clicked
 	f.wv.Run()
+	stopTray()
 
 	// Disable quit on message
 	f.mu.Lock()
@@ -1480,9 +1814,112 @@ func (f *Flow) ShowProgress(title string, work func(p Progress)) any {
 	return nil
 }
 
+// multiProgressImpl implements the MultiProgress interface.
+type multiProgressImpl struct {
+	*progressTracker
+
+	mu      sync.Mutex
+	percent map[string]float64 // Latest percentage per bar, for the overall average
+}
+
+func (p *multiProgressImpl) Update(name string, percent float64, status string) {
+	// Clamp percent to 0-100
+	if percent < 0 {
+		percent = 0
+	}
+	if percent > 100 {
+		percent = 100
+	}
+
+	p.mu.Lock()
+	if _, ok := p.percent[name]; !ok {
+		// Unknown bars would change the overall average
+		p.mu.Unlock()
+		return
+	}
+	p.percent[name] = percent
+	var sum float64
+	for _, v := range p.percent {
+		sum += v
+	}
+	overall := sum / float64(len(p.percent))
+	p.mu.Unlock()
+
+	p.report(overall, "")
+	p.flow.evaluate(`window.multiProgressUpdate(` + jsonString(name) + `, ` + formatFloat(percent) + `, ` +
+		jsonString(status) + `, ` + formatFloat(overall) + `);`)
+}
+
+func (p *multiProgressImpl) SetStatus(status string) {
+	p.flow.evaluate(`window.multiProgressSetStatus(` + jsonString(status) + `);`)
+}
+
+// Rate sampling for the progress time display.
+const (
+	progressSparklinePoints = 30 // Samples shown in the speed graph (one per second)
+	progressRateWindow      = 10 // Recent samples averaged for the remaining-time estimate
+)
+
+// progressTracker holds what a progress page shows beside its bars: the
+// tray icon (only used with WithTrayIcon) and the elapsed/remaining time
+// display (only used with WithProgressTime).
+type progressTracker struct {
+	flow  *Flow
+	title string
+	tray  *platform.TrayIcon
+
+	mu          sync.Mutex
+	tooltip     string
+	start       time.Time
+	percent     float64   // Latest reported percentage
+	lastPercent float64   // Percentage at the previous tick
+	lastTick    time.Time // Time of the previous tick
+	rates       []float64 // Percent per second, one sample per tick, for the estimate
+	bytes       int64     // Latest count from SetBytes, -1 if never called
+	lastBytes   int64     // Byte count at the previous tick
+	speeds      []float64 // Bytes per second, one sample per tick, for the graph
+}
+
+func newProgressTracker(f *Flow, title string) *progressTracker {
+	return &progressTracker{flow: f, title: title, start: time.Now(), bytes: -1}
+}
+
+// SetBytes implements ByteProgress.
+func (t *progressTracker) SetBytes(done int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.bytes < 0 {
+		// Count speed from the first report, not from zero
+		t.lastBytes = done
+	}
+	t.bytes = done
+}
+
+// report records the overall percentage for the time estimate and shows it
+// in the tray icon's tooltip.
+func (t *progressTracker) report(percent float64, status string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.percent = percent
+	if t.tray != nil {
+		tooltip := fmt.Sprintf("%s\n%d%%", t.title, int(percent))
+		if status != "" {
+			tooltip += " – " + status
+		}
+		if tooltip != t.tooltip {
+			t.tooltip = tooltip
+			t.tray.SetTooltip(tooltip)
+		}
+	}
+}
+
+func (t *progressTracker) Cancelled() bool {
+	return t.flow.progressCancelled.Load()
+}
+
 // progressImpl implements the Progress interface.
 type progressImpl struct {
-	flow *Flow
+	*progressTracker
 }
 
 // asyncScriptEvaluator is an optional interface for non-blocking script execution.
@@ -1491,6 +1928,16 @@ type asyncScriptEvaluator interface {
 	EvaluateScriptAsync(script string)
 }
 
+// evaluate runs script in the page, asynchronously where the backend
+// supports it, as work goroutines require on Windows.
+func (f *Flow) evaluate(script string) {
+	if async, ok := f.wv.(asyncScriptEvaluator); ok {
+		async.EvaluateScriptAsync(script)
+	} else {
+		f.wv.EvaluateScript(script)
+	}
+}
+
 func (p *progressImpl) Update(percent float64, status string) {
 	// Clamp percent to 0-100
 	if percent < 0 {
@@ -1500,19 +1947,130 @@ func (p *progressImpl) Update(percent float64, status string) {
 		percent = 100
 	}
 
-	// Update progress bar via JavaScript
-	script := `window.updateProgress(` + formatFloat(percent) + `, ` + jsonString(status) + `);`
+	p.report(percent, status)
+	p.flow.evaluate(`window.updateProgress(` + formatFloat(percent) + `, ` + jsonString(status) + `);`)
+}
 
-	// Use async script execution if available (required for cross-thread safety on Windows)
-	if async, ok := p.flow.wv.(asyncScriptEvaluator); ok {
-		async.EvaluateScriptAsync(script)
-	} else {
-		p.flow.wv.EvaluateScript(script)
+// keepAwake keeps the computer from going to sleep while the work of a
+// progress page runs, which may outlast the page if the user cancels (see
+// platform.PreventSleep). Call the returned function when the work returns.
+func keepAwake(reason string) (allow func()) {
+	if err := platform.PreventSleep(reason); err != nil {
+		return func() {}
 	}
+	return platform.AllowSleep
 }
 
-func (p *progressImpl) Cancelled() bool {
-	return p.flow.progressCancelled.Load()
+// progressTray adds the tray icon of a progress page (see WithTrayIcon). It
+// returns a nil icon where tray icons are unsupported. Call stop when the
+// page ends, so a late Cancel from the menu doesn't reach the next page.
+func (f *Flow) progressTray(title string) (tray *platform.TrayIcon, stop func()) {
+	var mu sync.Mutex
+	active := true
+	cancel := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if active {
+			f.deliver(messageResponse{Type: "button_click", Button: ButtonCancel})
+		}
+	}
+	tray, err := platform.NewTrayIcon(platform.TrayIconConfig{
+		Icon:    f.config.Icon,
+		Tooltip: title,
+		OnClick: f.Restore,
+		Items: []platform.TrayMenuItem{
+			{Label: f.T("tray.show"), OnClick: f.Restore},
+			{},
+			{Label: f.T("button.cancel"), OnClick: cancel},
+		},
+	})
+	if err != nil {
+		return nil, func() {}
+	}
+	return tray, func() {
+		mu.Lock()
+		active = false
+		mu.Unlock()
+		tray.Close()
+	}
+}
+
+// tick samples the progress rate and refreshes the elapsed/remaining time display.
+// The estimate uses the average rate over the last few seconds rather than the
+// overall average, so it adapts when later phases run faster or slower. The
+// graph shows the transfer speed once the work reports bytes (see
+// ByteProgress), and the rate of progress until then.
+func (t *progressTracker) tick() {
+	now := time.Now()
+
+	t.mu.Lock()
+	if t.lastTick.IsZero() {
+		t.lastTick = t.start
+	}
+	rate, speed := 0.0, 0.0
+	if dt := now.Sub(t.lastTick).Seconds(); dt > 0 {
+		rate = max(t.percent-t.lastPercent, 0) / dt
+		speed = float64(max(t.bytes-t.lastBytes, 0)) / dt
+	}
+	t.lastPercent = t.percent
+	t.lastBytes = t.bytes
+	t.lastTick = now
+	t.rates = appendSample(t.rates, rate)
+	hasBytes := t.bytes >= 0
+	if hasBytes {
+		t.speeds = appendSample(t.speeds, speed)
+	}
+
+	recent := t.rates[max(len(t.rates)-progressRateWindow, 0):]
+	var sum float64
+	for _, r := range recent {
+		sum += r
+	}
+	avg := sum / float64(len(recent))
+	percent := t.percent
+	graph := append([]float64(nil), t.rates...)
+	if hasBytes {
+		graph = append([]float64(nil), t.speeds...)
+	}
+	t.mu.Unlock()
+
+	elapsed := t.flow.TF("progress.elapsed", formatElapsed(now.Sub(t.start)))
+	remaining := t.flow.T("progress.estimating")
+	if percent >= 100 {
+		remaining = ""
+	} else if avg > 0 {
+		eta := time.Duration((100 - percent) / avg * float64(time.Second))
+		remaining = t.flow.TF("progress.remaining", formatElapsed(eta))
+	}
+
+	speedText := ""
+	if hasBytes {
+		speedText = t.flow.TF("progress.speed", t.flow.FormatBytes(int64(speed)))
+	}
+
+	graphJSON, _ := json.Marshal(graph)
+	t.flow.evaluate(`window.updateProgressTime(` + jsonString(elapsed) + `, ` + jsonString(remaining) + `, ` +
+		string(graphJSON) + `, ` + jsonString(speedText) + `);`)
+}
+
+// appendSample adds a sample to a graph, keeping the last
+// progressSparklinePoints.
+func appendSample(samples []float64, v float64) []float64 {
+	samples = append(samples, v)
+	if len(samples) > progressSparklinePoints {
+		samples = samples[len(samples)-progressSparklinePoints:]
+	}
+	return samples
+}
+
+// formatElapsed formats a duration as m:ss, or h:mm:ss for an hour or more.
+func formatElapsed(d time.Duration) string {
+	secs := int(d.Round(time.Second).Seconds())
+	h, m, s := secs/3600, secs/60%60, secs%60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%d:%02d", m, s)
 }
 
 // Helper functions
@@ -1532,6 +2090,23 @@ type webviewFocuser interface {
 	FocusWebView()
 }
 
+// handleLogSave handles a log_save message from the log view.
+// The page sends the full log text, which is written to a file chosen by the user.
+func (f *Flow) handleLogSave(resp messageResponse) {
+	content, _ := resp.Data["content"].(string)
+	path, ok := f.SaveFile(
+		DialogTitle(f.T("log.saveTitle")),
+		DialogDefaultName("log.txt"),
+		DialogFilters(
+			FileFilter{Name: "Text Files", Patterns: []string{"*.txt", "*.log"}},
+			FileFilter{Name: "All Files", Patterns: []string{"*.*"}},
+		),
+	)
+	if ok && path != "" {
+		os.WriteFile(path, []byte(content), 0644)
+	}
+}
+
 // handleBrowsePath handles a browse_path message from JavaScript.
 // It shows a native file or folder selection dialog and updates the input field with the result.
 func (f *Flow) handleBrowsePath(resp messageResponse) {
@@ -1577,7 +2152,7 @@ func (f *Flow) handleBrowsePath(resp messageResponse) {
 	}
 
 	// Update the input field with the selected path
-	script := `document.getElementById(` + jsonString(targetID) + `).value = ` + jsonString(path) + `;`
+	script := `(function(el) { el.value = ` + jsonString(path) + `; el.dispatchEvent(new Event('change', { bubbles: true })); })(document.getElementById(` + jsonString(targetID) + `));`
 
 	// Use async script execution if available
 	if async, ok := f.wv.(asyncScriptEvaluator); ok {
@@ -0,0 +1,116 @@
+package webflow
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// FormAction is passed to a FormField's OnSuffix callback. It gives access to
+// the values the user has entered so far and updates the form in place,
+// without submitting or re-rendering it.
+//
+// Example:
+//
+//	webflow.FormField{
+//	    ID:     "server",
+//	    Type:   webflow.FieldText,
+//	    Label:  "Server",
+//	    Suffix: webflow.NewButton("Test connection", "test"),
+//	    OnSuffix: func(form *webflow.FormAction) {
+//	        if err := ping(form.Value("server")); err != nil {
+//	            form.ShowAlert(webflow.AlertError, err.Error())
+//	            return
+//	        }
+//	        form.ShowAlert(webflow.AlertSuccess, "Connected")
+//	    },
+//	}
+type FormAction struct {
+	flow   *Flow
+	field  string
+	values map[string]any
+}
+
+// Field returns the ID of the field whose Suffix button was clicked.
+func (a *FormAction) Field() string {
+	return a.field
+}
+
+// Values returns the form values at the time of the click, keyed by field ID,
+// as ShowForm would return them.
+func (a *FormAction) Values() map[string]any {
+	return a.values
+}
+
+// Value returns the text of a field at the time of the click, or "" if the
+// field has no text value.
+func (a *FormAction) Value(id string) string {
+	s, _ := a.values[id].(string)
+	return s
+}
+
+// SetValue replaces the value of a field: a string for text fields and
+// selects, a bool for checkboxes.
+func (a *FormAction) SetValue(id string, value any) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+	a.values[id] = value
+	a.flow.wv.EvaluateScriptAsync(fmt.Sprintf(`window.setFieldValue(%s, %s);`, jsonString(id), data))
+}
+
+// ShowAlert shows a message below the field, replacing a previous one.
+// Error alerts disappear when the user edits the form.
+func (a *FormAction) ShowAlert(alertType AlertType, message string) {
+	a.flow.wv.EvaluateScriptAsync(fmt.Sprintf(`window.showFieldAlert(%s, %s, %s, %s);`,
+		jsonString(a.field), jsonString(string(alertType)), jsonString(message), jsonString(GetIcon(string(alertType)))))
+}
+
+// ClearAlert removes the message shown by ShowAlert.
+func (a *FormAction) ClearAlert() {
+	a.flow.wv.EvaluateScriptAsync(`window.clearFieldAlert(` + jsonString(a.field) + `);`)
+}
+
+// WithOnFieldChange calls fn as the user edits the page's inputs: after a short
+// pause in typing for text fields, and immediately for checkboxes, selects and
+// choices. form.Field() is the ID of the changed input ("_choice" for choice
+// lists) and form.Values() holds all values, as the page would return them.
+// Calls run one at a time on their own goroutine.
+//
+// Example:
+//
+//	f.ShowForm("Install Location", fields, webflow.WithOnFieldChange(func(form *webflow.FormAction) {
+//	    if form.Field() != "dir" {
+//	        return
+//	    }
+//	    if _, err := os.Stat(filepath.Dir(form.Value("dir"))); err != nil {
+//	        form.ShowAlert(webflow.AlertWarning, "The parent folder does not exist.")
+//	    } else {
+//	        form.ClearAlert()
+//	    }
+//	}))
+func WithOnFieldChange(fn func(form *FormAction)) PageOption {
+	return func(c *PageConfig) {
+		c.OnFieldChange = fn
+	}
+}
+
+// handleFieldChange runs the current page's OnFieldChange for a field_change message.
+func (f *Flow) handleFieldChange(resp messageResponse) {
+	f.mu.Lock()
+	fn := f.fieldChange
+	f.mu.Unlock()
+	if fn == nil {
+		return
+	}
+	values := resp.Data
+	if values == nil {
+		values = make(map[string]any)
+	}
+
+	go func() {
+		f.fieldChangeMu.Lock()
+		defer f.fieldChangeMu.Unlock()
+		fn(&FormAction{flow: f, field: resp.Button, values: values})
+	}()
+}
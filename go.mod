@@ -5,6 +5,7 @@ go 1.24.0
 require (
 	github.com/crafted-tech/webframe v0.3.2
 	github.com/go-ole/go-ole v1.3.0
+	golang.org/x/crypto v0.47.0
 	golang.org/x/sys v0.40.0
 )
 
@@ -0,0 +1,113 @@
+package webflow
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// groupRowPlaceholder stands for the row number in the IDs of a FieldGroup's
+// row template; runtime.js replaces it when the user adds a row.
+const groupRowPlaceholder = "__row__"
+
+// groupFieldID returns the ID of a field in a row of a FieldGroup, e.g.
+// "servers.0.host".
+func groupFieldID(group, row, id string) string {
+	return group + "." + row + "." + id
+}
+
+// rowFields returns the fields of one row of a FieldGroup, with IDs (and the
+// IDs they refer to) made unique to the row and defaults from values.
+func (field FormField) rowFields(row string, values map[string]any) []FormField {
+	fields := make([]FormField, 0, len(field.Fields))
+	for _, sub := range field.Fields {
+		if sub.Type == FieldGroup {
+			continue
+		}
+		if v, ok := values[sub.ID]; ok {
+			sub.Default = v
+		}
+		if sub.Suffix != nil {
+			suffix := *sub.Suffix
+			suffix.ID = groupFieldID(field.ID, row, suffix.ID)
+			sub.Suffix = &suffix
+		}
+		if sub.Confirm != "" {
+			sub.Confirm = groupFieldID(field.ID, row, sub.Confirm)
+		}
+		sub.ID = groupFieldID(field.ID, row, sub.ID)
+		sub.Focus = false
+		fields = append(fields, sub)
+	}
+	return fields
+}
+
+// groupRows returns the initial rows of a FieldGroup: its Default, or one
+// empty row.
+func (field FormField) groupRows() []map[string]any {
+	if rows, ok := field.Default.([]map[string]any); ok && len(rows) > 0 {
+		return rows
+	}
+	return []map[string]any{nil}
+}
+
+// groupRowFields returns the fields of the FieldGroup row that a field or
+// button ID belongs to, or nil.
+func groupRowFields(groups []FormField, id string) []FormField {
+	for _, group := range groups {
+		rest, ok := strings.CutPrefix(id, group.ID+".")
+		if !ok {
+			continue
+		}
+		if row, _, ok := strings.Cut(rest, "."); ok {
+			return group.rowFields(row, nil)
+		}
+	}
+	return nil
+}
+
+// collectGroupRows replaces the values of a FieldGroup's rows, which the page
+// reports by row field ID, with the rows as []map[string]any in page order.
+// Rows that are already a list, e.g. from an answers file, are kept and
+// converted to []map[string]any.
+func collectGroupRows(field FormField, values map[string]any) {
+	if list, ok := values[field.ID].([]any); ok {
+		rows := make([]map[string]any, 0, len(list))
+		for _, v := range list {
+			if row, ok := v.(map[string]any); ok {
+				normalizeValues(field.Fields, row)
+				rows = append(rows, row)
+			}
+		}
+		values[field.ID] = rows
+	}
+
+	byRow := make(map[int]map[string]any)
+	for key, value := range values {
+		rest, ok := strings.CutPrefix(key, field.ID+".")
+		if !ok {
+			continue
+		}
+		row, id, ok := strings.Cut(rest, ".")
+		n, err := strconv.Atoi(row)
+		if !ok || err != nil {
+			continue
+		}
+		if byRow[n] == nil {
+			byRow[n] = make(map[string]any)
+		}
+		byRow[n][id] = value
+		delete(values, key)
+	}
+	if _, ok := values[field.ID]; ok && len(byRow) == 0 {
+		return
+	}
+
+	rows := make([]map[string]any, 0, len(byRow))
+	for _, n := range slices.Sorted(maps.Keys(byRow)) {
+		normalizeValues(field.Fields, byRow[n])
+		rows = append(rows, byRow[n])
+	}
+	values[field.ID] = rows
+}
@@ -0,0 +1,243 @@
+package webflow
+
+import (
+	"html"
+	"path/filepath"
+	"strings"
+)
+
+// Languages for syntax highlighting.
+const (
+	LangJSON = "json"
+	LangYAML = "yaml"
+	LangINI  = "ini"
+	LangText = "text" // Plain text, shown with line numbers
+)
+
+// LanguageForPath returns the highlighting language for a file name by its
+// extension, or "" for plain text.
+func LanguageForPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return LangJSON
+	case ".yaml", ".yml":
+		return LangYAML
+	case ".ini", ".cfg", ".conf", ".properties", ".toml":
+		return LangINI
+	}
+	return ""
+}
+
+// highlightLine returns one line of lang as escaped HTML, with spans of the
+// classes hl-key, hl-string, hl-number, hl-keyword, hl-comment and
+// hl-section. Highlighting works line by line, which covers configuration
+// files; constructs spanning lines are shown as plain text.
+func highlightLine(lang, line string) string {
+	switch lang {
+	case LangJSON:
+		return highlightJSON(line)
+	case LangYAML:
+		return highlightYAML(line)
+	case LangINI:
+		return highlightINI(line)
+	}
+	return html.EscapeString(line)
+}
+
+// span wraps escaped text in a span of class.
+func span(class, text string) string {
+	return `<span class="` + class + `">` + html.EscapeString(text) + `</span>`
+}
+
+// highlightJSON highlights strings, numbers and literals; strings followed
+// by a colon are keys.
+func highlightJSON(line string) string {
+	var b strings.Builder
+	for i := 0; i < len(line); {
+		c := line[i]
+		switch {
+		case c == '"':
+			end := quotedEnd(line, i)
+			class := "hl-string"
+			if strings.HasPrefix(strings.TrimLeft(line[end:], " \t"), ":") {
+				class = "hl-key"
+			}
+			b.WriteString(span(class, line[i:end]))
+			i = end
+		case c == '-' || c >= '0' && c <= '9':
+			end := wordEnd(line, i)
+			b.WriteString(span("hl-number", line[i:end]))
+			i = end
+		case c >= 'a' && c <= 'z':
+			end := wordEnd(line, i)
+			if w := line[i:end]; w == "true" || w == "false" || w == "null" {
+				b.WriteString(span("hl-keyword", w))
+			} else {
+				b.WriteString(html.EscapeString(w))
+			}
+			i = end
+		default:
+			b.WriteString(html.EscapeString(string(c)))
+			i++
+		}
+	}
+	return b.String()
+}
+
+// highlightYAML highlights comments, mapping keys and scalar values.
+func highlightYAML(line string) string {
+	code, comment := cutComment(line, "#")
+	var b strings.Builder
+
+	rest := code
+	indent := len(rest) - len(strings.TrimLeft(rest, " \t"))
+	b.WriteString(rest[:indent])
+	rest = rest[indent:]
+	for strings.HasPrefix(rest, "- ") {
+		b.WriteString("- ")
+		rest = rest[2:]
+	}
+	if key, value, ok := cutYAMLKey(rest); ok {
+		b.WriteString(span("hl-key", key))
+		b.WriteString(":")
+		rest = value
+	}
+	b.WriteString(highlightScalar(rest))
+
+	if comment != "" {
+		b.WriteString(span("hl-comment", comment))
+	}
+	return b.String()
+}
+
+// cutYAMLKey splits "key: value" (or "key:" at the end of the line).
+func cutYAMLKey(s string) (key, value string, ok bool) {
+	if strings.HasPrefix(s, `"`) || strings.HasPrefix(s, "'") {
+		end := quotedEnd(s, 0)
+		if strings.HasPrefix(s[end:], ":") && (end+1 == len(s) || s[end+1] == ' ') {
+			return s[:end], s[end+1:], true
+		}
+		return "", "", false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] == ':' && (i+1 == len(s) || s[i+1] == ' ') {
+			return s[:i], s[i+1:], i > 0
+		}
+	}
+	return "", "", false
+}
+
+// highlightINI highlights comments, [section] headers, keys and values.
+func highlightINI(line string) string {
+	trimmed := strings.TrimSpace(line)
+	indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+	switch {
+	case strings.HasPrefix(trimmed, ";") || strings.HasPrefix(trimmed, "#"):
+		return indent + span("hl-comment", line[len(indent):])
+	case strings.HasPrefix(trimmed, "["):
+		return indent + span("hl-section", line[len(indent):])
+	}
+	i := strings.IndexAny(line, "=:")
+	if i < 0 {
+		return html.EscapeString(line)
+	}
+	key := line[len(indent):i]
+	value, comment := cutComment(line[i+1:], ";#")
+	out := indent + span("hl-key", strings.TrimRight(key, " \t")) + key[len(strings.TrimRight(key, " \t")):] +
+		html.EscapeString(line[i:i+1]) + highlightScalar(value)
+	if comment != "" {
+		out += span("hl-comment", comment)
+	}
+	return out
+}
+
+// highlightScalar highlights a value: quoted strings, numbers and the
+// literals true, false, yes, no, on, off, null and ~.
+func highlightScalar(s string) string {
+	trimmed := strings.TrimSpace(s)
+	if trimmed == "" {
+		return html.EscapeString(s)
+	}
+	lead := s[:strings.Index(s, trimmed)]
+	trail := s[len(lead)+len(trimmed):]
+
+	var class string
+	switch {
+	case strings.HasPrefix(trimmed, `"`) || strings.HasPrefix(trimmed, "'"):
+		class = "hl-string"
+	case isNumber(trimmed):
+		class = "hl-number"
+	default:
+		switch strings.ToLower(trimmed) {
+		case "true", "false", "yes", "no", "on", "off", "null", "~":
+			class = "hl-keyword"
+		default:
+			return html.EscapeString(s) // Unquoted text
+		}
+	}
+	return html.EscapeString(lead) + span(class, trimmed) + html.EscapeString(trail)
+}
+
+// cutComment splits a line at the first comment character that isn't inside
+// quotes and starts the line or follows a space.
+func cutComment(line, chars string) (code, comment string) {
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case c == '"' || c == '\'':
+			i = quotedEnd(line, i) - 1
+		case strings.IndexByte(chars, c) >= 0 && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i], line[i:]
+		}
+	}
+	return line, ""
+}
+
+// quotedEnd returns the index after the string starting with the quote at
+// start, or len(s) if it isn't closed on this line.
+func quotedEnd(s string, start int) int {
+	quote := s[start]
+	for i := start + 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if quote == '"' {
+				i++
+			}
+		case quote:
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+// wordEnd returns the index after the run of non-delimiter bytes at start.
+func wordEnd(s string, start int) int {
+	i := start
+	for i < len(s) && !strings.ContainsRune(" \t,:[]{}\"", rune(s[i])) {
+		i++
+	}
+	return i
+}
+
+// isNumber reports whether s is a decimal or hexadecimal number.
+func isNumber(s string) bool {
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "-"), "+")
+	if s == "" {
+		return false
+	}
+	if rest, ok := strings.CutPrefix(strings.ToLower(s), "0x"); ok {
+		return rest != "" && strings.Trim(rest, "0123456789abcdef") == ""
+	}
+	digits := false
+	for i, c := range s {
+		switch {
+		case c >= '0' && c <= '9':
+			digits = true
+		case c == '.' || c == '_':
+		case (c == 'e' || c == 'E') && digits && i+1 < len(s):
+		case (c == '-' || c == '+') && i > 0 && (s[i-1] == 'e' || s[i-1] == 'E'):
+		default:
+			return false
+		}
+	}
+	return digits
+}
@@ -0,0 +1,124 @@
+package webflow
+
+import (
+	"context"
+	"errors"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// hostnamePattern matches a DNS name: dot-separated labels of letters,
+// digits and inner hyphens.
+//
+// Must match hostnamePattern in runtime.js.
+var hostnamePattern = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?(\.[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?)*$`)
+
+// hostTestTimeout limits the connection test of a FieldHost.
+const hostTestTimeout = 5 * time.Second
+
+// SplitHost splits the value of a FieldHost into host and port. The port is
+// "" if the value has none; IPv6 addresses with a port are written in
+// brackets, e.g. "[::1]:8080".
+func SplitHost(value string) (host, port string) {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, "[") {
+		end := strings.Index(value, "]")
+		if end < 0 {
+			return value, ""
+		}
+		host, rest := value[1:end], value[end+1:]
+		port, _ = strings.CutPrefix(rest, ":")
+		return host, port
+	}
+	if strings.Count(value, ":") == 1 {
+		host, port, _ = strings.Cut(value, ":")
+		return host, port
+	}
+	return value, ""
+}
+
+// ValidHost reports whether value is a host name or IP address with an
+// optional port, as FieldHost checks it while the user types.
+func ValidHost(value string) bool {
+	host, port := SplitHost(value)
+	if port != "" || strings.HasSuffix(strings.TrimSpace(value), ":") {
+		n, err := strconv.Atoi(port)
+		if err != nil || n < 1 || n > 65535 {
+			return false
+		}
+	}
+	if strings.Contains(host, ":") {
+		ip := net.ParseIP(host)
+		return ip != nil && ip.To4() == nil
+	}
+	if strings.Trim(host, "0123456789.") == "" {
+		ip := net.ParseIP(host)
+		return ip != nil && ip.To4() != nil
+	}
+	return len(host) <= 253 && hostnamePattern.MatchString(host)
+}
+
+// CheckHost tests whether the host of a FieldHost value can be reached: it
+// resolves the name and, if the value or defaultPort gives a port, opens a
+// TCP connection to it. It returns how long that took.
+func CheckHost(value string, defaultPort int) (time.Duration, error) {
+	if !ValidHost(value) {
+		return 0, &LocalizedError{Key: "field.invalidHost"}
+	}
+	host, port := SplitHost(value)
+	if port == "" && defaultPort > 0 {
+		port = strconv.Itoa(defaultPort)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), hostTestTimeout)
+	defer cancel()
+	start := time.Now()
+	if port == "" {
+		if _, err := net.DefaultResolver.LookupHost(ctx, host); err != nil {
+			return 0, &LocalizedError{Key: "field.hostNotFound", Args: []any{host}}
+		}
+		return time.Since(start), nil
+	}
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", net.JoinHostPort(host, port))
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) {
+			return 0, &LocalizedError{Key: "field.hostNotFound", Args: []any{host}}
+		}
+		return 0, &LocalizedError{Key: "field.hostUnreachable", Args: []any{net.JoinHostPort(host, port)}}
+	}
+	conn.Close()
+	return time.Since(start), nil
+}
+
+// withHostTest returns a FieldHost with its built-in Test button, which runs
+// CheckHost and shows the result below the field. Fields with their own
+// Suffix button are returned unchanged.
+func (field FormField) withHostTest() FormField {
+	if field.Type != FieldHost || field.Suffix != nil {
+		return field
+	}
+	field.Suffix = NewButton(TKey("field.test"), field.ID+"_test")
+	if field.OnSuffix == nil {
+		port := field.Port
+		field.OnSuffix = func(form *FormAction) {
+			value := form.Value(form.Field())
+			elapsed, err := CheckHost(value, port)
+			if err != nil {
+				form.ShowAlert(AlertError, form.flow.ErrorText(err))
+				return
+			}
+			ms := strconv.FormatInt(elapsed.Milliseconds(), 10)
+			if _, p := SplitHost(value); p == "" && port == 0 {
+				form.ShowAlert(AlertSuccess, form.flow.TF("field.hostFound", ms))
+				return
+			}
+			form.ShowAlert(AlertSuccess, form.flow.TF("field.hostReachable", ms))
+		}
+	}
+	return field
+}
@@ -4,10 +4,17 @@ package webflow
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 	"sync"
+	"time"
+
+	"github.com/crafted-tech/webflow/platform"
 )
 
 // Translation markers - control characters for translation keys
@@ -33,6 +40,7 @@ func init() {
 var (
 	currentLanguage        = "en"
 	currentAppTranslations map[string]map[string]string
+	currentOnMissing       func(lang, key string) // OnMissingTranslation of the Flow that rendered last
 	langMu                 sync.RWMutex
 )
 
@@ -45,6 +53,15 @@ func SetLanguage(lang string, appTrans map[string]map[string]string) {
 	langMu.Unlock()
 }
 
+// setCurrentTranslator makes tr the package-level state used by T() and TF().
+func setCurrentTranslator(tr translator) {
+	langMu.Lock()
+	currentLanguage = tr.lang
+	currentAppTranslations = tr.appTrans
+	currentOnMissing = tr.onMissing
+	langMu.Unlock()
+}
+
 // GetLanguage returns the current UI language code.
 func GetLanguage() string {
 	langMu.RLock()
@@ -54,40 +71,173 @@ func GetLanguage() string {
 
 // T translates a string immediately using the current language.
 // Call SetLanguage() before rendering to ensure correct translations.
+// Flows set the current language to their own before each page; use Flow.T
+// when several Flows run at once.
 //
 // Example:
 //
 //	button := Button{Label: T("button.next")} // Will be translated to "Next", "Weiter", etc.
 func T(key string) string {
-	langMu.RLock()
-	lang := currentLanguage
-	appTrans := currentAppTranslations
-	langMu.RUnlock()
-
-	return lookupTranslation(key, lang, appTrans)
+	return currentTranslator().T(key)
 }
 
 // TF translates a key with format arguments, substituting placeholders immediately.
 // Call SetLanguage() before rendering to ensure correct translations.
 //
+// Besides positional placeholders, templates may use named placeholders with
+// an Args argument, plural and select cases, and locale-aware numbers and
+// dates in ICU MessageFormat style (see Args).
+//
 // Example:
 //
 //	title := TF("welcome.title", "Unison Auditor")
 //	// "Welcome to the {0} Setup Wizard" → "Welcome to the Unison Auditor Setup Wizard"
+//
+//	status := TF("files.remaining", webflow.Args{"count": 1200})
+//	// "{count, plural, one {# file} other {# files}} left" → "1,200 files left" (de: "1.200 Dateien übrig")
 func TF(key string, args ...any) string {
+	return currentTranslator().TF(key, args...)
+}
+
+// translator translates keys for one language and set of app translations.
+type translator struct {
+	lang      string
+	appTrans  map[string]map[string]string
+	onMissing func(lang, key string) // Optional, see WithOnMissingTranslation
+}
+
+// currentTranslator returns a translator for the package-level language set
+// by SetLanguage.
+func currentTranslator() translator {
 	langMu.RLock()
-	lang := currentLanguage
-	appTrans := currentAppTranslations
-	langMu.RUnlock()
+	defer langMu.RUnlock()
+	return translator{lang: currentLanguage, appTrans: currentAppTranslations, onMissing: currentOnMissing}
+}
 
-	template := lookupTranslation(key, lang, appTrans)
+// T translates a key.
+func (tr translator) T(key string) string {
+	if tr.onMissing != nil && !hasTranslation(key, tr.lang, tr.appTrans) {
+		tr.onMissing(tr.lang, key)
+	}
+	return lookupTranslation(key, tr.lang, tr.appTrans)
+}
 
-	// Substitute {0}, {1}, etc. with args
-	for i, arg := range args {
-		placeholder := fmt.Sprintf("{%d}", i)
-		template = strings.ReplaceAll(template, placeholder, fmt.Sprint(arg))
+// TF translates a key and substitutes its placeholders.
+func (tr translator) TF(key string, args ...any) string {
+	return formatMessage(tr.T(key), tr.lang, args)
+}
+
+// translator returns the Flow's translator for its current language.
+func (f *Flow) translator() translator {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return translator{lang: f.language, appTrans: f.config.AppTranslations, onMissing: f.config.OnMissingTranslation}
+}
+
+// Language returns the Flow's current UI language code.
+func (f *Flow) Language() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.language
+}
+
+// T translates a key in the Flow's language with the Flow's app translations.
+// Unlike the package-level T it does not depend on which Flow rendered last,
+// so it is safe when several Flows (or parallel tests) use different languages.
+func (f *Flow) T(key string) string {
+	return f.translator().T(key)
+}
+
+// TF is the Flow-scoped TF (see Flow.T).
+func (f *Flow) TF(key string, args ...any) string {
+	return f.translator().TF(key, args...)
+}
+
+// TKey returns a translation marker for key and args (see TranslationPrefix)
+// instead of translating it. Pages translate markers in labels when they are
+// rendered, in the language of the Flow showing them, so TKey suits labels
+// built without a Flow, such as default button bars.
+//
+// Example:
+//
+//	btn := webflow.NewButton(webflow.TKey("button.skip"), "skip")
+func TKey(key string, args ...any) string {
+	if len(args) == 0 {
+		return TranslationPrefix + key
+	}
+	data, err := json.Marshal(args)
+	if err != nil {
+		return TranslationPrefix + key
+	}
+	return TranslationPrefix + key + ArgSeparator + string(data)
+}
+
+// Translate translates s if it is a translation marker (see TKey) in the
+// Flow's language, and returns other strings unchanged.
+func (f *Flow) Translate(s string) string {
+	return f.translator().translate(s)
+}
+
+// ErrorText returns the message of err in the Flow's language if it is a
+// *LocalizedError, and err.Error() otherwise.
+func (f *Flow) ErrorText(err error) string {
+	var lerr *LocalizedError
+	if errors.As(err, &lerr) {
+		return f.TF(lerr.Key, lerr.Args...)
+	}
+	return err.Error()
+}
+
+// LocalizedError is an error whose message is a translation, so a Flow can
+// show it in its own language (see Flow.ErrorText). Error translates it in
+// the package-level language.
+type LocalizedError struct {
+	Key  string
+	Args []any
+}
+
+func (e *LocalizedError) Error() string {
+	return TF(e.Key, e.Args...)
+}
+
+// translate translates s if it is a translation marker, else returns it.
+func (tr translator) translate(s string) string {
+	if !strings.HasPrefix(s, TranslationPrefix) {
+		return s
+	}
+	key, _, _ := strings.Cut(s[len(TranslationPrefix):], ArgSeparator)
+	if tr.onMissing != nil && !hasTranslation(key, tr.lang, tr.appTrans) {
+		tr.onMissing(tr.lang, key)
+	}
+	return TranslateString(s, tr.lang, tr.appTrans)
+}
+
+// button returns a copy of btn with its label and tooltip translated.
+func (tr translator) button(btn *Button) *Button {
+	if btn == nil {
+		return nil
+	}
+	b := *btn
+	b.Label = tr.translate(b.Label)
+	b.Tooltip = tr.translate(b.Tooltip)
+	return &b
+}
+
+// buttons returns page with the labels of its buttons translated.
+func (tr translator) buttons(page Page) Page {
+	bb := page.ButtonBar
+	bb.Back, bb.Next, bb.Close, bb.Left = tr.button(bb.Back), tr.button(bb.Next), tr.button(bb.Close), tr.button(bb.Left)
+	bb.Actions = make([]*Button, len(page.ButtonBar.Actions))
+	for i, btn := range page.ButtonBar.Actions {
+		bb.Actions[i] = tr.button(btn)
+	}
+	page.ButtonBar = bb
+	buttons := make([]Button, len(page.Buttons))
+	for i := range page.Buttons {
+		buttons[i] = *tr.button(&page.Buttons[i])
 	}
-	return template
+	page.Buttons = buttons
+	return page
 }
 
 // TranslateString translates a string that may contain translation markers.
@@ -132,24 +282,17 @@ func TranslateString(s string, lang string, appTrans map[string]map[string]strin
 		}
 	}
 
-	// Substitute placeholders: {0}, {1}, etc.
-	result := template
-	for i, arg := range args {
-		placeholder := "{" + string(rune('0'+i)) + "}"
-		argStr := ""
-		switch v := arg.(type) {
-		case string:
-			argStr = v
-		default:
-			// Convert non-string args to string via JSON
-			if b, err := json.Marshal(v); err == nil {
-				argStr = string(b)
-			}
-		}
-		result = strings.ReplaceAll(result, placeholder, argStr)
-	}
+	return formatMessage(template, lang, args)
+}
 
-	return result
+// hasTranslation reports whether key is translated for lang itself, without
+// falling back to English.
+func hasTranslation(key, lang string, appTrans map[string]map[string]string) bool {
+	if _, ok := appTrans[lang][key]; ok {
+		return true
+	}
+	_, ok := libraryTranslations[lang][key]
+	return ok
 }
 
 // lookupTranslation finds the translation for a key with fallback chain.
@@ -192,9 +335,88 @@ func lookupTranslation(key, lang string, appTrans map[string]map[string]string)
 	return key
 }
 
-// appTranslations stores application-specific translations set via WithAppTranslations.
-// These are merged with library translations on the frontend.
-var appTranslations map[string]map[string]string
+// TextDirection is the writing direction of the UI.
+type TextDirection string
+
+const (
+	DirectionAuto TextDirection = ""    // From the current language (default)
+	DirectionLTR  TextDirection = "ltr" // Left to right
+	DirectionRTL  TextDirection = "rtl" // Right to left: mirrored layout, buttons and icons
+)
+
+// rtlLanguages lists the base language codes written right to left.
+var rtlLanguages = map[string]bool{
+	"ar": true, // Arabic
+	"fa": true, // Persian
+	"he": true, // Hebrew
+	"iw": true, // Hebrew (legacy code)
+	"ps": true, // Pashto
+	"ur": true, // Urdu
+	"yi": true, // Yiddish
+}
+
+// IsRTL reports whether a language code (e.g. "ar", "he-IL") is written
+// right to left.
+func IsRTL(lang string) bool {
+	base, _, _ := strings.Cut(strings.ReplaceAll(lang, "_", "-"), "-")
+	return rtlLanguages[strings.ToLower(base)]
+}
+
+// WithTextDirection overrides the writing direction, which otherwise follows
+// the current language (Arabic, Hebrew, Persian and Urdu are RTL). In RTL the
+// page content is right-aligned and the button bar is mirrored; give
+// directional icons such as arrows class="rtl-mirror" to flip them too.
+func WithTextDirection(dir TextDirection) Option {
+	return func(c *Config) {
+		c.TextDirection = dir
+	}
+}
+
+// textDirection returns the direction for rendering pages in lang.
+func (f *Flow) textDirection(lang string) TextDirection {
+	if f.config.TextDirection != DirectionAuto {
+		return f.config.TextDirection
+	}
+	if IsRTL(lang) {
+		return DirectionRTL
+	}
+	return DirectionLTR
+}
+
+// DetectSystemLanguage returns the built-in language that best matches the
+// user's OS display languages (GetUserPreferredUILanguages on Windows,
+// AppleLanguages on macOS, LANGUAGE/LC_ALL/LANG elsewhere), or "en" if none
+// matches. It is the default when WithInitialLanguage is not set.
+func DetectSystemLanguage() string {
+	for _, tag := range platform.PreferredLanguages() {
+		if lang := matchLanguage(tag); lang != "" {
+			return lang
+		}
+	}
+	return "en"
+}
+
+// matchLanguage maps a BCP 47 tag such as "de-AT" or "zh-TW" to a built-in
+// language code, or returns "" if there is none.
+func matchLanguage(tag string) string {
+	parts := strings.Split(strings.ReplaceAll(tag, "_", "-"), "-")
+	base := strings.ToLower(parts[0])
+
+	// Chinese translations are per script; infer it from the region if needed
+	if base == "zh" {
+		for _, p := range parts[1:] {
+			switch strings.ToUpper(p) {
+			case "HANT", "TW", "HK", "MO":
+				return "zh-Hant"
+			}
+		}
+		return "zh-Hans"
+	}
+	if _, ok := libraryTranslations[base]; ok {
+		return base
+	}
+	return ""
+}
 
 // LanguageInfo holds the code and display name for a language.
 type LanguageInfo struct {
@@ -213,8 +435,27 @@ func GetAvailableLanguages() []LanguageInfo {
 		}
 		langs = append(langs, LanguageInfo{Code: code, Name: name})
 	}
+	sortLanguages(langs)
+	return langs
+}
 
-	// Sort: English first, then alphabetically by name
+// languages returns the built-in languages plus app languages that have a
+// "_name" (e.g. added with WithTranslationDir), sorted as GetAvailableLanguages.
+func (tr translator) languages() []LanguageInfo {
+	langs := GetAvailableLanguages()
+	for code, trans := range tr.appTrans {
+		name, ok := trans["_name"]
+		if _, builtin := libraryTranslations[code]; builtin || !ok {
+			continue
+		}
+		langs = append(langs, LanguageInfo{Code: code, Name: name})
+	}
+	sortLanguages(langs)
+	return langs
+}
+
+// sortLanguages sorts English first, then alphabetically by name.
+func sortLanguages(langs []LanguageInfo) {
 	sort.Slice(langs, func(i, j int) bool {
 		// English always first
 		if langs[i].Code == "en" {
@@ -226,8 +467,6 @@ func GetAvailableLanguages() []LanguageInfo {
 		// Rest sorted alphabetically by name
 		return langs[i].Name < langs[j].Name
 	})
-
-	return langs
 }
 
 // WithAppTranslations sets application-specific translations that are merged
@@ -244,6 +483,229 @@ func GetAvailableLanguages() []LanguageInfo {
 func WithAppTranslations(translations map[string]map[string]string) Option {
 	return func(c *Config) {
 		c.AppTranslations = translations
-		appTranslations = translations // Also store in global for template access
 	}
 }
+
+// WithTranslationDir loads translation files from a directory when the Flow
+// is created, so OEMs and translators can add or override languages without
+// recompiling. Each *.json file is either one language named after the file
+// ("pt-BR.json" with {"button.next": "Próximo", ...}) or several languages in
+// the format of WithAppTranslations ({"pt-BR": {...}, "nl": {...}}). Files
+// take precedence over WithAppTranslations. Give a new language a "_name"
+// key to list it in the welcome page's language selector.
+//
+// New fails if a file cannot be read or parsed. With reload set (meant for
+// development), the directory is checked again before each page and changed
+// files take effect on the next page; errors during reload keep the previous
+// translations.
+//
+// Example:
+//
+//	exeDir := filepath.Dir(os.Args[0])
+//	flow, err := webflow.New(webflow.WithTranslationDir(filepath.Join(exeDir, "lang"), false))
+func WithTranslationDir(path string, reload bool) Option {
+	return func(c *Config) {
+		c.TranslationDir = path
+		c.TranslationReload = reload
+	}
+}
+
+// loadTranslationDir reads the translation files in dir and returns them merged.
+func loadTranslationDir(dir string) (map[string]map[string]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	merged := make(map[string]map[string]string)
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		var multi map[string]map[string]string
+		if err := json.Unmarshal(data, &multi); err == nil {
+			for lang, keys := range multi {
+				mergeTranslations(merged, lang, keys)
+			}
+			continue
+		}
+		var single map[string]string
+		if err := json.Unmarshal(data, &single); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", filepath.Base(file), err)
+		}
+		mergeTranslations(merged, strings.TrimSuffix(filepath.Base(file), ".json"), single)
+	}
+	return merged, nil
+}
+
+// mergeTranslations copies keys into dst[lang], overriding existing keys.
+func mergeTranslations(dst map[string]map[string]string, lang string, keys map[string]string) {
+	if dst[lang] == nil {
+		dst[lang] = make(map[string]string, len(keys))
+	}
+	maps.Copy(dst[lang], keys)
+}
+
+// applyTranslationDir merges the translation directory over the app
+// translations given in code.
+func (f *Flow) applyTranslationDir() error {
+	stamp := translationDirStamp(f.config.TranslationDir)
+	loaded, err := loadTranslationDir(f.config.TranslationDir)
+	if err != nil {
+		return fmt.Errorf("load translations: %w", err)
+	}
+	merged := make(map[string]map[string]string)
+	for lang, keys := range f.codeTranslations {
+		mergeTranslations(merged, lang, keys)
+	}
+	for lang, keys := range loaded {
+		mergeTranslations(merged, lang, keys)
+	}
+
+	f.mu.Lock()
+	f.config.AppTranslations = merged
+	f.translationStamp = stamp
+	f.mu.Unlock()
+	return nil
+}
+
+// reloadTranslations re-reads the translation directory if a file in it
+// changed, when reloading is enabled.
+func (f *Flow) reloadTranslations() {
+	if f.config.TranslationDir == "" || !f.config.TranslationReload {
+		return
+	}
+	stamp := translationDirStamp(f.config.TranslationDir)
+	f.mu.Lock()
+	changed := !stamp.Equal(f.translationStamp)
+	f.mu.Unlock()
+	if changed {
+		f.applyTranslationDir() // Keep the previous translations on error
+	}
+}
+
+// translationDirStamp returns the newest modification time of the
+// translation files in dir.
+func translationDirStamp(dir string) time.Time {
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	var newest time.Time
+	for _, file := range files {
+		if info, err := os.Stat(file); err == nil && info.ModTime().After(newest) {
+			newest = info.ModTime()
+		}
+	}
+	return newest
+}
+
+// WithOnMissingTranslation calls fn whenever a key has no translation for the
+// current language and falls back to English (or to the key itself), both for
+// library strings and for the app's T()/TF() calls. Use it during development
+// to log untranslated strings; see also AuditTranslations.
+//
+// Example:
+//
+//	webflow.WithOnMissingTranslation(func(lang, key string) {
+//	    log.Printf("missing %s translation: %s", lang, key)
+//	})
+func WithOnMissingTranslation(fn func(lang, key string)) Option {
+	return func(c *Config) {
+		c.OnMissingTranslation = fn
+	}
+}
+
+// TranslationReport lists translation keys missing per language, from
+// AuditTranslations.
+type TranslationReport struct {
+	// Missing maps language codes to the keys that have an English text but no
+	// translation in that language, sorted. Complete languages are left out.
+	Missing map[string][]string
+
+	// Extra maps language codes to keys that have no English text, which
+	// usually means a typo or a key that was renamed.
+	Extra map[string][]string
+}
+
+// Complete reports whether every language has every key.
+func (r TranslationReport) Complete() bool {
+	return len(r.Missing) == 0
+}
+
+// String formats the report for logs, one line per language.
+func (r TranslationReport) String() string {
+	if r.Complete() && len(r.Extra) == 0 {
+		return "all translations complete"
+	}
+	var b strings.Builder
+	for _, lang := range sortedKeys(r.Missing) {
+		fmt.Fprintf(&b, "%s: %d missing: %s\n", lang, len(r.Missing[lang]), strings.Join(r.Missing[lang], ", "))
+	}
+	for _, lang := range sortedKeys(r.Extra) {
+		fmt.Fprintf(&b, "%s: %d without English text: %s\n", lang, len(r.Extra[lang]), strings.Join(r.Extra[lang], ", "))
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// AuditTranslations checks app translations, together with the built-in
+// ones, for keys that are missing in some language. The key set is the
+// English text of both; every built-in language and every language in
+// appTrans is checked. Run it in a test or at startup in debug builds.
+//
+// Example:
+//
+//	func TestTranslations(t *testing.T) {
+//	    if r := webflow.AuditTranslations(appTranslations); !r.Complete() {
+//	        t.Error(r)
+//	    }
+//	}
+func AuditTranslations(appTrans map[string]map[string]string) TranslationReport {
+	english := make(map[string]bool)
+	for key := range libraryTranslations["en"] {
+		english[key] = true
+	}
+	for key := range appTrans["en"] {
+		english[key] = true
+	}
+
+	langs := make(map[string]bool)
+	for lang := range libraryTranslations {
+		langs[lang] = true
+	}
+	for lang := range appTrans {
+		langs[lang] = true
+	}
+
+	report := TranslationReport{Missing: make(map[string][]string), Extra: make(map[string][]string)}
+	for lang := range langs {
+		if lang == "en" {
+			continue
+		}
+		for key := range english {
+			if !strings.HasPrefix(key, "_") && !hasTranslation(key, lang, appTrans) {
+				report.Missing[lang] = append(report.Missing[lang], key)
+			}
+		}
+		for key := range appTrans[lang] {
+			if !english[key] && !strings.HasPrefix(key, "_") {
+				report.Extra[lang] = append(report.Extra[lang], key)
+			}
+		}
+		sort.Strings(report.Missing[lang])
+		sort.Strings(report.Extra[lang])
+	}
+	maps.DeleteFunc(report.Missing, func(_ string, keys []string) bool { return len(keys) == 0 })
+	maps.DeleteFunc(report.Extra, func(_ string, keys []string) bool { return len(keys) == 0 })
+	return report
+}
+
+// sortedKeys returns the keys of m in order.
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,60 @@
+package installer
+
+import (
+	"time"
+
+	"github.com/crafted-tech/webflow"
+)
+
+// Analytics receives the events of an install funnel, so vendors can see
+// where users leave the installer. Implementations must be safe for
+// concurrent use and must not block: events are reported from the UI and
+// from running steps. nethelper.BatchAnalytics sends them to a server.
+//
+// Only report events the user agreed to: AnalyticsFor returns NoAnalytics
+// unless the consent page granted analytics, and App does this for
+// AppConfig.Analytics. Implementations that hold events before sending them
+// should also have a Discard() method, which App calls to drop them when the
+// user turns analytics off.
+type Analytics interface {
+	// PageViewed is called when a page is shown, with its ID (see
+	// webflow.WithPageID) or title.
+	PageViewed(page string)
+
+	// StepCompleted is called after a step succeeded, with its duration.
+	StepCompleted(step string, elapsed time.Duration)
+
+	// InstallFinished is called once at the end with ResultSuccess,
+	// ResultFailed or ResultCancelled. Batching implementations send what
+	// they hold before returning.
+	InstallFinished(outcome string)
+}
+
+// analyticsDiscarder is an Analytics holding events that can be dropped.
+type analyticsDiscarder interface {
+	Discard()
+}
+
+// NoAnalytics discards all events. It is the Analytics of an App until the
+// user consents.
+type NoAnalytics struct{}
+
+func (NoAnalytics) PageViewed(string)                   {}
+func (NoAnalytics) StepCompleted(string, time.Duration) {}
+func (NoAnalytics) InstallFinished(string)              {}
+
+// AnalyticsFor returns a if consent grants analytics (see
+// webflow.ConsentAnalytics), and NoAnalytics if it does not or a is nil.
+//
+// Example:
+//
+//	resp := ui.ShowConsent("Privacy", webflow.ConsentConfig{PolicyURL: policyURL})
+//	if consent, ok := resp.(webflow.ConsentResult); ok {
+//	    analytics = installer.AnalyticsFor(consent, nethelper.NewBatchAnalytics(client, "/events", "Acme", version))
+//	}
+func AnalyticsFor(consent webflow.ConsentResult, a Analytics) Analytics {
+	if a == nil || !consent.Analytics {
+		return NoAnalytics{}
+	}
+	return a
+}
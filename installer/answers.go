@@ -0,0 +1,216 @@
+package installer
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/crafted-tech/webflow"
+	"github.com/crafted-tech/webflow/platform"
+)
+
+// AnswersMode controls what happens when an answers file has no entry for a
+// page that asks for input.
+type AnswersMode int
+
+const (
+	// AnswersStrict closes the wizard at the first unanswered input page and
+	// reports it through Err. Use it for deployments that must not guess.
+	AnswersStrict AnswersMode = iota
+
+	// AnswersLenient accepts the page defaults for unanswered input pages.
+	AnswersLenient
+
+	// AnswersInteractive shows unanswered input pages to the user, so an
+	// answers file can preset some choices of an otherwise normal install.
+	AnswersInteractive
+)
+
+// ErrMissingAnswer is reported by Answers.Err when a strict run hit a page
+// without an answer.
+var ErrMissingAnswer = errors.New("missing answer")
+
+// Answers drives a wizard from an answers file for unattended installs.
+// The file maps page IDs (see webflow.WithPageID) to the values the user would
+// enter; Session.WriteAnswers produces one from a recorded session:
+//
+//	{
+//	  "pages": {
+//	    "install-type": {"_selected_index": 1},
+//	    "settings": {"port": "8080", "autostart": true},
+//	    "license": {"_button": "next"}
+//	  }
+//	}
+//
+// Pages without inputs (messages, welcome, summaries) are accepted
+// automatically; Mode decides what happens to input pages that are missing.
+//
+// Passwords are never recorded. To keep one in the file, store it encrypted
+// with ProtectAnswer, e.g. {"password": {"protected": "AQAAANCMnd8B..."}};
+// LoadAnswers decrypts it.
+//
+// Example:
+//
+//	answers, err := installer.LoadAnswers(*answersPath, installer.AnswersStrict)
+//	if err != nil {
+//	    return err
+//	}
+//	ui, _ := webflow.New(answers.Option())
+//	runWizard(ui)
+//	if err := answers.Err(); err != nil {
+//	    log.Error("%v", err)
+//	    os.Exit(1)
+//	}
+type Answers struct {
+	mu      sync.Mutex
+	pages   map[string]map[string]any
+	mode    AnswersMode
+	missing []string
+}
+
+// answersFile is the on-disk answers format.
+type answersFile struct {
+	Pages map[string]map[string]any `json:"pages"`
+}
+
+// LoadAnswers reads an answers file.
+func LoadAnswers(path string, mode AnswersMode) (*Answers, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read answers: %w", err)
+	}
+	var file answersFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parse answers: %w", err)
+	}
+	if file.Pages == nil {
+		file.Pages = make(map[string]map[string]any)
+	}
+	for pageID, values := range file.Pages {
+		for field, v := range values {
+			obj, ok := v.(map[string]any)
+			if !ok {
+				continue
+			}
+			protected, ok := obj["protected"].(string)
+			if !ok {
+				continue
+			}
+			plain, err := unprotectAnswer(protected)
+			if err != nil {
+				return nil, fmt.Errorf("answer %s/%s: %w", pageID, field, err)
+			}
+			values[field] = plain
+		}
+	}
+	return &Answers{pages: file.Pages, mode: mode}, nil
+}
+
+// ProtectAnswer encrypts a secret answer, such as a service account password,
+// for an answers file with platform.ProtectData. Only the same user on the
+// same machine can decrypt it, so it suits files that stay on the machine,
+// such as the answers of an install that continues after a reboot.
+//
+// Example:
+//
+//	protected, err := installer.ProtectAnswer(password.Reveal())
+//	answers["account"]["password"] = protected
+func ProtectAnswer(value string) (map[string]any, error) {
+	data, err := platform.ProtectData([]byte(value))
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"protected": base64.StdEncoding.EncodeToString(data)}, nil
+}
+
+// unprotectAnswer decrypts a value written by ProtectAnswer.
+func unprotectAnswer(protected string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(protected)
+	if err != nil {
+		return "", fmt.Errorf("decode protected value: %w", err)
+	}
+	plain, err := platform.UnprotectData(data)
+	if err != nil {
+		return "", err
+	}
+	defer clear(plain)
+	return string(plain), nil
+}
+
+// Option returns the webflow option that answers pages from the file.
+func (a *Answers) Option() webflow.Option {
+	return webflow.WithAnswers(a.answer)
+}
+
+// Get returns the answer for a field of a page, e.g. for values the installer
+// needs outside the wizard.
+func (a *Answers) Get(pageID, field string) (any, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	v, ok := a.pages[pageID][field]
+	return v, ok
+}
+
+// Missing returns the IDs of input pages that had no answer, in order.
+func (a *Answers) Missing() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return slices.Clone(a.missing)
+}
+
+// Err returns ErrMissingAnswer naming the unanswered pages if a strict run
+// stopped at one, or nil.
+func (a *Answers) Err() error {
+	missing := a.Missing()
+	if a.mode != AnswersStrict || len(missing) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w for page %s", ErrMissingAnswer, strings.Join(missing, ", "))
+}
+
+// answer implements webflow.WithAnswers.
+func (a *Answers) answer(id string, page webflow.Page) (webflow.PageAnswer, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if values, ok := a.pages[id]; ok {
+		data := maps.Clone(values)
+		button, _ := data["_button"].(string)
+		delete(data, "_button")
+		return webflow.PageAnswer{Button: button, Data: data}, true
+	}
+	if !hasInput(page) {
+		return webflow.PageAnswer{}, true
+	}
+
+	a.missing = append(a.missing, id)
+	switch a.mode {
+	case AnswersLenient:
+		return webflow.PageAnswer{}, true
+	case AnswersInteractive:
+		return webflow.PageAnswer{}, false
+	default:
+		return webflow.PageAnswer{Close: true}, true
+	}
+}
+
+// hasInput reports whether a page asks the user for a value or a choice.
+func hasInput(page webflow.Page) bool {
+	switch c := page.Content.(type) {
+	case []webflow.Choice, webflow.MultiChoice, []webflow.MenuItem:
+		return true
+	case []webflow.FormField:
+		for _, field := range c {
+			if field.Type != webflow.FieldInfo {
+				return true
+			}
+		}
+	}
+	return false
+}
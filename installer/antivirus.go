@@ -0,0 +1,49 @@
+package installer
+
+import (
+	"strings"
+
+	"github.com/crafted-tech/webflow"
+	"github.com/crafted-tech/webflow/platform"
+)
+
+// ShowAntivirusWarning shows a warning page if an antivirus product other
+// than Microsoft Defender is active (see platform.ThirdPartyAntivirus). Such
+// products don't honor Defender exclusions and often slow down or block the
+// install, so the page names them and lists the paths the user may want to
+// exclude in them. Uses WizardMiddle() unless opts set a ButtonBar.
+//
+// Returns nil without showing a page if no such product is active, or the
+// page's response (Next, Back or Close).
+//
+// Example:
+//
+//	if resp := installer.ShowAntivirusWarning(ui, []string{installDir}); webflow.IsClose(resp) {
+//	    return installer.ErrCancelled
+//	}
+func ShowAntivirusWarning(ui *webflow.Flow, paths []string, opts ...webflow.PageOption) any {
+	products := platform.ThirdPartyAntivirus()
+	if len(products) == 0 {
+		return nil
+	}
+	names := make([]string, len(products))
+	for i, p := range products {
+		names[i] = p.Name
+	}
+
+	fields := []webflow.FormField{{
+		ID:        "antivirus_warning",
+		Type:      webflow.FieldInfo,
+		Label:     ui.TF("antivirus.message", strings.Join(names, ", ")),
+		AlertType: webflow.AlertWarning,
+	}}
+	if len(paths) > 0 {
+		fields = append(fields, webflow.FormField{
+			ID:    "antivirus_paths",
+			Type:  webflow.FieldInfo,
+			Label: ui.T("antivirus.exclude") + "\n" + strings.Join(paths, "\n"),
+		})
+	}
+	pageOpts := append([]webflow.PageOption{webflow.WithButtonBar(webflow.WizardMiddle())}, opts...)
+	return ui.ShowForm(ui.T("antivirus.title"), fields, pageOpts...)
+}
@@ -0,0 +1,397 @@
+package installer
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+
+	"github.com/crafted-tech/webflow"
+	"github.com/crafted-tech/webflow/platform"
+)
+
+// AppConfig describes an installer run by Run.
+type AppConfig struct {
+	Name    string // Product name, e.g. "Acme Agent"; also names the lock, log and journal
+	Version string // Product version shown on the welcome page and logged
+
+	Title  string // Window title (default "<Name> Setup")
+	Width  string // Window width (default "45em")
+	Height string // Window height (default "35em")
+	Icon   []byte // Window icon PNG (default InstallerIconPNG)
+	Logo   []byte // Logo for the welcome page (SVG or PNG)
+
+	PrimaryColorLight string // Primary color for light mode, HSL (see webflow.WithPrimaryColor)
+	PrimaryColorDark  string // Primary color for dark mode, HSL
+
+	Elevate      bool                         // Relaunch with administrator (root) rights before the UI appears
+	Analytics    Analytics                    // Receives funnel events once the user consents (see App.ShowConsent)
+	Translations map[string]map[string]string // App translations (see webflow.WithAppTranslations)
+	Options      []webflow.Option             // Further options for webflow.New
+
+	// Flags registers installer-specific command-line flags; they are parsed
+	// together with the standard ones (see AppArgs).
+	Flags func(fs *flag.FlagSet)
+}
+
+// AppArgs holds the standard command-line flags of an App:
+//
+//	-answers file   Answer pages from an answers file (see LoadAnswers)
+//	-silent         With -answers: fail instead of asking for a missing answer
+//	-lang code      UI language, e.g. "de" (default: the OS language)
+//	-log file       Append the log to file instead of a new file in the temp directory
+//	-status file    Write the outcome as JSON for deployment tools (see WriteStatusFile)
+//	-bundle dir     Take downloads from an offline bundle directory (see OfflineBundle)
+//	-online         With -bundle: download files that are missing from the bundle
+type AppArgs struct {
+	AnswersPath string
+	Silent      bool
+	Lang        string
+	LogPath     string
+	StatusPath  string
+	BundleDir   string
+	Online      bool
+	Args        []string // Arguments after the flags
+}
+
+// App is the common skeleton of an installer, set up by Run: a single
+// instance with the requested rights, a log, command-line flags, a resume
+// journal and a window with the product's branding.
+type App struct {
+	Config  AppConfig
+	Args    AppArgs
+	UI      *webflow.Flow
+	Log     *Logger
+	Journal *Journal // Records completed steps so an interrupted install can resume (see RunSteps)
+	Status  *Status  // Outcome, written to -status and used for the exit code
+	Answers *Answers // Non-nil with -answers
+
+	// Analytics is AppConfig.Analytics if the user consented to analytics,
+	// now or in an earlier run, and NoAnalytics otherwise. It sees every
+	// page, the steps of RunSteps and the outcome.
+	Analytics Analytics
+}
+
+// Run sets up an App, calls run and exits the process with the exit code of
+// the outcome (see ExitCodeFor). A panic in run is logged, written to a crash
+// report beside the log and shown to the user instead of closing the window
+// without a word (see WriteCrashReport and CatchCrashes).
+//
+// Example:
+//
+//	func main() {
+//	    installer.Run(installer.AppConfig{
+//	        Name:    "Acme Agent",
+//	        Version: version,
+//	        Logo:    logoSVG,
+//	        Elevate: true,
+//	    }, func(app *installer.App) error {
+//	        if webflow.IsClose(app.ShowWelcome()) {
+//	            return installer.ErrCancelled
+//	        }
+//	        if webflow.IsClose(app.ShowLicense(webflow.LicenseConfig{Content: license})) {
+//	            return installer.ErrCancelled
+//	        }
+//	        return app.RunSteps(app.UI.T("installing.title"), []installer.Step{
+//	            installer.StepEnsureDir(targetDir),
+//	            installer.StepCopyExecutable(payload, filepath.Join(targetDir, "acme.exe")),
+//	        })
+//	    })
+//	}
+func Run(cfg AppConfig, run func(app *App) error) {
+	os.Exit(int(RunApp(cfg, os.Args[1:], run)))
+}
+
+// RunApp is Run without the exit: it returns the exit code instead.
+func RunApp(cfg AppConfig, args []string, run func(app *App) error) ExitCode {
+	app := &App{Config: cfg}
+
+	fs := flag.NewFlagSet(cfg.Name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.StringVar(&app.Args.AnswersPath, "answers", "", "answers file for an unattended install")
+	fs.BoolVar(&app.Args.Silent, "silent", false, "fail on pages the answers file doesn't answer")
+	fs.StringVar(&app.Args.Lang, "lang", "", "UI language")
+	fs.StringVar(&app.Args.LogPath, "log", "", "log file")
+	fs.StringVar(&app.Args.StatusPath, "status", "", "status file")
+	fs.StringVar(&app.Args.BundleDir, "bundle", "", "offline bundle directory")
+	fs.BoolVar(&app.Args.Online, "online", false, "download files missing from the offline bundle")
+	if cfg.Flags != nil {
+		cfg.Flags(fs)
+	}
+	if err := fs.Parse(args); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", cfg.Name, err)
+		return ExitInvalidCommand
+	}
+	app.Args.Args = fs.Args()
+
+	if cfg.Elevate {
+		if err := platform.EnsureElevated(); err != nil {
+			return ExitElevationRequired
+		}
+	}
+
+	release, ok := platform.AcquireSingleInstance(appSlug(cfg.Name) + "-setup")
+	if !ok {
+		NativeWarning(app.title(), webflow.TF("app.alreadyRunning", cfg.Name))
+		return ExitAlreadyRunning
+	}
+	defer release()
+
+	return app.run(run)
+}
+
+// run sets up the log, answers, journal and window, then calls fn.
+func (a *App) run(fn func(app *App) error) (code ExitCode) {
+	var err error
+	if a.Args.LogPath != "" {
+		a.Log, err = NewLoggerToFile(a.Args.LogPath)
+	} else {
+		a.Log, err = NewLogger(appSlug(a.Config.Name) + "-install")
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", a.Config.Name, err) // A nil Logger discards
+	}
+	defer a.Log.Close()
+	defer CatchCrashes(a.Log)()
+	a.Status = &Status{LogPath: a.Log.Path()}
+	a.Log.Info("%s %s (%s/%s)", a.Config.Name, a.Config.Version, runtime.GOOS, platform.Arch())
+	a.Analytics = NoAnalytics{}
+	if consent, ok, _ := webflow.LoadConsent(a.Config.Name); ok {
+		a.Analytics = AnalyticsFor(consent, a.Config.Analytics)
+	}
+
+	finish := func(err error) ExitCode {
+		if err != nil || a.Status.Result == "" {
+			a.Status.finish(err, a.Status.FailedStep, a.Log)
+		}
+		a.analytics().InstallFinished(a.Status.Result)
+		if err != nil && !errors.Is(err, ErrCancelled) {
+			a.Log.Error("%v", err)
+		}
+		if a.Args.StatusPath != "" {
+			if err := WriteStatusFile(a.Args.StatusPath, a.Status); err != nil {
+				a.Log.Error("%v", err)
+			}
+		}
+		return a.Status.ExitCode
+	}
+
+	if a.Args.AnswersPath != "" {
+		mode := AnswersInteractive
+		if a.Args.Silent {
+			mode = AnswersStrict
+		}
+		if a.Answers, err = LoadAnswers(a.Args.AnswersPath, mode); err != nil {
+			return finish(err)
+		}
+	}
+
+	if a.Args.BundleDir != "" {
+		dir, err := filepath.Abs(a.Args.BundleDir)
+		if err != nil {
+			return finish(err)
+		}
+		SetOfflineBundle(&OfflineBundle{Dir: dir, AllowNetwork: a.Args.Online})
+		a.Log.Info("Offline bundle: %s (network fallback: %t)", dir, a.Args.Online)
+	}
+
+	if a.Journal, err = OpenJournal(JournalPath(appSlug(a.Config.Name))); err != nil {
+		a.Log.Warn("Resume journal unavailable: %v", err)
+	}
+
+	if err := EnsureWebView2(); err != nil {
+		NativeError(a.title(), err.Error())
+		return finish(err)
+	}
+	if a.UI, err = webflow.New(a.options()...); err != nil {
+		return finish(err)
+	}
+	defer a.UI.Close()
+
+	if err := VerifySelf(); err != nil {
+		ShowCorruptedInstaller(a.UI)
+		return finish(err)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			report := WriteCrashReport(r, debug.Stack(), a.Log)
+			ShowCrashReport(a.UI, report)
+			code = finish(report)
+		}
+	}()
+
+	err = fn(a)
+	if err == nil && a.Answers != nil {
+		err = a.Answers.Err()
+	}
+	return finish(err)
+}
+
+// options returns the webflow options for the App's window.
+func (a *App) options() []webflow.Option {
+	width, height := a.Config.Width, a.Config.Height
+	if width == "" {
+		width = "45em"
+	}
+	if height == "" {
+		height = "35em"
+	}
+	icon := a.Config.Icon
+	if icon == nil {
+		icon = InstallerIconPNG
+	}
+	opts := []webflow.Option{
+		webflow.WithTitle(a.title()),
+		webflow.WithSize(width, height),
+		webflow.WithResizable(false),
+		webflow.WithWindowIcon(icon),
+		webflow.WithOnPageShown(func(id string) {
+			a.analytics().PageViewed(id)
+		}),
+	}
+	if a.Config.PrimaryColorLight != "" || a.Config.PrimaryColorDark != "" {
+		opts = append(opts, webflow.WithPrimaryColor(a.Config.PrimaryColorLight, a.Config.PrimaryColorDark))
+	}
+	if a.Config.Translations != nil {
+		opts = append(opts, webflow.WithAppTranslations(a.Config.Translations))
+	}
+	if a.Args.Lang != "" {
+		opts = append(opts, webflow.WithInitialLanguage(a.Args.Lang))
+	}
+	if a.Answers != nil {
+		opts = append(opts, a.Answers.Option())
+	}
+	return append(opts, a.Config.Options...)
+}
+
+// title returns the window title.
+func (a *App) title() string {
+	if a.Config.Title != "" {
+		return a.Config.Title
+	}
+	return a.Config.Name + " Setup"
+}
+
+// ShowWelcome shows the standard welcome page with the App's logo, name and
+// version and a language selector. It shows the page again after a language
+// change, so the response is Next or Close.
+func (a *App) ShowWelcome(opts ...webflow.PageOption) any {
+	for {
+		resp := a.UI.ShowWelcome(webflow.WelcomeConfig{
+			Logo:             a.Config.Logo,
+			LogoHeight:       64,
+			Title:            a.UI.TF("welcome.title", a.Config.Name),
+			Message:          a.UI.TF("welcome.message", a.Config.Name, a.Config.Version),
+			LanguageSelector: true,
+		}, opts...)
+		if !webflow.LanguageChanged(resp) {
+			return resp
+		}
+	}
+}
+
+// RunSteps runs steps on a progress page with the App's log, status and
+// resume journal. If a previous run was interrupted, the user first chooses
+// to resume, start over or roll back (see AskResume). A failure is shown with
+// the log as details and returned.
+func (a *App) RunSteps(title string, steps []Step) error {
+	if a.Journal != nil && a.Journal.Interrupted() {
+		switch AskResume(a.UI, a.Journal, a.Config.Name) {
+		case ResumeRestart:
+			if err := a.Journal.Reset(); err != nil {
+				return err
+			}
+		case ResumeRollback:
+			if err := RollbackSteps(a.UI, title, steps, a.Journal); err != nil {
+				a.ShowError(a.UI.T("error.installFailed"), err.Error())
+				return err
+			}
+			return ErrCancelled
+		case ResumeCancel:
+			return ErrCancelled
+		}
+	}
+
+	err := RunStepsWithConfig(a.UI, title, steps, RunConfig{
+		Logger:          a.Log,
+		Journal:         a.Journal,
+		Status:          a.Status,
+		Analytics:       a.analytics(),
+		ReturnCancelled: true,
+	})
+	if err != nil && !errors.Is(err, ErrCancelled) {
+		a.ShowError(a.UI.T("error.installFailed"), err.Error())
+	}
+	return err
+}
+
+// ShowConsent shows the privacy consent page (see webflow.Flow.ShowConsent),
+// saves the user's choices with webflow.SaveConsent and turns Analytics on
+// or off accordingly; events AppConfig.Analytics still holds are discarded
+// when it is turned off. Show it early, as events before consent are not
+// reported.
+func (a *App) ShowConsent(cfg webflow.ConsentConfig, opts ...webflow.PageOption) any {
+	resp := a.UI.ShowConsent(a.UI.T("consent.title"), cfg, opts...)
+	consent, ok := resp.(webflow.ConsentResult)
+	if !ok {
+		return resp
+	}
+	if err := webflow.SaveConsent(a.Config.Name, consent); err != nil {
+		a.Log.Warn("Cannot save consent: %v", err)
+	}
+	a.Analytics = AnalyticsFor(consent, a.Config.Analytics)
+	if d, ok := a.Config.Analytics.(analyticsDiscarder); ok && !consent.Analytics {
+		d.Discard()
+	}
+	a.Log.Info("Analytics consent: %t", consent.Analytics)
+	return resp
+}
+
+// analytics returns Analytics, or NoAnalytics if it was set to nil.
+func (a *App) analytics() Analytics {
+	if a.Analytics == nil {
+		return NoAnalytics{}
+	}
+	return a.Analytics
+}
+
+// ShowError shows an error page whose Details button opens the log. Save to
+// File writes a diagnostics bundle (see SaveDiagnostics).
+func (a *App) ShowError(title, message string) {
+	log := a.Log.Content()
+	a.UI.ShowErrorDetails(title, message, log,
+		func() {
+			platform.CopyToClipboard(log)
+		},
+		func() {
+			SaveDiagnostics(a.UI, a.Diagnostics())
+		})
+}
+
+// Diagnostics returns a DiagnosticsConfig with the app's log and journal, to
+// add services and files to before passing it to CollectDiagnostics.
+func (a *App) Diagnostics() DiagnosticsConfig {
+	return DiagnosticsConfig{Log: a.Log, Journal: a.Journal}
+}
+
+// appSlug turns a product name into a name for files and locks:
+// "Acme Agent" becomes "acme-agent".
+func appSlug(name string) string {
+	slug := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + 'a' - 'A'
+		default:
+			return '-'
+		}
+	}, name)
+	return strings.Trim(slug, "-")
+}
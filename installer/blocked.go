@@ -0,0 +1,112 @@
+package installer
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/crafted-tech/webflow"
+	"github.com/crafted-tech/webflow/platform"
+)
+
+// maxBlockedFiles is how many blocked files ShowBlockedFiles lists by name.
+const maxBlockedFiles = 10
+
+// ShowBlockedFiles shows a warning page if any of paths, or a file in a
+// directory among them, carries the Mark of the Web (see platform.HasMotW).
+// SmartScreen, Smart App Control or Gatekeeper may then refuse to run them,
+// and launching a helper program fails. The page lists the files and offers
+// an Unblock button that removes the mark (see platform.RemoveMotW). Without
+// paths it checks the installer itself, which Windows would block again when
+// it restarts elevated. Uses WizardMiddle() unless opts set a ButtonBar.
+//
+// Returns nil without showing a page if no file is blocked, or once the user
+// unblocked them all; otherwise the page's response (Next, Back or Close).
+//
+// Example:
+//
+//	if resp := installer.ShowBlockedFiles(ui, []string{helpersDir}); webflow.IsClose(resp) {
+//	    return installer.ErrCancelled
+//	}
+func ShowBlockedFiles(ui *webflow.Flow, paths []string, opts ...webflow.PageOption) any {
+	if len(paths) == 0 {
+		exe, err := os.Executable()
+		if err != nil {
+			return nil
+		}
+		paths = []string{exe}
+	}
+
+	var failure string
+	for {
+		blocked := blockedFiles(paths)
+		if len(blocked) == 0 {
+			return nil
+		}
+
+		listed := blocked
+		if len(listed) > maxBlockedFiles {
+			listed = append(listed[:maxBlockedFiles:maxBlockedFiles], ui.TF("motw.more", len(blocked)-maxBlockedFiles))
+		}
+		fields := []webflow.FormField{{
+			ID:        "motw_warning",
+			Type:      webflow.FieldInfo,
+			Label:     ui.T("motw.message") + "\n" + strings.Join(listed, "\n"),
+			AlertType: webflow.AlertWarning,
+		}}
+		if runtime.GOOS == "windows" {
+			fields = append(fields, webflow.FormField{
+				ID:    "motw_hint",
+				Type:  webflow.FieldInfo,
+				Label: ui.T("motw.hint"),
+			})
+		}
+		if failure != "" {
+			fields = append(fields, webflow.FormField{
+				ID:        "motw_error",
+				Type:      webflow.FieldInfo,
+				Label:     failure,
+				AlertType: webflow.AlertError,
+			})
+		}
+
+		bb := webflow.WizardMiddle()
+		bb.Left = webflow.NewButton(ui.T("motw.unblock"), "unblock")
+		pageOpts := append([]webflow.PageOption{webflow.WithButtonBar(bb)}, opts...)
+		resp := ui.ShowForm(ui.T("motw.title"), fields, pageOpts...)
+		if !webflow.IsButton(resp, "unblock") {
+			return resp
+		}
+
+		failure = ""
+		for _, path := range blocked {
+			if err := platform.RemoveMotW(path); err != nil {
+				failure = ui.TF("motw.failed", filepath.Base(path), err.Error())
+				break
+			}
+		}
+	}
+}
+
+// blockedFiles returns paths, and the files in directories among them, that
+// carry the Mark of the Web.
+func blockedFiles(paths []string) []string {
+	var blocked []string
+	for _, root := range paths {
+		filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			if platform.HasMotW(path) {
+				blocked = append(blocked, path)
+				if d.IsDir() {
+					return filepath.SkipDir // RemoveMotW unblocks its contents too
+				}
+			}
+			return nil
+		})
+	}
+	return blocked
+}
@@ -0,0 +1,286 @@
+package installer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Defaults for Cache.
+const (
+	defaultCacheMaxSize = 2 << 30
+	defaultCacheMaxAge  = 90 * 24 * time.Hour
+)
+
+// ErrInvalidChecksum is returned for a checksum that is not a SHA-256 hex
+// string.
+var ErrInvalidChecksum = errors.New("invalid SHA-256 checksum")
+
+// Cache stores downloaded files by their SHA-256 checksum, so installers of
+// the same vendor share large prerequisites (runtimes, redistributables)
+// instead of downloading them once per product. nethelper.Client.Download
+// uses it when Client.Cache is set and the download has a checksum.
+//
+// Entries are plain files named by their checksum. Using an entry marks it as
+// recently used; Evict removes entries unused for MaxAge and then the least
+// recently used ones until the cache fits in MaxSize. Several installers may
+// use the same cache at once.
+//
+// Example:
+//
+//	cache, err := installer.NewCache("Acme")
+//	if err == nil {
+//	    client.Cache = cache
+//	}
+type Cache struct {
+	Dir     string        // Cache directory
+	MaxSize int64         // Size limit in bytes (default 2 GiB; -1 for none)
+	MaxAge  time.Duration // Entries unused this long are evicted (default 90 days; -1 for none)
+}
+
+// CacheStats describes the contents of a Cache.
+type CacheStats struct {
+	Entries    int       // Number of cached files
+	Size       int64     // Total size in bytes
+	OldestUsed time.Time // Last use of the least recently used entry
+	NewestUsed time.Time // Last use of the most recently used entry
+}
+
+// NewCache returns the cache shared by the installers of vendor, in the user
+// cache directory (or the temp directory if that is unavailable), with the
+// default limits. The directory is created on first use.
+func NewCache(vendor string) (*Cache, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	slug := appSlug(vendor)
+	if slug == "" {
+		return nil, fmt.Errorf("cache: invalid vendor name %q", vendor)
+	}
+	return &Cache{Dir: filepath.Join(dir, slug, "installer-cache")}, nil
+}
+
+// Lookup returns the path of the cached file with checksum sum and marks it
+// as used. It reports false if the file is not cached.
+func (c *Cache) Lookup(sum string) (string, bool) {
+	path, err := c.entryPath(sum)
+	if err != nil {
+		return "", false
+	}
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		return "", false
+	}
+	now := time.Now()
+	os.Chtimes(path, now, now)
+	return path, true
+}
+
+// Add copies the file at src into the cache under checksum sum and returns
+// the cached path. It fails if the file does not match sum, so a cache entry
+// always has the content its name promises. An existing entry is kept.
+func (c *Cache) Add(src, sum string) (string, error) {
+	path, err := c.entryPath(sum)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := c.Lookup(sum); ok {
+		return path, nil
+	}
+	if err := os.MkdirAll(c.Dir, 0o755); err != nil {
+		return "", err
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return "", err
+	}
+	defer in.Close()
+
+	// Write to a temporary file and rename it, so other installers using the
+	// cache never see a partial entry.
+	tmp, err := os.CreateTemp(c.Dir, ".tmp-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+	h := sha256.New()
+	_, err = io.Copy(io.MultiWriter(tmp, h), in)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", err
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != strings.ToLower(sum) {
+		return "", fmt.Errorf("cache %s: file has checksum %s", filepath.Base(src), got)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// Remove deletes the entry with checksum sum, if there is one.
+func (c *Cache) Remove(sum string) error {
+	path, err := c.entryPath(sum)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Evict removes entries unused for MaxAge, then the least recently used
+// entries until the cache fits in MaxSize. Leftovers of interrupted Add calls
+// older than a day are removed as well.
+func (c *Cache) Evict() error {
+	entries, err := c.entries()
+	if err != nil {
+		return err
+	}
+	maxAge := c.MaxAge
+	if maxAge == 0 {
+		maxAge = defaultCacheMaxAge
+	}
+	maxSize := c.MaxSize
+	if maxSize == 0 {
+		maxSize = defaultCacheMaxSize
+	}
+
+	var errs []error
+	remove := func(e cacheEntry) {
+		if err := os.Remove(e.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	var size int64
+	var kept []cacheEntry
+	for _, e := range entries {
+		if maxAge > 0 && time.Since(e.used) > maxAge {
+			remove(e)
+			continue
+		}
+		kept = append(kept, e)
+		size += e.size
+	}
+	// Oldest first, so the least recently used entries go first.
+	slices.SortFunc(kept, func(a, b cacheEntry) int { return a.used.Compare(b.used) })
+	for _, e := range kept {
+		if maxSize < 0 || size <= maxSize {
+			break
+		}
+		remove(e)
+		size -= e.size
+	}
+
+	if temps, err := filepath.Glob(filepath.Join(c.Dir, ".tmp-*")); err == nil {
+		for _, path := range temps {
+			if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > 24*time.Hour {
+				os.Remove(path)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Stats returns the number and total size of the cached files.
+func (c *Cache) Stats() (CacheStats, error) {
+	entries, err := c.entries()
+	if err != nil {
+		return CacheStats{}, err
+	}
+	var stats CacheStats
+	for _, e := range entries {
+		stats.Entries++
+		stats.Size += e.size
+		if stats.OldestUsed.IsZero() || e.used.Before(stats.OldestUsed) {
+			stats.OldestUsed = e.used
+		}
+		if e.used.After(stats.NewestUsed) {
+			stats.NewestUsed = e.used
+		}
+	}
+	return stats, nil
+}
+
+// Clear removes all entries.
+func (c *Cache) Clear() error {
+	entries, err := c.entries()
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, e := range entries {
+		if err := os.Remove(e.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// cacheEntry is a cached file.
+type cacheEntry struct {
+	path string
+	size int64
+	used time.Time
+}
+
+// entries lists the cached files. A cache directory that doesn't exist yet
+// is empty.
+func (c *Cache) entries() ([]cacheEntry, error) {
+	dirEntries, err := os.ReadDir(c.Dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var entries []cacheEntry
+	for _, d := range dirEntries {
+		if !d.Type().IsRegular() || !isSHA256(d.Name()) {
+			continue
+		}
+		info, err := d.Info()
+		if err != nil {
+			continue // removed meanwhile
+		}
+		entries = append(entries, cacheEntry{
+			path: filepath.Join(c.Dir, d.Name()),
+			size: info.Size(),
+			used: info.ModTime(),
+		})
+	}
+	return entries, nil
+}
+
+// entryPath returns the path of the entry for sum.
+func (c *Cache) entryPath(sum string) (string, error) {
+	sum = strings.ToLower(sum)
+	if !isSHA256(sum) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidChecksum, sum)
+	}
+	return filepath.Join(c.Dir, sum), nil
+}
+
+// isSHA256 reports whether s is a lowercase SHA-256 hex string.
+func isSHA256(s string) bool {
+	if len(s) != sha256.Size*2 {
+		return false
+	}
+	for _, r := range s {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return false
+		}
+	}
+	return true
+}
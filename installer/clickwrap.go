@@ -0,0 +1,206 @@
+package installer
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/crafted-tech/webflow"
+	"github.com/crafted-tech/webflow/platform"
+)
+
+// ErrAcceptanceLogTampered is returned when a record of an acceptance log
+// was changed, removed or inserted after it was written.
+var ErrAcceptanceLogTampered = errors.New("acceptance log has been tampered with")
+
+// acceptanceMu serializes appends to acceptance logs within the process.
+var acceptanceMu sync.Mutex
+
+// Acceptance records that a user accepted a license or other agreement
+// (clickwrap). Records are chained by hash in the acceptance log: each one
+// includes the hash of the one before, so changing, removing or inserting a
+// record breaks the chain (see ReadAcceptanceLog). Rewriting the whole chain
+// goes unnoticed, so keep exported copies where the user can't change them.
+type Acceptance struct {
+	Time       time.Time `json:"time"`
+	App        string    `json:"app"`
+	AppVersion string    `json:"appVersion,omitempty"`
+	Document   string    `json:"document"` // Title of the agreement, e.g. "License Agreement"
+	TextHash   string    `json:"textHash"` // SHA-256 of the text shown, hex (see HashAgreement)
+	Language   string    `json:"language"` // Language the page was shown in
+	User       string    `json:"user"`
+	Machine    string    `json:"machine"`             // Host name
+	MachineID  string    `json:"machineId,omitempty"` // See platform.MachineIDFor
+
+	Prev string `json:"prev"` // Hash of the previous record, "" for the first
+	Hash string `json:"hash"` // Hash of this record, including Prev
+}
+
+// AcceptanceLogPath returns the default acceptance log location for an app:
+// a file in the user config directory, or the temp directory if that is
+// unavailable.
+func AcceptanceLogPath(appName string) string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, appName, "acceptance.jsonl")
+}
+
+// HashAgreement returns the SHA-256 of an agreement's text as recorded in
+// Acceptance.TextHash, so the accepted version can be matched to its text.
+func HashAgreement(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+// RecordAcceptance appends acc to the acceptance log at path and writes it to
+// log, which may be nil. Time, User, Machine and MachineID are filled in if
+// empty. It returns the record as written, with its hashes.
+func RecordAcceptance(path string, acc Acceptance, log *Logger) (Acceptance, error) {
+	if acc.Time.IsZero() {
+		acc.Time = time.Now().UTC()
+	}
+	if acc.User == "" {
+		if u, err := user.Current(); err == nil {
+			acc.User = u.Username
+		}
+	}
+	if acc.Machine == "" {
+		acc.Machine, _ = os.Hostname()
+	}
+	if acc.MachineID == "" {
+		acc.MachineID, _ = platform.MachineIDFor(acc.App)
+	}
+	log.Info("%s accepted by %s on %s (language %s, text SHA-256 %s)",
+		acc.Document, acc.User, acc.Machine, acc.Language, acc.TextHash)
+
+	acceptanceMu.Lock()
+	defer acceptanceMu.Unlock()
+
+	records, err := ReadAcceptanceLog(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return acc, err
+	}
+	acc.Prev = ""
+	if len(records) > 0 {
+		acc.Prev = records[len(records)-1].Hash
+	}
+	acc.Hash = acc.hash()
+
+	data, err := json.Marshal(acc)
+	if err != nil {
+		return acc, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return acc, err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return acc, err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return acc, err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return acc, err
+	}
+	return acc, f.Close()
+}
+
+// ReadAcceptanceLog reads the acceptance log at path and verifies its hash
+// chain. If the chain is broken, it returns the records up to the break with
+// an error wrapping ErrAcceptanceLogTampered.
+func ReadAcceptanceLog(path string) ([]Acceptance, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []Acceptance
+	prev := ""
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var acc Acceptance
+		if err := json.Unmarshal(scanner.Bytes(), &acc); err != nil {
+			return records, fmt.Errorf("%w: line %d: %v", ErrAcceptanceLogTampered, line, err)
+		}
+		if acc.Prev != prev || acc.Hash != acc.hash() {
+			return records, fmt.Errorf("%w: line %d", ErrAcceptanceLogTampered, line)
+		}
+		records = append(records, acc)
+		prev = acc.Hash
+	}
+	if err := scanner.Err(); err != nil {
+		return records, err
+	}
+	return records, nil
+}
+
+// ExportAcceptanceLog verifies the acceptance log at path and writes its
+// records to w as an indented JSON array, e.g. for a legal request. Nothing
+// is written if the log fails verification.
+func ExportAcceptanceLog(path string, w io.Writer) error {
+	records, err := ReadAcceptanceLog(path)
+	if err != nil {
+		return err
+	}
+	if records == nil {
+		records = []Acceptance{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(records)
+}
+
+// hash returns the hash of the record: SHA-256 over its JSON encoding with
+// Hash empty, which covers Prev.
+func (acc Acceptance) hash() string {
+	acc.Hash = ""
+	data, _ := json.Marshal(acc)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// ShowLicense shows a license page and, when the user accepts, records the
+// acceptance in the App's log and in the acceptance log at
+// AcceptanceLogPath(Name). A failure to record it is logged as a warning and
+// doesn't stop the install.
+func (a *App) ShowLicense(cfg webflow.LicenseConfig, opts ...webflow.PageOption) any {
+	resp := a.UI.ShowLicense(cfg, opts...)
+	if resp == true {
+		recordLicense(a.UI, AcceptanceLogPath(a.Config.Name), a.Config.Name, a.Config.Version, cfg, a.Log)
+	}
+	return resp
+}
+
+// recordLicense records the acceptance of the license shown by cfg.
+func recordLicense(ui *webflow.Flow, path, app, version string, cfg webflow.LicenseConfig, log *Logger) {
+	_, err := RecordAcceptance(path, Acceptance{
+		App:        app,
+		AppVersion: version,
+		Document:   cmp.Or(cfg.Title, ui.T("license.title")),
+		TextHash:   HashAgreement(cfg.Content),
+		Language:   ui.Language(),
+	}, log)
+	if err != nil {
+		log.Warn("Could not record license acceptance: %v", err)
+	}
+}
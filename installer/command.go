@@ -0,0 +1,156 @@
+package installer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/crafted-tech/webflow"
+	"github.com/crafted-tech/webflow/platform"
+)
+
+// RunCommandStreaming runs cmd and writes its output to log line by line as
+// it runs: stdout as normal lines, stderr as warnings, and lines that look
+// like errors ("error:", "fatal:", "failed") as errors at either. ANSI colors
+// in the output decide the style where present. A carriage return starts
+// the line over, so progress bars show their final state only.
+//
+// When ctx is done or the user cancels the log page, the process and every
+// process it started are killed, and ErrCancelled (or ctx's error) is
+// returned. A non-zero exit status is returned wrapping an *exec.ExitError.
+// cmd's Stdout and Stderr are replaced.
+//
+// Example:
+//
+//	ui.ShowLog("Installing", func(lw webflow.LogWriter) {
+//	    cmd := exec.Command(filepath.Join(dir, "setup-db.exe"), "--migrate")
+//	    err = installer.RunCommandStreaming(ctx, cmd, installer.TeeLogWriter(lw, log))
+//	})
+func RunCommandStreaming(ctx context.Context, cmd *exec.Cmd, log webflow.LogWriter) error {
+	name := filepath.Base(cmd.Path)
+	var mu sync.Mutex
+	stdout := &lineWriter{log: log, mu: &mu, style: webflow.LogNormal}
+	stderr := &lineWriter{log: log, mu: &mu, style: webflow.LogWarning}
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+	// Don't wait forever for output from a background process it left behind
+	cmd.WaitDelay = 2 * time.Second
+	setProcessTree(cmd)
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("start %s: %w", name, err)
+	}
+
+	// Kill the process tree on cancellation until it exits.
+	done := make(chan struct{})
+	cancelled := make(chan error, 1)
+	go func() {
+		ticker := time.NewTicker(200 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				cancelled <- ctx.Err()
+			case <-ticker.C:
+				if !log.Cancelled() {
+					continue
+				}
+				cancelled <- ErrCancelled
+			}
+			platform.KillProcessTree(uint32(cmd.Process.Pid))
+			return
+		}
+	}()
+
+	err := cmd.Wait()
+	close(done)
+	if errors.Is(err, exec.ErrWaitDelay) {
+		err = nil // Exited fine, but a background process kept its output open
+	}
+	stdout.flush()
+	stderr.flush()
+	select {
+	case cancelErr := <-cancelled:
+		return cancelErr
+	default:
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// maxOutputLine is the longest line RunCommandStreaming buffers before
+// writing it to the log anyway.
+const maxOutputLine = 64 * 1024
+
+// lineWriter is an io.Writer that writes complete lines to a LogWriter, in
+// style unless they carry ANSI colors or look like errors.
+type lineWriter struct {
+	log   webflow.LogWriter
+	mu    *sync.Mutex // Shared by stdout and stderr
+	style webflow.LogStyle
+	buf   []byte
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.writeLine(string(w.buf[:i]))
+		w.buf = w.buf[i+1:]
+	}
+	if len(w.buf) > maxOutputLine {
+		w.flush()
+	}
+	return len(p), nil
+}
+
+// flush writes the rest of an unterminated line.
+func (w *lineWriter) flush() {
+	if len(w.buf) > 0 {
+		w.writeLine(string(w.buf))
+		w.buf = nil
+	}
+}
+
+func (w *lineWriter) writeLine(line string) {
+	line = strings.TrimRight(line, "\r")
+	if i := strings.LastIndexByte(line, '\r'); i >= 0 {
+		line = line[i+1:]
+	}
+	text, style := webflow.ParseANSI(line)
+	if style == webflow.LogNormal {
+		style = outputLineStyle(text, w.style)
+	}
+	w.mu.Lock()
+	w.log.WriteLineStyled(text, style)
+	w.mu.Unlock()
+}
+
+// outputLineStyle returns LogError for lines that report errors, otherwise
+// style.
+func outputLineStyle(line string, style webflow.LogStyle) webflow.LogStyle {
+	lower := strings.ToLower(strings.TrimSpace(line))
+	for _, prefix := range []string{"error", "fatal", "fail"} {
+		if strings.HasPrefix(lower, prefix) {
+			return webflow.LogError
+		}
+	}
+	if strings.Contains(lower, " error:") || strings.Contains(lower, " failed") {
+		return webflow.LogError
+	}
+	if strings.HasPrefix(lower, "warn") {
+		return webflow.LogWarning
+	}
+	return style
+}
@@ -0,0 +1,17 @@
+//go:build !windows
+
+package installer
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setProcessTree prepares cmd for RunCommandStreaming: it starts a process
+// group that its children inherit, so they can be killed together.
+func setProcessTree(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+}
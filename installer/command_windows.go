@@ -0,0 +1,17 @@
+//go:build windows
+
+package installer
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setProcessTree prepares cmd for RunCommandStreaming: it runs without a
+// console window.
+func setProcessTree(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.HideWindow = true
+}
@@ -0,0 +1,129 @@
+package installer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/crafted-tech/webflow"
+	"github.com/crafted-tech/webflow/platform"
+)
+
+// CrashReport is a panic recorded by WriteCrashReport. It is an error, so it
+// can be returned as the outcome of an install.
+type CrashReport struct {
+	Value      any    // Value passed to panic
+	Stack      string // Stack trace of the panicking goroutine
+	ReportPath string // Text report beside the log ("" if it could not be written)
+	DumpPath   string // Minidump beside the log (Windows; "" if none)
+
+	log *Logger
+}
+
+func (r *CrashReport) Error() string {
+	return fmt.Sprintf("panic: %v", r.Value)
+}
+
+// WriteCrashReport records a recovered panic: it logs the stack trace and
+// writes a text report and, on Windows, a minidump beside the log (see
+// CrashPaths). Call it from a deferred function with the recovered value.
+// App does this for panics in its run function.
+//
+// Example:
+//
+//	defer func() {
+//	    if r := recover(); r != nil {
+//	        report := installer.WriteCrashReport(r, debug.Stack(), log)
+//	        installer.ShowCrashReport(ui, report)
+//	        err = report
+//	    }
+//	}()
+func WriteCrashReport(value any, stack []byte, log *Logger) *CrashReport {
+	report := &CrashReport{Value: value, Stack: string(stack), log: log}
+	log.Error("panic: %v\n%s", value, stack)
+
+	reportPath, dumpPath := CrashPaths(log)
+	var b strings.Builder
+	fmt.Fprintf(&b, "Time: %s\n", time.Now().Format(time.RFC3339))
+	fmt.Fprintf(&b, "System: %s/%s, %s\n", runtime.GOOS, platform.Arch(), runtime.Version())
+	if exe, err := os.Executable(); err == nil {
+		fmt.Fprintf(&b, "Executable: %s\n", exe)
+	}
+	fmt.Fprintf(&b, "Log: %s\n\npanic: %v\n\n%s", log.Path(), value, stack)
+	if err := os.WriteFile(reportPath, []byte(b.String()), 0o644); err != nil {
+		log.Warn("Cannot write crash report: %v", err)
+	} else {
+		report.ReportPath = reportPath
+	}
+
+	if err := platform.WriteMiniDump(dumpPath); err == nil {
+		report.DumpPath = dumpPath
+	}
+	if report.ReportPath != "" {
+		log.Info("Crash report: %s", report.ReportPath)
+	}
+	return report
+}
+
+// CrashPaths returns where crash reports for log are written: the log's path
+// with "-crash.txt" for the text report and "-crash.dmp" for the minidump. A
+// nil log puts them in the temp directory.
+func CrashPaths(log *Logger) (report, dump string) {
+	base := strings.TrimSuffix(log.Path(), filepath.Ext(log.Path()))
+	if base == "" {
+		base = filepath.Join(os.TempDir(), fmt.Sprintf("installer-%d", os.Getpid()))
+	}
+	return base + "-crash.txt", base + "-crash.dmp"
+}
+
+// CatchCrashes makes the runtime also write unrecovered panics and fatal
+// errors of any goroutine to the crash report beside log (see CrashPaths and
+// debug.SetCrashOutput), which WriteCrashReport cannot catch. The returned
+// function undoes this and removes the report if nothing was written.
+func CatchCrashes(log *Logger) (stop func()) {
+	reportPath, _ := CrashPaths(log)
+	f, err := os.Create(reportPath)
+	if err != nil {
+		return func() {}
+	}
+	if err := debug.SetCrashOutput(f, debug.CrashOptions{}); err != nil {
+		f.Close()
+		os.Remove(reportPath)
+		return func() {}
+	}
+	return func() {
+		debug.SetCrashOutput(nil, debug.CrashOptions{})
+		f.Close()
+		if info, err := os.Stat(reportPath); err == nil && info.Size() == 0 {
+			os.Remove(reportPath)
+		}
+	}
+}
+
+// ShowCrashReport tells the user the installer stopped because of an
+// unexpected error. Details shows the log and stack trace with Copy and Save
+// to File; Save to File writes a diagnostics bundle with the crash report and
+// minidump to send to support (see SaveDiagnostics).
+func ShowCrashReport(ui *webflow.Flow, report *CrashReport) {
+	details := report.log.Content()
+	if details == "" {
+		details = report.Error() + "\n\n" + report.Stack
+	}
+	ui.ShowErrorDetails(ui.T("error.installFailed"), ui.T("app.crashed"), details,
+		func() {
+			platform.CopyToClipboard(details)
+		},
+		func() {
+			cfg := DiagnosticsConfig{Log: report.log}
+			for _, file := range []string{report.ReportPath, report.DumpPath} {
+				if file != "" {
+					cfg.Files = append(cfg.Files, file)
+				}
+			}
+			SaveDiagnostics(ui, cfg)
+		})
+}
@@ -0,0 +1,243 @@
+package installer
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/crafted-tech/webflow"
+	"github.com/crafted-tech/webflow/platform"
+)
+
+// ButtonDiagnostics is the ID of the button returned by DiagnosticsButton.
+const ButtonDiagnostics = "diagnostics"
+
+// DiagnosticsConfig selects what CollectDiagnostics gathers.
+type DiagnosticsConfig struct {
+	Log      *Logger  // Install log
+	Journal  *Journal // Step journal of the install (see RunConfig.Journal)
+	Services []string // Services whose status and recent log entries are included
+	Files    []string // Further files, e.g. configuration or crash reports; missing ones are skipped
+	Events   int      // Recent system log warnings and errors (default 200; -1 for none)
+}
+
+// CollectDiagnostics writes a zip file for a support ticket to outZip: the
+// install log, the step journal, a summary of the system, the status and
+// recent log entries of the configured services, recent warnings and errors
+// from the system logs (see platform.SystemEvents) and any further files.
+// Parts that cannot be collected are listed in the bundle's notes.txt
+// instead of failing the whole bundle.
+//
+// Example:
+//
+//	err := installer.CollectDiagnostics(filepath.Join(desktop, "acme-diagnostics.zip"), installer.DiagnosticsConfig{
+//	    Log:      log,
+//	    Services: []string{"acmesvc"},
+//	})
+func CollectDiagnostics(outZip string, cfg DiagnosticsConfig) error {
+	f, err := os.Create(outZip)
+	if err != nil {
+		return err
+	}
+	zw := zip.NewWriter(f)
+	var notes []string
+	note := func(format string, args ...any) {
+		notes = append(notes, fmt.Sprintf(format, args...))
+	}
+
+	err = addTextToZip(zw, "system.txt", systemSummary())
+	if err == nil && cfg.Log.Path() != "" {
+		if addErr := addFileToZip(zw, cfg.Log.Path(), filepath.Base(cfg.Log.Path())); addErr != nil {
+			note("Log %s: %v", cfg.Log.Path(), addErr)
+		}
+	}
+	if err == nil && cfg.Journal != nil {
+		cfg.Journal.mu.Lock()
+		data, _ := json.MarshalIndent(cfg.Journal.state, "", "  ")
+		cfg.Journal.mu.Unlock()
+		err = addTextToZip(zw, "journal.json", string(data))
+	}
+	if err == nil && len(cfg.Services) > 0 {
+		err = addTextToZip(zw, "services.txt", servicesSummary(cfg.Services))
+	}
+	if err == nil && cfg.Events >= 0 {
+		lines := cfg.Events
+		if lines == 0 {
+			lines = 200
+		}
+		events, eventsErr := platform.SystemEvents(lines)
+		if eventsErr != nil {
+			note("System events: %v", eventsErr)
+		} else {
+			err = addTextToZip(zw, "events.txt", strings.Join(events, "\n"))
+		}
+	}
+	for _, file := range cfg.Files {
+		if err != nil {
+			break
+		}
+		if addErr := addFileToZip(zw, file, "files/"+filepath.Base(file)); addErr != nil {
+			note("File %s: %v", file, addErr)
+		}
+	}
+	if err == nil && len(notes) > 0 {
+		err = addTextToZip(zw, "notes.txt", strings.Join(notes, "\n"))
+	}
+
+	if closeErr := zw.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(outZip)
+		return fmt.Errorf("collect diagnostics: %w", err)
+	}
+	return nil
+}
+
+// DiagnosticsButton returns a Save Diagnostics button to put on failure
+// pages; handle it with SaveDiagnostics.
+//
+// Example:
+//
+//	bb := webflow.ButtonBar{Left: installer.DiagnosticsButton(), Close: webflow.NewButton(ui.T("button.close"), webflow.ButtonClose)}
+//	for {
+//	    resp := ui.ShowMessage(title, message, webflow.WithButtonBar(bb))
+//	    if !webflow.IsButton(resp, installer.ButtonDiagnostics) {
+//	        break
+//	    }
+//	    installer.SaveDiagnostics(ui, cfg)
+//	}
+func DiagnosticsButton() *webflow.Button {
+	return webflow.NewButton(webflow.TKey("diagnostics.save"), ButtonDiagnostics)
+}
+
+// SaveDiagnostics asks the user where to save a diagnostics bundle and
+// collects it there (see CollectDiagnostics). A failure is shown to the user
+// and returned; cancelling the dialog returns nil.
+func SaveDiagnostics(ui *webflow.Flow, cfg DiagnosticsConfig) error {
+	path, ok := ui.SaveFile(
+		webflow.DialogTitle(ui.T("diagnostics.saveTitle")),
+		webflow.DialogDefaultName("diagnostics-"+time.Now().Format("20060102-150405")+".zip"),
+		webflow.DialogFilters(webflow.FileFilter{Name: "ZIP", Patterns: []string{"*.zip"}}),
+	)
+	if !ok || path == "" {
+		return nil
+	}
+	if err := CollectDiagnostics(path, cfg); err != nil {
+		cfg.Log.Error("%v", err)
+		ui.ShowAlertError(ui.T("error.title"), err.Error())
+		return err
+	}
+	cfg.Log.Info("Diagnostics saved to %s", path)
+	return nil
+}
+
+// systemSummary describes the system for a diagnostics bundle.
+func systemSummary() string {
+	var b strings.Builder
+	line := func(label, format string, args ...any) {
+		fmt.Fprintf(&b, "%-14s %s\n", label+":", fmt.Sprintf(format, args...))
+	}
+	line("Time", "%s", time.Now().Format(time.RFC3339))
+	line("System", "%s", osDescription())
+	line("Architecture", "%s/%s", runtime.GOOS, platform.Arch())
+	line("Go", "%s", runtime.Version())
+	if exe, err := os.Executable(); err == nil {
+		line("Executable", "%s", exe)
+	}
+	line("Elevated", "%t", platform.IsElevated())
+	line("Managed", "%t", platform.IsManagedDevice())
+	line("Language", "%s", webflow.GetLanguage())
+	if total, err := platform.TotalMemory(); err == nil {
+		line("Memory", "%s", webflow.FormatBytes(int64(total)))
+	}
+	if free, err := platform.FreeDiskSpace(os.TempDir()); err == nil {
+		line("Free (temp)", "%s", webflow.FormatBytes(int64(free)))
+	}
+	return b.String()
+}
+
+// osDescription returns the operating system name and version.
+func osDescription() string {
+	switch runtime.GOOS {
+	case "windows":
+		return platform.GetWindowsVersionString()
+	case "darwin":
+		if out, err := exec.Command("sw_vers", "-productVersion").Output(); err == nil {
+			return "macOS " + strings.TrimSpace(string(out))
+		}
+	case "linux":
+		if data, err := os.ReadFile("/etc/os-release"); err == nil {
+			for _, l := range strings.Split(string(data), "\n") {
+				if name, ok := strings.CutPrefix(l, "PRETTY_NAME="); ok {
+					return strings.Trim(name, `"`)
+				}
+			}
+		}
+	}
+	return runtime.GOOS
+}
+
+// servicesSummary reports the status and recent log entries of services.
+func servicesSummary(names []string) string {
+	var b strings.Builder
+	for _, name := range names {
+		status, err := platform.ServiceStatus(name)
+		if err != nil {
+			status = err.Error()
+		}
+		fmt.Fprintf(&b, "== %s: %s\n", name, status)
+		if logs, err := platform.ServiceLogs(name, 50); err == nil {
+			for _, l := range logs {
+				fmt.Fprintf(&b, "%s\n", l)
+			}
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// addTextToZip adds text to zw as a file called name.
+func addTextToZip(zw *zip.Writer, name, text string) error {
+	w, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: time.Now()})
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, text)
+	return err
+}
+
+// addFileToZip adds the file at path to zw as name.
+func addFileToZip(zw *zip.Writer, path, name string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	info, err := src.Stat()
+	if err != nil {
+		return err
+	}
+	header, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return err
+	}
+	header.Name = name
+	header.Method = zip.Deflate
+	w, err := zw.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, src)
+	return err
+}
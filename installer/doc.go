@@ -1,11 +1,29 @@
 // Package installer provides utilities for building webflow-based installers.
 //
 // This package offers reusable components that installers can pick from:
+//   - App: Installer skeleton with single instance, elevation, log, flags and branding (Run)
+//   - Uninstaller: Confirm, removal and finish pages with self-delete (UninstallApp)
 //   - Logger: Unified logging with in-memory buffer and file output
-//   - Step execution: Run steps with webflow progress UI
+//   - Clickwrap audit: Hash-chained record of license acceptances with export (App.ShowLicense)
+//   - Step execution: Run steps with webflow progress UI, with optional resume journal
+//   - Commands: Stream a command's output into the log page, killing it on cancel (RunCommandStreaming)
 //   - Common step functions: Reusable implementations (copy files, create dirs, etc.)
 //   - Service management: Windows service start/stop/install/uninstall utilities
 //   - Detection helpers: Registry queries, version comparison, process detection
+//   - System requirements: Built-in checks and a pass/fail page (ShowRequirements)
+//   - Security software: Antivirus and blocked-download warning pages (ShowAntivirusWarning, ShowBlockedFiles)
+//   - Other users: Warning page when other users are signed in (ShowOtherUsersWarning)
+//   - Manifest: Data-driven installs for simple products (LoadManifest)
+//   - Answer files: Unattended installs from recorded answers (LoadAnswers)
+//   - Status files: Deployment exit codes and JSON outcome (ExitCode, WriteStatusFile)
+//   - Server requests: JSON client with retries and cancellation, and downloads
+//     from mirrors in parallel chunks (subpackage nethelper)
+//   - Offline bundles: Downloads from a local directory for air-gapped installs (SetOfflineBundle, -bundle)
+//   - Download cache: Shared store of payloads by SHA-256 with eviction (NewCache)
+//   - Self check: Detects truncated or modified installer downloads (StampIntegrity, VerifySelf)
+//   - Crash reports: Stack traces and minidumps beside the log, with a report page (WriteCrashReport, ShowCrashReport)
+//   - Diagnostics: Support bundle zip with the log, step journal, system info, service status and system events (CollectDiagnostics)
+//   - Analytics: Page, step and outcome events for measuring drop-off, sent only with consent (Analytics, nethelper.BatchAnalytics)
 //
 // # Design Philosophy
 //
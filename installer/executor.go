@@ -1,6 +1,9 @@
 package installer
 
 import (
+	"errors"
+	"time"
+
 	"github.com/crafted-tech/webflow"
 )
 
@@ -39,21 +42,115 @@ func RunStepsWithCancel(ui *webflow.Flow, title string, steps []Step) error {
 // RunStepsWithLogger executes steps with logging to the provided Logger.
 // If log is nil, no logging is performed.
 func RunStepsWithLogger(ui *webflow.Flow, title string, steps []Step, log *Logger) error {
-	return runStepsInternal(ui, title, steps, log, false)
+	return RunStepsWithConfig(ui, title, steps, RunConfig{Logger: log})
 }
 
 // RunStepsWithLoggerCancel executes steps with logging and cancellation support.
 // Returns ErrCancelled if the user cancels during execution.
 func RunStepsWithLoggerCancel(ui *webflow.Flow, title string, steps []Step, log *Logger) error {
-	return runStepsInternal(ui, title, steps, log, true)
+	return RunStepsWithConfig(ui, title, steps, RunConfig{Logger: log, ReturnCancelled: true})
+}
+
+// RunStepsWithJournal executes steps like RunStepsWithLoggerCancel, recording
+// progress in j. Steps the journal lists as completed are skipped, so after an
+// interrupted install the run resumes at the first incomplete step. The journal
+// file is removed once all steps succeed. See Journal.
+func RunStepsWithJournal(ui *webflow.Flow, title string, steps []Step, log *Logger, j *Journal) error {
+	return RunStepsWithConfig(ui, title, steps, RunConfig{Logger: log, Journal: j, ReturnCancelled: true})
 }
 
-func runStepsInternal(ui *webflow.Flow, title string, steps []Step, log *Logger, returnCancelled bool) error {
+// RunConfig configures RunStepsWithConfig.
+//
+// The hooks run on the worker goroutine behind the progress page, so they must
+// not call Show* methods on the Flow. They are meant for cross-cutting concerns
+// such as timing, metrics, extra logging or guarding destructive steps.
+type RunConfig struct {
+	Logger          *Logger  // Optional logger (nil = no logging)
+	Journal         *Journal // Optional resume journal (see RunStepsWithJournal)
+	ReturnCancelled bool     // Return ErrCancelled instead of nil when the user cancels
+
+	// History, if set, weights the progress bar by each step's duration in
+	// previous runs and advances it while a step runs. It is updated with this
+	// run's durations and saved when the run ends.
+	History *TimingHistory
+
+	// Timings, if set, receives the duration of every step that ran.
+	// The report is also written to Logger at the end of the run.
+	Timings *TimingReport
+
+	// Status, if set, receives the outcome of the run (result, exit code and
+	// failed step) for WriteStatusFile.
+	Status *Status
+
+	// Analytics, if set, is told about every step that succeeded.
+	Analytics Analytics
+
+	// BeforeEach is called before each step runs. Return ErrSkipStep to skip
+	// the step, or any other error to stop the run with that error.
+	BeforeEach func(step Step) error
+
+	// AfterEach is called after each step that ran, with its result and duration.
+	// It is also called for failed steps, before OnFailure.
+	AfterEach func(step Step, result StepResult, elapsed time.Duration)
+
+	// OnFailure is called when a step fails. Return nil to ignore the failure
+	// and continue with the next step, or an error (typically err, possibly
+	// wrapped) to stop the run. Without OnFailure the first failure stops the run.
+	OnFailure func(step Step, err error) error
+}
+
+// ErrSkipStep can be returned from RunConfig.BeforeEach to skip a step.
+var ErrSkipStep = errors.New("skip step")
+
+// RunStepsWithConfig executes steps with the given logger, journal and hooks.
+//
+// Example:
+//
+//	err := installer.RunStepsWithConfig(ui, "Installing...", steps, installer.RunConfig{
+//	    Logger: log,
+//	    AfterEach: func(step installer.Step, r installer.StepResult, d time.Duration) {
+//	        metrics.Record(step.Name, d, r.Err)
+//	    },
+//	    OnFailure: func(step installer.Step, err error) error {
+//	        if step.Name == "Register file types" {
+//	            return nil // Optional, keep going
+//	        }
+//	        return err
+//	    },
+//	})
+func RunStepsWithConfig(ui *webflow.Flow, title string, steps []Step, cfg RunConfig) error {
 	var execErr error
+	var failedStep string
+	log := cfg.Logger
+	journal := cfg.Journal
 
+	runStart := time.Now()
 	result := ui.ShowProgress(title, func(p webflow.Progress) {
 		totalSteps := len(steps)
 
+		// Progress offsets per step: equal shares, or expected durations from history
+		var weights []time.Duration
+		var offsets []time.Duration
+		var totalWeight time.Duration
+		if cfg.History != nil {
+			weights = cfg.History.weights(steps)
+			for _, w := range weights {
+				offsets = append(offsets, totalWeight)
+				totalWeight += w
+			}
+			defer func() {
+				if err := cfg.History.Save(); err != nil && log != nil {
+					log.Warn("Could not save step timing history: %v", err)
+				}
+			}()
+		}
+
+		if journal != nil {
+			if err := journal.begin(totalSteps); err != nil && log != nil {
+				log.Warn("Could not write install journal: %v", err)
+			}
+		}
+
 		for i, step := range steps {
 			// Check for cancellation before each step
 			if p.Cancelled() {
@@ -66,21 +163,92 @@ func runStepsInternal(ui *webflow.Flow, title string, steps []Step, log *Logger,
 
 			// Calculate progress percentage
 			progress := float64(i) / float64(totalSteps) * 100
+			if weights != nil {
+				progress = float64(offsets[i]) / float64(totalWeight) * 100
+			}
 			p.Update(progress, step.Name)
 
+			if journal != nil && journal.IsCompleted(step.Name) {
+				if log != nil {
+					log.Info("Step '%s' skipped: completed in a previous run", step.Name)
+				}
+				continue
+			}
+
+			if cfg.BeforeEach != nil {
+				if err := cfg.BeforeEach(step); errors.Is(err, ErrSkipStep) {
+					if log != nil {
+						log.Info("Step '%s' skipped by hook", step.Name)
+					}
+					continue
+				} else if err != nil {
+					if log != nil {
+						log.Error("Step '%s' aborted: %v", step.Name, err)
+					}
+					execErr = err
+					failedStep = step.Name
+					return
+				}
+			}
+
 			if log != nil {
 				log.Step("Starting: %s", step.Name)
 			}
 
+			if journal != nil {
+				if err := journal.stepStarted(step.Name); err != nil && log != nil {
+					log.Warn("Could not write install journal: %v", err)
+				}
+			}
+
 			// Execute the step
+			start := time.Now()
+			var stopCreep func()
+			if weights != nil {
+				stopCreep = creepProgress(p, step.Name, offsets[i], weights[i], totalWeight)
+			}
 			result := step.Action()
+			elapsed := time.Since(start)
+			if stopCreep != nil {
+				stopCreep()
+			}
+			if cfg.History != nil && result.Err == nil {
+				cfg.History.Record(step.Name, elapsed)
+			}
+			if cfg.Timings != nil {
+				cfg.Timings.record(StepTiming{Name: step.Name, Duration: elapsed, Skipped: result.Skip, Err: result.Err})
+			}
+			if cfg.Analytics != nil && result.Err == nil && !result.Skip {
+				cfg.Analytics.StepCompleted(step.Name, elapsed)
+			}
+			if cfg.AfterEach != nil {
+				cfg.AfterEach(step, result, elapsed)
+			}
 
 			if result.Err != nil {
 				if log != nil {
 					log.Error("Step '%s' failed: %v", step.Name, result.Err)
 				}
-				execErr = result.Err
-				return
+				if cfg.OnFailure == nil {
+					execErr = result.Err
+					failedStep = step.Name
+					return
+				}
+				if err := cfg.OnFailure(step, result.Err); err != nil {
+					execErr = err
+					failedStep = step.Name
+					return
+				}
+				if log != nil {
+					log.Warn("Step '%s' failure ignored, continuing", step.Name)
+				}
+				continue
+			}
+
+			if journal != nil {
+				if err := journal.stepCompleted(step.Name); err != nil && log != nil {
+					log.Warn("Could not write install journal: %v", err)
+				}
 			}
 
 			if result.Skip {
@@ -107,15 +275,35 @@ func runStepsInternal(ui *webflow.Flow, title string, steps []Step, log *Logger,
 		if log != nil {
 			log.Info("All steps completed successfully")
 		}
+		if journal != nil {
+			journal.Reset()
+		}
 	})
 
+	if cfg.Timings != nil {
+		cfg.Timings.finish(time.Since(runStart))
+		if log != nil {
+			log.Info("%s", cfg.Timings)
+		}
+	}
+
 	// Check if cancelled via UI
 	if webflow.IsClose(result) {
-		if returnCancelled {
+		if cfg.Status != nil {
+			cfg.Status.finish(ErrCancelled, "", log)
+		}
+		if cfg.ReturnCancelled {
 			return ErrCancelled
 		}
 		return nil
 	}
 
+	if errors.Is(execErr, ErrElevationRequired) {
+		ui.ShowAlertWarning(ui.T("elevation.title"), ui.T("elevation.message"))
+	}
+	if cfg.Status != nil {
+		cfg.Status.finish(execErr, failedStep, log)
+	}
+
 	return execErr
 }
@@ -0,0 +1,422 @@
+package installer
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// A tiny expression language for OEM hooks (see Hooks). Supported syntax:
+//
+//	literals      "text" 'text' 42 3.5 true false nil
+//	variables     installDir  os  arch
+//	operators     + - * / == != < <= > >= && || ! ( )
+//	calls         exists(join(installDir, "oem.cfg"))
+//
+// && and || short-circuit, so `exists(p) && copy(p, dst)` runs copy only
+// when p exists. + concatenates when either side is a string.
+
+// ExprFunc is a function callable from hook expressions.
+type ExprFunc func(args []any) (any, error)
+
+// exprEnv holds the variables and functions visible to an expression.
+type exprEnv struct {
+	vars  map[string]any
+	funcs map[string]ExprFunc
+}
+
+// Token kinds.
+const (
+	tokEOF = iota
+	tokNumber
+	tokString
+	tokIdent
+	tokOp
+)
+
+type exprToken struct {
+	kind int
+	text string
+	pos  int
+}
+
+// tokenizeExpr splits an expression into tokens.
+func tokenizeExpr(src string) ([]exprToken, error) {
+	var toks []exprToken
+	i := 0
+	for i < len(src) {
+		c, size := utf8.DecodeRuneInString(src[i:])
+		switch {
+		case unicode.IsSpace(c):
+			i += size
+		case c == '"' || c == '\'':
+			quote := src[i]
+			start := i
+			var b strings.Builder
+			i++
+			for i < len(src) && src[i] != quote {
+				if src[i] == '\\' && i+1 < len(src) {
+					i++
+					switch src[i] {
+					case 'n':
+						b.WriteByte('\n')
+					case 't':
+						b.WriteByte('\t')
+					default:
+						b.WriteByte(src[i])
+					}
+				} else {
+					b.WriteByte(src[i])
+				}
+				i++
+			}
+			if i >= len(src) {
+				return nil, fmt.Errorf("unterminated string at %d", start)
+			}
+			i++
+			toks = append(toks, exprToken{tokString, b.String(), start})
+		case unicode.IsDigit(c):
+			start := i
+			for i < len(src) {
+				r, size := utf8.DecodeRuneInString(src[i:])
+				if !unicode.IsDigit(r) && r != '.' {
+					break
+				}
+				i += size
+			}
+			toks = append(toks, exprToken{tokNumber, src[start:i], start})
+		case unicode.IsLetter(c) || c == '_':
+			start := i
+			for i < len(src) {
+				r, size := utf8.DecodeRuneInString(src[i:])
+				if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+					break
+				}
+				i += size
+			}
+			toks = append(toks, exprToken{tokIdent, src[start:i], start})
+		default:
+			start := i
+			if i+1 < len(src) {
+				switch two := src[i : i+2]; two {
+				case "==", "!=", "<=", ">=", "&&", "||":
+					toks = append(toks, exprToken{tokOp, two, start})
+					i += 2
+					continue
+				}
+			}
+			if !strings.ContainsRune("+-*/<>!(),", c) {
+				return nil, fmt.Errorf("unexpected %q at %d", c, start)
+			}
+			toks = append(toks, exprToken{tokOp, string(c), start})
+			i += size
+		}
+	}
+	return append(toks, exprToken{tokEOF, "", len(src)}), nil
+}
+
+// exprParser is a precedence-climbing parser that evaluates while parsing.
+// When skip is set (the unevaluated side of && or ||), it only checks syntax.
+type exprParser struct {
+	toks []exprToken
+	pos  int
+	env  *exprEnv
+	skip bool
+}
+
+// evalExpr parses and evaluates a single expression.
+func evalExpr(src string, env *exprEnv) (any, error) {
+	toks, err := tokenizeExpr(src)
+	if err != nil {
+		return nil, err
+	}
+	return runExpr(toks, env, false)
+}
+
+// checkExpr parses an expression without evaluating it, reporting syntax
+// errors. Variables and functions are resolved only when it runs.
+func checkExpr(src string) error {
+	toks, err := tokenizeExpr(src)
+	if err != nil {
+		return err
+	}
+	_, err = runExpr(toks, nil, true)
+	return err
+}
+
+// runExpr parses tokens as one expression, evaluating it unless skip is set.
+func runExpr(toks []exprToken, env *exprEnv, skip bool) (any, error) {
+	p := &exprParser{toks: toks, env: env, skip: skip}
+	v, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %q at %d", t.text, t.pos)
+	}
+	return v, nil
+}
+
+// Binary operator precedence, lowest first.
+var exprPrecedence = map[string]int{
+	"||": 1,
+	"&&": 2,
+	"==": 3, "!=": 3,
+	"<": 4, "<=": 4, ">": 4, ">=": 4,
+	"+": 5, "-": 5,
+	"*": 6, "/": 6,
+}
+
+func (p *exprParser) peek() exprToken { return p.toks[p.pos] }
+
+func (p *exprParser) next() exprToken {
+	t := p.toks[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *exprParser) expect(op string) error {
+	if t := p.next(); t.kind != tokOp || t.text != op {
+		return fmt.Errorf("expected %q at %d", op, t.pos)
+	}
+	return nil
+}
+
+func (p *exprParser) parseBinary(minPrec int) (any, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		prec, ok := exprPrecedence[t.text]
+		if t.kind != tokOp || !ok || prec <= minPrec {
+			return left, nil
+		}
+		p.next()
+
+		// Short-circuit: parse the right side without evaluating it
+		if (t.text == "&&" && !truthy(left)) || (t.text == "||" && truthy(left)) {
+			wasSkipping := p.skip
+			p.skip = true
+			_, err := p.parseBinary(prec)
+			p.skip = wasSkipping
+			if err != nil {
+				return nil, err
+			}
+			left = truthy(left)
+			continue
+		}
+
+		right, err := p.parseBinary(prec)
+		if err != nil {
+			return nil, err
+		}
+		if p.skip {
+			continue
+		}
+		if left, err = applyBinary(t.text, left, right); err != nil {
+			return nil, fmt.Errorf("at %d: %w", t.pos, err)
+		}
+	}
+}
+
+func (p *exprParser) parseUnary() (any, error) {
+	t := p.peek()
+	if t.kind == tokOp && (t.text == "!" || t.text == "-") {
+		p.next()
+		v, err := p.parseUnary()
+		if err != nil || p.skip {
+			return nil, err
+		}
+		if t.text == "!" {
+			return !truthy(v), nil
+		}
+		n, ok := v.(float64)
+		if !ok {
+			return nil, fmt.Errorf("cannot negate %T at %d", v, t.pos)
+		}
+		return -n, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *exprParser) parsePrimary() (any, error) {
+	t := p.next()
+	switch t.kind {
+	case tokNumber:
+		n, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at %d", t.text, t.pos)
+		}
+		return n, nil
+	case tokString:
+		return t.text, nil
+	case tokIdent:
+		switch t.text {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "nil":
+			return nil, nil
+		}
+		if next := p.peek(); next.kind == tokOp && next.text == "(" {
+			return p.parseCall(t)
+		}
+		if p.skip {
+			return nil, nil
+		}
+		v, ok := p.env.vars[t.text]
+		if !ok {
+			return nil, fmt.Errorf("undefined variable %q at %d", t.text, t.pos)
+		}
+		return v, nil
+	case tokOp:
+		if t.text == "(" {
+			v, err := p.parseBinary(0)
+			if err != nil {
+				return nil, err
+			}
+			return v, p.expect(")")
+		}
+	}
+	if t.kind == tokEOF {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	return nil, fmt.Errorf("unexpected %q at %d", t.text, t.pos)
+}
+
+func (p *exprParser) parseCall(name exprToken) (any, error) {
+	p.next() // (
+	var args []any
+	if t := p.peek(); !(t.kind == tokOp && t.text == ")") {
+		for {
+			v, err := p.parseBinary(0)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, v)
+			if t := p.peek(); t.kind == tokOp && t.text == "," {
+				p.next()
+				continue
+			}
+			break
+		}
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	if p.skip {
+		return nil, nil
+	}
+
+	fn, ok := p.env.funcs[name.text]
+	if !ok {
+		return nil, fmt.Errorf("undefined function %q at %d", name.text, name.pos)
+	}
+	v, err := fn(args)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name.text, err)
+	}
+	return v, nil
+}
+
+// applyBinary evaluates a binary operator other than && and ||.
+func applyBinary(op string, a, b any) (any, error) {
+	switch op {
+	case "&&":
+		return truthy(a) && truthy(b), nil
+	case "||":
+		return truthy(a) || truthy(b), nil
+	case "==":
+		return reflect.DeepEqual(a, b), nil
+	case "!=":
+		return !reflect.DeepEqual(a, b), nil
+	case "+":
+		if as, ok := a.(string); ok {
+			return as + exprString(b), nil
+		}
+		if bs, ok := b.(string); ok {
+			return exprString(a) + bs, nil
+		}
+	}
+
+	switch x := a.(type) {
+	case float64:
+		y, ok := b.(float64)
+		if !ok {
+			break
+		}
+		switch op {
+		case "+":
+			return x + y, nil
+		case "-":
+			return x - y, nil
+		case "*":
+			return x * y, nil
+		case "/":
+			if y == 0 {
+				return nil, fmt.Errorf("division by zero")
+			}
+			return x / y, nil
+		case "<":
+			return x < y, nil
+		case "<=":
+			return x <= y, nil
+		case ">":
+			return x > y, nil
+		case ">=":
+			return x >= y, nil
+		}
+	case string:
+		y, ok := b.(string)
+		if !ok {
+			break
+		}
+		switch op {
+		case "<":
+			return x < y, nil
+		case "<=":
+			return x <= y, nil
+		case ">":
+			return x > y, nil
+		case ">=":
+			return x >= y, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid operands for %s: %T and %T", op, a, b)
+}
+
+// truthy reports whether a value counts as true: false, nil, 0 and "" are false.
+func truthy(v any) bool {
+	switch x := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return x
+	case float64:
+		return x != 0
+	case string:
+		return x != ""
+	}
+	return true
+}
+
+// exprString converts a value to its string form for concatenation and output.
+func exprString(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return x
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
@@ -1,10 +1,14 @@
 package installer
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+
+	"github.com/crafted-tech/webflow/platform"
 )
 
 // StepCopyFile creates a Step that copies a file from src to dst.
@@ -94,6 +98,25 @@ func StepDeleteDirIfEmpty(path string) Step {
 	}
 }
 
+// StepSetDirectoryACL creates a Step that grants principal access to a
+// directory and its contents, e.g. "Users" Modify access to a data directory
+// a service and its users share (see platform.SetDirectoryACL). Creates the
+// directory if needed. The info is the grant in icacls notation.
+func StepSetDirectoryACL(path, principal string, access platform.Access) Step {
+	return Step{
+		Name: fmt.Sprintf("Set permissions of %s", filepath.Base(path)),
+		Action: func() StepResult {
+			if err := os.MkdirAll(path, 0755); err != nil {
+				return Failed(fmt.Errorf("create directory: %w", err))
+			}
+			if err := platform.SetDirectoryACL(path, principal, access); err != nil {
+				return Failed(err)
+			}
+			return Success(fmt.Sprintf("%s:%s", principal, access))
+		},
+	}
+}
+
 // StepWriteFile creates a Step that writes content to a file.
 // Creates parent directories if needed.
 func StepWriteFile(path string, content []byte) Step {
@@ -197,3 +220,174 @@ func DirExists(path string) bool {
 	info, err := os.Stat(path)
 	return err == nil && info.IsDir()
 }
+
+// SizeOf returns the total size in bytes of the given files, including the
+// contents of directories, e.g. to fill Choice.SizeBytes for a component:
+//
+//	size, err := installer.SizeOf(filepath.Join(payload, "docs"), filepath.Join(payload, "samples"))
+func SizeOf(paths ...string) (int64, error) {
+	var total int64
+	for _, path := range paths {
+		err := filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			total += info.Size()
+			return nil
+		})
+		if err != nil {
+			return total, fmt.Errorf("size of %s: %w", path, err)
+		}
+	}
+	return total, nil
+}
+
+// StepCreateSymlink creates a Step that points link at target, e.g.
+// "current" at "versions/1.2.3" so paths stay the same across upgrades. A
+// relative target is relative to the link's directory. An existing link is
+// replaced, and restored by Undo; an existing file or directory fails the
+// step. Where Windows doesn't allow symbolic links (see
+// platform.ErrSymlinkPrivilege), a directory gets a junction and a file a
+// hard link instead, as the step's info says.
+func StepCreateSymlink(link, target string) Step {
+	return stepCreateLink(link, target, false)
+}
+
+// StepCreateJunction creates a Step like StepCreateSymlink that creates a
+// directory junction (see platform.CreateJunction), which Windows allows
+// without privileges. Elsewhere it creates a symbolic link.
+func StepCreateJunction(link, target string) Step {
+	return stepCreateLink(link, target, true)
+}
+
+// StepRemoveLink creates a Step that removes a link created by
+// StepCreateSymlink or StepCreateJunction, leaving its target alone.
+// Skips if link doesn't exist or is a real directory.
+func StepRemoveLink(link string) Step {
+	return Step{
+		Name: fmt.Sprintf("Remove %s", filepath.Base(link)),
+		Action: func() StepResult {
+			info, err := os.Lstat(link)
+			if os.IsNotExist(err) {
+				return Skipped("not found")
+			}
+			if err != nil {
+				return Failed(err)
+			}
+			if _, isLink := readLink(link); !isLink && info.IsDir() {
+				return Skipped("not a link")
+			}
+			// Removes symbolic links and junctions, not their targets; a
+			// regular file is a hard link created as a fallback
+			if err := os.Remove(link); err != nil {
+				return Failed(err)
+			}
+			return Success("")
+		},
+	}
+}
+
+// stepCreateLink creates the Step of StepCreateSymlink or, with junction,
+// StepCreateJunction.
+func stepCreateLink(link, target string, junction bool) Step {
+	var created bool
+	var previous string // Target of the link replaced
+	return Step{
+		Name: fmt.Sprintf("Link %s to %s", filepath.Base(link), target),
+		Action: func() StepResult {
+			if linksTo(link, target) {
+				return Skipped("already linked")
+			}
+			if _, err := os.Lstat(link); err == nil {
+				old, isLink := readLink(link)
+				if !isLink {
+					return Failed(fmt.Errorf("%s exists and is not a link", link))
+				}
+				if err := os.Remove(link); err != nil {
+					return Failed(fmt.Errorf("remove old link: %w", err))
+				}
+				previous = old
+			}
+			if err := os.MkdirAll(filepath.Dir(link), 0755); err != nil {
+				return Failed(fmt.Errorf("create directory: %w", err))
+			}
+
+			kind, err := createLink(link, target, junction)
+			if err != nil {
+				if previous != "" {
+					createLink(link, previous, junction)
+				}
+				return Failed(err)
+			}
+			created = true
+			return Success(kind)
+		},
+		Undo: func() error {
+			if !created {
+				return nil
+			}
+			if err := os.Remove(link); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			if previous != "" {
+				_, err := createLink(link, previous, junction)
+				return err
+			}
+			return nil
+		},
+	}
+}
+
+// createLink creates a junction, or a symbolic link with the fallbacks of
+// StepCreateSymlink, and returns the kind of link if it's not the one
+// asked for.
+func createLink(link, target string, junction bool) (kind string, err error) {
+	if junction {
+		return "", platform.CreateJunction(link, target)
+	}
+	err = platform.CreateSymlink(link, target)
+	if !errors.Is(err, platform.ErrSymlinkPrivilege) {
+		return "", err
+	}
+
+	resolved := target
+	if !filepath.IsAbs(resolved) {
+		resolved = filepath.Join(filepath.Dir(link), target)
+	}
+	info, statErr := os.Stat(resolved)
+	switch {
+	case statErr != nil:
+		return "", err
+	case info.IsDir():
+		return "junction", platform.CreateJunction(link, resolved)
+	default:
+		return "hard link", os.Link(resolved, link)
+	}
+}
+
+// linksTo reports whether link exists and leads to target.
+func linksTo(link, target string) bool {
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(filepath.Dir(link), target)
+	}
+	a, err := os.Stat(link)
+	if err != nil {
+		return false
+	}
+	b, err := os.Stat(target)
+	return err == nil && os.SameFile(a, b)
+}
+
+// readLink returns the target of a symbolic link or junction, and false if
+// path is neither.
+func readLink(path string) (string, bool) {
+	target, err := os.Readlink(path)
+	return target, err == nil
+}
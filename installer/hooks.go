@@ -0,0 +1,312 @@
+package installer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// Standard hook points. Installers may define their own names as well.
+const (
+	HookPreInstall    = "pre-install"
+	HookPostFileCopy  = "post-file-copy"
+	HookPostInstall   = "post-install"
+	HookPreUninstall  = "pre-uninstall"
+	HookPostUninstall = "post-uninstall"
+)
+
+// ErrHookFailed is returned when a hook calls fail() or an expression errors.
+var ErrHookFailed = errors.New("hook failed")
+
+// Hooks runs small user-provided expressions at defined points of an install,
+// so OEMs can customize a build without recompiling the installer.
+// Hooks are loaded from a JSON file:
+//
+//	{
+//	  "vars": {"brand": "Acme"},
+//	  "hooks": {
+//	    "pre-install": [
+//	      "os == \"windows\" || fail(\"Windows only\")"
+//	    ],
+//	    "post-file-copy": [
+//	      "exists(join(sourceDir, \"oem.cfg\")) && copy(join(sourceDir, \"oem.cfg\"), join(installDir, \"oem.cfg\"))",
+//	      "log(\"Branded for \" + brand)"
+//	    ]
+//	  }
+//	}
+//
+// Each entry is one expression (see the syntax in expr.go). Built-in
+// variables are os and arch; Set adds more, such as installDir. Built-in
+// functions: env, exists, join, contains, lower, upper, replace, log, fail,
+// set, mkdir, write, copy, remove and run. Register adds custom functions.
+type Hooks struct {
+	mu     sync.Mutex
+	points map[string][]string
+	env    exprEnv
+	log    *Logger
+}
+
+// hooksFile is the JSON manifest format.
+type hooksFile struct {
+	Vars  map[string]any      `json:"vars"`
+	Hooks map[string][]string `json:"hooks"`
+}
+
+// LoadHooks reads a hook manifest. log receives log() output and may be nil.
+func LoadHooks(path string, log *Logger) (*Hooks, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read hooks: %w", err)
+	}
+	var file hooksFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parse hooks: %w", err)
+	}
+
+	h := &Hooks{
+		points: file.Hooks,
+		env: exprEnv{
+			vars: map[string]any{
+				"os":   runtime.GOOS,
+				"arch": runtime.GOARCH,
+			},
+			funcs: make(map[string]ExprFunc),
+		},
+		log: log,
+	}
+	for name, v := range file.Vars {
+		h.env.vars[name] = v
+	}
+	h.registerBuiltins()
+
+	// Parse everything up front so syntax errors surface before anything runs
+	for point, exprs := range h.points {
+		for _, src := range exprs {
+			if err := checkExpr(src); err != nil {
+				return nil, fmt.Errorf("hook %s: %q: %w", point, src, err)
+			}
+		}
+	}
+	return h, nil
+}
+
+// Set defines a variable visible to hook expressions.
+// Numbers should be passed as float64.
+func (h *Hooks) Set(name string, value any) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.env.vars[name] = value
+}
+
+// Get returns a variable, including ones set by hooks via set().
+func (h *Hooks) Get(name string) (any, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	v, ok := h.env.vars[name]
+	return v, ok
+}
+
+// Register adds a custom function callable from hook expressions.
+// Functions run while the hook engine is locked and must not call Hooks methods.
+func (h *Hooks) Register(name string, fn ExprFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.env.funcs[name] = fn
+}
+
+// Run evaluates the expressions for a hook point in order, stopping at the
+// first error. Points without expressions are a no-op.
+func (h *Hooks) Run(point string) error {
+	h.mu.Lock()
+	exprs := h.points[point]
+	h.mu.Unlock()
+
+	for _, src := range exprs {
+		if err := h.eval(src); err != nil {
+			if errors.Is(err, ErrHookFailed) {
+				return fmt.Errorf("hook %s: %w", point, err)
+			}
+			return fmt.Errorf("%w: %s: %q: %w", ErrHookFailed, point, src, err)
+		}
+	}
+	return nil
+}
+
+// eval evaluates one expression with the engine locked, so a panicking
+// custom function does not leave it locked.
+func (h *Hooks) eval(src string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := evalExpr(src, &h.env)
+	return err
+}
+
+// Step creates a Step that runs a hook point. It is skipped when the
+// manifest defines nothing for that point.
+func (h *Hooks) Step(point string) Step {
+	return Step{
+		Name: fmt.Sprintf("Run %s hooks", point),
+		Action: func() StepResult {
+			h.mu.Lock()
+			n := len(h.points[point])
+			h.mu.Unlock()
+			if n == 0 {
+				return Skipped("no hooks defined")
+			}
+			if err := h.Run(point); err != nil {
+				return Failed(err)
+			}
+			return Success("")
+		},
+	}
+}
+
+// registerBuiltins installs the built-in functions. They run with h.mu held,
+// so they access h.env directly.
+func (h *Hooks) registerBuiltins() {
+	f := h.env.funcs
+
+	f["env"] = func(args []any) (any, error) {
+		name, err := stringArgs(args, 1)
+		if err != nil {
+			return nil, err
+		}
+		return os.Getenv(name[0]), nil
+	}
+	f["exists"] = func(args []any) (any, error) {
+		path, err := stringArgs(args, 1)
+		if err != nil {
+			return nil, err
+		}
+		_, statErr := os.Stat(path[0])
+		return statErr == nil, nil
+	}
+	f["join"] = func(args []any) (any, error) {
+		parts := make([]string, len(args))
+		for i, a := range args {
+			parts[i] = exprString(a)
+		}
+		return filepath.Join(parts...), nil
+	}
+	f["contains"] = func(args []any) (any, error) {
+		s, err := stringArgs(args, 2)
+		if err != nil {
+			return nil, err
+		}
+		return strings.Contains(s[0], s[1]), nil
+	}
+	f["lower"] = func(args []any) (any, error) {
+		s, err := stringArgs(args, 1)
+		if err != nil {
+			return nil, err
+		}
+		return strings.ToLower(s[0]), nil
+	}
+	f["upper"] = func(args []any) (any, error) {
+		s, err := stringArgs(args, 1)
+		if err != nil {
+			return nil, err
+		}
+		return strings.ToUpper(s[0]), nil
+	}
+	f["replace"] = func(args []any) (any, error) {
+		s, err := stringArgs(args, 3)
+		if err != nil {
+			return nil, err
+		}
+		return strings.ReplaceAll(s[0], s[1], s[2]), nil
+	}
+	f["log"] = func(args []any) (any, error) {
+		parts := make([]string, len(args))
+		for i, a := range args {
+			parts[i] = exprString(a)
+		}
+		h.log.Info("hook: %s", strings.Join(parts, " "))
+		return true, nil
+	}
+	f["fail"] = func(args []any) (any, error) {
+		msg := "failed"
+		if len(args) > 0 {
+			msg = exprString(args[0])
+		}
+		return nil, fmt.Errorf("%w: %s", ErrHookFailed, msg)
+	}
+	f["set"] = func(args []any) (any, error) {
+		if len(args) != 2 {
+			return nil, fmt.Errorf("expected 2 arguments, got %d", len(args))
+		}
+		name, ok := args[0].(string)
+		if !ok {
+			return nil, fmt.Errorf("variable name must be a string")
+		}
+		h.env.vars[name] = args[1]
+		return true, nil
+	}
+	f["mkdir"] = func(args []any) (any, error) {
+		path, err := stringArgs(args, 1)
+		if err != nil {
+			return nil, err
+		}
+		return true, os.MkdirAll(path[0], 0755)
+	}
+	f["write"] = func(args []any) (any, error) {
+		s, err := stringArgs(args, 2)
+		if err != nil {
+			return nil, err
+		}
+		return true, os.WriteFile(s[0], []byte(s[1]), 0644)
+	}
+	f["copy"] = func(args []any) (any, error) {
+		s, err := stringArgs(args, 2)
+		if err != nil {
+			return nil, err
+		}
+		return true, CopyFile(s[0], s[1])
+	}
+	f["remove"] = func(args []any) (any, error) {
+		path, err := stringArgs(args, 1)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.RemoveAll(path[0]); err != nil {
+			return nil, err
+		}
+		return true, nil
+	}
+	f["run"] = func(args []any) (any, error) {
+		s, err := stringArgs(args, -1)
+		if err != nil {
+			return nil, err
+		}
+		if len(s) == 0 {
+			return nil, fmt.Errorf("expected a command")
+		}
+		out, err := exec.Command(s[0], s[1:]...).CombinedOutput()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+		}
+		return strings.TrimSpace(string(out)), nil
+	}
+}
+
+// stringArgs checks that args are n strings (any number if n < 0).
+func stringArgs(args []any, n int) ([]string, error) {
+	if n >= 0 && len(args) != n {
+		return nil, fmt.Errorf("expected %d arguments, got %d", n, len(args))
+	}
+	s := make([]string, len(args))
+	for i, a := range args {
+		str, ok := a.(string)
+		if !ok {
+			return nil, fmt.Errorf("argument %d must be a string, got %T", i+1, a)
+		}
+		s[i] = str
+	}
+	return s, nil
+}
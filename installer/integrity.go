@@ -0,0 +1,242 @@
+package installer
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"debug/macho"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/crafted-tech/webflow"
+)
+
+// ErrCorruptedInstaller is returned by VerifySelf when the executable is
+// truncated or modified.
+var ErrCorruptedInstaller = errors.New("installer file is damaged")
+
+// Layout of the integrity stamp: a marker, then the stamp's own file offset,
+// the number of bytes covered and their SHA-256.
+const (
+	stampMarkerSize = 16
+	stampSize       = stampMarkerSize + 8 + 8 + sha256.Size
+)
+
+// selfStamp is the integrity stamp. StampIntegrity finds the marker in the
+// built executable and fills in the rest; until then the fields are zero and
+// VerifySelf has nothing to check.
+var selfStamp = [stampSize]byte{'W', 'F', '-', 'S', 'E', 'L', 'F', '-', 'C', 'H', 'E', 'C', 'K', '-', 'v', '1'}
+
+// VerifySelf checks the running executable against the integrity stamp
+// written by StampIntegrity and returns ErrCorruptedInstaller if it is
+// truncated or was modified. An interrupted download otherwise surfaces
+// later as bizarre extraction or launch failures. Executables that were
+// never stamped (such as development builds) pass.
+//
+// Code signing after stamping is fine: a PE file's checksum and certificate
+// table, and a Mach-O file's load commands and code signature, are not
+// covered by the stamp.
+//
+// App calls VerifySelf before showing the first page and shows
+// ShowCorruptedInstaller if it fails.
+func VerifySelf() error {
+	offset := int64(binary.LittleEndian.Uint64(selfStamp[stampMarkerSize:]))
+	length := int64(binary.LittleEndian.Uint64(selfStamp[stampMarkerSize+8:]))
+	if length == 0 {
+		return nil
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(exe)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size() < length {
+		return fmt.Errorf("%w: %s has %d of %d bytes", ErrCorruptedInstaller, exe, info.Size(), length)
+	}
+
+	sum, err := integrityHash(f, offset, length)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(sum, selfStamp[stampMarkerSize+16:]) {
+		return fmt.Errorf("%w: %s does not match its checksum", ErrCorruptedInstaller, exe)
+	}
+	return nil
+}
+
+// StampIntegrity writes the integrity stamp that VerifySelf checks into the
+// executable at path. Run it as part of the build, after go build and before
+// code signing, e.g. from a small program in the installer's repository:
+//
+//	// stamp/main.go: go run ./stamp dist/acme-setup.exe
+//	func main() {
+//	    if err := installer.StampIntegrity(os.Args[1]); err != nil {
+//	        log.Fatal(err)
+//	    }
+//	}
+//
+// The executable must be built with this package and call VerifySelf (App
+// does), or it contains no stamp to fill in. On macOS, sign the executable
+// again after stamping (ad hoc at least): Go's linker signs it, and the
+// stamp invalidates that signature.
+func StampIntegrity(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	// The marker is read from this program's own stamp, so the only copy of
+	// it in an executable is the stamp itself.
+	marker := selfStamp[:stampMarkerSize]
+	offset := bytes.Index(data, marker)
+	if offset < 0 {
+		return fmt.Errorf("%s: no integrity stamp; the program must call installer.VerifySelf", path)
+	}
+	if bytes.Contains(data[offset+1:], marker) {
+		return fmt.Errorf("%s: more than one integrity stamp", path)
+	}
+
+	length := int64(len(data))
+	if sigOffset := machoSignatureOffset(bytes.NewReader(data)); sigOffset > 0 {
+		length = sigOffset // re-signing rewrites the signature that Go's linker added
+	}
+	sum, err := integrityHash(bytes.NewReader(data), int64(offset), length)
+	if err != nil {
+		return err
+	}
+
+	stamp := data[offset : offset+stampSize]
+	binary.LittleEndian.PutUint64(stamp[stampMarkerSize:], uint64(offset))
+	binary.LittleEndian.PutUint64(stamp[stampMarkerSize+8:], uint64(length))
+	copy(stamp[stampMarkerSize+16:], sum)
+
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteAt(stamp, int64(offset)); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// ShowCorruptedInstaller shows an error page asking the user to download the
+// installer again, for when VerifySelf fails.
+func ShowCorruptedInstaller(ui *webflow.Flow, opts ...webflow.PageOption) {
+	ui.ShowAlert(webflow.AlertError, ui.T("integrity.title"), ui.T("integrity.message"), opts...)
+}
+
+// integrityHash returns the SHA-256 of the first length bytes of r, reading
+// the stamp's fields after the marker and the ranges that code signing
+// changes as zeros.
+func integrityHash(r io.ReaderAt, stampOffset, length int64) ([]byte, error) {
+	skip := [][2]int64{{stampOffset + stampMarkerSize, stampOffset + stampSize}}
+	skip = append(skip, peSigningRanges(r)...)
+	if headerEnd := machoHeaderEnd(r); headerEnd > 0 {
+		skip = append(skip, [2]int64{0, headerEnd})
+	}
+
+	h := sha256.New()
+	buf := make([]byte, 1<<20)
+	for pos := int64(0); pos < length; {
+		n, err := r.ReadAt(buf[:min(int64(len(buf)), length-pos)], pos)
+		if n > 0 {
+			zeroRanges(buf[:n], pos, skip)
+			h.Write(buf[:n])
+			pos += int64(n)
+		}
+		if err == io.EOF && pos < length {
+			return nil, io.ErrUnexpectedEOF
+		}
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+	}
+	return h.Sum(nil), nil
+}
+
+// zeroRanges zeroes the parts of buf, which starts at file offset pos, that
+// fall into one of the [start, end) ranges.
+func zeroRanges(buf []byte, pos int64, ranges [][2]int64) {
+	for _, rg := range ranges {
+		start := max(rg[0]-pos, 0)
+		end := min(rg[1]-pos, int64(len(buf)))
+		if start < end {
+			clear(buf[start:end])
+		}
+	}
+}
+
+// peSigningRanges returns the file ranges of a PE file that Authenticode
+// signing changes: the checksum and the certificate table entry of the
+// optional header. The certificate table itself is appended at the end.
+func peSigningRanges(r io.ReaderAt) [][2]int64 {
+	header := make([]byte, 64)
+	if _, err := r.ReadAt(header, 0); err != nil || header[0] != 'M' || header[1] != 'Z' {
+		return nil
+	}
+	peOffset := int64(binary.LittleEndian.Uint32(header[0x3c:]))
+	sig := make([]byte, 4+20+2)
+	if _, err := r.ReadAt(sig, peOffset); err != nil || !bytes.Equal(sig[:4], []byte("PE\x00\x00")) {
+		return nil
+	}
+	optional := peOffset + 4 + 20
+	var dataDirs int64
+	switch binary.LittleEndian.Uint16(sig[24:]) {
+	case 0x10b: // PE32
+		dataDirs = optional + 96
+	case 0x20b: // PE32+
+		dataDirs = optional + 112
+	default:
+		return nil
+	}
+	const certificateTable = 4
+	return [][2]int64{
+		{optional + 64, optional + 68},
+		{dataDirs + certificateTable*8, dataDirs + certificateTable*8 + 8},
+	}
+}
+
+// machoHeaderEnd returns where the first section of a Mach-O file starts:
+// the header and load commands before it change when the file is signed.
+// It returns 0 for other files.
+func machoHeaderEnd(r io.ReaderAt) int64 {
+	f, err := macho.NewFile(r)
+	if err != nil {
+		return 0
+	}
+	var end int64
+	for _, s := range f.Sections {
+		if s.Offset > 0 && (end == 0 || int64(s.Offset) < end) {
+			end = int64(s.Offset)
+		}
+	}
+	return end
+}
+
+// machoSignatureOffset returns where the code signature of a Mach-O file
+// starts, or 0 if it has none.
+func machoSignatureOffset(r io.ReaderAt) int64 {
+	f, err := macho.NewFile(r)
+	if err != nil {
+		return 0
+	}
+	const lcCodeSignature = 0x1d
+	for _, load := range f.Loads {
+		raw := load.Raw()
+		if len(raw) >= 16 && f.ByteOrder.Uint32(raw) == lcCodeSignature {
+			return int64(f.ByteOrder.Uint32(raw[8:]))
+		}
+	}
+	return 0
+}
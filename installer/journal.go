@@ -0,0 +1,255 @@
+package installer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/crafted-tech/webflow"
+)
+
+// Journal records which steps of an install have completed, so an install
+// interrupted by a crash or power loss can be resumed or rolled back on the
+// next launch. The journal file is rewritten atomically after every step and
+// removed when all steps succeed.
+//
+// Steps are identified by Name, so names must be unique within a run.
+//
+// Example:
+//
+//	j, err := installer.OpenJournal(installer.JournalPath("myapp"))
+//	if err != nil {
+//	    return err
+//	}
+//	if j.Interrupted() {
+//	    switch installer.AskResume(ui, j, "My App") {
+//	    case installer.ResumeRestart:
+//	        j.Reset()
+//	    case installer.ResumeRollback:
+//	        return installer.RollbackSteps(ui, "Rolling back...", steps, j)
+//	    case installer.ResumeCancel:
+//	        return nil
+//	    }
+//	}
+//	return installer.RunStepsWithJournal(ui, "Installing...", steps, log, j)
+type Journal struct {
+	mu          sync.Mutex
+	path        string
+	state       journalState
+	interrupted bool
+}
+
+// journalState is the on-disk journal format.
+type journalState struct {
+	Started   time.Time `json:"started"`
+	Updated   time.Time `json:"updated"`
+	Total     int       `json:"total"`
+	Completed []string  `json:"completed"`
+	Current   string    `json:"current,omitempty"` // Step running when the journal was last written
+}
+
+// JournalPath returns the default journal location for an app: a file in the
+// user cache directory, or the temp directory if that is unavailable.
+func JournalPath(appName string) string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, appName, "install-journal.json")
+}
+
+// OpenJournal loads the journal at path, or starts an empty one if the file
+// doesn't exist. A journal left behind by a previous run reports Interrupted.
+func OpenJournal(path string) (*Journal, error) {
+	j := &Journal{path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return j, nil
+		}
+		return nil, fmt.Errorf("read journal: %w", err)
+	}
+	if err := json.Unmarshal(data, &j.state); err != nil {
+		// A torn write from a crash; nothing reliable to resume from
+		os.Remove(path)
+		return j, nil
+	}
+	j.interrupted = len(j.state.Completed) > 0 || j.state.Current != ""
+	return j, nil
+}
+
+// Interrupted reports whether a previous run left an unfinished install behind.
+func (j *Journal) Interrupted() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.interrupted
+}
+
+// Progress returns the number of completed steps and the total step count
+// recorded by the previous run.
+func (j *Journal) Progress() (completed, total int) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return len(j.state.Completed), j.state.Total
+}
+
+// IsCompleted reports whether the named step has already completed.
+func (j *Journal) IsCompleted(name string) bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return slices.Contains(j.state.Completed, name)
+}
+
+// Reset discards the recorded progress so the next run starts from the first step.
+func (j *Journal) Reset() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.state = journalState{}
+	j.interrupted = false
+	if err := os.Remove(j.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("remove journal: %w", err)
+	}
+	return nil
+}
+
+// begin records that a run of total steps started, keeping earlier progress.
+func (j *Journal) begin(total int) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.state.Started.IsZero() {
+		j.state.Started = time.Now()
+	}
+	j.state.Total = total
+	return j.save()
+}
+
+// stepStarted records that the named step is running.
+func (j *Journal) stepStarted(name string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.state.Current = name
+	return j.save()
+}
+
+// stepCompleted records that the named step finished (successfully or skipped).
+func (j *Journal) stepCompleted(name string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if !slices.Contains(j.state.Completed, name) {
+		j.state.Completed = append(j.state.Completed, name)
+	}
+	j.state.Current = ""
+	return j.save()
+}
+
+// save writes the journal to a temp file and renames it into place, so a
+// crash mid-write leaves either the old or the new journal.
+// Must be called with j.mu held.
+func (j *Journal) save() error {
+	j.state.Updated = time.Now()
+	data, err := json.MarshalIndent(j.state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(j.path), 0755); err != nil {
+		return fmt.Errorf("create journal directory: %w", err)
+	}
+
+	tmp := j.path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("write journal: %w", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return fmt.Errorf("write journal: %w", err)
+	}
+	// Flush to disk before the rename, otherwise power loss can leave an empty file
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return fmt.Errorf("write journal: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("write journal: %w", err)
+	}
+	if err := os.Rename(tmp, j.path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("write journal: %w", err)
+	}
+	return nil
+}
+
+// ResumeAction is the user's choice for an interrupted install.
+type ResumeAction int
+
+const (
+	ResumeContinue ResumeAction = iota // Continue from the first incomplete step
+	ResumeRestart                      // Discard progress and run all steps again
+	ResumeRollback                     // Undo the completed steps
+	ResumeCancel                       // User closed the page
+)
+
+// AskResume shows a page explaining that a previous install of appName was
+// interrupted and lets the user resume, start over, or roll back.
+func AskResume(ui *webflow.Flow, j *Journal, appName string) ResumeAction {
+	completed, total := j.Progress()
+	choices := []webflow.Choice{
+		{Label: ui.T("resume.continue"), Description: ui.T("resume.continueDesc")},
+		{Label: ui.T("resume.restart"), Description: ui.T("resume.restartDesc")},
+		{Label: ui.T("resume.rollback"), Description: ui.T("resume.rollbackDesc")},
+	}
+
+	result := ui.ShowChoice(ui.T("resume.title"), choices,
+		webflow.WithSubtitle(ui.TF("resume.message", appName, completed, total)),
+		webflow.WithIcon("warning"),
+		webflow.WithButtonBar(webflow.WizardFirst()),
+	)
+
+	switch result {
+	case 0:
+		return ResumeContinue
+	case 1:
+		return ResumeRestart
+	case 2:
+		return ResumeRollback
+	default:
+		return ResumeCancel
+	}
+}
+
+// RollbackSteps undoes the steps recorded as completed in the journal, in
+// reverse order, using each Step's Undo function. Steps without Undo are left
+// as they are. The journal is removed when the rollback succeeds.
+func RollbackSteps(ui *webflow.Flow, title string, steps []Step, j *Journal) error {
+	var undo []Step
+	for _, step := range steps {
+		if step.Undo != nil && j.IsCompleted(step.Name) {
+			undo = append(undo, step)
+		}
+	}
+	slices.Reverse(undo)
+
+	var rollbackErr error
+	ui.ShowProgress(title, func(p webflow.Progress) {
+		for i, step := range undo {
+			p.Update(float64(i)/float64(len(undo))*100, step.Name)
+			if err := step.Undo(); err != nil {
+				rollbackErr = fmt.Errorf("undo %s: %w", step.Name, err)
+				return
+			}
+		}
+		p.Update(100, "Complete")
+	})
+	if rollbackErr != nil {
+		return rollbackErr
+	}
+	return j.Reset()
+}
@@ -7,6 +7,8 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/crafted-tech/webflow"
 )
 
 // Logger provides structured logging with file output and in-memory buffering.
@@ -146,3 +148,44 @@ func (l *Logger) log(level, format string, args ...any) {
 		l.file.Sync()
 	}
 }
+
+// TeeLogWriter wraps a webflow.LogWriter so every line written to the log view
+// is also recorded in the Logger. ANSI color codes are stripped before writing
+// to the file, and LogError/LogWarning lines are logged at ERROR/WARN level.
+// If log is nil, w is returned unchanged.
+//
+// Example:
+//
+//	ui.ShowLog("Installing", func(lw webflow.LogWriter) {
+//	    lw = installer.TeeLogWriter(lw, log)
+//	    lw.WriteLine("Extracting files...")
+//	})
+func TeeLogWriter(w webflow.LogWriter, log *Logger) webflow.LogWriter {
+	if log == nil {
+		return w
+	}
+	return &teeLogWriter{LogWriter: w, log: log}
+}
+
+// teeLogWriter forwards to the wrapped LogWriter and mirrors lines to a Logger.
+type teeLogWriter struct {
+	webflow.LogWriter
+	log *Logger
+}
+
+func (t *teeLogWriter) WriteLine(text string) {
+	t.WriteLineStyled(webflow.ParseANSI(text))
+}
+
+func (t *teeLogWriter) WriteLineStyled(text string, style webflow.LogStyle) {
+	plain, _ := webflow.ParseANSI(text)
+	switch style {
+	case webflow.LogError:
+		t.log.Error("%s", plain)
+	case webflow.LogWarning:
+		t.log.Warn("%s", plain)
+	default:
+		t.log.Info("%s", plain)
+	}
+	t.LogWriter.WriteLineStyled(plain, style)
+}
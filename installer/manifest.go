@@ -0,0 +1,897 @@
+package installer
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/crafted-tech/webflow"
+	"github.com/crafted-tech/webflow/platform"
+)
+
+// Manifest describes a simple product install as data, so a generic installer
+// binary can be configured without writing Go code. Load it with LoadManifest
+// and run it with Run:
+//
+//	{
+//	  "name": "Acme Tool",
+//	  "version": "1.2.0",
+//	  "publisher": "Acme",
+//	  "installDir": "${programFiles}/Acme Tool",
+//	  "pages": [
+//	    {"type": "welcome"},
+//	    {"type": "license", "file": "LICENSE.txt"},
+//	    {"type": "directory"},
+//	    {"type": "components"},
+//	    {"type": "summary"},
+//	    {"type": "install"},
+//	    {"type": "finish"}
+//	  ],
+//	  "components": [
+//	    {"id": "core", "name": "Core files", "required": true},
+//	    {"id": "docs", "name": "Documentation", "default": true}
+//	  ],
+//	  "files": [
+//	    {"source": "bin/acme.exe", "dest": "acme.exe", "component": "core"},
+//	    {"source": "docs", "dest": "docs", "component": "docs"}
+//	  ],
+//	  "links": [
+//	    {"path": "current", "target": "versions/${version}"}
+//	  ],
+//	  "shortcuts": [
+//	    {"name": "Acme Tool", "target": "${installDir}/acme.exe", "location": "startmenu"}
+//	  ],
+//	  "registry": [
+//	    {"root": "HKLM", "key": "SOFTWARE\\Acme\\Tool", "name": "InstallDir", "value": "${installDir}"}
+//	  ],
+//	  "services": [
+//	    {"name": "acmesvc", "displayName": "Acme Service", "executable": "${installDir}/acmesvc.exe", "start": true}
+//	  ],
+//	  "hosts": [
+//	    {"ip": "127.0.0.1", "hostnames": ["acme.local"]}
+//	  ],
+//	  "offline": {"bundle": "bundle", "allowNetwork": true}
+//	}
+//
+// File sources are relative to the manifest's directory; directories are
+// copied recursively. Destinations and link paths are relative to the
+// install directory; link targets are relative to the link's directory.
+// Strings may reference ${installDir}, ${sourceDir}, ${name}, ${version},
+// ${publisher}, ${home}, ${bundleDir}, ${env:NAME} and, on Windows,
+// ${programFiles}, ${localAppData} and ${programData}. Unknown variables
+// expand to "".
+//
+// Items with a component are installed only when that component is selected;
+// items without one are always installed. Shortcuts and registry values are
+// Windows-only and are skipped elsewhere. Links are symbolic links, or
+// directory junctions where junction is set (see StepCreateSymlink). Hosts
+// entries are added to the system hosts file; UninstallSteps removes the
+// ones the install added. An offline section makes downloads resolve from a
+// local bundle directory, relative to the manifest (see OfflineBundle); the
+// -bundle flag of an App takes precedence. Only JSON manifests are supported.
+type Manifest struct {
+	Name       string                  `json:"name"`
+	Version    string                  `json:"version"`
+	Publisher  string                  `json:"publisher"`
+	InstallDir string                  `json:"installDir"` // Default install location (default: per-platform apps dir + name)
+	Hooks      string                  `json:"hooks"`      // Optional hooks file (see LoadHooks), relative to the manifest
+	Pages      []ManifestPage          `json:"pages"`      // Wizard pages in order (default: welcome, directory, components, install, finish)
+	Components []ManifestComponent     `json:"components"`
+	Files      []ManifestFile          `json:"files"`
+	Links      []ManifestLink          `json:"links"`
+	Registry   []ManifestRegistryValue `json:"registry"`
+	Shortcuts  []ManifestShortcut      `json:"shortcuts"`
+	Services   []ManifestService       `json:"services"`
+	Hosts      []ManifestHostsEntry    `json:"hosts"`
+	Offline    *ManifestOffline        `json:"offline"`
+
+	sourceDir string // Directory containing the manifest
+}
+
+// Manifest page types.
+const (
+	PageWelcome    = "welcome"
+	PageLicense    = "license"
+	PageDirectory  = "directory"
+	PageComponents = "components"
+	PageSummary    = "summary"
+	PageInstall    = "install"
+	PageFinish     = "finish"
+)
+
+// ManifestPage is one wizard page. Title and Message override the default texts.
+type ManifestPage struct {
+	Type    string `json:"type"`
+	Title   string `json:"title"`
+	Message string `json:"message"`
+	File    string `json:"file"` // License text file, relative to the manifest
+}
+
+// ManifestComponent is an optional part of the product the user can select.
+type ManifestComponent struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Default     bool     `json:"default"`  // Selected initially
+	Required    bool     `json:"required"` // Always installed, even if deselected
+	Requires    []string `json:"requires"` // Components selected along with this one
+	Excludes    []string `json:"excludes"` // Components that cannot be installed together with this one
+}
+
+// ManifestFile copies a file or directory into the install directory.
+type ManifestFile struct {
+	Source    string `json:"source"`
+	Dest      string `json:"dest"` // Defaults to the source's base name
+	Component string `json:"component"`
+}
+
+// ManifestLink creates a symbolic link or junction in the install directory.
+type ManifestLink struct {
+	Path      string `json:"path"`
+	Target    string `json:"target"`
+	Junction  bool   `json:"junction"` // Create a directory junction on Windows
+	Component string `json:"component"`
+}
+
+// ManifestRegistryValue writes a registry value (Windows only).
+type ManifestRegistryValue struct {
+	Root      string `json:"root"` // HKLM (default) or HKCU
+	Key       string `json:"key"`
+	Name      string `json:"name"`
+	Type      string `json:"type"` // string (default), expand or dword
+	Value     string `json:"value"`
+	Component string `json:"component"`
+}
+
+// ManifestShortcut creates a shortcut (Windows only).
+type ManifestShortcut struct {
+	Name        string `json:"name"`
+	Target      string `json:"target"`
+	Args        string `json:"args"`
+	Description string `json:"description"`
+	Location    string `json:"location"` // desktop or startmenu (default)
+	Folder      string `json:"folder"`   // Start menu subfolder (default: none)
+	Component   string `json:"component"`
+}
+
+// ManifestService installs a service with platform.ServiceConfig defaults.
+type ManifestService struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+	Description string `json:"description"`
+	Executable  string `json:"executable"`
+	Args        string `json:"args"`
+	Start       bool   `json:"start"` // Start the service after installing it
+	Component   string `json:"component"`
+}
+
+// ManifestHostsEntry maps host names to an address in the system hosts file.
+type ManifestHostsEntry struct {
+	IP        string   `json:"ip"`
+	Hostnames []string `json:"hostnames"`
+	Component string   `json:"component"`
+}
+
+// ManifestOffline takes downloads from an offline bundle (see OfflineBundle).
+type ManifestOffline struct {
+	Bundle       string `json:"bundle"`       // Bundle directory, relative to the manifest
+	AllowNetwork bool   `json:"allowNetwork"` // Download files that are missing from the bundle
+}
+
+// ErrInvalidManifest is returned when a manifest fails validation.
+var ErrInvalidManifest = errors.New("invalid manifest")
+
+// LoadManifest reads and validates a manifest file.
+func LoadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read manifest: %w", err)
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parse manifest: %w", err)
+	}
+	if m.sourceDir, err = filepath.Abs(filepath.Dir(path)); err != nil {
+		return nil, fmt.Errorf("resolve manifest directory: %w", err)
+	}
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// validate checks required fields and references between sections.
+func (m *Manifest) validate() error {
+	if m.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidManifest)
+	}
+	for _, p := range m.Pages {
+		switch p.Type {
+		case PageWelcome, PageDirectory, PageComponents, PageSummary, PageInstall, PageFinish:
+		case PageLicense:
+			if p.File == "" {
+				return fmt.Errorf("%w: license page needs a file", ErrInvalidManifest)
+			}
+		default:
+			return fmt.Errorf("%w: unknown page type %q", ErrInvalidManifest, p.Type)
+		}
+	}
+
+	ids := make(map[string]bool)
+	for _, c := range m.Components {
+		if c.ID == "" || ids[c.ID] {
+			return fmt.Errorf("%w: component ids must be unique and non-empty", ErrInvalidManifest)
+		}
+		ids[c.ID] = true
+	}
+	for _, c := range m.Components {
+		for _, id := range append(slices.Clone(c.Requires), c.Excludes...) {
+			if !ids[id] {
+				return fmt.Errorf("%w: component %s refers to unknown component %q", ErrInvalidManifest, c.ID, id)
+			}
+		}
+	}
+	checkComponent := func(section, id string) error {
+		if id != "" && !ids[id] {
+			return fmt.Errorf("%w: %s refers to unknown component %q", ErrInvalidManifest, section, id)
+		}
+		return nil
+	}
+
+	for _, f := range m.Files {
+		if f.Source == "" {
+			return fmt.Errorf("%w: file source is required", ErrInvalidManifest)
+		}
+		if err := checkComponent("file "+f.Source, f.Component); err != nil {
+			return err
+		}
+	}
+	for _, r := range m.Registry {
+		if r.Key == "" {
+			return fmt.Errorf("%w: registry key is required", ErrInvalidManifest)
+		}
+		if err := checkComponent("registry "+r.Key, r.Component); err != nil {
+			return err
+		}
+	}
+	for _, s := range m.Shortcuts {
+		if s.Name == "" || s.Target == "" {
+			return fmt.Errorf("%w: shortcut name and target are required", ErrInvalidManifest)
+		}
+		if err := checkComponent("shortcut "+s.Name, s.Component); err != nil {
+			return err
+		}
+	}
+	for _, s := range m.Services {
+		if s.Name == "" || s.Executable == "" {
+			return fmt.Errorf("%w: service name and executable are required", ErrInvalidManifest)
+		}
+		if err := checkComponent("service "+s.Name, s.Component); err != nil {
+			return err
+		}
+	}
+	for _, l := range m.Links {
+		if l.Path == "" || l.Target == "" {
+			return fmt.Errorf("%w: link path and target are required", ErrInvalidManifest)
+		}
+		if err := checkComponent("link "+l.Path, l.Component); err != nil {
+			return err
+		}
+	}
+	for _, h := range m.Hosts {
+		if net.ParseIP(h.IP) == nil || len(h.Hostnames) == 0 {
+			return fmt.Errorf("%w: hosts entry needs an IP address and host names", ErrInvalidManifest)
+		}
+		if err := checkComponent("hosts "+h.IP, h.Component); err != nil {
+			return err
+		}
+	}
+	if m.Offline != nil && m.Offline.Bundle == "" {
+		return fmt.Errorf("%w: offline bundle directory is required", ErrInvalidManifest)
+	}
+	return nil
+}
+
+// ManifestState holds the user's choices while a manifest runs. Steps
+// saves it to the install directory (see InstalledState), so the uninstaller
+// knows what the install changed.
+type ManifestState struct {
+	InstallDir string          `json:"installDir"` // Chosen install directory
+	Components map[string]bool `json:"components"` // Selected component IDs
+
+	// AddedHosts holds, by IP address, the host names the install added to
+	// the hosts file. Mappings that existed before are not listed, so
+	// UninstallSteps leaves them alone.
+	AddedHosts map[string][]string `json:"addedHosts,omitempty"`
+}
+
+// manifestStateFile is the file in the install directory that holds the
+// ManifestState of the install.
+const manifestStateFile = ".install-state.json"
+
+// InstalledState returns the state saved by the install in installDir, or
+// DefaultState with installDir if there is none, e.g. for an install made
+// before states were saved.
+func (m *Manifest) InstalledState(installDir string) *ManifestState {
+	state := m.DefaultState()
+	data, err := os.ReadFile(filepath.Join(installDir, manifestStateFile))
+	if err == nil {
+		var saved ManifestState
+		if json.Unmarshal(data, &saved) == nil {
+			state = &saved
+		}
+	}
+	state.InstallDir = installDir
+	return state
+}
+
+// recordHosts notes host names the install added for ip.
+func (s *ManifestState) recordHosts(ip string, added []string) {
+	if s.AddedHosts == nil {
+		s.AddedHosts = make(map[string][]string)
+	}
+	for _, name := range added {
+		if !slices.Contains(s.AddedHosts[ip], name) {
+			s.AddedHosts[ip] = append(s.AddedHosts[ip], name)
+		}
+	}
+}
+
+// DefaultState returns the initial choices: the default install directory and
+// the components marked default or required.
+func (m *Manifest) DefaultState() *ManifestState {
+	var ids []string
+	for _, c := range m.Components {
+		if c.Default {
+			ids = append(ids, c.ID)
+		}
+	}
+	s := &ManifestState{Components: m.resolveComponents(ids)}
+	s.InstallDir = m.Expand(m.InstallDir, s)
+	if s.InstallDir == "" {
+		s.InstallDir = manifestDefaultInstallDir(m.Name)
+	}
+	return s
+}
+
+// Expand replaces ${...} variables in s (see Manifest).
+func (m *Manifest) Expand(s string, state *ManifestState) string {
+	return os.Expand(s, func(name string) string {
+		if env, ok := strings.CutPrefix(name, "env:"); ok {
+			return os.Getenv(env)
+		}
+		switch name {
+		case "installDir":
+			return state.InstallDir
+		case "sourceDir":
+			return m.sourceDir
+		case "name":
+			return m.Name
+		case "version":
+			return m.Version
+		case "publisher":
+			return m.Publisher
+		case "home":
+			home, _ := os.UserHomeDir()
+			return home
+		case "bundleDir":
+			if b := CurrentOfflineBundle(); b != nil {
+				return b.Dir
+			}
+			return ""
+		}
+		return manifestPlatformVar(name)
+	})
+}
+
+// selected reports whether an item belonging to component is installed.
+func (s *ManifestState) selected(component string) bool {
+	return component == "" || s.Components[component]
+}
+
+// Steps builds the install steps for the given choices: create the install
+// directory, copy files, create links, write registry values, create shortcuts, add hosts
+// entries, install and start services, then save state to the install directory.
+func (m *Manifest) Steps(state *ManifestState) []Step {
+	return append(m.fileSteps(state), m.systemSteps(state)...)
+}
+
+// fileSteps creates the install directory, copies the selected files and
+// creates the selected links.
+func (m *Manifest) fileSteps(state *ManifestState) []Step {
+	steps := []Step{StepEnsureDir(state.InstallDir)}
+
+	for _, f := range m.Files {
+		if !state.selected(f.Component) {
+			continue
+		}
+		src := m.sourcePath(f, state)
+		dest := m.Expand(f.Dest, state)
+		if dest == "" {
+			dest = filepath.Base(src)
+		}
+		steps = append(steps, stepCopyTree(src, filepath.Join(state.InstallDir, dest)))
+	}
+	for _, l := range m.Links {
+		if !state.selected(l.Component) {
+			continue
+		}
+		path := filepath.Join(state.InstallDir, m.Expand(l.Path, state))
+		if l.Junction {
+			steps = append(steps, StepCreateJunction(path, m.Expand(l.Target, state)))
+		} else {
+			steps = append(steps, StepCreateSymlink(path, m.Expand(l.Target, state)))
+		}
+	}
+	return steps
+}
+
+// sourcePath returns the absolute source path of a file entry.
+func (m *Manifest) sourcePath(f ManifestFile, state *ManifestState) string {
+	src := m.Expand(f.Source, state)
+	if !filepath.IsAbs(src) {
+		src = filepath.Join(m.sourceDir, src)
+	}
+	return src
+}
+
+// componentSize returns the size of a component's files. Missing sources count
+// as zero here; they fail later when copied.
+func (m *Manifest) componentSize(id string, state *ManifestState) int64 {
+	var total int64
+	for _, f := range m.Files {
+		if f.Component == id {
+			size, _ := SizeOf(m.sourcePath(f, state))
+			total += size
+		}
+	}
+	return total
+}
+
+// systemSteps writes registry values, creates shortcuts, adds hosts entries,
+// installs services and saves state.
+func (m *Manifest) systemSteps(state *ManifestState) []Step {
+	var steps []Step
+	for _, r := range m.Registry {
+		if state.selected(r.Component) {
+			r.Key = m.Expand(r.Key, state)
+			r.Value = m.Expand(r.Value, state)
+			steps = append(steps, stepManifestRegistry(r))
+		}
+	}
+	for _, s := range m.Shortcuts {
+		if state.selected(s.Component) {
+			s.Target = m.Expand(s.Target, state)
+			s.Args = m.Expand(s.Args, state)
+			s.Folder = m.Expand(s.Folder, state)
+			steps = append(steps, stepManifestShortcut(s))
+		}
+	}
+	for _, h := range m.Hosts {
+		if state.selected(h.Component) {
+			ip := h.IP
+			steps = append(steps, stepAddHostsEntry(ip, h.Hostnames, func(added []string) {
+				state.recordHosts(ip, added)
+			}))
+		}
+	}
+
+	for _, s := range m.Services {
+		if !state.selected(s.Component) {
+			continue
+		}
+		steps = append(steps, StepInstallServiceWithConfig(platform.ServiceConfig{
+			Name:        s.Name,
+			DisplayName: s.DisplayName,
+			Description: s.Description,
+			Executable:  m.Expand(s.Executable, state),
+			Args:        m.Expand(s.Args, state),
+		}))
+		if s.Start {
+			steps = append(steps, StepStartService(s.Name))
+		}
+	}
+	return append(steps, stepSaveManifestState(state))
+}
+
+// stepSaveManifestState creates a Step that writes state to the install
+// directory for InstalledState. Undo removes the file.
+func stepSaveManifestState(state *ManifestState) Step {
+	path := filepath.Join(state.InstallDir, manifestStateFile)
+	return Step{
+		Name: "Save install state",
+		Action: func() StepResult {
+			data, err := json.MarshalIndent(state, "", "  ")
+			if err != nil {
+				return Failed(err)
+			}
+			if err := os.WriteFile(path, data, 0644); err != nil {
+				return Failed(fmt.Errorf("save install state: %w", err))
+			}
+			return Success("")
+		},
+		Undo: func() error {
+			return removeIfExists(path)
+		},
+	}
+}
+
+// UninstallSteps builds the steps that undo Steps: stop and remove services,
+// delete shortcuts, registry values and hosts entries, delete the links,
+// copied files and saved state, then remove the install directory if nothing
+// else is left in it. Items of all components are removed, whatever was
+// selected at install time; those that were never installed are skipped.
+// Hosts entries are only removed if state records that the install added
+// them; pass InstalledState.
+func (m *Manifest) UninstallSteps(state *ManifestState) []Step {
+	var steps []Step
+	for _, s := range m.Services {
+		steps = append(steps, StepStopService(s.Name), StepUninstallService(s.Name))
+	}
+	for _, s := range m.Shortcuts {
+		s.Folder = m.Expand(s.Folder, state)
+		steps = append(steps, stepManifestShortcutRemove(s))
+	}
+	for _, r := range m.Registry {
+		r.Key = m.Expand(r.Key, state)
+		steps = append(steps, stepManifestRegistryRemove(r))
+	}
+	for _, h := range m.Hosts {
+		var added []string
+		for _, name := range h.Hostnames {
+			if slices.Contains(state.AddedHosts[h.IP], name) {
+				added = append(added, name)
+			}
+		}
+		if len(added) > 0 {
+			steps = append(steps, StepRemoveHostsEntry(h.IP, added...))
+		}
+	}
+	for _, l := range m.Links {
+		steps = append(steps, StepRemoveLink(filepath.Join(state.InstallDir, m.Expand(l.Path, state))))
+	}
+	for _, f := range m.Files {
+		dest := m.Expand(f.Dest, state)
+		if dest == "" {
+			dest = filepath.Base(m.sourcePath(f, state))
+		}
+		// A dest of "." or one leading out of the directory would delete
+		// the install directory itself, or more
+		path := filepath.Join(state.InstallDir, dest)
+		if !insideDir(state.InstallDir, path) {
+			continue
+		}
+		steps = append(steps, stepRemoveTree(path))
+	}
+	steps = append(steps, stepRemoveTree(filepath.Join(state.InstallDir, manifestStateFile)))
+	return append(steps, StepDeleteDirIfEmpty(state.InstallDir))
+}
+
+// stepCopyTree creates a Step that copies a file, or a directory recursively.
+func stepCopyTree(src, dst string) Step {
+	return Step{
+		Name: fmt.Sprintf("Copy %s", filepath.Base(dst)),
+		Action: func() StepResult {
+			info, err := os.Stat(src)
+			if err != nil {
+				return Failed(err)
+			}
+			if !info.IsDir() {
+				if err := CopyFile(src, dst); err != nil {
+					return Failed(err)
+				}
+				return Success("")
+			}
+			err = filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				rel, err := filepath.Rel(src, path)
+				if err != nil {
+					return err
+				}
+				target := filepath.Join(dst, rel)
+				if d.IsDir() {
+					return os.MkdirAll(target, 0755)
+				}
+				return CopyFile(path, target)
+			})
+			if err != nil {
+				return Failed(err)
+			}
+			return Success("")
+		},
+	}
+}
+
+// stepRemoveTree creates a Step that deletes a file, or a directory with its
+// contents. Skips if the path doesn't exist.
+func stepRemoveTree(path string) Step {
+	return Step{
+		Name: fmt.Sprintf("Delete %s", filepath.Base(path)),
+		Action: func() StepResult {
+			if _, err := os.Lstat(path); os.IsNotExist(err) {
+				return Skipped("not found")
+			}
+			if err := removeTree(path); err != nil {
+				return Failed(err)
+			}
+			return Success("")
+		},
+	}
+}
+
+// removeTree deletes path like os.RemoveAll. On Windows the running
+// executable, usually the uninstaller, can't be deleted: it and the
+// directories holding it are left for its self-delete (see RunSecondPhase).
+func removeTree(path string) error {
+	exe, err := os.Executable()
+	if runtime.GOOS != "windows" || err != nil {
+		return os.RemoveAll(path)
+	}
+	return removeTreeExcept(path, exe)
+}
+
+// removeTreeExcept deletes path, except keep if it is inside path.
+func removeTreeExcept(path, keep string) error {
+	if !insideDir(path, keep) {
+		if strings.EqualFold(filepath.Clean(path), filepath.Clean(keep)) {
+			return nil
+		}
+		return os.RemoveAll(path)
+	}
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, e := range entries {
+		errs = append(errs, removeTreeExcept(filepath.Join(path, e.Name()), keep))
+	}
+	return errors.Join(errs...)
+}
+
+// insideDir reports whether path is below dir, and not dir itself.
+func insideDir(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil || rel == "." || rel == ".." {
+		return false
+	}
+	return !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// Run shows the manifest's wizard pages and performs the install. Back moves
+// to the previous page; closing the window returns ErrCancelled. Install
+// failures are shown to the user and returned. log may be nil.
+func (m *Manifest) Run(ui *webflow.Flow, log *Logger) error {
+	if m.Offline != nil && CurrentOfflineBundle() == nil {
+		dir := m.Offline.Bundle
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(m.sourceDir, dir)
+		}
+		SetOfflineBundle(&OfflineBundle{Dir: dir, AllowNetwork: m.Offline.AllowNetwork})
+		log.Info("Offline bundle: %s (network fallback: %t)", dir, m.Offline.AllowNetwork)
+	}
+
+	state := m.DefaultState()
+
+	var hooks *Hooks
+	if m.Hooks != "" {
+		path := m.Hooks
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(m.sourceDir, path)
+		}
+		var err error
+		if hooks, err = LoadHooks(path, log); err != nil {
+			return err
+		}
+		hooks.Set("sourceDir", m.sourceDir)
+	}
+
+	pages := m.Pages
+	if len(pages) == 0 {
+		pages = []ManifestPage{{Type: PageWelcome}, {Type: PageDirectory}}
+		if len(m.Components) > 0 {
+			pages = append(pages, ManifestPage{Type: PageComponents})
+		}
+		pages = append(pages, ManifestPage{Type: PageInstall}, ManifestPage{Type: PageFinish})
+	}
+
+	for i := 0; i < len(pages); {
+		page := pages[i]
+		var resp any
+		switch page.Type {
+		case PageWelcome:
+			resp = ui.ShowWelcome(webflow.WelcomeConfig{
+				Title:   cmp.Or(page.Title, ui.TF("welcome.title", m.Name)),
+				Message: cmp.Or(page.Message, ui.TF("welcome.message", m.Name, m.Version)+"\n\n"+ui.T("welcome.continue")),
+			}, webflow.WithButtonBar(m.buttonBar(pages, i)))
+		case PageLicense:
+			text, err := os.ReadFile(filepath.Join(m.sourceDir, page.File))
+			if err != nil {
+				return fmt.Errorf("read license: %w", err)
+			}
+			cfg := webflow.LicenseConfig{
+				Title:   cmp.Or(page.Title, ui.T("license.title")),
+				Label:   cmp.Or(page.Message, ui.T("license.label")),
+				Content: string(text),
+			}
+			resp = ui.ShowLicense(cfg)
+			if resp == true {
+				recordLicense(ui, AcceptanceLogPath(m.Name), m.Name, m.Version, cfg, log)
+			}
+		case PageDirectory:
+			resp = ui.ShowForm(cmp.Or(page.Title, ui.T("directory.title")), []webflow.FormField{{
+				ID:       "installDir",
+				Type:     webflow.FieldFolder,
+				Label:    cmp.Or(page.Message, ui.TF("directory.label", m.Name)),
+				Default:  state.InstallDir,
+				Required: true,
+			}}, webflow.WithButtonBar(m.buttonBar(pages, i)))
+			if data, ok := resp.(map[string]any); ok {
+				if dir, ok := data["installDir"].(string); ok && dir != "" {
+					state.InstallDir = dir
+				}
+			}
+		case PageComponents:
+			resp = m.showComponents(ui, page, state, m.buttonBar(pages, i))
+		case PageSummary:
+			resp = ui.ShowMessage(cmp.Or(page.Title, ui.T("ready.title")), m.summary(ui, state),
+				webflow.WithSubtitle(cmp.Or(page.Message, ui.TF("ready.message", m.Name))),
+				webflow.WithButtonBar(m.buttonBar(pages, i)))
+		case PageInstall:
+			if hooks != nil {
+				hooks.Set("installDir", state.InstallDir)
+			}
+			if err := m.install(ui, page, state, hooks, log); err != nil {
+				if errors.Is(err, ErrCancelled) {
+					return err
+				}
+				ui.ShowError(ui.T("error.installFailed"), err.Error())
+				return err
+			}
+			i++ // Never go back to pages before the install
+			continue
+		case PageFinish:
+			ui.ShowMessage(cmp.Or(page.Title, ui.T("complete.title")),
+				cmp.Or(page.Message, ui.TF("complete.message", m.Name)),
+				webflow.WithIcon("success"), webflow.WithButtonBar(webflow.WizardFinish()))
+			return nil
+		}
+
+		switch {
+		case webflow.IsClose(resp):
+			return ErrCancelled
+		case webflow.IsBack(resp):
+			if i > 0 && pages[i-1].Type != PageInstall {
+				i--
+			}
+		case webflow.LanguageChanged(resp):
+			// Show the page again with the new language
+		default:
+			i++
+		}
+	}
+	return nil
+}
+
+// buttonBar picks the wizard buttons for page i: Install before the install
+// page, Back hidden on the first page.
+func (m *Manifest) buttonBar(pages []ManifestPage, i int) webflow.ButtonBar {
+	if i+1 < len(pages) && pages[i+1].Type == PageInstall {
+		return webflow.WizardInstall()
+	}
+	if i == 0 {
+		return webflow.WizardFirst()
+	}
+	return webflow.WizardMiddle()
+}
+
+// showComponents shows the component selection and updates state.
+// Required components stay selected even if the user unticks them.
+func (m *Manifest) showComponents(ui *webflow.Flow, page ManifestPage, state *ManifestState, bar webflow.ButtonBar) any {
+	mc := webflow.MultiChoice{}
+	for i, c := range m.Components {
+		mc.Choices = append(mc.Choices, webflow.Choice{
+			Label:       c.Name,
+			Description: c.Description,
+			Value:       c.ID,
+			Requires:    c.Requires,
+			Excludes:    c.Excludes,
+			SizeBytes:   m.componentSize(c.ID, state),
+		})
+		if state.Components[c.ID] {
+			mc.Selected = append(mc.Selected, i)
+		}
+	}
+
+	resp := ui.ShowPage(webflow.Page{
+		Title:     cmp.Or(page.Title, ui.T("components.title")),
+		Subtitle:  cmp.Or(page.Message, ui.TF("components.message", m.Name)),
+		Content:   mc,
+		ButtonBar: bar,
+	})
+	data, ok := resp.(map[string]any)
+	if !ok {
+		return resp
+	}
+
+	indices, _ := data["_selected_indices"].([]any)
+	var ids []string
+	for _, idx := range indices {
+		if i, ok := idx.(float64); ok && int(i) >= 0 && int(i) < len(m.Components) {
+			ids = append(ids, m.Components[int(i)].ID)
+		}
+	}
+	state.Components = m.resolveComponents(ids)
+	return resp
+}
+
+// resolveComponents applies the required flags and the Requires/Excludes rules
+// to a selection of component IDs. Required components take precedence over
+// selected ones that conflict with them.
+func (m *Manifest) resolveComponents(ids []string) map[string]bool {
+	choices := make([]webflow.Choice, len(m.Components))
+	var selected []int
+	for i, c := range m.Components {
+		choices[i] = webflow.Choice{Label: c.Name, Value: c.ID, Requires: c.Requires, Excludes: c.Excludes}
+		if c.Required {
+			selected = append(selected, i)
+		}
+	}
+	for _, id := range ids {
+		if i := slices.IndexFunc(m.Components, func(c ManifestComponent) bool { return c.ID == id }); i >= 0 {
+			selected = append(selected, i)
+		}
+	}
+
+	result := make(map[string]bool)
+	for _, i := range webflow.ResolveChoices(choices, selected) {
+		result[m.Components[i].ID] = true
+	}
+	return result
+}
+
+// summary lists the version, install location and selected components.
+func (m *Manifest) summary(ui *webflow.Flow, state *ManifestState) webflow.SummaryConfig {
+	items := []webflow.SummaryItem{
+		{Label: ui.T("summary.location"), Value: state.InstallDir},
+	}
+	if m.Version != "" {
+		items = append(items, webflow.SummaryItem{Label: ui.T("summary.version"), Value: m.Version})
+	}
+	var names []string
+	for _, c := range m.Components {
+		if state.Components[c.ID] {
+			names = append(names, c.Name)
+		}
+	}
+	if len(names) > 0 {
+		items = append(items, webflow.SummaryItem{Label: ui.T("summary.components"), Value: strings.Join(names, ", ")})
+	}
+	return webflow.SummaryConfig{Items: items}
+}
+
+// install runs the steps, with pre-install, post-file-copy and post-install
+// hooks around them when the manifest has a hooks file.
+func (m *Manifest) install(ui *webflow.Flow, page ManifestPage, state *ManifestState, hooks *Hooks, log *Logger) error {
+	steps := m.Steps(state)
+	if hooks != nil {
+		steps = []Step{hooks.Step(HookPreInstall)}
+		steps = append(steps, m.fileSteps(state)...)
+		steps = append(steps, hooks.Step(HookPostFileCopy))
+		steps = append(steps, m.systemSteps(state)...)
+		steps = append(steps, hooks.Step(HookPostInstall))
+	}
+
+	title := cmp.Or(page.Title, ui.T("installing.title"))
+	return RunStepsWithConfig(ui, title, steps, RunConfig{Logger: log, ReturnCancelled: true})
+}
@@ -0,0 +1,66 @@
+//go:build !windows
+
+package installer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/crafted-tech/webflow/platform"
+)
+
+// manifestDefaultInstallDir returns <user data dir>/<name>.
+func manifestDefaultInstallDir(name string) string {
+	dir, err := platform.UserDataPath()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, name)
+}
+
+// manifestPlatformVar resolves platform-specific manifest variables.
+// There are none outside Windows.
+func manifestPlatformVar(name string) string {
+	return ""
+}
+
+// stepManifestRegistry is skipped: the registry only exists on Windows.
+func stepManifestRegistry(r ManifestRegistryValue) Step {
+	return Step{
+		Name: fmt.Sprintf("Write registry %s", r.Key),
+		Action: func() StepResult {
+			return Skipped("not supported on this platform")
+		},
+	}
+}
+
+// stepManifestShortcut is skipped: shortcuts are only supported on Windows.
+func stepManifestShortcut(s ManifestShortcut) Step {
+	return Step{
+		Name: fmt.Sprintf("Create %s shortcut", s.Name),
+		Action: func() StepResult {
+			return Skipped("not supported on this platform")
+		},
+	}
+}
+
+// stepManifestRegistryRemove is skipped: nothing was written to a registry.
+func stepManifestRegistryRemove(r ManifestRegistryValue) Step {
+	return Step{
+		Name: fmt.Sprintf("Remove registry %s", r.Key),
+		Action: func() StepResult {
+			return Skipped("not supported on this platform")
+		},
+	}
+}
+
+// stepManifestShortcutRemove is skipped: no shortcuts were created.
+func stepManifestShortcutRemove(s ManifestShortcut) Step {
+	return Step{
+		Name: fmt.Sprintf("Remove %s shortcut", s.Name),
+		Action: func() StepResult {
+			return Skipped("not supported on this platform")
+		},
+	}
+}
@@ -0,0 +1,149 @@
+//go:build windows
+
+package installer
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/windows/registry"
+
+	"github.com/crafted-tech/webflow/platform"
+)
+
+// manifestDefaultInstallDir returns Program Files\<name>.
+func manifestDefaultInstallDir(name string) string {
+	return filepath.Join(platform.ProgramFilesPath(), name)
+}
+
+// manifestPlatformVar resolves the Windows-specific manifest variables.
+func manifestPlatformVar(name string) string {
+	switch name {
+	case "programFiles":
+		return platform.ProgramFilesPath()
+	case "localAppData":
+		dir, _ := platform.LocalAppDataPath()
+		return dir
+	case "programData":
+		dir, _ := platform.ProgramDataPath()
+		return dir
+	}
+	return ""
+}
+
+// stepManifestRegistry creates a Step that writes a registry value.
+func stepManifestRegistry(r ManifestRegistryValue) Step {
+	return Step{
+		Name: fmt.Sprintf("Write registry %s", r.Key),
+		Action: func() StepResult {
+			root := registry.LOCAL_MACHINE
+			if strings.EqualFold(r.Root, "HKCU") {
+				root = registry.CURRENT_USER
+			}
+			key, _, err := registry.CreateKey(root, r.Key, registry.SET_VALUE)
+			if err != nil {
+				return Failed(fmt.Errorf("create registry key: %w", err))
+			}
+			defer key.Close()
+
+			switch r.Type {
+			case "", "string":
+				err = key.SetStringValue(r.Name, r.Value)
+			case "expand":
+				err = key.SetExpandStringValue(r.Name, r.Value)
+			case "dword":
+				var n uint64
+				if n, err = strconv.ParseUint(r.Value, 0, 32); err == nil {
+					err = key.SetDWordValue(r.Name, uint32(n))
+				}
+			default:
+				err = fmt.Errorf("unknown value type %q", r.Type)
+			}
+			if err != nil {
+				return Failed(fmt.Errorf("set registry value %s: %w", r.Name, err))
+			}
+			return Success("")
+		},
+	}
+}
+
+// stepManifestShortcut creates a Step that creates a desktop or Start Menu shortcut.
+func stepManifestShortcut(s ManifestShortcut) Step {
+	return Step{
+		Name: fmt.Sprintf("Create %s shortcut", s.Name),
+		Action: func() StepResult {
+			lnk := platform.Shortcut{
+				Target:      s.Target,
+				Arguments:   s.Args,
+				Description: s.Description,
+			}
+			var err error
+			switch s.Location {
+			case "desktop":
+				err = platform.CreateDesktopShortcut(s.Name, lnk)
+			case "", "startmenu":
+				err = platform.CreateStartMenuShortcut(s.Folder, s.Name, lnk)
+			default:
+				err = fmt.Errorf("unknown shortcut location %q", s.Location)
+			}
+			if err != nil {
+				return Failed(err)
+			}
+			return Success("")
+		},
+	}
+}
+
+// stepManifestRegistryRemove creates a Step that deletes a registry value
+// written by stepManifestRegistry. The key itself is left alone, as other
+// software may keep values in it.
+func stepManifestRegistryRemove(r ManifestRegistryValue) Step {
+	return Step{
+		Name: fmt.Sprintf("Remove registry %s", r.Key),
+		Action: func() StepResult {
+			root := registry.LOCAL_MACHINE
+			if strings.EqualFold(r.Root, "HKCU") {
+				root = registry.CURRENT_USER
+			}
+			key, err := registry.OpenKey(root, r.Key, registry.SET_VALUE)
+			if err == registry.ErrNotExist {
+				return Skipped("not found")
+			}
+			if err != nil {
+				return Failed(fmt.Errorf("open registry key: %w", err))
+			}
+			defer key.Close()
+
+			if err := key.DeleteValue(r.Name); err != nil {
+				if err == registry.ErrNotExist {
+					return Skipped("not found")
+				}
+				return Failed(fmt.Errorf("delete registry value %s: %w", r.Name, err))
+			}
+			return Success("")
+		},
+	}
+}
+
+// stepManifestShortcutRemove creates a Step that deletes a shortcut created
+// by stepManifestShortcut, and its Start Menu folder once empty.
+func stepManifestShortcutRemove(s ManifestShortcut) Step {
+	return Step{
+		Name: fmt.Sprintf("Remove %s shortcut", s.Name),
+		Action: func() StepResult {
+			var err error
+			switch s.Location {
+			case "desktop":
+				err = platform.DeleteDesktopShortcut(s.Name)
+			default:
+				err = platform.DeleteStartMenuShortcut(s.Folder, s.Name)
+			}
+			if err != nil {
+				return Failed(err)
+			}
+			return Success("")
+		},
+	}
+}
@@ -0,0 +1,186 @@
+package nethelper
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/crafted-tech/webflow/platform"
+)
+
+// Defaults for BatchAnalytics.
+const (
+	defaultAnalyticsBatch    = 20
+	defaultAnalyticsInterval = 30 * time.Second
+	analyticsSendTimeout     = 5 * time.Second
+)
+
+// AnalyticsEvent is one event in a batch sent by BatchAnalytics.
+type AnalyticsEvent struct {
+	Type       string    `json:"type"`                 // "page", "step" or "finish"
+	Name       string    `json:"name,omitempty"`       // Page ID or step name
+	DurationMS int64     `json:"durationMs,omitempty"` // Duration of a step
+	Outcome    string    `json:"outcome,omitempty"`    // Result of the install, for "finish"
+	Time       time.Time `json:"time"`
+}
+
+// AnalyticsBatch is the JSON body of a request sent by BatchAnalytics.
+type AnalyticsBatch struct {
+	Session string           `json:"session"` // Random ID shared by the events of one installer run
+	Product string           `json:"product"`
+	Version string           `json:"version"`
+	OS      string           `json:"os"`
+	Arch    string           `json:"arch"`
+	Events  []AnalyticsEvent `json:"events"`
+}
+
+// BatchAnalytics is an installer.Analytics that posts events in batches
+// (AnalyticsBatch) to a path of an HTTPS server. Events are sent in the
+// background once BatchSize have been collected or FlushInterval has passed,
+// and InstallFinished sends the rest, waiting at most five seconds. Failed
+// batches are dropped: analytics never holds up or fails an install.
+//
+// Wrap it with installer.AnalyticsFor so nothing is sent without consent.
+//
+// Example:
+//
+//	client := nethelper.New("https://telemetry.acme.example/v1")
+//	installer.Run(installer.AppConfig{
+//	    Name:      "Acme Agent",
+//	    Analytics: nethelper.NewBatchAnalytics(client, "/installer-events", "Acme Agent", version),
+//	}, run)
+type BatchAnalytics struct {
+	BatchSize     int           // Events per request (default 20)
+	FlushInterval time.Duration // Longest time events are held (default 30s)
+
+	client   *Client
+	path     string
+	batch    AnalyticsBatch
+	mu       sync.Mutex
+	events   []AnalyticsEvent
+	timer    *time.Timer
+	finished bool
+	sending  sync.WaitGroup
+}
+
+// NewBatchAnalytics returns analytics for product that post to path on
+// client's server. Events are only sent if the client's BaseURL is HTTPS.
+func NewBatchAnalytics(client *Client, path, product, version string) *BatchAnalytics {
+	session := make([]byte, 16)
+	rand.Read(session)
+	client.client() // created now, as batches are sent from other goroutines
+	return &BatchAnalytics{
+		client: client,
+		path:   path,
+		batch: AnalyticsBatch{
+			Session: hex.EncodeToString(session),
+			Product: product,
+			Version: version,
+			OS:      runtime.GOOS,
+			Arch:    platform.Arch(),
+		},
+	}
+}
+
+// PageViewed records that a page was shown.
+func (a *BatchAnalytics) PageViewed(page string) {
+	a.add(AnalyticsEvent{Type: "page", Name: page})
+}
+
+// StepCompleted records that a step succeeded.
+func (a *BatchAnalytics) StepCompleted(step string, elapsed time.Duration) {
+	a.add(AnalyticsEvent{Type: "step", Name: step, DurationMS: elapsed.Milliseconds()})
+}
+
+// InstallFinished records the outcome and sends all events that have not
+// been sent yet. Events reported after it are dropped.
+func (a *BatchAnalytics) InstallFinished(outcome string) {
+	a.add(AnalyticsEvent{Type: "finish", Outcome: outcome})
+	a.mu.Lock()
+	events := a.take()
+	a.finished = true
+	a.mu.Unlock()
+	a.send(events)
+	a.sending.Wait()
+}
+
+// Flush sends the collected events in the background.
+func (a *BatchAnalytics) Flush() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.finished {
+		return
+	}
+	events := a.take()
+	if len(events) == 0 {
+		return
+	}
+	a.sending.Add(1)
+	go func() {
+		defer a.sending.Done()
+		a.send(events)
+	}()
+}
+
+// Discard drops the events that have not been sent yet, e.g. when the user
+// withdraws consent. Batches already being sent are not recalled.
+func (a *BatchAnalytics) Discard() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.take()
+}
+
+// take removes and returns the collected events. a.mu must be held.
+func (a *BatchAnalytics) take() []AnalyticsEvent {
+	events := a.events
+	a.events = nil
+	if a.timer != nil {
+		a.timer.Stop()
+		a.timer = nil
+	}
+	return events
+}
+
+// send posts events as one batch.
+func (a *BatchAnalytics) send(events []AnalyticsEvent) {
+	if len(events) == 0 || !strings.HasPrefix(a.client.BaseURL, "https://") {
+		return
+	}
+	batch := a.batch
+	batch.Events = events
+	ctx, cancel := context.WithTimeout(context.Background(), analyticsSendTimeout)
+	defer cancel()
+	a.client.PostJSON(ctx, a.path, batch, nil)
+}
+
+// add collects an event and sends the batch when it is full.
+func (a *BatchAnalytics) add(e AnalyticsEvent) {
+	e.Time = time.Now().UTC()
+	size := a.BatchSize
+	if size <= 0 {
+		size = defaultAnalyticsBatch
+	}
+	interval := a.FlushInterval
+	if interval <= 0 {
+		interval = defaultAnalyticsInterval
+	}
+
+	a.mu.Lock()
+	if a.finished {
+		a.mu.Unlock()
+		return
+	}
+	a.events = append(a.events, e)
+	full := len(a.events) >= size
+	if !full && a.timer == nil {
+		a.timer = time.AfterFunc(interval, a.Flush)
+	}
+	a.mu.Unlock()
+	if full {
+		a.Flush()
+	}
+}
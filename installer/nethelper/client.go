@@ -0,0 +1,297 @@
+// Package nethelper provides a small JSON-over-HTTP client for installer
+// pages that talk to a server, such as "register this install" or "fetch
+// configuration": system proxy settings, retries for transient failures,
+// cancellation from a webflow progress page and error messages that can be
+// shown to the user as they are. Client.Download fetches large files from
+// several mirrors with parallel chunks and a bandwidth limit, and
+// BatchAnalytics reports installer funnel events.
+package nethelper
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/crafted-tech/webflow"
+	"github.com/crafted-tech/webflow/installer"
+)
+
+// maxResponseSize limits how much of a response body is read.
+const maxResponseSize = 8 << 20
+
+// Client sends JSON requests to one server. The zero value is not usable;
+// create clients with New and adjust the fields before the first request.
+//
+// Example:
+//
+//	client := nethelper.New("https://api.example.com/v1")
+//	client.Header.Set("Authorization", "Bearer "+token)
+//
+//	var reg registration
+//	ui.ShowProgress("Registering", func(p webflow.Progress) {
+//	    ctx, cancel := nethelper.WithProgress(context.Background(), p)
+//	    defer cancel()
+//	    err = client.PostJSON(ctx, "/installs", request, &reg)
+//	})
+//	if err != nil {
+//	    ui.ShowAlertError("Registration failed", nethelper.Describe(err))
+//	}
+type Client struct {
+	BaseURL   string        // Prefix for request paths, e.g. "https://api.example.com/v1"
+	Header    http.Header   // Sent with every request (authorization, API keys)
+	UserAgent string        // User-Agent header (default "webflow-installer")
+	Timeout   time.Duration // Limit for each attempt (default 30s)
+
+	// Retries is the number of extra attempts after a transient failure
+	// (default 2; -1 for none). 429 and 503 responses are retried for any
+	// method; 502, 504 and network errors only for GET and HEAD, except
+	// connection and DNS failures, which never reach the server, so a POST
+	// is not applied twice.
+	Retries int
+
+	// Proxy chooses the proxy for a request (default SystemProxy: the
+	// HTTPS_PROXY/HTTP_PROXY variables, else the Windows Internet settings).
+	Proxy func(*http.Request) (*url.URL, error)
+
+	// Cache keeps downloads that have a checksum for reuse by later
+	// downloads and other installers (optional; see installer.NewCache).
+	Cache *installer.Cache
+
+	// Bundle, if set, is an offline bundle that Download takes files from
+	// before going to the network (see installer.OfflineBundle). Installers
+	// run by App pass installer.CurrentOfflineBundle(), which the -bundle
+	// flag sets.
+	Bundle *installer.OfflineBundle
+
+	http *http.Client
+}
+
+// New returns a client for the server at baseURL.
+func New(baseURL string) *Client {
+	return &Client{
+		BaseURL:   strings.TrimRight(baseURL, "/"),
+		Header:    make(http.Header),
+		UserAgent: "webflow-installer",
+		Timeout:   30 * time.Second,
+		Retries:   2,
+	}
+}
+
+// GetJSON requests path and decodes the JSON response into out (which may be
+// nil to discard it).
+func (c *Client) GetJSON(ctx context.Context, path string, out any) error {
+	return c.Do(ctx, http.MethodGet, path, nil, out)
+}
+
+// PostJSON sends in as JSON to path and decodes the JSON response into out
+// (which may be nil to discard it).
+func (c *Client) PostJSON(ctx context.Context, path string, in, out any) error {
+	return c.Do(ctx, http.MethodPost, path, in, out)
+}
+
+// Do sends a request with an optional JSON body and decodes the JSON
+// response into out. Connection failures, timeouts and 429/502/503/504
+// responses are retried with a growing delay (honoring Retry-After);
+// other errors are returned at once. Requests that got no response at all are
+// retried for every method; those that got a response only for GET, since the
+// server may already have acted on a POST.
+func (c *Client) Do(ctx context.Context, method, path string, in, out any) error {
+	var body []byte
+	if in != nil {
+		var err error
+		if body, err = json.Marshal(in); err != nil {
+			return fmt.Errorf("encode request: %w", err)
+		}
+	}
+
+	target := c.BaseURL + path
+	retries := c.Retries
+	if retries < 0 {
+		retries = 0
+	}
+	delay := time.Second
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(ctx, method, target, body)
+		retryAfter := time.Duration(0)
+		if err == nil {
+			err = decodeResponse(resp, out)
+			retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+		}
+		if err == nil {
+			return nil
+		}
+		if cause := context.Cause(ctx); cause != nil {
+			return cause
+		}
+		if attempt >= retries || !retryable(err, method) {
+			var urlErr *url.Error
+			if errors.As(err, &urlErr) {
+				return err // already names the method and URL
+			}
+			return fmt.Errorf("%s %s: %w", method, target, err)
+		}
+
+		wait := max(delay, retryAfter)
+		delay *= 2
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		}
+	}
+}
+
+// send performs one attempt.
+func (c *Client) send(ctx context.Context, method, target string, body []byte) (*http.Response, error) {
+	timeout := c.Timeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+
+	req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(body))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	for key, values := range c.Header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+
+	resp, err := c.client().Do(req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// client returns the underlying http.Client, created on first use.
+func (c *Client) client() *http.Client {
+	if c.http == nil {
+		proxy := c.Proxy
+		if proxy == nil {
+			proxy = SystemProxy
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.Proxy = proxy
+		c.http = &http.Client{Transport: transport}
+	}
+	return c.http
+}
+
+// cancelBody releases the attempt's timeout when the body is closed.
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// decodeResponse checks the status and decodes a JSON body into out.
+func decodeResponse(resp *http.Response, out any) error {
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &StatusError{Code: resp.StatusCode, Body: strings.TrimSpace(string(data))}
+	}
+	if out == nil || len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+// retryable reports whether a failed attempt is worth repeating.
+func retryable(err error, method string) bool {
+	var status *StatusError
+	if errors.As(err, &status) {
+		switch status.Code {
+		case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+			return true
+		case http.StatusBadGateway, http.StatusGatewayTimeout:
+			return method == http.MethodGet
+		}
+		return false
+	}
+	if isCertificateError(err) {
+		return false
+	}
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return false
+	}
+	if method == http.MethodGet || method == http.MethodHead {
+		return true
+	}
+	return neverSent(err)
+}
+
+// neverSent reports whether a transport error happened before the request
+// reached the server, so repeating a POST cannot apply it twice.
+func neverSent(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds.
+func parseRetryAfter(v string) time.Duration {
+	seconds, err := strconv.Atoi(strings.TrimSpace(v))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return min(time.Duration(seconds)*time.Second, time.Minute)
+}
+
+// WithProgress returns a context that is cancelled when the user cancels the
+// progress page, so requests made from its work function stop promptly. The
+// context's cause is then installer.ErrCancelled, which Do returns.
+func WithProgress(ctx context.Context, p webflow.Progress) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	go func() {
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if p.Cancelled() {
+					cancel(installer.ErrCancelled)
+					return
+				}
+			}
+		}
+	}()
+	return ctx, func() { cancel(context.Canceled) }
+}
@@ -0,0 +1,686 @@
+package nethelper
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/crafted-tech/webflow"
+	"github.com/crafted-tech/webflow/installer"
+	"github.com/crafted-tech/webflow/platform"
+)
+
+// Defaults for Download.
+const (
+	defaultConnections    = 4
+	defaultChunkThreshold = 16 << 20
+	progressInterval      = 100 * time.Millisecond
+)
+
+// ErrChecksumMismatch is returned by Client.Download when the downloaded file
+// does not match Download.SHA256.
+var ErrChecksumMismatch = errors.New("downloaded file does not match the expected checksum")
+
+// errMirrorMismatch is returned when a mirror serves a file of a different
+// size than the others. That mirror is not used again.
+var errMirrorMismatch = errors.New("mirror serves a file of a different size")
+
+// Download describes a file to fetch with Client.Download.
+type Download struct {
+	// URLs lists mirrors of the same file in order of preference. When one
+	// fails, the download continues from the next one where it left off.
+	URLs []string
+
+	Dest   string // Destination path; written as Dest+".part" and renamed when complete
+	SHA256 string // Expected checksum as hex (optional)
+	Name   string // File name in an offline bundle (default: the last element of the first URL)
+
+	// PublicKey, if set, is the minisign key the file must be signed with
+	// (see installer.VerifySignature). The detached signature is fetched from
+	// SigURL (default: each URL + ".minisig"), or from the offline bundle as
+	// Name + ".minisig", and checked before the file is renamed to Dest,
+	// whether it came from the network, the cache or a bundle.
+	PublicKey string
+	SigURL    string
+
+	// CodeSigned requires the file to carry a valid, trusted code signature
+	// (see platform.VerifyCodeSignature), from ExpectedSigner if that is set,
+	// which implies CodeSigned. It is checked before the file is renamed to
+	// Dest, so an unsigned or tampered executable never gets there. Skipped
+	// on platforms without system code signing (Linux).
+	CodeSigned     bool
+	ExpectedSigner string
+
+	// Connections is the number of ranged requests made in parallel for a
+	// file of at least ChunkThreshold bytes on a server that supports ranges
+	// (default 4; 1 downloads in one piece). ChunkThreshold defaults to 16 MiB.
+	Connections    int
+	ChunkThreshold int64
+
+	// BytesPerSecond limits the bandwidth of all connections together
+	// (0 for no limit).
+	BytesPerSecond int64
+
+	// Progress is called with the bytes received so far and the file size
+	// (-1 if the server did not report it), at most every 100ms and once
+	// more when the download completes. Calls are never concurrent.
+	Progress func(done, total int64)
+}
+
+// Download fetches d.URLs to d.Dest. A large file on a server that supports
+// ranges is split into chunks that are fetched in parallel, possibly from
+// different mirrors. A failed transfer continues from where it stopped on the
+// next mirror; after trying every mirror it starts another round with a
+// growing delay, up to Retries extra rounds. Mirrors that answer with a
+// permanent error (such as 404) or serve a different file size are dropped.
+// Client.Timeout limits how long a connection may go without receiving data.
+//
+// A file with a checksum is taken from Client.Cache if it is there, and added
+// to it after downloading. If Client.Bundle is set, the file is otherwise
+// copied from the bundle. If it is missing there, Download fails with
+// installer.ErrNotInBundle unless the bundle allows network access.
+//
+// Example:
+//
+//	ui.ShowProgress("Downloading", func(p webflow.Progress) {
+//	    ctx, cancel := nethelper.WithProgress(context.Background(), p)
+//	    defer cancel()
+//	    err = client.Download(ctx, nethelper.Download{
+//	        URLs:     []string{"https://cdn.example.com/app.zip", "https://mirror.example.org/app.zip"},
+//	        Dest:     zipPath,
+//	        SHA256:   release.SHA256,
+//	        Progress: nethelper.ProgressBar(ui, p),
+//	    })
+//	})
+func (c *Client) Download(ctx context.Context, d Download) error {
+	if len(d.URLs) == 0 {
+		return errors.New("download: no URLs")
+	}
+	part := d.Dest + ".part"
+	file, err := os.Create(part)
+	if err != nil {
+		return err
+	}
+	dl := &download{
+		c:       c,
+		d:       d,
+		file:    file,
+		total:   -1,
+		dead:    make([]bool, len(d.URLs)),
+		limiter: newRateLimiter(d.BytesPerSecond),
+	}
+	err = dl.fetchFile(ctx)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && d.PublicKey != "" {
+		err = c.verifySignature(ctx, d, part)
+	}
+	if err == nil && (d.CodeSigned || d.ExpectedSigner != "") {
+		err = verifyCodeSignature(part, d.ExpectedSigner)
+	}
+	if err == nil {
+		err = os.Rename(part, d.Dest)
+	}
+	if err != nil {
+		os.Remove(part)
+		return err
+	}
+	if dl.fromNetwork && c.Cache != nil && d.SHA256 != "" {
+		// The cache only saves time; failing to fill it doesn't fail the
+		// download.
+		c.Cache.Add(d.Dest, d.SHA256)
+		c.Cache.Evict()
+	}
+	dl.report(true)
+	return nil
+}
+
+// ProgressBar returns a Download.Progress function that shows the download
+// on a progress page of ui, e.g. "12.5 MB of 48 MB" in ui's language.
+// With WithProgressTime the page also shows the download speed.
+func ProgressBar(ui *webflow.Flow, p webflow.Progress) func(done, total int64) {
+	return func(done, total int64) {
+		if bp, ok := p.(webflow.ByteProgress); ok {
+			bp.SetBytes(done)
+		}
+		if total <= 0 {
+			p.Update(0, ui.FormatBytes(done))
+			return
+		}
+		pct := float64(done) * 100 / float64(total)
+		p.Update(pct, ui.TF("download.status", ui.FormatBytes(done), ui.FormatBytes(total)))
+	}
+}
+
+// verifySignature downloads the signature of d and checks the file at path
+// against it.
+func (c *Client) verifySignature(ctx context.Context, d Download, path string) error {
+	sig := Download{Dest: d.Dest + ".minisig", Name: d.bundleName() + ".minisig"}
+	if d.SigURL != "" {
+		sig.URLs = []string{d.SigURL}
+	} else {
+		for _, u := range d.URLs {
+			sig.URLs = append(sig.URLs, u+".minisig")
+		}
+	}
+	if err := c.Download(ctx, sig); err != nil {
+		return fmt.Errorf("download signature: %w", err)
+	}
+	defer os.Remove(sig.Dest)
+	return installer.VerifySignature(path, sig.Dest, d.PublicKey)
+}
+
+// verifyCodeSignature checks the code signature of the file at path,
+// accepting platforms that can't check one.
+func verifyCodeSignature(path, expectedSigner string) error {
+	err := platform.VerifyCodeSignature(path, expectedSigner)
+	if err != nil && !errors.Is(err, platform.ErrSignatureUnsupported) {
+		return fmt.Errorf("verify code signature: %w", err)
+	}
+	return nil
+}
+
+// bundleName returns the name of the file in an offline bundle.
+func (d *Download) bundleName() string {
+	if d.Name != "" {
+		return d.Name
+	}
+	u, err := url.Parse(d.URLs[0])
+	if err != nil {
+		return ""
+	}
+	name := path.Base(u.Path)
+	if name == "/" || name == "." {
+		return ""
+	}
+	return name
+}
+
+// download is the state of one Client.Download call.
+type download struct {
+	c       *Client
+	d       Download
+	file    *os.File
+	limiter *rateLimiter
+
+	total int64 // file size, or -1 if unknown
+	done  atomic.Int64
+
+	mu      sync.Mutex
+	mirror  int    // index of the mirror new requests go to
+	dead    []bool // mirrors dropped after a permanent error
+	lastErr error
+
+	fromNetwork bool // the file was downloaded rather than copied
+
+	progressMu   sync.Mutex
+	lastProgress time.Time
+}
+
+// run downloads the file in one piece or in parallel chunks.
+func (dl *download) run(ctx context.Context) error {
+	connections := dl.d.Connections
+	if connections <= 0 {
+		connections = defaultConnections
+	}
+	threshold := dl.d.ChunkThreshold
+	if threshold <= 0 {
+		threshold = defaultChunkThreshold
+	}
+
+	// Ask for the whole file as a range: a 206 answer tells that the server
+	// supports ranges. Small files, servers without ranges and single
+	// connections simply continue reading this response.
+	var (
+		resp     *http.Response
+		mirror   string
+		attempts int
+	)
+	for resp == nil {
+		var err error
+		if mirror, err = dl.next(); err != nil {
+			return err
+		}
+		if resp, err = dl.request(ctx, mirror, 0, -1); err != nil {
+			if err := dl.fail(ctx, mirror, err, &attempts); err != nil {
+				return err
+			}
+		}
+	}
+	dl.total = resp.ContentLength
+	if resp.StatusCode == http.StatusPartialContent {
+		dl.total = contentRangeTotal(resp.Header.Get("Content-Range"))
+	}
+	end := dl.total - 1
+	if dl.total < 0 {
+		end = -1
+	}
+
+	if resp.StatusCode != http.StatusPartialContent || connections < 2 || dl.total < threshold {
+		n, err := dl.copy(ctx, resp, 0, end)
+		if err == nil {
+			return nil
+		}
+		if err := dl.fail(ctx, mirror, err, &attempts); err != nil {
+			return err
+		}
+		return dl.fetch(ctx, n, end)
+	}
+	resp.Body.Close()
+
+	if err := dl.file.Truncate(dl.total); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	size := (dl.total + int64(connections) - 1) / int64(connections)
+	var wg sync.WaitGroup
+	for start := int64(0); start < dl.total; start += size {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := dl.fetch(ctx, start, min(start+size, dl.total)-1); err != nil {
+				cancel(err)
+			}
+		}()
+	}
+	wg.Wait()
+	return context.Cause(ctx)
+}
+
+// fetchFile fills the file from the cache, the offline bundle or the network,
+// in that order, and verifies its checksum.
+func (dl *download) fetchFile(ctx context.Context) error {
+	if cache := dl.c.Cache; cache != nil && dl.d.SHA256 != "" {
+		if src, ok := cache.Lookup(dl.d.SHA256); ok {
+			err := dl.copyFrom(ctx, src)
+			if err == nil {
+				err = dl.verify()
+			}
+			if err == nil || context.Cause(ctx) != nil {
+				return err
+			}
+			// A damaged entry: drop it and get the file elsewhere.
+			cache.Remove(dl.d.SHA256)
+			if err := dl.reset(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if bundle := dl.c.Bundle; bundle != nil {
+		src, err := bundle.Find(dl.d.bundleName(), dl.d.SHA256)
+		if err == nil {
+			if err := dl.copyFrom(ctx, src); err != nil {
+				return err
+			}
+			return dl.verify()
+		}
+		if !bundle.AllowNetwork {
+			return err
+		}
+	}
+
+	dl.fromNetwork = true
+	if err := dl.run(ctx); err != nil {
+		return err
+	}
+	return dl.verify()
+}
+
+// verify checks the file against Download.SHA256, if given.
+func (dl *download) verify() error {
+	if dl.d.SHA256 == "" {
+		return nil
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, io.NewSectionReader(dl.file, 0, math.MaxInt64)); err != nil {
+		return err
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); !strings.EqualFold(got, strings.TrimSpace(dl.d.SHA256)) {
+		return fmt.Errorf("%w (got %s)", ErrChecksumMismatch, got)
+	}
+	return nil
+}
+
+// reset empties the file to start over from another source.
+func (dl *download) reset() error {
+	if err := dl.file.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := dl.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	dl.total = -1
+	dl.done.Store(0)
+	return nil
+}
+
+// copyFrom copies the file at src, such as a file in an offline bundle.
+func (dl *download) copyFrom(ctx context.Context, src string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if info, err := f.Stat(); err == nil {
+		dl.total = info.Size()
+	}
+
+	buf := make([]byte, 256<<10)
+	for {
+		if cause := context.Cause(ctx); cause != nil {
+			return cause
+		}
+		n, err := f.Read(buf)
+		if n > 0 {
+			if _, err := dl.file.Write(buf[:n]); err != nil {
+				return err
+			}
+			dl.done.Add(int64(n))
+			dl.report(false)
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// fetch downloads the bytes from start to end (inclusive; -1 for the end of
+// the file), moving between mirrors until done or out of attempts.
+func (dl *download) fetch(ctx context.Context, start, end int64) error {
+	attempts := 0
+	for {
+		mirror, err := dl.next()
+		if err != nil {
+			return err
+		}
+		resp, err := dl.request(ctx, mirror, start, end)
+		if err == nil {
+			var n int64
+			n, err = dl.copy(ctx, resp, start, end)
+			start += n
+			if n > 0 {
+				attempts = 0 // a transfer that made progress starts over
+			}
+		}
+		if err == nil {
+			return nil
+		}
+		if err := dl.fail(ctx, mirror, err, &attempts); err != nil {
+			return err
+		}
+	}
+}
+
+// request sends a GET for the range from start to end and checks the
+// response.
+func (dl *download) request(ctx context.Context, mirror string, start, end int64) (*http.Response, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, mirror, nil)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	for key, values := range dl.c.Header {
+		req.Header[key] = values
+	}
+	if dl.c.UserAgent != "" {
+		req.Header.Set("User-Agent", dl.c.UserAgent)
+	}
+	if end >= 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", start, end))
+	} else {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", start))
+	}
+
+	// Only the wait for the response headers is limited here; copy limits
+	// the time between reads of the body.
+	timer := time.AfterFunc(dl.timeout(), cancel)
+	resp, err := dl.c.client().Do(req)
+	if !timer.Stop() {
+		if err == nil {
+			resp.Body.Close()
+		}
+		err = context.DeadlineExceeded
+	}
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+
+	total := int64(-1)
+	switch resp.StatusCode {
+	case http.StatusOK:
+		total = resp.ContentLength
+	case http.StatusPartialContent:
+		total = contentRangeTotal(resp.Header.Get("Content-Range"))
+	default:
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, &StatusError{Code: resp.StatusCode, Body: strings.TrimSpace(string(data))}
+	}
+	if dl.total >= 0 && total >= 0 && total != dl.total {
+		resp.Body.Close()
+		return nil, errMirrorMismatch
+	}
+	return resp, nil
+}
+
+// copy writes the body of resp to the file from offset up to end (inclusive;
+// -1 to read until EOF) and returns the number of bytes written. The body of
+// a 200 response from a server that ignored the range starts at the
+// beginning of the file, so the bytes before offset are skipped.
+func (dl *download) copy(ctx context.Context, resp *http.Response, offset, end int64) (int64, error) {
+	defer resp.Body.Close()
+	skip := int64(0)
+	if resp.StatusCode == http.StatusOK {
+		skip = offset
+	}
+
+	timeout := dl.timeout()
+	var stalled atomic.Bool
+	timer := time.AfterFunc(timeout, func() {
+		stalled.Store(true)
+		resp.Body.Close()
+	})
+	defer timer.Stop()
+
+	buf := make([]byte, 32<<10)
+	var written int64
+	for end < 0 || offset+written <= end {
+		want := int64(len(buf))
+		if skip > 0 {
+			want = min(want, skip)
+		} else if end >= 0 {
+			want = min(want, end-offset-written+1)
+		}
+		n, err := resp.Body.Read(buf[:want])
+		timer.Reset(timeout)
+		if n > 0 && skip > 0 {
+			skip -= int64(n)
+		} else if n > 0 {
+			if _, err := dl.file.WriteAt(buf[:n], offset+written); err != nil {
+				return written, err
+			}
+			written += int64(n)
+			dl.done.Add(int64(n))
+			dl.report(false)
+			if err := dl.limiter.wait(ctx, n); err != nil {
+				return written, err
+			}
+		}
+		switch {
+		case err == io.EOF && end >= 0 && offset+written <= end:
+			return written, io.ErrUnexpectedEOF
+		case err == io.EOF:
+			return written, nil
+		case err != nil && stalled.Load():
+			return written, context.DeadlineExceeded
+		case err != nil:
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+// next returns the mirror for the next request, or the last error when every
+// mirror has been dropped.
+func (dl *download) next() (string, error) {
+	dl.mu.Lock()
+	defer dl.mu.Unlock()
+	for i := range dl.d.URLs {
+		index := (dl.mirror + i) % len(dl.d.URLs)
+		if !dl.dead[index] {
+			dl.mirror = index
+			return dl.d.URLs[index], nil
+		}
+	}
+	return "", dl.lastErr
+}
+
+// fail records a failed transfer from mirror and moves new requests on to the
+// next one. It returns the error to give up with, or nil to try again, after
+// waiting with a growing delay when a round over all mirrors is complete.
+// attempts counts the caller's consecutive failures.
+func (dl *download) fail(ctx context.Context, mirror string, err error, attempts *int) error {
+	if cause := context.Cause(ctx); cause != nil {
+		return cause
+	}
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		return err // writing the file failed; another mirror won't help
+	}
+
+	dl.mu.Lock()
+	dl.lastErr = fmt.Errorf("GET %s: %w", mirror, err)
+	for i, u := range dl.d.URLs {
+		if u != mirror {
+			continue
+		}
+		var status *StatusError
+		if errors.As(err, &status) && !retryable(err, http.MethodGet) ||
+			errors.Is(err, errMirrorMismatch) || isCertificateError(err) {
+			dl.dead[i] = true
+		}
+		if dl.mirror == i {
+			dl.mirror = (i + 1) % len(dl.d.URLs)
+		}
+	}
+	lastErr := dl.lastErr
+	live := slices.Contains(dl.dead, false)
+	dl.mu.Unlock()
+	if !live {
+		return lastErr
+	}
+
+	*attempts++
+	mirrors := len(dl.d.URLs)
+	round := *attempts / mirrors
+	if round > max(dl.c.Retries, 0) {
+		return lastErr
+	}
+	if *attempts%mirrors != 0 {
+		return nil
+	}
+	select {
+	case <-time.After(time.Duration(1<<(round-1)) * time.Second):
+		return nil
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	}
+}
+
+// timeout returns the limit for waiting on the server.
+func (dl *download) timeout() time.Duration {
+	if dl.c.Timeout <= 0 {
+		return 30 * time.Second
+	}
+	return dl.c.Timeout
+}
+
+// report passes the combined progress of all connections to d.Progress,
+// throttled unless final is set.
+func (dl *download) report(final bool) {
+	if dl.d.Progress == nil {
+		return
+	}
+	dl.progressMu.Lock()
+	defer dl.progressMu.Unlock()
+	now := time.Now()
+	if !final && now.Sub(dl.lastProgress) < progressInterval {
+		return
+	}
+	dl.lastProgress = now
+	dl.d.Progress(dl.done.Load(), dl.total)
+}
+
+// contentRangeTotal returns the total size from a Content-Range header such
+// as "bytes 0-99/1234", or -1 if it is missing or unknown ("*").
+func contentRangeTotal(header string) int64 {
+	_, total, ok := strings.Cut(header, "/")
+	if !ok {
+		return -1
+	}
+	n, err := strconv.ParseInt(strings.TrimSpace(total), 10, 64)
+	if err != nil || n < 0 {
+		return -1
+	}
+	return n
+}
+
+// rateLimiter is a token bucket shared by all connections of a download.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // bytes per second, 0 for no limit
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(bytesPerSecond int64) *rateLimiter {
+	return &rateLimiter{rate: float64(bytesPerSecond), last: time.Now()}
+}
+
+// wait takes n bytes from the bucket and sleeps until they are covered. The
+// bucket holds at most one second's worth, so idle time is not saved up.
+func (l *rateLimiter) wait(ctx context.Context, n int) error {
+	if l.rate <= 0 {
+		return nil
+	}
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = min(l.tokens+now.Sub(l.last).Seconds()*l.rate, l.rate)
+	l.last = now
+	l.tokens -= float64(n)
+	debt := l.tokens
+	l.mu.Unlock()
+	if debt >= 0 {
+		return nil
+	}
+	select {
+	case <-time.After(time.Duration(-debt / l.rate * float64(time.Second))):
+		return nil
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	}
+}
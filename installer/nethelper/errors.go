@@ -0,0 +1,90 @@
+package nethelper
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+
+	"github.com/crafted-tech/webflow/installer"
+)
+
+// StatusError is returned for a response with a non-2xx status.
+type StatusError struct {
+	Code int    // HTTP status code
+	Body string // Response body, e.g. the server's error message
+}
+
+func (e *StatusError) Error() string {
+	if e.Body != "" && len(e.Body) <= 200 {
+		return fmt.Sprintf("server returned %d %s: %s", e.Code, http.StatusText(e.Code), e.Body)
+	}
+	return fmt.Sprintf("server returned %d %s", e.Code, http.StatusText(e.Code))
+}
+
+// Describe returns a message for a request error that can be shown to the
+// user: certificate problems (often a proxy inspecting HTTPS), unknown hosts,
+// refused connections, timeouts and server errors are explained in plain
+// words. Other errors are returned as they are.
+func Describe(err error) string {
+	var (
+		unknownAuthority x509.UnknownAuthorityError
+		hostname         x509.HostnameError
+		invalid          x509.CertificateInvalidError
+		recordHeader     tls.RecordHeaderError
+		dnsErr           *net.DNSError
+		status           *StatusError
+	)
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, installer.ErrCancelled):
+		return "The request was cancelled."
+	case errors.As(err, &unknownAuthority):
+		return "The server's certificate is not trusted. A proxy or security product that inspects HTTPS traffic may need its certificate installed."
+	case errors.As(err, &hostname):
+		return fmt.Sprintf("The server's certificate is for a different name than %q.", hostname.Host)
+	case errors.As(err, &invalid) && invalid.Reason == x509.Expired:
+		return "The server's certificate has expired, or this computer's date and time are wrong."
+	case errors.As(err, &invalid):
+		return "The server's certificate is not valid."
+	case errors.As(err, &recordHeader):
+		return "The server did not answer with HTTPS. Check the address and port."
+	case errors.As(err, &dnsErr):
+		return fmt.Sprintf("The server %q could not be found. Check the address and your network connection.", dnsErr.Name)
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return "The server refused the connection. It may be down, or a firewall may block it."
+	case errors.Is(err, context.DeadlineExceeded), isTimeout(err):
+		return "The server did not respond in time."
+	case errors.As(err, &status) && status.Code == http.StatusProxyAuthRequired:
+		return "The proxy server requires authentication."
+	case errors.As(err, &status) && (status.Code == http.StatusUnauthorized || status.Code == http.StatusForbidden):
+		return "The server rejected the credentials."
+	case errors.As(err, &status) && status.Code >= 500:
+		return "The server had a problem processing the request. Try again later."
+	}
+	return err.Error()
+}
+
+// isCertificateError reports whether err is a TLS certificate problem, which
+// retrying won't fix.
+func isCertificateError(err error) bool {
+	var (
+		unknownAuthority x509.UnknownAuthorityError
+		hostname         x509.HostnameError
+		invalid          x509.CertificateInvalidError
+		verification     *tls.CertificateVerificationError
+	)
+	return errors.As(err, &unknownAuthority) || errors.As(err, &hostname) ||
+		errors.As(err, &invalid) || errors.As(err, &verification)
+}
+
+// isTimeout reports whether err is a network timeout.
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
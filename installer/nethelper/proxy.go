@@ -0,0 +1,74 @@
+package nethelper
+
+import (
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// SystemProxy returns the proxy for a request: the one named by the
+// HTTPS_PROXY and HTTP_PROXY environment variables if they are set, otherwise
+// the proxy configured in the system settings (Internet Options on Windows),
+// otherwise none.
+func SystemProxy(req *http.Request) (*url.URL, error) {
+	if proxy, err := http.ProxyFromEnvironment(req); proxy != nil || err != nil {
+		return proxy, err
+	}
+	return systemProxy(req)
+}
+
+// bypassProxy reports whether host matches a proxy exception list in the
+// Windows format: entries separated by ";", "*" wildcards, and "<local>" for
+// host names without a dot.
+func bypassProxy(host, exceptions string) bool {
+	host = strings.ToLower(host)
+	for _, entry := range strings.Split(exceptions, ";") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		switch {
+		case entry == "":
+		case entry == "<local>":
+			if !strings.Contains(host, ".") {
+				return true
+			}
+		default:
+			if ok, _ := path.Match(entry, host); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseProxyServer picks the proxy for scheme from a Windows proxy server
+// setting, either "host:port" for all protocols or a list such as
+// "http=host:port;https=host:port".
+func parseProxyServer(server, scheme string) *url.URL {
+	var chosen string
+	for _, entry := range strings.Split(server, ";") {
+		entry = strings.TrimSpace(entry)
+		proto, addr, ok := strings.Cut(entry, "=")
+		if !ok {
+			chosen = entry
+			break
+		}
+		if strings.EqualFold(proto, scheme) {
+			chosen = addr
+			break
+		}
+		if strings.EqualFold(proto, "http") && chosen == "" {
+			chosen = addr
+		}
+	}
+	if chosen == "" {
+		return nil
+	}
+	if !strings.Contains(chosen, "://") {
+		chosen = "http://" + chosen
+	}
+	proxy, err := url.Parse(chosen)
+	if err != nil {
+		return nil
+	}
+	return proxy
+}
@@ -0,0 +1,14 @@
+//go:build !windows
+
+package nethelper
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// systemProxy finds no proxy; outside Windows, proxies are configured with
+// the environment variables SystemProxy already reads.
+func systemProxy(*http.Request) (*url.URL, error) {
+	return nil, nil
+}
@@ -0,0 +1,34 @@
+//go:build windows
+
+package nethelper
+
+import (
+	"net/http"
+	"net/url"
+
+	"golang.org/x/sys/windows/registry"
+)
+
+// systemProxy returns the manual proxy from the current user's Internet
+// Options. Automatic configuration scripts (PAC) are not evaluated.
+func systemProxy(req *http.Request) (*url.URL, error) {
+	key, err := registry.OpenKey(registry.CURRENT_USER,
+		`Software\Microsoft\Windows\CurrentVersion\Internet Settings`, registry.QUERY_VALUE)
+	if err != nil {
+		return nil, nil
+	}
+	defer key.Close()
+
+	if enabled, _, err := key.GetIntegerValue("ProxyEnable"); err != nil || enabled == 0 {
+		return nil, nil
+	}
+	server, _, err := key.GetStringValue("ProxyServer")
+	if err != nil || server == "" {
+		return nil, nil
+	}
+	exceptions, _, _ := key.GetStringValue("ProxyOverride")
+	if bypassProxy(req.URL.Hostname(), exceptions) {
+		return nil, nil
+	}
+	return parseProxyServer(server, req.URL.Scheme), nil
+}
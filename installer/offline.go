@@ -0,0 +1,86 @@
+package installer
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ErrNotInBundle is returned when a file is missing from the offline bundle
+// and network access is not allowed.
+var ErrNotInBundle = errors.New("file is not in the offline bundle")
+
+// OfflineBundle is a local directory holding the files an installer would
+// otherwise download, for air-gapped machines. Downloads made with a
+// nethelper.Client whose Bundle is set take their files from it.
+//
+// A file is found by its name (the last element of its URL unless the
+// download names it), or by its SHA-256 checksum as a lowercase hex file name,
+// at the top of the directory or in any subdirectory.
+type OfflineBundle struct {
+	Dir          string // Directory holding the bundled files
+	AllowNetwork bool   // Download files that are missing from the bundle
+}
+
+var (
+	offlineMu     sync.Mutex
+	offlineBundle *OfflineBundle
+)
+
+// SetOfflineBundle makes b the bundle of the install for the rest of the
+// process (see CurrentOfflineBundle), or clears it if b is nil. App sets it
+// from the -bundle and -online flags, and Manifest.Run from the manifest's
+// offline section unless a flag already did.
+func SetOfflineBundle(b *OfflineBundle) {
+	offlineMu.Lock()
+	defer offlineMu.Unlock()
+	offlineBundle = b
+}
+
+// CurrentOfflineBundle returns the bundle set by SetOfflineBundle, or nil.
+// Pass it to nethelper.Client.Bundle so downloads use it.
+func CurrentOfflineBundle() *OfflineBundle {
+	offlineMu.Lock()
+	defer offlineMu.Unlock()
+	return offlineBundle
+}
+
+// Find returns the path of the bundled file called name or, if sha256 is not
+// empty, named by that checksum. It fails with ErrNotInBundle if there is
+// neither.
+func (b *OfflineBundle) Find(name, sha256 string) (string, error) {
+	var candidates []string
+	if name != "" {
+		candidates = append(candidates, name)
+	}
+	if sha256 != "" {
+		candidates = append(candidates, strings.ToLower(sha256))
+	}
+	for _, candidate := range candidates {
+		path := filepath.Join(b.Dir, candidate)
+		if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() {
+			return path, nil
+		}
+	}
+
+	var found string
+	filepath.WalkDir(b.Dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil // unreadable entries can't hold the file
+		}
+		if d.Type().IsRegular() && slices.Contains(candidates, d.Name()) {
+			found = path
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	if found == "" {
+		return "", fmt.Errorf("%w: %s (%s)", ErrNotInBundle, cmp.Or(name, sha256), b.Dir)
+	}
+	return found, nil
+}
@@ -0,0 +1,255 @@
+package installer
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/crafted-tech/webflow"
+	"github.com/crafted-tech/webflow/platform"
+)
+
+// Requirement is one row of a system requirements check.
+type Requirement struct {
+	Name string // Row label, e.g. "Memory", or a translation key (see webflow.TKey)
+
+	// Check returns a detail shown when the requirement is met (e.g. "16 GB"),
+	// or an error explaining why it is not. Both may be translated when shown
+	// (see webflow.TKey and webflow.LocalizedError).
+	Check func() (string, error)
+
+	// Optional requirements that fail are shown as warnings and don't block
+	// the install, e.g. a recommended amount of memory.
+	Optional bool
+}
+
+// RequirementResult is the outcome of checking one Requirement.
+type RequirementResult struct {
+	Requirement Requirement
+	Detail      string // Check's detail when met
+	Err         error  // Why the requirement is not met (nil = met)
+}
+
+// CheckRequirements runs the checks of reqs in order.
+func CheckRequirements(reqs []Requirement) []RequirementResult {
+	results := make([]RequirementResult, len(reqs))
+	for i, req := range reqs {
+		detail, err := req.Check()
+		results[i] = RequirementResult{Requirement: req, Detail: detail, Err: err}
+	}
+	return results
+}
+
+// RequirementsMet reports whether every requirement that is not Optional is met.
+func RequirementsMet(results []RequirementResult) bool {
+	return !slices.ContainsFunc(results, func(r RequirementResult) bool {
+		return r.Err != nil && !r.Requirement.Optional
+	})
+}
+
+// ShowRequirements checks reqs and shows a "System Requirements" page with a
+// row per requirement: met, a warning for Optional ones that aren't, or an
+// error. Next is disabled while a required one is not met; "Check again"
+// re-runs the checks, e.g. after the user frees disk space. Returns the
+// page's response (Next, Back or Close).
+//
+// Example:
+//
+//	resp := installer.ShowRequirements(ui, []installer.Requirement{
+//	    installer.RequireArch("amd64", "arm64"),
+//	    installer.RequireWindowsVersion(platform.Windows10v1809),
+//	    installer.RequireMemory(4 << 30),
+//	    installer.RequireDiskSpace(targetDir, 500 << 20),
+//	    installer.RequireAdmin(),
+//	})
+//	if webflow.IsBack(resp) { ... }
+func ShowRequirements(ui *webflow.Flow, reqs []Requirement, opts ...webflow.PageOption) any {
+	for {
+		results := CheckRequirements(reqs)
+		met := RequirementsMet(results)
+
+		fields := make([]webflow.FormField, len(results))
+		warnings := false
+		for i, r := range results {
+			alert, text := webflow.AlertSuccess, ui.Translate(r.Detail)
+			if r.Err != nil {
+				alert, text = webflow.AlertError, ui.ErrorText(r.Err)
+				if r.Requirement.Optional {
+					alert = webflow.AlertWarning
+					warnings = true
+				}
+			}
+			label := ui.Translate(r.Requirement.Name)
+			if text != "" {
+				label += ": " + text
+			}
+			fields[i] = webflow.FormField{
+				ID:        "requirement_" + strconv.Itoa(i),
+				Type:      webflow.FieldInfo,
+				Label:     label,
+				AlertType: alert,
+			}
+		}
+
+		subtitle := ui.T("requirements.met")
+		switch {
+		case !met:
+			subtitle = ui.T("requirements.notMet")
+		case warnings:
+			subtitle = ui.T("requirements.warnings")
+		}
+		bb := webflow.WizardMiddle()
+		if !met {
+			bb.Next = bb.Next.Disabled()
+		}
+		bb.Left = webflow.NewButton(ui.T("requirements.recheck"), "recheck")
+
+		pageOpts := append([]webflow.PageOption{
+			webflow.WithSubtitle(subtitle),
+			webflow.WithButtonBar(bb),
+		}, opts...)
+		resp := ui.ShowForm(ui.T("requirements.title"), fields, pageOpts...)
+		if !webflow.IsButton(resp, "recheck") {
+			return resp
+		}
+	}
+}
+
+// notMet returns the error of a requirement that isn't met.
+func notMet(key string, args ...any) error {
+	return &webflow.LocalizedError{Key: key, Args: args}
+}
+
+// RequireArch requires the computer's processor to be one of archs, given as
+// GOARCH values ("amd64", "arm64", "386"). The native architecture counts,
+// not the installer's: an amd64 installer emulated on ARM64 Windows sees "arm64".
+func RequireArch(archs ...string) Requirement {
+	return Requirement{
+		Name: webflow.TKey("requirements.arch"),
+		Check: func() (string, error) {
+			arch := platform.Arch()
+			if slices.Contains(archs, arch) {
+				return arch, nil
+			}
+			return "", notMet("requirements.needed", arch, strings.Join(archs, ", "))
+		},
+	}
+}
+
+// RequireMemory requires at least minBytes of physical memory.
+func RequireMemory(minBytes uint64) Requirement {
+	return Requirement{
+		Name: webflow.TKey("requirements.memory"),
+		Check: func() (string, error) {
+			total, err := platform.TotalMemory()
+			if err != nil {
+				return "", err
+			}
+			if total < minBytes {
+				return "", notMet("requirements.needed", webflow.FormatBytes(int64(total)), webflow.FormatBytes(int64(minBytes)))
+			}
+			return webflow.FormatBytes(int64(total)), nil
+		},
+	}
+}
+
+// RequireDiskSpace requires at least minBytes free on the volume of path,
+// which need not exist yet.
+func RequireDiskSpace(path string, minBytes uint64) Requirement {
+	return Requirement{
+		Name: webflow.TKey("requirements.disk"),
+		Check: func() (string, error) {
+			free, err := platform.FreeDiskSpace(path)
+			if err != nil {
+				return "", err
+			}
+			if free < minBytes {
+				return "", notMet("requirements.needed", webflow.FormatBytes(int64(free)), webflow.FormatBytes(int64(minBytes)))
+			}
+			return webflow.TKey("requirements.free", webflow.FormatBytes(int64(free))), nil
+		},
+	}
+}
+
+// RequireWindowsVersion requires at least Windows version v. It is always met
+// on other platforms.
+func RequireWindowsVersion(v platform.WindowsVersion) Requirement {
+	return Requirement{
+		Name: webflow.TKey("requirements.os"),
+		Check: func() (string, error) {
+			if err := platform.CheckWindowsVersion(v); err != nil {
+				return "", notMet("requirements.needed", platform.GetWindowsVersionString(), v.Name)
+			}
+			return platform.GetWindowsVersionString(), nil
+		},
+	}
+}
+
+// RequireGPU requires a hardware graphics adapter; software renderers such
+// as Microsoft Basic Display Adapter don't count.
+func RequireGPU() Requirement {
+	return Requirement{
+		Name: webflow.TKey("requirements.gpu"),
+		Check: func() (string, error) {
+			names := platform.GPUNames()
+			if len(names) == 0 {
+				return "", notMet("requirements.gpuNotMet")
+			}
+			return strings.Join(names, ", "), nil
+		},
+	}
+}
+
+// RequireDotNet requires a .NET version: "4.x" versions check the .NET
+// Framework (Windows only), others the .NET runtime, matching its major and
+// minor version and accepting later patches, e.g. "8.0" or "6.0.5". Use
+// RequireDotNetRuntime for a runtime other than Microsoft.NETCore.App.
+func RequireDotNet(version string) Requirement {
+	if strings.HasPrefix(version, "4.") {
+		return Requirement{
+			Name: webflow.TKey("requirements.dotnet", "Framework "+version),
+			Check: func() (string, error) {
+				installed := platform.DotNetFrameworkVersion()
+				if installed == "" || CompareVersions(installed, version) < 0 {
+					return "", notMet("requirements.notInstalled")
+				}
+				return installed, nil
+			},
+		}
+	}
+	return RequireDotNetRuntime("Microsoft.NETCore.App", version)
+}
+
+// RequireDotNetRuntime requires the .NET runtime name (e.g.
+// "Microsoft.WindowsDesktop.App", "Microsoft.AspNetCore.App") in a version
+// with the same major and minor as version and at least its patch.
+func RequireDotNetRuntime(name, version string) Requirement {
+	return Requirement{
+		Name: webflow.TKey("requirements.dotnet", version),
+		Check: func() (string, error) {
+			want := parseVersion(version)
+			for _, rt := range platform.DotNetRuntimes() {
+				have := parseVersion(rt.Version)
+				if rt.Name == name && len(have) >= 2 && len(want) >= 2 &&
+					have[0] == want[0] && have[1] == want[1] && CompareVersions(rt.Version, version) >= 0 {
+					return rt.Version, nil
+				}
+			}
+			return "", notMet("requirements.notInstalled")
+		},
+	}
+}
+
+// RequireAdmin requires the installer to run with administrator (root)
+// rights.
+func RequireAdmin() Requirement {
+	return Requirement{
+		Name: webflow.TKey("requirements.admin"),
+		Check: func() (string, error) {
+			if !platform.IsElevated() {
+				return "", notMet("requirements.adminNotMet")
+			}
+			return "", nil
+		},
+	}
+}
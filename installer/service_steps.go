@@ -1,7 +1,9 @@
 package installer
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/crafted-tech/webflow/platform"
 )
@@ -20,7 +22,7 @@ func StepStopService(name string) Step {
 				return Skipped("not running")
 			}
 			if err := platform.StopService(name); err != nil {
-				return Failed(err)
+				return Failed(serviceError(err))
 			}
 			return Success("")
 		},
@@ -28,7 +30,8 @@ func StepStopService(name string) Step {
 }
 
 // StepStartService creates a Step that starts a Windows service.
-// Skips if the service is already running.
+// Skips if the service is already running. On failure the error includes the
+// service's recent log output.
 func StepStartService(name string) Step {
 	return Step{
 		Name: fmt.Sprintf("Start %s service", name),
@@ -41,7 +44,10 @@ func StepStartService(name string) Step {
 				return Skipped("already running")
 			}
 			if err := platform.StartService(name); err != nil {
-				return Failed(err)
+				if errors.Is(err, platform.ErrInsufficientPrivileges) {
+					return Failed(serviceError(err))
+				}
+				return Failed(withServiceLogs(name, err))
 			}
 			return Success("")
 		},
@@ -60,7 +66,8 @@ func StepInstallService(name, displayName, exePath, args string) Step {
 }
 
 // StepInstallServiceWithConfig creates a Step that installs a Windows service with full configuration.
-// Skips if the service already exists.
+// Skips if the service already exists. If it exists but points at a different
+// executable or arguments (e.g. the install directory changed), the path is updated.
 func StepInstallServiceWithConfig(cfg platform.ServiceConfig) Step {
 	return Step{
 		Name: fmt.Sprintf("Install %s service", cfg.Name),
@@ -70,10 +77,17 @@ func StepInstallServiceWithConfig(cfg platform.ServiceConfig) Step {
 				return Failed(err)
 			}
 			if exists {
+				updated, err := platform.UpdateServiceExecutable(cfg.Name, cfg.Executable, cfg.Args)
+				if err != nil {
+					return Failed(serviceError(err))
+				}
+				if updated {
+					return Success("updated executable path")
+				}
 				return Skipped("already installed")
 			}
 			if err := platform.InstallServiceWithConfig(cfg); err != nil {
-				return Failed(err)
+				return Failed(serviceError(err))
 			}
 			return Success("")
 		},
@@ -94,9 +108,28 @@ func StepUninstallService(name string) Step {
 				return Skipped("not installed")
 			}
 			if err := platform.UninstallService(name); err != nil {
-				return Failed(err)
+				return Failed(serviceError(err))
 			}
 			return Success("")
 		},
 	}
 }
+
+// serviceError marks missing privileges as ErrElevationRequired so RunSteps
+// can show an elevation message instead of the raw error.
+func serviceError(err error) error {
+	if errors.Is(err, platform.ErrInsufficientPrivileges) {
+		return fmt.Errorf("%w: %w", ErrElevationRequired, err)
+	}
+	return err
+}
+
+// withServiceLogs appends the service's most recent log output to a start
+// failure, so the error shown on the failure page explains why it didn't start.
+func withServiceLogs(name string, err error) error {
+	lines, logErr := platform.ServiceLogs(name, 10)
+	if logErr != nil || len(lines) == 0 {
+		return err
+	}
+	return fmt.Errorf("%w\n\nRecent service log:\n%s", err, strings.Join(lines, "\n"))
+}
@@ -0,0 +1,66 @@
+package installer
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/crafted-tech/webflow"
+	"github.com/crafted-tech/webflow/platform"
+)
+
+// ShowOtherUsersWarning shows a warning page if other users are signed in to
+// the computer (see platform.OtherLoggedOnUsers), locally, over Remote
+// Desktop or SSH, or in a disconnected session. Restarting services and
+// replacing per-machine files can disrupt what they are doing, so the page
+// lists them and asks to have them sign out first. A Recheck button lists
+// the sessions again. Uses WizardMiddle() unless opts set a ButtonBar.
+//
+// Returns nil without showing a page if no other user is signed in, or once
+// they all signed out; otherwise the page's response (Next, Back or Close).
+//
+// Example:
+//
+//	if resp := installer.ShowOtherUsersWarning(ui); webflow.IsBack(resp) {
+//	    continue
+//	}
+func ShowOtherUsersWarning(ui *webflow.Flow, opts ...webflow.PageOption) any {
+	for {
+		others := platform.OtherLoggedOnUsers()
+		if len(others) == 0 {
+			return nil
+		}
+
+		var names []string
+		for _, u := range others {
+			name := u.Username
+			switch {
+			case !u.Active:
+				name = ui.TF("users.disconnected", name)
+			case u.Remote:
+				name = ui.TF("users.remote", name)
+			}
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+
+		fields := []webflow.FormField{{
+			ID:        "users_warning",
+			Type:      webflow.FieldInfo,
+			Label:     ui.T("users.message") + "\n" + strings.Join(names, "\n"),
+			AlertType: webflow.AlertWarning,
+		}, {
+			ID:    "users_hint",
+			Type:  webflow.FieldInfo,
+			Label: ui.T("users.hint"),
+		}}
+
+		bb := webflow.WizardMiddle()
+		bb.Left = webflow.NewButton(ui.T("requirements.recheck"), "recheck")
+		pageOpts := append([]webflow.PageOption{webflow.WithButtonBar(bb)}, opts...)
+		resp := ui.ShowForm(ui.T("users.title"), fields, pageOpts...)
+		if !webflow.IsButton(resp, "recheck") {
+			return resp
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package installer
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// ErrBadSignature is returned when a payload does not match its detached signature.
+var ErrBadSignature = errors.New("signature verification failed")
+
+// minisign algorithm identifiers.
+const (
+	minisignAlgLegacy    = "Ed" // Signature over the raw payload
+	minisignAlgPrehashed = "ED" // Signature over BLAKE2b-512 of the payload
+)
+
+// VerifySignature checks a payload file against a detached minisign signature.
+// sigFile is the .minisig file (payload + ".minisig" if empty). publicKey is the
+// base64 public key as printed by `minisign -G`, or the full contents of a
+// minisign.pub file. Both legacy and prehashed signatures are accepted, and
+// the trusted comment is authenticated as well.
+//
+// Example:
+//
+//	const releaseKey = "RWQf6LRCGA9i53mlYecO4IzT51TGPpvWucNSCh1CBM0QTaLn73Y7GFO3"
+//	if err := installer.VerifySignature(archive, "", releaseKey); err != nil {
+//	    return err
+//	}
+func VerifySignature(payload, sigFile, publicKey string) error {
+	if sigFile == "" {
+		sigFile = payload + ".minisig"
+	}
+
+	keyID, pub, err := parseMinisignPublicKey(publicKey)
+	if err != nil {
+		return err
+	}
+
+	sigData, err := os.ReadFile(sigFile)
+	if err != nil {
+		return fmt.Errorf("read signature: %w", err)
+	}
+	sig, err := parseMinisignSignature(sigData)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(sig.keyID, keyID) {
+		return fmt.Errorf("%w: signed with a different key", ErrBadSignature)
+	}
+
+	f, err := os.Open(payload)
+	if err != nil {
+		return fmt.Errorf("open payload: %w", err)
+	}
+	defer f.Close()
+
+	var message []byte
+	if sig.algorithm == minisignAlgPrehashed {
+		h, _ := blake2b.New512(nil)
+		if _, err := io.Copy(h, f); err != nil {
+			return fmt.Errorf("read payload: %w", err)
+		}
+		message = h.Sum(nil)
+	} else {
+		if message, err = io.ReadAll(f); err != nil {
+			return fmt.Errorf("read payload: %w", err)
+		}
+	}
+
+	if !ed25519.Verify(pub, message, sig.signature) {
+		return ErrBadSignature
+	}
+
+	// The global signature covers the signature and trusted comment,
+	// so the comment (often a timestamp and file name) can't be swapped.
+	global := append(append([]byte{}, sig.signature...), sig.trustedComment...)
+	if !ed25519.Verify(pub, global, sig.globalSignature) {
+		return fmt.Errorf("%w: trusted comment", ErrBadSignature)
+	}
+	return nil
+}
+
+// StepVerifySignature creates a Step that verifies a payload against its
+// detached minisign signature before it is used. See VerifySignature.
+func StepVerifySignature(payload, sigFile, publicKey string) Step {
+	return Step{
+		Name: fmt.Sprintf("Verify %s", filepath.Base(payload)),
+		Action: func() StepResult {
+			if err := VerifySignature(payload, sigFile, publicKey); err != nil {
+				return Failed(err)
+			}
+			return Success("")
+		},
+	}
+}
+
+type minisignSignature struct {
+	algorithm       string
+	keyID           []byte
+	signature       []byte
+	trustedComment  []byte
+	globalSignature []byte
+}
+
+// parseMinisignPublicKey decodes a public key: 2-byte algorithm, 8-byte key ID
+// and 32-byte Ed25519 key. Comment lines are ignored.
+func parseMinisignPublicKey(s string) (keyID []byte, pub ed25519.PublicKey, err error) {
+	var encoded string
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "untrusted comment:") {
+			encoded = line
+			break
+		}
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(raw) != 2+8+ed25519.PublicKeySize {
+		return nil, nil, fmt.Errorf("invalid public key")
+	}
+	if string(raw[:2]) != minisignAlgLegacy {
+		return nil, nil, fmt.Errorf("unsupported public key algorithm %q", raw[:2])
+	}
+	return raw[2:10], ed25519.PublicKey(raw[10:]), nil
+}
+
+// parseMinisignSignature decodes the four-line .minisig format:
+//
+//	untrusted comment: ...
+//	base64(algorithm | key ID | signature)
+//	trusted comment: ...
+//	base64(global signature)
+func parseMinisignSignature(data []byte) (*minisignSignature, error) {
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	if len(lines) < 4 {
+		return nil, fmt.Errorf("invalid signature file")
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[1]))
+	if err != nil || len(raw) != 2+8+ed25519.SignatureSize {
+		return nil, fmt.Errorf("invalid signature")
+	}
+	algorithm := string(raw[:2])
+	if algorithm != minisignAlgLegacy && algorithm != minisignAlgPrehashed {
+		return nil, fmt.Errorf("unsupported signature algorithm %q", algorithm)
+	}
+
+	comment, ok := strings.CutPrefix(lines[2], "trusted comment: ")
+	if !ok {
+		return nil, fmt.Errorf("invalid signature file: missing trusted comment")
+	}
+
+	global, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[3]))
+	if err != nil || len(global) != ed25519.SignatureSize {
+		return nil, fmt.Errorf("invalid global signature")
+	}
+
+	return &minisignSignature{
+		algorithm:       algorithm,
+		keyID:           raw[2:10],
+		signature:       raw[10:],
+		trustedComment:  []byte(comment),
+		globalSignature: global,
+	}, nil
+}
@@ -0,0 +1,143 @@
+package installer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// ExitCode is a process exit code following the Windows Installer
+// conventions, which SCCM, Intune and most deployment tools interpret
+// without further configuration.
+type ExitCode int
+
+const (
+	ExitSuccess           ExitCode = 0    // Installed successfully
+	ExitElevationRequired ExitCode = 740  // ERROR_ELEVATION_REQUIRED: must run as administrator
+	ExitUserCancelled     ExitCode = 1602 // ERROR_INSTALL_USEREXIT: cancelled by the user
+	ExitFailure           ExitCode = 1603 // ERROR_INSTALL_FAILURE: fatal error during installation
+	ExitAlreadyRunning    ExitCode = 1618 // ERROR_INSTALL_ALREADY_RUNNING: another install is in progress
+	ExitInvalidCommand    ExitCode = 1639 // ERROR_INVALID_COMMAND_LINE: bad command-line arguments
+	ExitUnsupported       ExitCode = 1633 // ERROR_INSTALL_PLATFORM_UNSUPPORTED: wrong OS or architecture
+	ExitRebootInitiated   ExitCode = 1641 // ERROR_SUCCESS_REBOOT_INITIATED: succeeded, reboot started
+	ExitRebootRequired    ExitCode = 3010 // ERROR_SUCCESS_REBOOT_REQUIRED: succeeded, reboot needed
+)
+
+// ExitCodeFor returns the exit code for the error returned by an install:
+// ExitSuccess for nil, ExitUserCancelled for ErrCancelled,
+// ExitElevationRequired for ErrElevationRequired and ExitFailure otherwise.
+func ExitCodeFor(err error) ExitCode {
+	switch {
+	case err == nil:
+		return ExitSuccess
+	case errors.Is(err, ErrCancelled):
+		return ExitUserCancelled
+	case errors.Is(err, ErrElevationRequired):
+		return ExitElevationRequired
+	default:
+		return ExitFailure
+	}
+}
+
+// Install results reported in Status.Result.
+const (
+	ResultSuccess   = "success"
+	ResultFailed    = "failed"
+	ResultCancelled = "cancelled"
+)
+
+// Status is the machine-readable outcome of an install, written with
+// WriteStatusFile so deployment tools can report results without parsing
+// logs. Set RunConfig.Status to have RunStepsWithConfig fill it in; steps
+// that need a reboot call RequireReboot.
+//
+// Example:
+//
+//	status := &installer.Status{LogPath: log.Path()}
+//	err := installer.RunStepsWithConfig(ui, "Installing...", steps, installer.RunConfig{
+//	    Logger: log,
+//	    Status: status,
+//	})
+//	installer.WriteStatusFile(*statusPath, status)
+//	os.Exit(int(status.ExitCode))
+type Status struct {
+	mu sync.Mutex
+
+	Result      string    `json:"result"`               // ResultSuccess, ResultFailed or ResultCancelled
+	ExitCode    ExitCode  `json:"exitCode"`             // Exit code for the result (see ExitCodeFor)
+	FailedStep  string    `json:"failedStep,omitempty"` // Name of the step that stopped the install
+	Error       string    `json:"error,omitempty"`      // Error message of a failed install
+	LogPath     string    `json:"logPath,omitempty"`    // Full install log
+	NeedsReboot bool      `json:"needsReboot"`          // A reboot is required to complete the install
+	Finished    time.Time `json:"finished"`
+}
+
+// RequireReboot marks that a reboot is needed to complete the install.
+// It is safe to call from steps.
+func (s *Status) RequireReboot() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.NeedsReboot = true
+	if s.ExitCode == ExitSuccess && s.Result == ResultSuccess {
+		s.ExitCode = ExitRebootRequired
+	}
+}
+
+// SetError records the outcome of an install from its error, for installs
+// that don't run through RunStepsWithConfig or that fail before it.
+func (s *Status) SetError(err error) {
+	s.finish(err, "", nil)
+}
+
+// finish records the outcome of a run.
+func (s *Status) finish(err error, failedStep string, log *Logger) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.Finished = time.Now()
+	s.FailedStep = failedStep
+	s.Error = ""
+	s.ExitCode = ExitCodeFor(err)
+	switch {
+	case err == nil:
+		s.Result = ResultSuccess
+		if s.NeedsReboot {
+			s.ExitCode = ExitRebootRequired
+		}
+	case errors.Is(err, ErrCancelled):
+		s.Result = ResultCancelled
+	default:
+		s.Result = ResultFailed
+		s.Error = err.Error()
+	}
+	if s.LogPath == "" && log != nil {
+		s.LogPath = log.Path()
+	}
+}
+
+// WriteStatusFile writes status as JSON to path, e.g.:
+//
+//	{
+//	  "result": "failed",
+//	  "exitCode": 1603,
+//	  "failedStep": "Install service",
+//	  "error": "service already installed",
+//	  "logPath": "C:\\Users\\me\\AppData\\Local\\Temp\\myapp-install-20240101-120000.log",
+//	  "needsReboot": false,
+//	  "finished": "2024-01-01T12:00:42Z"
+//	}
+func WriteStatusFile(path string, status *Status) error {
+	status.mu.Lock()
+	data, err := json.MarshalIndent(status, "", "  ")
+	status.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("write status file: %w", err)
+	}
+	return nil
+}
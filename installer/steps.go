@@ -1,7 +1,10 @@
 package installer
 
 import (
+	"errors"
 	"fmt"
+	"path/filepath"
+	"strings"
 
 	"github.com/crafted-tech/webflow/platform"
 )
@@ -81,3 +84,177 @@ func StepScheduleFileDelete(path string) Step {
 		},
 	}
 }
+
+// StepEnableAutostart creates a Step that registers the application to start
+// when the current user logs in. An existing registration is overwritten so
+// a changed path or arguments take effect.
+func StepEnableAutostart(appName, exePath, args string) Step {
+	return Step{
+		Name: fmt.Sprintf("Start %s at login", appName),
+		Action: func() StepResult {
+			if err := platform.EnableAutostart(appName, exePath, args); err != nil {
+				return Failed(err)
+			}
+			return Success("")
+		},
+	}
+}
+
+// StepDisableAutostart creates a Step that removes the application's login item.
+// Skips if autostart is not enabled.
+func StepDisableAutostart(appName string) Step {
+	return Step{
+		Name: fmt.Sprintf("Remove %s login item", appName),
+		Action: func() StepResult {
+			enabled, err := platform.IsAutostartEnabled(appName)
+			if err != nil {
+				return Failed(err)
+			}
+			if !enabled {
+				return Skipped("not enabled")
+			}
+			if err := platform.DisableAutostart(appName); err != nil {
+				return Failed(err)
+			}
+			return Success("")
+		},
+	}
+}
+
+// StepVerifyCodeSignature creates a Step that refuses to continue unless the
+// file has a valid code signature from expectedSubject (any trusted signer if empty).
+// Skips on platforms without system code signing (Linux). For downloaded
+// payloads, prefer nethelper's Download.CodeSigned, which checks the file
+// before it reaches its destination.
+func StepVerifyCodeSignature(path, expectedSubject string) Step {
+	return Step{
+		Name: fmt.Sprintf("Verify signature of %s", filepath.Base(path)),
+		Action: func() StepResult {
+			err := platform.VerifyCodeSignature(path, expectedSubject)
+			if errors.Is(err, platform.ErrSignatureUnsupported) {
+				return Skipped("not supported on this platform")
+			}
+			if err != nil {
+				return Failed(fmt.Errorf("verify signature: %w", err))
+			}
+			return Success("")
+		},
+	}
+}
+
+// StepAddHostsEntry creates a Step that maps hostnames to ip in the system
+// hosts file (see platform.AddHostsEntry). Skips if all are mapped already.
+// Undo removes only the names the step added.
+func StepAddHostsEntry(ip string, hostnames ...string) Step {
+	return stepAddHostsEntry(ip, hostnames, nil)
+}
+
+// stepAddHostsEntry is StepAddHostsEntry, calling record, if set, with the
+// names it added.
+func stepAddHostsEntry(ip string, hostnames []string, record func(added []string)) Step {
+	var added []string
+	return Step{
+		Name: fmt.Sprintf("Add %s to hosts file", strings.Join(hostnames, ", ")),
+		Action: func() StepResult {
+			added = nil
+			for _, name := range hostnames {
+				ok, err := platform.HasHostsEntry(ip, name)
+				if err != nil {
+					return Failed(fmt.Errorf("read hosts file: %w", err))
+				}
+				if !ok {
+					added = append(added, name)
+				}
+			}
+			if len(added) == 0 {
+				return Skipped("already mapped to " + ip)
+			}
+			if err := platform.AddHostsEntry(ip, added...); err != nil {
+				added = nil
+				return Failed(fmt.Errorf("add hosts entry: %w", err))
+			}
+			if record != nil {
+				record(added)
+			}
+			return Success("")
+		},
+		Undo: func() error {
+			if len(added) == 0 {
+				return nil
+			}
+			return platform.RemoveHostsEntry(ip, added...)
+		},
+	}
+}
+
+// StepRemoveHostsEntry creates a Step that removes the mappings of hostnames
+// to ip from the system hosts file. Skips if none of them are mapped.
+func StepRemoveHostsEntry(ip string, hostnames ...string) Step {
+	return Step{
+		Name: fmt.Sprintf("Remove %s from hosts file", strings.Join(hostnames, ", ")),
+		Action: func() StepResult {
+			found := false
+			for _, name := range hostnames {
+				ok, err := platform.HasHostsEntry(ip, name)
+				if err != nil {
+					return Failed(fmt.Errorf("read hosts file: %w", err))
+				}
+				found = found || ok
+			}
+			if !found {
+				return Skipped("not in hosts file")
+			}
+			if err := platform.RemoveHostsEntry(ip, hostnames...); err != nil {
+				return Failed(fmt.Errorf("remove hosts entry: %w", err))
+			}
+			return Success("")
+		},
+	}
+}
+
+// StepAddDefenderExclusion creates a Step that excludes path from Microsoft
+// Defender scanning (see platform.AddDefenderExclusion). Skips on other
+// platforms, and when another antivirus product is active, since Defender
+// then doesn't scan and may refuse the change. Undo removes the exclusion.
+func StepAddDefenderExclusion(path string) Step {
+	return Step{
+		Name: fmt.Sprintf("Exclude %s from antivirus scans", filepath.Base(path)),
+		Action: func() StepResult {
+			if others := platform.ThirdPartyAntivirus(); len(others) > 0 {
+				return Skipped(fmt.Sprintf("%s is active instead of Defender", others[0].Name))
+			}
+			err := platform.AddDefenderExclusion(path)
+			if errors.Is(err, platform.ErrDefenderUnsupported) {
+				return Skipped("not supported on this platform")
+			}
+			if err != nil {
+				return Failed(fmt.Errorf("add Defender exclusion: %w", err))
+			}
+			return Success(path)
+		},
+		Undo: func() error {
+			if err := platform.RemoveDefenderExclusion(path); err != nil && !errors.Is(err, platform.ErrDefenderUnsupported) {
+				return err
+			}
+			return nil
+		},
+	}
+}
+
+// StepRemoveDefenderExclusion creates a Step that removes a Defender
+// exclusion added by StepAddDefenderExclusion. Skips on other platforms.
+func StepRemoveDefenderExclusion(path string) Step {
+	return Step{
+		Name: fmt.Sprintf("Remove antivirus exclusion for %s", filepath.Base(path)),
+		Action: func() StepResult {
+			err := platform.RemoveDefenderExclusion(path)
+			if errors.Is(err, platform.ErrDefenderUnsupported) {
+				return Skipped("not supported on this platform")
+			}
+			if err != nil {
+				return Failed(fmt.Errorf("remove Defender exclusion: %w", err))
+			}
+			return Success("")
+		},
+	}
+}
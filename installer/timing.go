@@ -0,0 +1,270 @@
+package installer
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/crafted-tech/webflow"
+)
+
+// StepTiming is the recorded duration of one step.
+type StepTiming struct {
+	Name     string
+	Duration time.Duration
+	Skipped  bool  // Step reported Skipped
+	Err      error // Non-nil if the step failed
+}
+
+// TimingReport collects per-step durations during RunStepsWithConfig, to show
+// where install time goes. Set RunConfig.Timings to a new report to fill it.
+//
+// Example:
+//
+//	timings := &installer.TimingReport{}
+//	err := installer.RunStepsWithConfig(ui, "Installing...", steps, installer.RunConfig{
+//	    Logger:  log,
+//	    Timings: timings,
+//	})
+//	log.Info("%s", timings)
+type TimingReport struct {
+	mu    sync.Mutex
+	steps []StepTiming
+	total time.Duration
+}
+
+// record appends a step timing.
+func (r *TimingReport) record(t StepTiming) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.steps = append(r.steps, t)
+}
+
+// finish sets the wall-clock duration of the whole run.
+func (r *TimingReport) finish(total time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.total = total
+}
+
+// Steps returns the recorded timings in execution order.
+func (r *TimingReport) Steps() []StepTiming {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.steps)
+}
+
+// Total returns the wall-clock duration of the run, including UI overhead
+// between steps.
+func (r *TimingReport) Total() time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.total
+}
+
+// Slowest returns up to n timings, longest first.
+func (r *TimingReport) Slowest(n int) []StepTiming {
+	steps := r.Steps()
+	slices.SortStableFunc(steps, func(a, b StepTiming) int {
+		return cmp.Compare(b.Duration, a.Duration)
+	})
+	if n < len(steps) {
+		steps = steps[:n]
+	}
+	return steps
+}
+
+// String formats the report as a table of steps, longest first, with each
+// step's share of the total time.
+func (r *TimingReport) String() string {
+	steps := r.Slowest(len(r.Steps()))
+	total := r.Total()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Install timing (total %s):\n", total.Round(time.Millisecond))
+	for _, s := range steps {
+		share := 0.0
+		if total > 0 {
+			share = float64(s.Duration) / float64(total) * 100
+		}
+		status := ""
+		switch {
+		case s.Err != nil:
+			status = " [failed]"
+		case s.Skipped:
+			status = " [skipped]"
+		}
+		fmt.Fprintf(&b, "  %10s  %5.1f%%  %s%s\n", s.Duration.Round(time.Millisecond), share, s.Name, status)
+	}
+	return b.String()
+}
+
+// ShowTimingReport displays the report as a summary page, longest steps first.
+// Returns the navigation result of the page.
+func ShowTimingReport(ui *webflow.Flow, title string, r *TimingReport) any {
+	var items []webflow.SummaryItem
+	for _, s := range r.Slowest(len(r.Steps())) {
+		value := s.Duration.Round(time.Millisecond).String()
+		if total := r.Total(); total > 0 {
+			value = fmt.Sprintf("%s (%.0f%%)", value, float64(s.Duration)/float64(total)*100)
+		}
+		items = append(items, webflow.SummaryItem{Label: s.Name, Value: value})
+	}
+	items = append(items, webflow.SummaryItem{
+		Label: ui.T("timing.total"),
+		Value: r.Total().Round(time.Millisecond).String(),
+	})
+
+	return ui.ShowMessage(title, webflow.SummaryConfig{Items: items})
+}
+
+// TimingHistory stores step durations from previous runs so RunStepsWithConfig
+// can advance the progress bar in proportion to expected time instead of
+// step count. Durations are smoothed across runs; steps never seen before are
+// weighted with the average of the known ones.
+//
+// Example:
+//
+//	history, _ := installer.LoadTimingHistory(installer.TimingHistoryPath("myapp"))
+//	err := installer.RunStepsWithConfig(ui, "Installing...", steps, installer.RunConfig{
+//	    History: history, // Updated and saved after the run
+//	})
+type TimingHistory struct {
+	mu        sync.Mutex
+	path      string
+	durations map[string]time.Duration
+}
+
+// TimingHistoryPath returns the default history location for an app, next to
+// the resume journal.
+func TimingHistoryPath(appName string) string {
+	return filepath.Join(filepath.Dir(JournalPath(appName)), "step-timings.json")
+}
+
+// LoadTimingHistory loads step durations from path. A missing or unreadable
+// file yields an empty history, which weights all steps equally.
+func LoadTimingHistory(path string) (*TimingHistory, error) {
+	h := &TimingHistory{path: path, durations: make(map[string]time.Duration)}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return h, nil
+		}
+		return h, fmt.Errorf("read timing history: %w", err)
+	}
+
+	var ms map[string]int64
+	if err := json.Unmarshal(data, &ms); err != nil {
+		return h, fmt.Errorf("parse timing history: %w", err)
+	}
+	for name, v := range ms {
+		h.durations[name] = time.Duration(v) * time.Millisecond
+	}
+	return h, nil
+}
+
+// Estimate returns the expected duration of the named step, if known.
+func (h *TimingHistory) Estimate(name string) (time.Duration, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	d, ok := h.durations[name]
+	return d, ok
+}
+
+// Record folds a measured duration into the history. Recent runs count for
+// half, so the estimate follows changes without jumping on one outlier.
+func (h *TimingHistory) Record(name string, d time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if prev, ok := h.durations[name]; ok {
+		d = (prev + d) / 2
+	}
+	h.durations[name] = d
+}
+
+// Save writes the history back to its file.
+func (h *TimingHistory) Save() error {
+	h.mu.Lock()
+	ms := make(map[string]int64, len(h.durations))
+	for name, d := range h.durations {
+		ms[name] = d.Milliseconds()
+	}
+	h.mu.Unlock()
+
+	data, err := json.MarshalIndent(ms, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(h.path), 0755); err != nil {
+		return fmt.Errorf("create timing history directory: %w", err)
+	}
+	if err := os.WriteFile(h.path, data, 0644); err != nil {
+		return fmt.Errorf("write timing history: %w", err)
+	}
+	return nil
+}
+
+// weights returns the expected duration of each step, used as its share of
+// the progress bar. Unknown steps get the average of the known ones, and all
+// steps get equal weight when nothing is known.
+func (h *TimingHistory) weights(steps []Step) []time.Duration {
+	weights := make([]time.Duration, len(steps))
+	var known time.Duration
+	var count int
+	for i, step := range steps {
+		if d, ok := h.Estimate(step.Name); ok {
+			// Keep instant steps visible and avoid zero total weight
+			weights[i] = max(d, 10*time.Millisecond)
+			known += weights[i]
+			count++
+		}
+	}
+
+	fallback := time.Second
+	if count > 0 {
+		fallback = known / time.Duration(count)
+	}
+	for i := range weights {
+		if weights[i] == 0 {
+			weights[i] = fallback
+		}
+	}
+	return weights
+}
+
+// creepProgress moves the progress bar through a running step's share in real
+// time, based on its expected duration. It stops short of the step's end so the
+// bar never runs ahead of actual completion. The returned function stops the
+// updates and waits until no further Update calls can happen.
+func creepProgress(p webflow.Progress, status string, offset, expected, total time.Duration) func() {
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	start := time.Now()
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(250 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				within := min(time.Since(start), expected*95/100)
+				p.Update(float64(offset+within)/float64(total)*100, status)
+			}
+		}
+	}()
+
+	return func() {
+		close(stop)
+		<-done
+	}
+}
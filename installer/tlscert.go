@@ -0,0 +1,260 @@
+package installer
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/crafted-tech/webflow"
+	"github.com/crafted-tech/webflow/platform"
+)
+
+// Files written by GenerateSelfSignedCert.
+const (
+	TLSCertFile = "cert.pem" // Certificate, PEM
+	TLSKeyFile  = "key.pem"  // ECDSA P-256 private key, PEM (PKCS #8)
+)
+
+// certRenewBefore is how long before expiry StepGenerateSelfSignedCert
+// replaces an existing certificate instead of keeping it.
+const certRenewBefore = 30 * 24 * time.Hour
+
+// GenerateSelfSignedCert creates an ECDSA P-256 key and a self-signed TLS
+// server certificate for hosts, valid for validity (default one year), and
+// writes them to outDir as TLSCertFile and TLSKeyFile, replacing existing
+// ones. hosts are DNS names or IP addresses, all included as subject
+// alternative names; the first is also the common name. Without hosts the
+// certificate is for the computer's name, localhost, 127.0.0.1 and ::1.
+//
+// The key file is private to its owner (mode 0600); on Windows it gets a
+// protected DACL for SYSTEM, Administrators and the current user instead of
+// the permissions of outDir, which under C:\ProgramData let all users read
+// it. readers are accounts that may read the key as well, usually the
+// service's (see platform.SetPrivateFileACL). Keep validity at 825 days or
+// less: macOS and iOS reject longer-lived server certificates.
+func GenerateSelfSignedCert(hosts []string, outDir string, validity time.Duration, readers ...string) (*platform.Certificate, error) {
+	if len(hosts) == 0 {
+		hosts = defaultCertHosts()
+	}
+	if validity <= 0 {
+		validity = 365 * 24 * time.Hour
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate key: %w", err)
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("generate serial number: %w", err)
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: hosts[0]},
+		NotBefore:             now.Add(-time.Hour), // Tolerate clocks running slightly behind
+		NotAfter:              now.Add(validity),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, fmt.Errorf("create certificate: %w", err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("encode key: %w", err)
+	}
+
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return nil, fmt.Errorf("create certificate directory: %w", err)
+	}
+	keyPath := filepath.Join(outDir, TLSKeyFile)
+	if err := writePrivateFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), readers); err != nil {
+		return nil, fmt.Errorf("write key: %w", err)
+	}
+	certPath := filepath.Join(outDir, TLSCertFile)
+	if err := os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644); err != nil {
+		return nil, fmt.Errorf("write certificate: %w", err)
+	}
+	return platform.ParseCertificate(der)
+}
+
+// StepGenerateSelfSignedCert creates a Step that writes a self-signed TLS
+// certificate and key for hosts to outDir, the key readable by readers (see
+// GenerateSelfSignedCert).
+// Skips if outDir already has a certificate and key that cover hosts and
+// stay valid for another 30 days, so upgrades keep the certificate clients
+// already trust. The step's info is the certificate's SHA-256 fingerprint;
+// show it on the finish page with TLSCertSummary.
+func StepGenerateSelfSignedCert(hosts []string, outDir string, validity time.Duration, readers ...string) Step {
+	var created bool
+	return Step{
+		Name: "Generate TLS certificate",
+		Action: func() StepResult {
+			if cert, ok := reusableCert(hosts, outDir); ok {
+				return Skipped("existing certificate " + cert.Fingerprint)
+			}
+			cert, err := GenerateSelfSignedCert(hosts, outDir, validity, readers...)
+			if err != nil {
+				return Failed(err)
+			}
+			created = true
+			return Success(cert.Fingerprint)
+		},
+		Undo: func() error {
+			if !created {
+				return nil
+			}
+			return errors.Join(
+				removeIfExists(filepath.Join(outDir, TLSCertFile)),
+				removeIfExists(filepath.Join(outDir, TLSKeyFile)),
+			)
+		},
+	}
+}
+
+// TLSCertSummary returns the location, hosts, validity and fingerprint of
+// the certificate in outDir for a summary page in ui's language, so users
+// can compare the fingerprint when a client asks them to trust the
+// certificate.
+//
+// Example:
+//
+//	items, err := installer.TLSCertSummary(ui, certDir)
+//	if err == nil {
+//	    ui.ShowMessage(ui.T("finish.title"), webflow.SummaryConfig{Items: items})
+//	}
+func TLSCertSummary(ui *webflow.Flow, outDir string) ([]webflow.SummaryItem, error) {
+	path := filepath.Join(outDir, TLSCertFile)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, platform.ErrNoCertificate
+	}
+	x, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := platform.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	return []webflow.SummaryItem{
+		{Label: ui.T("summary.certificate"), Value: path},
+		{Label: ui.T("certificate.subject"), Value: strings.Join(certHosts(x), ", ")},
+		{Label: ui.T("certificate.validity"), Value: ui.TF("certificate.validityRange", cert.NotBefore, cert.NotAfter)},
+		{Label: ui.T("certificate.fingerprint"), Value: cert.Fingerprint},
+	}, nil
+}
+
+// reusableCert returns the certificate in outDir if it and its key exist,
+// it covers hosts and it is valid for at least certRenewBefore.
+func reusableCert(hosts []string, outDir string) (*platform.Certificate, bool) {
+	if len(hosts) == 0 {
+		hosts = defaultCertHosts()
+	}
+	if _, err := os.Stat(filepath.Join(outDir, TLSKeyFile)); err != nil {
+		return nil, false
+	}
+	data, err := os.ReadFile(filepath.Join(outDir, TLSCertFile))
+	if err != nil {
+		return nil, false
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, false
+	}
+	x, err := x509.ParseCertificate(block.Bytes)
+	if err != nil || time.Until(x.NotAfter) < certRenewBefore {
+		return nil, false
+	}
+	for _, host := range hosts {
+		if x.VerifyHostname(host) != nil {
+			return nil, false
+		}
+	}
+	cert, err := platform.ParseCertificate(block.Bytes)
+	return cert, err == nil
+}
+
+// certHosts returns the DNS names and IP addresses a certificate is for.
+func certHosts(cert *x509.Certificate) []string {
+	hosts := append([]string(nil), cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		hosts = append(hosts, ip.String())
+	}
+	if len(hosts) == 0 {
+		hosts = append(hosts, cert.Subject.CommonName)
+	}
+	return hosts
+}
+
+// defaultCertHosts returns the hosts of a certificate generated without
+// hosts: the computer's name, localhost and the loopback addresses.
+func defaultCertHosts() []string {
+	hosts := []string{"localhost", "127.0.0.1", "::1"}
+	if name, err := os.Hostname(); err == nil && name != "" && !strings.EqualFold(name, "localhost") {
+		hosts = append([]string{name}, hosts...)
+	}
+	return hosts
+}
+
+// writePrivateFile writes data to a new file only its owner and readers can
+// read. An existing file is removed first, since writing to it would keep its
+// permissions. The file is created empty and restricted before data is
+// written, so the key is never readable with the directory's permissions.
+func writePrivateFile(path string, data []byte, readers []string) error {
+	if err := removeIfExists(path); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	if err := platform.SetPrivateFileACL(path, readers...); err != nil {
+		f.Close()
+		os.Remove(path)
+		return err
+	}
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(path)
+	}
+	return err
+}
+
+// removeIfExists deletes a file, ignoring that it doesn't exist.
+func removeIfExists(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
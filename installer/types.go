@@ -5,6 +5,11 @@ import "errors"
 // ErrCancelled is returned when an operation was cancelled by the user.
 var ErrCancelled = errors.New("operation cancelled")
 
+// ErrElevationRequired is returned when a step needs administrator (root) rights
+// the installer doesn't have. RunSteps explains this to the user instead of showing
+// the raw error; callers can check for it and relaunch elevated.
+var ErrElevationRequired = errors.New("administrator rights required")
+
 // StepResult represents the outcome of a step execution.
 type StepResult struct {
 	// Skip indicates the step was skipped (already done, not needed).
@@ -43,6 +48,10 @@ type Step struct {
 	// Action executes the step and returns the result.
 	// The action should check for cancellation if it's long-running.
 	Action func() StepResult
+
+	// Undo reverts the step's effect. Optional; used by RollbackSteps when
+	// the user chooses to roll back an interrupted install.
+	Undo func() error
 }
 
 // SimpleStep creates a Step from a simple function that returns error.
@@ -0,0 +1,201 @@
+package installer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/crafted-tech/webflow"
+	"github.com/crafted-tech/webflow/platform"
+)
+
+// ErrUninstallIncomplete is returned by an uninstall in which some steps
+// failed; the remaining steps still ran.
+var ErrUninstallIncomplete = errors.New("uninstall incomplete")
+
+const (
+	keepSettingsFieldID = "keep_settings"                    // The "keep my settings" checkbox
+	unregisterStepName  = "Remove Add/Remove Programs entry" // Skipped after a failure
+)
+
+// UninstallConfig describes an uninstaller run by UninstallApp.
+type UninstallConfig struct {
+	App AppConfig // Branding, rights and flags; Title defaults to "<Name> Uninstall"
+
+	// Manifest, if set, removes what the manifest installed (see
+	// Manifest.UninstallSteps) from InstallDir.
+	Manifest   *Manifest
+	InstallDir string // Install directory (default: the InstallLocation of RegistryKey, else the uninstaller's directory)
+
+	Services      []string // Services to stop and remove first
+	Steps         []Step   // Further removal steps, run after the manifest's
+	SettingsPaths []string // Settings and data, removed unless the user keeps them
+
+	RegistryKey string // Add/Remove Programs key to remove last (Windows)
+	PerUser     bool   // RegistryKey is a per-user (HKCU) entry
+
+	// SelfDelete deletes the uninstaller after a complete uninstall, using
+	// the two-phase mechanism on Windows (see RunSecondPhase).
+	SelfDelete bool
+}
+
+// UninstallApp runs a standard uninstaller and exits the process: a confirm
+// page with a "keep my settings" checkbox, a progress page that removes
+// services, the manifest's files, shortcuts and registry values, the
+// settings and the Add/Remove Programs entry, and a finish page.
+//
+// A failed step doesn't stop the uninstall: the remaining steps run and the
+// finish page lists what could not be removed. The Add/Remove Programs entry
+// and the uninstaller itself are then kept, so the user can try again.
+//
+// Call it first thing in main(); when the uninstaller was started as the
+// second phase of its self-delete, it only finishes the deletion.
+//
+// Example:
+//
+//	func main() {
+//	    m, _ := installer.LoadManifest("manifest.json")
+//	    installer.UninstallApp(installer.UninstallConfig{
+//	        App:           installer.AppConfig{Name: "Acme Agent", Version: version, Elevate: true},
+//	        Manifest:      m,
+//	        SettingsPaths: []string{settingsDir},
+//	        RegistryKey:   "AcmeAgent",
+//	        SelfDelete:    true,
+//	    })
+//	}
+func UninstallApp(cfg UninstallConfig) {
+	if platform.IsSecondPhase() {
+		RunSecondPhase("")
+		return
+	}
+	if cfg.App.Title == "" {
+		cfg.App.Title = cfg.App.Name + " Uninstall"
+	}
+
+	code := RunApp(cfg.App, os.Args[1:], cfg.run)
+	if (code == ExitSuccess || code == ExitRebootRequired) && cfg.SelfDelete {
+		if err := platform.ScheduleSelfDelete(); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", cfg.App.Name, err)
+		}
+	}
+	os.Exit(int(code))
+}
+
+// run shows the uninstall pages.
+func (cfg UninstallConfig) run(app *App) error {
+	keep, ok := cfg.confirm(app)
+	if !ok {
+		return ErrCancelled
+	}
+
+	var failures []string
+	err := RunStepsWithConfig(app.UI, app.UI.T("uninstall.removing"), cfg.steps(keep), RunConfig{
+		Logger:          app.Log,
+		Status:          app.Status,
+		ReturnCancelled: true,
+		BeforeEach: func(step Step) error {
+			if step.Name == unregisterStepName && len(failures) > 0 {
+				return ErrSkipStep
+			}
+			return nil
+		},
+		OnFailure: func(step Step, err error) error {
+			failures = append(failures, fmt.Sprintf("%s: %v", step.Name, err))
+			return nil
+		},
+	})
+	if err != nil {
+		if !errors.Is(err, ErrCancelled) {
+			app.ShowError(app.UI.T("error.uninstallFailed"), err.Error())
+		}
+		return err
+	}
+
+	if len(failures) > 0 {
+		app.UI.ShowMessage(app.UI.T("uninstall.incomplete"),
+			app.UI.TF("uninstall.incompleteMessage", app.Config.Name)+"\n\n"+strings.Join(failures, "\n"),
+			webflow.WithIcon("warning"), webflow.WithButtonBar(webflow.WizardFinish()))
+		return ErrUninstallIncomplete
+	}
+
+	message := app.UI.TF("uninstall.completeMessage", app.Config.Name)
+	if keep {
+		message += "\n\n" + app.UI.T("uninstall.dataPreserved")
+	}
+	app.UI.ShowMessage(app.UI.T("uninstall.complete"), message,
+		webflow.WithIcon("success"), webflow.WithButtonBar(webflow.WizardFinish()))
+	return nil
+}
+
+// confirm shows the confirm page and reports whether the user keeps their
+// settings and whether they chose to uninstall.
+func (cfg UninstallConfig) confirm(app *App) (keep, ok bool) {
+	fields := []webflow.FormField{{
+		ID:    "uninstall_message",
+		Type:  webflow.FieldInfo,
+		Label: app.UI.TF("uninstall.confirmMessage", app.Config.Name),
+	}}
+	if len(cfg.SettingsPaths) > 0 {
+		fields = append(fields, webflow.FormField{
+			ID:      keepSettingsFieldID,
+			Type:    webflow.FieldCheckbox,
+			Label:   app.UI.T("uninstall.keepSettings"),
+			Default: true,
+		})
+	}
+
+	bar := webflow.ButtonBar{
+		Next:  webflow.NewButton(app.UI.T("button.uninstall"), webflow.ButtonNext).WithPrimary(),
+		Close: webflow.NewButton(app.UI.T("button.close"), webflow.ButtonClose),
+	}
+	title := strings.TrimSpace(app.UI.TF("uninstall.title", app.Config.Name, app.Config.Version))
+	resp := app.UI.ShowForm(title, fields, webflow.WithButtonBar(bar))
+	if webflow.IsClose(resp) || webflow.IsBack(resp) {
+		return false, false
+	}
+	return len(cfg.SettingsPaths) > 0 && webflow.IsCheckboxChecked(resp, keepSettingsFieldID), true
+}
+
+// steps returns the removal steps in order: services, the manifest, the
+// app's own steps, settings unless kept, and the Add/Remove Programs entry.
+func (cfg UninstallConfig) steps(keepSettings bool) []Step {
+	var steps []Step
+	for _, name := range cfg.Services {
+		steps = append(steps, StepStopService(name), StepUninstallService(name))
+	}
+	if dir := cfg.installDir(); cfg.Manifest != nil && dir != "" {
+		steps = append(steps, cfg.Manifest.UninstallSteps(cfg.Manifest.InstalledState(dir))...)
+	}
+	steps = append(steps, cfg.Steps...)
+	if !keepSettings {
+		for _, path := range cfg.SettingsPaths {
+			steps = append(steps, stepRemoveTree(filepath.Clean(path)))
+		}
+	}
+	if cfg.RegistryKey != "" {
+		steps = append(steps, stepUnregisterApp(cfg.RegistryKey, cfg.PerUser))
+	}
+	return steps
+}
+
+// installDir returns the directory the app was installed to: InstallDir, the
+// location recorded in the Add/Remove Programs entry, or the directory of the
+// uninstaller, which is installed with the app. The manifest's default would
+// be wrong whenever the user chose another directory.
+func (cfg UninstallConfig) installDir() string {
+	if cfg.InstallDir != "" {
+		return cfg.InstallDir
+	}
+	if cfg.RegistryKey != "" {
+		if dir := installedLocation(cfg.RegistryKey, cfg.PerUser); dir != "" {
+			return dir
+		}
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return ""
+	}
+	return filepath.Dir(exe)
+}
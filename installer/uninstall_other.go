@@ -0,0 +1,18 @@
+//go:build !windows
+
+package installer
+
+// installedLocation returns "": Add/Remove Programs only exists on Windows.
+func installedLocation(key string, perUser bool) string {
+	return ""
+}
+
+// stepUnregisterApp is skipped: Add/Remove Programs only exists on Windows.
+func stepUnregisterApp(key string, perUser bool) Step {
+	return Step{
+		Name: unregisterStepName,
+		Action: func() StepResult {
+			return Skipped("not supported on this platform")
+		},
+	}
+}
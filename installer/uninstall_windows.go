@@ -0,0 +1,30 @@
+//go:build windows
+
+package installer
+
+import "github.com/crafted-tech/webflow/platform"
+
+// installedLocation returns the InstallLocation of an Add/Remove Programs
+// entry, or "" if there is none.
+func installedLocation(key string, perUser bool) string {
+	find := platform.FindInstalledApp
+	if perUser {
+		find = platform.FindInstalledUserApp
+	}
+	info, err := find(key)
+	if err != nil || info == nil {
+		return ""
+	}
+	return info.InstallLocation
+}
+
+// stepUnregisterApp creates a Step that removes the app's Add/Remove Programs
+// entry, from HKCU for a per-user install.
+func stepUnregisterApp(key string, perUser bool) Step {
+	return SimpleStep(unregisterStepName, func() error {
+		if perUser {
+			return platform.UnregisterUserApp(key)
+		}
+		return platform.UnregisterApp(key)
+	})
+}
@@ -0,0 +1,81 @@
+package webflow
+
+// ListItem is an entry of an item list page: a file, folder, old version or
+// plugin the user can select.
+type ListItem struct {
+	ID          string // Returned when the item is selected
+	Label       string // Display name
+	Description string // Optional second line, e.g. a path or version
+	SizeBytes   int64  // Size shown next to the item and added to the selected total (0 = not shown)
+	Details     string // Optional text shown in a collapsed "Details" section, e.g. the files of a folder
+	Checked     bool   // Initially selected
+}
+
+// ItemList is the content of ShowItemList.
+type ItemList struct {
+	Items []ListItem
+}
+
+// ShowItemList displays items with checkboxes, sizes and expandable details,
+// with a "Select all" checkbox and the total size of the selection. It suits
+// cleanup and migration pages, e.g. old versions or leftover data folders.
+// Default is WizardMiddle() if no ButtonBar is provided.
+//
+// Returns:
+//   - []string (IDs of the selected items, in list order) if user clicked Next
+//   - Navigation (Back/Close) for navigation
+//
+// Example:
+//
+//	resp := f.ShowItemList("Remove Old Versions", []webflow.ListItem{
+//	    {ID: `C:\Acme\2.1`, Label: "Acme 2.1", Description: `C:\Acme\2.1`, SizeBytes: size21, Checked: true},
+//	    {ID: `C:\Acme\data`, Label: "Old data", SizeBytes: dataSize, Details: strings.Join(files, "\n")},
+//	})
+//	if ids, ok := resp.([]string); ok {
+//	    for _, dir := range ids {
+//	        os.RemoveAll(dir)
+//	    }
+//	}
+func (f *Flow) ShowItemList(title string, items []ListItem, opts ...PageOption) any {
+	hasButtonBar := false
+	for _, opt := range opts {
+		cfg := PageConfig{}
+		opt(&cfg)
+		if cfg.ButtonBar != nil {
+			hasButtonBar = true
+			break
+		}
+	}
+	if !hasButtonBar {
+		opts = append(opts, WithButtonBar(WizardMiddle()))
+	}
+
+	page := applyPageConfig(title, ItemList{Items: items}, opts)
+	msg := f.showPageInternal(page)
+
+	switch msg.Button {
+	case ButtonBack:
+		return Back
+	case ButtonClose, ButtonCancel, "":
+		if msg.Button == "" && msg.Type != "window_close" && msg.Data != nil {
+			return selectedItemIDs(items, msg.Data)
+		}
+		return Close
+	case ButtonNext:
+		return selectedItemIDs(items, msg.Data)
+	default:
+		return Navigation(msg.Button)
+	}
+}
+
+// selectedItemIDs returns the IDs of the items checked in a response.
+func selectedItemIDs(items []ListItem, data map[string]any) []string {
+	ids := []string{}
+	indices, _ := data["_selected_indices"].([]any)
+	for _, idx := range indices {
+		if i, ok := idx.(float64); ok && int(i) >= 0 && int(i) < len(items) {
+			ids = append(ids, items[int(i)].ID)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,116 @@
+package webflow
+
+import (
+	"strconv"
+	"strings"
+)
+
+// InputMask restricts what can be typed into a text field and formats the
+// value as the user types (see FormField.InputMask). Besides the predefined
+// masks, any other value is a template in which 9 stands for a digit, a for a
+// letter and * for a letter or digit; other characters are inserted as typed,
+// e.g. "99999-9999" or "aa-999".
+//
+// Must match the masks in runtime.js.
+type InputMask string
+
+const (
+	MaskDigits InputMask = "digits" // Digits only; returned as typed
+	MaskPhone  InputMask = "phone"  // Phone number; returned as + and digits, e.g. "+4930123456"
+	MaskIPv4   InputMask = "ipv4"   // IPv4 address; returned without leading zeros
+	MaskMAC    InputMask = "mac"    // MAC address; returned as "00:1A:2B:3C:4D:5E"
+)
+
+// Normalize returns a value entered with the mask in the form returned by
+// ShowForm, e.g. a phone number without spaces or a MAC address in upper
+// case with colons. Values that don't fit the mask are returned unchanged.
+func (m InputMask) Normalize(value string) string {
+	switch m {
+	case MaskDigits:
+		return keepRunes(value, "0123456789")
+	case MaskPhone:
+		digits := keepRunes(value, "0123456789")
+		if strings.HasPrefix(strings.TrimSpace(value), "+") {
+			return "+" + digits
+		}
+		return digits
+	case MaskIPv4:
+		parts := strings.Split(value, ".")
+		if len(parts) != 4 {
+			return value
+		}
+		for i, part := range parts {
+			n, err := strconv.Atoi(part)
+			if err != nil || n < 0 || n > 255 {
+				return value
+			}
+			parts[i] = strconv.Itoa(n)
+		}
+		return strings.Join(parts, ".")
+	case MaskMAC:
+		hex := strings.ToUpper(keepRunes(value, "0123456789abcdefABCDEF"))
+		if len(hex) != 12 {
+			return value
+		}
+		pairs := make([]string, 6)
+		for i := range pairs {
+			pairs[i] = hex[2*i : 2*i+2]
+		}
+		return strings.Join(pairs, ":")
+	}
+	return value
+}
+
+// keepRunes returns s with only the runes in chars.
+func keepRunes(s, chars string) string {
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(chars, r) {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+// NormalizeColor returns a hex color as FieldColor returns it: "#rrggbb" in
+// lower case, with "#rgb" expanded. It reports false if s isn't a hex color.
+func NormalizeColor(s string) (string, bool) {
+	hex, ok := strings.CutPrefix(strings.TrimSpace(s), "#")
+	if !ok || strings.Trim(hex, "0123456789abcdefABCDEF") != "" {
+		return s, false
+	}
+	switch len(hex) {
+	case 3:
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	case 6:
+	default:
+		return s, false
+	}
+	return "#" + strings.ToLower(hex), true
+}
+
+// normalizeValues applies the InputMask of each field, and the hex format of
+// color fields, to its value in a page's form values. It also collects the
+// rows of FieldGroups and the paths of FieldFileDrops.
+func normalizeValues(fields []FormField, values map[string]any) {
+	if values == nil {
+		return
+	}
+	for _, field := range fields {
+		if field.Type == FieldGroup {
+			collectGroupRows(field, values)
+			continue
+		}
+		s, ok := values[field.ID].(string)
+		if !ok {
+			continue
+		}
+		switch {
+		case field.Type == FieldFileDrop:
+			values[field.ID] = field.dropValue(s)
+		case field.Type == FieldColor:
+			values[field.ID], _ = NormalizeColor(s)
+		case field.InputMask != "":
+			values[field.ID] = field.InputMask.Normalize(s)
+		}
+	}
+}
@@ -0,0 +1,398 @@
+package webflow
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Args holds named arguments for TF, for translations with named
+// placeholders such as "{name}" or "{count, plural, ...}".
+//
+// Example:
+//
+//	// "files.copied": "{count, plural, =0 {No files} one {# file} other {# files}} copied to {dir}"
+//	status := TF("files.copied", webflow.Args{"count": n, "dir": target})
+type Args map[string]any
+
+// formatMessage substitutes the placeholders of a translation template in the
+// style of ICU MessageFormat:
+//
+//	{0}, {name}                         the argument as text
+//	{n, number}                         a number with the language's separators
+//	{d, date}                           a time.Time as a short local date
+//	{n, plural, one {# file} other {# files}}
+//	{g, select, male {his} female {her} other {their}}
+//
+// Plural cases are "=N" for an exact value, then the language's category
+// (zero, one, two, few, many), then "other"; "#" in the chosen case is the
+// number. Arguments are positional, or named when the only argument is Args
+// or a map. Placeholders without a matching argument are left as they are.
+func formatMessage(template, lang string, args []any) string {
+	if len(args) == 0 || !strings.Contains(template, "{") {
+		return template
+	}
+
+	var buf strings.Builder
+	for i := 0; i < len(template); {
+		if template[i] != '{' {
+			next := strings.IndexByte(template[i:], '{')
+			if next < 0 {
+				buf.WriteString(template[i:])
+				break
+			}
+			buf.WriteString(template[i : i+next])
+			i += next
+			continue
+		}
+		end := matchingBrace(template, i)
+		if end < 0 {
+			buf.WriteString(template[i:])
+			break
+		}
+		placeholder := template[i : end+1]
+		if text, ok := formatPlaceholder(template[i+1:end], lang, args); ok {
+			buf.WriteString(text)
+		} else {
+			buf.WriteString(placeholder)
+		}
+		i = end + 1
+	}
+	return buf.String()
+}
+
+// formatPlaceholder formats the content of one {...} placeholder.
+func formatPlaceholder(spec, lang string, args []any) (string, bool) {
+	name, rest, _ := strings.Cut(spec, ",")
+	value, ok := messageArg(strings.TrimSpace(name), args)
+	if !ok {
+		return "", false
+	}
+	kind, options, _ := strings.Cut(rest, ",")
+
+	switch strings.TrimSpace(kind) {
+	case "":
+		return argString(value), true
+	case "number":
+		if n, ok := toFloat(value); ok {
+			return formatNumber(lang, n, -1), true
+		}
+		return argString(value), true
+	case "date":
+		if t, ok := value.(time.Time); ok {
+			return t.Format(dateLayout(lang)), true
+		}
+		return argString(value), true
+	case "plural":
+		n, ok := toFloat(value)
+		if !ok {
+			return "", false
+		}
+		cases := parseCases(options)
+		text, ok := cases["="+strconv.FormatFloat(n, 'f', -1, 64)]
+		if !ok {
+			text, ok = cases[pluralCategory(lang, n)]
+		}
+		if !ok {
+			text, ok = cases["other"]
+		}
+		if !ok {
+			return "", false
+		}
+		text = strings.ReplaceAll(text, "#", formatNumber(lang, n, -1))
+		return formatMessage(text, lang, args), true
+	case "select":
+		cases := parseCases(options)
+		text, ok := cases[argString(value)]
+		if !ok {
+			text, ok = cases["other"]
+		}
+		if !ok {
+			return "", false
+		}
+		return formatMessage(text, lang, args), true
+	}
+	return "", false
+}
+
+// matchingBrace returns the index of the '}' closing the '{' at start, or -1.
+func matchingBrace(s string, start int) int {
+	depth := 0
+	for i := start; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// parseCases parses plural/select cases such as "one {# file} other {# files}".
+func parseCases(s string) map[string]string {
+	cases := make(map[string]string)
+	for {
+		open := strings.IndexByte(s, '{')
+		if open < 0 {
+			return cases
+		}
+		end := matchingBrace(s, open)
+		if end < 0 {
+			return cases
+		}
+		cases[strings.TrimSpace(s[:open])] = s[open+1 : end]
+		s = s[end+1:]
+	}
+}
+
+// messageArg returns the argument for a placeholder name: an index into args,
+// or a key of a single Args/map argument.
+func messageArg(name string, args []any) (any, bool) {
+	if i, err := strconv.Atoi(name); err == nil {
+		if i >= 0 && i < len(args) {
+			return args[i], true
+		}
+		return nil, false
+	}
+	if len(args) != 1 {
+		return nil, false
+	}
+	var named map[string]any
+	switch m := args[0].(type) {
+	case Args:
+		named = m
+	case map[string]any:
+		named = m
+	}
+	v, ok := named[name]
+	return v, ok
+}
+
+// argString converts an argument to text.
+func argString(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprint(v)
+}
+
+// toFloat converts a numeric argument to float64.
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+// pluralCategory returns the CLDR plural category of n for a language.
+func pluralCategory(lang string, n float64) string {
+	base, _, _ := strings.Cut(lang, "-")
+	integer := n == math.Trunc(n)
+	i := int64(math.Abs(n))
+
+	switch base {
+	case "ja", "ko", "th", "zh":
+		return "other"
+	case "fr", "pt":
+		if i == 0 || i == 1 {
+			return "one"
+		}
+	case "ru":
+		if !integer {
+			return "other"
+		}
+		switch {
+		case i%10 == 1 && i%100 != 11:
+			return "one"
+		case i%10 >= 2 && i%10 <= 4 && (i%100 < 12 || i%100 > 14):
+			return "few"
+		default:
+			return "many"
+		}
+	default:
+		if integer && i == 1 {
+			return "one"
+		}
+	}
+	return "other"
+}
+
+// numberSymbols holds a language's decimal and grouping separators.
+type numberSymbols struct {
+	decimal     string
+	group       string
+	minGrouping int // Digits before grouping applies (Spanish doesn't group 4-digit numbers)
+}
+
+var numberFormats = map[string]numberSymbols{
+	"de": {decimal: ",", group: "."},
+	"es": {decimal: ",", group: ".", minGrouping: 5},
+	"fr": {decimal: ",", group: "\u202f"}, // Narrow no-break space
+	"it": {decimal: ",", group: "."},
+	"pt": {decimal: ",", group: "."},
+	"ru": {decimal: ",", group: "\u00a0"}, // No-break space
+}
+
+// formatNumber formats n with the language's separators. decimals is the
+// number of fraction digits, or -1 for as many as needed.
+func formatNumber(lang string, n float64, decimals int) string {
+	base, _, _ := strings.Cut(lang, "-")
+	sym, ok := numberFormats[base]
+	if !ok {
+		sym = numberSymbols{decimal: ".", group: ","}
+	}
+
+	s := strconv.FormatFloat(math.Abs(n), 'f', decimals, 64)
+	intPart, frac, _ := strings.Cut(s, ".")
+	if len(intPart) >= max(sym.minGrouping, 4) {
+		var grouped strings.Builder
+		for i, d := range intPart {
+			if i > 0 && (len(intPart)-i)%3 == 0 {
+				grouped.WriteString(sym.group)
+			}
+			grouped.WriteRune(d)
+		}
+		intPart = grouped.String()
+	}
+
+	out := intPart
+	if frac != "" {
+		out += sym.decimal + frac
+	}
+	if n < 0 && strings.Trim(s, "0.") != "" {
+		out = "-" + out
+	}
+	return out
+}
+
+// byteUnits returns the unit names for FormatBytes in a language; French
+// counts in octets and Russian uses Cyrillic abbreviations.
+func byteUnits(lang string) []string {
+	base, _, _ := strings.Cut(lang, "-")
+	switch base {
+	case "fr":
+		return []string{"o", "Ko", "Mo", "Go", "To"}
+	case "ru":
+		return []string{"Б", "КБ", "МБ", "ГБ", "ТБ"}
+	}
+	return []string{"B", "KB", "MB", "GB", "TB"}
+}
+
+// percentSuffix returns what follows the number in a percentage.
+func percentSuffix(lang string) string {
+	base, _, _ := strings.Cut(lang, "-")
+	switch base {
+	case "de", "es", "ru":
+		return "\u00a0%"
+	case "fr":
+		return "\u202f%"
+	}
+	return "%"
+}
+
+// formatBytes formats a byte count with binary units, e.g. "1.5 MB".
+// Must match formatSize in runtime.js.
+func formatBytes(lang string, n int64) string {
+	units := byteUnits(lang)
+	if n < 1024 && n > -1024 {
+		return formatNumber(lang, float64(n), 0) + " " + units[0]
+	}
+	value := float64(n) / 1024
+	i := 1
+	for math.Abs(value) >= 1024 && i < len(units)-1 {
+		value /= 1024
+		i++
+	}
+	decimals := 0
+	if math.Abs(value) < 10 {
+		decimals = 1
+	}
+	return formatNumber(lang, value, decimals) + " " + units[i]
+}
+
+// FormatNumber formats n in the current language, with its decimal and
+// grouping separators ("1,234.5" in English, "1.234,5" in German). decimals
+// is the number of fraction digits, or -1 for as many as needed.
+func FormatNumber(n float64, decimals int) string {
+	return formatNumber(GetLanguage(), n, decimals)
+}
+
+// FormatPercent formats a percentage (0-100, as used by Progress) in the
+// current language, e.g. "42%" in English and "42 %" in German and French.
+func FormatPercent(percent float64, decimals int) string {
+	lang := GetLanguage()
+	return formatNumber(lang, percent, decimals) + percentSuffix(lang)
+}
+
+// FormatBytes formats a size in the current language with binary units,
+// e.g. "1.5 MB" in English, "1,5 MB" in German and "1,5 Mo" in French.
+//
+// Example:
+//
+//	p.Update(pct, webflow.TF("download.status", webflow.FormatBytes(done), webflow.FormatBytes(total)))
+func FormatBytes(n int64) string {
+	return formatBytes(GetLanguage(), n)
+}
+
+// FormatNumber is the Flow-scoped FormatNumber, in the Flow's language.
+func (f *Flow) FormatNumber(n float64, decimals int) string {
+	return formatNumber(f.Language(), n, decimals)
+}
+
+// FormatPercent is the Flow-scoped FormatPercent, in the Flow's language.
+func (f *Flow) FormatPercent(percent float64, decimals int) string {
+	lang := f.Language()
+	return formatNumber(lang, percent, decimals) + percentSuffix(lang)
+}
+
+// FormatBytes is the Flow-scoped FormatBytes, in the Flow's language.
+func (f *Flow) FormatBytes(n int64) string {
+	return formatBytes(f.Language(), n)
+}
+
+// dateLayout returns the short date layout for a language.
+func dateLayout(lang string) string {
+	base, _, _ := strings.Cut(lang, "-")
+	switch base {
+	case "de", "ru":
+		return "02.01.2006"
+	case "es", "fr", "it", "pt":
+		return "02/01/2006"
+	case "ja", "zh":
+		return "2006/01/02"
+	case "ko":
+		return "2006. 01. 02."
+	case "th":
+		return "2/1/2006"
+	}
+	return "1/2/2006"
+}
@@ -0,0 +1,171 @@
+package webflow
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// onboardingSkip is the ID of the Skip button of a skippable onboarding.
+const onboardingSkip = "onboarding_skip"
+
+// OnboardingPage is one page of an OnboardingFlow.
+type OnboardingPage struct {
+	// ID names the page in the saved progress, so an interrupted onboarding
+	// resumes here. It must be unique and stay the same between versions.
+	ID string
+
+	// Show shows the page with nav, which sets the button bar, and returns the
+	// response. Back goes to the previous page and Close ends the onboarding
+	// for now; any other response moves on.
+	Show func(f *Flow, nav PageOption) any
+}
+
+// OnboardingConfig describes a first-run flow shown by OnboardingFlow.Run.
+type OnboardingConfig struct {
+	App       string           // App name; names the per-user folder the progress is saved in
+	Version   int              // Bump to show the onboarding again to users who completed an older one
+	Pages     []OnboardingPage // Pages in order
+	Skippable bool             // Show a Skip button that ends the onboarding and marks it complete
+}
+
+// OnboardingFlow is a first-run flow: a few pages shown once per user, without
+// the installer's elevation, logs or journal. Progress is saved in the user's
+// config folder after each page, so closing the window midway resumes at the
+// same page next time, and completion is remembered so Run shows nothing
+// once the user is through.
+//
+// Example:
+//
+//	onboarding := webflow.NewOnboardingFlow(webflow.OnboardingConfig{
+//	    App:     "Acme",
+//	    Version: 1,
+//	    Pages: []webflow.OnboardingPage{
+//	        {ID: "welcome", Show: func(f *webflow.Flow, nav webflow.PageOption) any {
+//	            return f.ShowMessage("Welcome to Acme", intro, nav)
+//	        }},
+//	        {ID: "account", Show: func(f *webflow.Flow, nav webflow.PageOption) any {
+//	            return f.ShowForm("Sign In", accountFields, nav)
+//	        }},
+//	    },
+//	})
+//	if !onboarding.Completed() {
+//	    f, _ := webflow.New(webflow.WithTitle("Acme"))
+//	    defer f.Close()
+//	    onboarding.Run(f)
+//	}
+type OnboardingFlow struct {
+	cfg   OnboardingConfig
+	path  string // Progress file; "" if the config folder is unknown
+	state onboardingState
+}
+
+// onboardingState is the saved progress of an OnboardingFlow.
+type onboardingState struct {
+	Version   int    `json:"version"`
+	Completed bool   `json:"completed"`
+	Page      string `json:"page,omitempty"` // ID of the page to resume at
+}
+
+// NewOnboardingFlow returns the OnboardingFlow for cfg with the current
+// user's saved progress. Without a config folder, progress is not saved.
+func NewOnboardingFlow(cfg OnboardingConfig) *OnboardingFlow {
+	o := &OnboardingFlow{cfg: cfg}
+	if dir, err := os.UserConfigDir(); err == nil && cfg.App != "" {
+		o.path = filepath.Join(dir, cfg.App, "onboarding.json")
+	}
+	if data, err := os.ReadFile(o.path); err == nil {
+		json.Unmarshal(data, &o.state)
+	}
+	if o.state.Version != cfg.Version {
+		o.state = onboardingState{Version: cfg.Version}
+	}
+	return o
+}
+
+// Completed reports whether the user has finished or skipped the onboarding.
+func (o *OnboardingFlow) Completed() bool {
+	return o.state.Completed
+}
+
+// Run shows the onboarding's pages in f, starting at the page where the user
+// left off, and reports whether the onboarding is now complete. It returns
+// true without showing anything if it already was. An error means the
+// progress could not be saved.
+func (o *OnboardingFlow) Run(f *Flow) (bool, error) {
+	if o.state.Completed || len(o.cfg.Pages) == 0 {
+		return true, nil
+	}
+
+	i := max(0, slices.IndexFunc(o.cfg.Pages, func(p OnboardingPage) bool {
+		return p.ID == o.state.Page
+	}))
+	for i < len(o.cfg.Pages) {
+		page := o.cfg.Pages[i]
+		o.state.Page = page.ID
+		if err := o.save(); err != nil {
+			return false, err
+		}
+
+		resp := page.Show(f, WithButtonBar(o.buttonBar(i)))
+		switch {
+		case IsBack(resp):
+			i = max(0, i-1)
+		case IsClose(resp):
+			return false, nil
+		case IsButton(resp, onboardingSkip):
+			i = len(o.cfg.Pages)
+		default:
+			i++
+		}
+	}
+
+	o.state = onboardingState{Version: o.cfg.Version, Completed: true}
+	return true, o.save()
+}
+
+// Reset forgets the user's progress, so the onboarding is shown again from
+// the first page.
+func (o *OnboardingFlow) Reset() error {
+	o.state = onboardingState{Version: o.cfg.Version}
+	if o.path == "" {
+		return nil
+	}
+	if err := os.Remove(o.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// buttonBar returns the button bar of page i: Back except on the first page,
+// Next or Finish on the last, Close and, if skippable, Skip.
+func (o *OnboardingFlow) buttonBar(i int) ButtonBar {
+	bb := WizardMiddle()
+	if i == 0 {
+		bb.Back = nil
+	}
+	if i == len(o.cfg.Pages)-1 {
+		bb.Next = NewButton(TKey("button.finish"), ButtonNext).WithPrimary()
+	}
+	if o.cfg.Skippable {
+		bb.Left = NewButton(TKey("button.skip"), onboardingSkip)
+	}
+	return bb
+}
+
+// save writes the progress file.
+func (o *OnboardingFlow) save() error {
+	if o.path == "" {
+		return nil
+	}
+	data, err := json.Marshal(o.state)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(o.path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(o.path, data, 0644)
+}
@@ -1,5 +1,7 @@
 package webflow
 
+import "github.com/crafted-tech/webframe/types"
+
 // ThemeMode specifies the color theme for the UI.
 type ThemeMode int
 
@@ -22,7 +24,30 @@ type Config struct {
 	PrimaryColorDark  string                       // HSL values for dark mode, e.g., "142 70% 50%"
 	AppTranslations   map[string]map[string]string // App-specific translations: lang -> key -> value
 	InitialLanguage   string                       // Initial language code (e.g., "en", "de", "ja")
+	TextDirection     TextDirection                // Writing direction (default: from the language)
+	TranslationDir    string                       // Directory of translation files (see WithTranslationDir)
+	TranslationReload bool                         // Re-read TranslationDir when its files change
+	FontScale         float64                      // Base text size factor (default 1), applied on top of the OS text size
+	WindowMode        WindowMode                   // Normal, frameless, fullscreen or kiosk (see WithWindowMode)
 	UserDataFolder    string                       // WebView2 user data folder (Windows only, passed to webframe)
+	OnLinkClicked     func(url string) bool        // Called when a link is clicked; return true if handled
+	OnCloseRequest    func() bool                  // Called when the window X is clicked; return false to keep the window open
+	RecordPath        string                       // Session file recording answered pages (see WithRecording)
+	OnPageShown       func(id string)              // Called before each page is shown or answered (see WithOnPageShown)
+
+	// OnMissingTranslation is called for keys without a translation (see WithOnMissingTranslation).
+	OnMissingTranslation func(lang, key string)
+
+	// ContextMenu replaces the browser's right-click menu: nil keeps it, an
+	// empty slice disables it (see WithContextMenu).
+	ContextMenu []ContextMenuItem
+
+	// Answers supplies responses for unattended runs (see WithAnswers).
+	Answers func(id string, page Page) (PageAnswer, bool)
+
+	// Backend creates the window backend (default: webframe.New).
+	// Tests replace it with a fake; see package webflowtest.
+	Backend func(types.Config) (types.WebFrame, error)
 }
 
 // Option is a function that configures a Flow.
@@ -70,6 +95,23 @@ func WithTheme(mode ThemeMode) Option {
 	}
 }
 
+// WithWindowMode sets how the window is presented: WindowFrameless for a
+// compact window without native frame, WindowFullscreen, or WindowKiosk for
+// dedicated provisioning machines. The app can change it later with
+// Flow.SetWindowMode.
+//
+// Example:
+//
+//	f, err := webflow.New(
+//	    webflow.WithTitle("Device Setup"),
+//	    webflow.WithWindowMode(webflow.WindowKiosk),
+//	)
+func WithWindowMode(mode WindowMode) Option {
+	return func(c *Config) {
+		c.WindowMode = mode
+	}
+}
+
 // WithNativeTitleBar uses native system titlebar instead of app-drawn stylable titlebar.
 // When true: Window uses native system titlebar (no frame styling)
 // When false (default): Window uses stylable titlebar
@@ -95,13 +137,23 @@ func WithPrimaryColor(light, dark string) Option {
 
 // WithInitialLanguage sets the initial UI language.
 // Use this to restore a previously saved language preference (e.g., for uninstallers).
-// If not set, defaults to "en".
+// If not set, defaults to the OS display language (see DetectSystemLanguage).
 func WithInitialLanguage(lang string) Option {
 	return func(c *Config) {
 		c.InitialLanguage = lang
 	}
 }
 
+// WithBaseFontScale enlarges (or shrinks) all text by a factor, e.g. 1.15 for
+// 15% larger text on a kiosk screen. The user's OS text size setting (Windows
+// Accessibility > Text size, GNOME Large Text) is applied on top, and users can
+// zoom further with Ctrl+Plus, Ctrl+Minus and Ctrl+0.
+func WithBaseFontScale(scale float64) Option {
+	return func(c *Config) {
+		c.FontScale = scale
+	}
+}
+
 // WithUserDataFolder sets the WebView2 user data folder path (Windows only, required).
 // Each app must specify a unique folder to prevent WebView2 cross-app interference.
 // Convention: %LOCALAPPDATA%\webframe\<app-name>\
@@ -111,6 +163,53 @@ func WithUserDataFolder(path string) Option {
 	}
 }
 
+// WithOnLinkClicked sets a hook that is called when the user clicks a link in
+// page content. Return true to mark the click as handled; otherwise the URL is
+// opened in the system browser. Links never navigate the embedded webview.
+func WithOnLinkClicked(fn func(url string) bool) Option {
+	return func(c *Config) {
+		c.OnLinkClicked = fn
+	}
+}
+
+// WithOnCloseRequest sets a hook that is called when the user clicks the window's
+// X button. Return true to let the close proceed (delivered to the current page
+// as Close navigation) or false to keep the window open, e.g. while files are
+// being copied. The hook runs on the UI thread, so it must not call Show* methods;
+// use a native dialog for an "are you sure?" confirmation.
+//
+// Example:
+//
+//	var copying atomic.Bool
+//	flow, _ := webflow.New(
+//	    webflow.WithOnCloseRequest(func() bool {
+//	        return !copying.Load()
+//	    }),
+//	)
+func WithOnCloseRequest(fn func() bool) Option {
+	return func(c *Config) {
+		c.OnCloseRequest = fn
+	}
+}
+
+// WithOnPageShown sets a hook that is called with the page ID (see
+// WithPageID) before each page that waits for the user is shown, including
+// pages answered by WithAnswers, e.g. to measure where users leave a wizard.
+// A page shown again after a language change is reported again.
+func WithOnPageShown(fn func(id string)) Option {
+	return func(c *Config) {
+		c.OnPageShown = fn
+	}
+}
+
+// WithBackend replaces the webframe window backend, e.g. with the fake from
+// package webflowtest to run wizard logic without a display.
+func WithBackend(backend func(types.Config) (types.WebFrame, error)) Option {
+	return func(c *Config) {
+		c.Backend = backend
+	}
+}
+
 // defaultConfig returns the default configuration.
 func defaultConfig() Config {
 	return Config{
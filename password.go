@@ -0,0 +1,69 @@
+package webflow
+
+import "unicode"
+
+// PasswordStrength rates a password from 0 (very weak) to 4 (strong) by
+// length and the mix of lowercase, uppercase, digits and symbols. It is the
+// rating shown by FormField.StrengthMeter, so an installer can enforce the
+// same minimum on the submitted value.
+//
+// Must match passwordStrength in runtime.js.
+func PasswordStrength(password string) int {
+	var lower, upper, digit, symbol bool
+	length := 0
+	for _, r := range password {
+		length++
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+	classes := 0
+	for _, has := range []bool{lower, upper, digit, symbol} {
+		if has {
+			classes++
+		}
+	}
+
+	if length < 6 {
+		return 0
+	}
+	score := 0
+	if length >= 8 {
+		score++
+	}
+	if length >= 12 {
+		score++
+	}
+	if classes >= 3 {
+		score++
+	}
+	if classes == 4 || length >= 16 {
+		score++
+	}
+	return score
+}
+
+// ConfirmPasswordField returns a password field that must repeat the password
+// field passwordID; Next stays disabled and a message is shown until they match.
+//
+// Example:
+//
+//	fields := []webflow.FormField{
+//	    {ID: "password", Type: webflow.FieldPassword, Label: "Password", StrengthMeter: true, RevealToggle: true},
+//	    webflow.ConfirmPasswordField("password_confirm", "Confirm password", "password"),
+//	}
+func ConfirmPasswordField(id, label, passwordID string) FormField {
+	return FormField{
+		ID:      id,
+		Type:    FieldPassword,
+		Label:   label,
+		Confirm: passwordID,
+	}
+}
@@ -0,0 +1,35 @@
+package platform
+
+import "strings"
+
+// Access is a level of access to a directory, for SetDirectoryACL.
+type Access int
+
+const (
+	AccessRead   Access = iota // List and read files, run programs
+	AccessModify               // Also create, change and delete files
+	AccessFull                 // Also change permissions and take ownership (Windows)
+)
+
+// String returns the access level as icacls abbreviates it: "RX", "M" or "F".
+func (a Access) String() string {
+	switch a {
+	case AccessRead:
+		return "RX"
+	case AccessModify:
+		return "M"
+	case AccessFull:
+		return "F"
+	}
+	return "?"
+}
+
+// isAllUsersPrincipal reports whether principal names every user of the
+// computer: "Users", "Authenticated Users" or "Everyone", in any case.
+func isAllUsersPrincipal(principal string) bool {
+	switch strings.ToLower(principal) {
+	case "users", "authenticated users", "everyone":
+		return true
+	}
+	return false
+}
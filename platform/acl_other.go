@@ -0,0 +1,123 @@
+//go:build !windows
+
+package platform
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// SetDirectoryACL grants principal access to a directory and everything in
+// it by changing owners and permission bits. principal is a user, which
+// becomes the owner; "user:group" or ":group", which also or only sets the
+// group, and marks directories setgid so new files get the group too; or
+// "Users", "Authenticated Users" or "Everyone" for the permissions of other
+// users. Permissions are only added, never removed. AccessFull is the same
+// as AccessModify, and files get execute permission only if they had it.
+// Changing owners requires root.
+func SetDirectoryACL(path, principal string, access Access) error {
+	if access < AccessRead || access > AccessFull {
+		return fmt.Errorf("invalid access %d", access)
+	}
+
+	uid, gid := -1, -1
+	var shifts []uint // Positions of the permission bits to add
+	if isAllUsersPrincipal(principal) {
+		shifts = []uint{0}
+	} else {
+		name, group, _ := strings.Cut(principal, ":")
+		if name != "" {
+			u, err := user.Lookup(name)
+			if err != nil {
+				return err
+			}
+			uid, _ = strconv.Atoi(u.Uid)
+			shifts = append(shifts, 6)
+		}
+		if group != "" {
+			g, err := user.LookupGroup(group)
+			if err != nil {
+				return err
+			}
+			gid, _ = strconv.Atoi(g.Gid)
+			shifts = append(shifts, 3)
+		}
+		if len(shifts) == 0 {
+			return fmt.Errorf("invalid principal %q", principal)
+		}
+	}
+
+	return filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type()&fs.ModeSymlink != 0 {
+			return nil
+		}
+		if uid >= 0 || gid >= 0 {
+			if err := os.Lchown(p, uid, gid); err != nil {
+				return err
+			}
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		var bits fs.FileMode = 4 // r
+		if access >= AccessModify {
+			bits |= 2 // w
+		}
+		if d.IsDir() || info.Mode()&0100 != 0 {
+			bits |= 1 // x: list directories, keep programs runnable
+		}
+		mode := info.Mode() & (fs.ModePerm | fs.ModeSetuid | fs.ModeSetgid | fs.ModeSticky)
+		for _, shift := range shifts {
+			mode |= bits << shift
+		}
+		if d.IsDir() && gid >= 0 {
+			mode |= fs.ModeSetgid
+		}
+		return os.Chmod(p, mode)
+	})
+}
+
+// SetPrivateFileACL makes a file readable and writable by its owner only
+// (mode 0600). readers take SetDirectoryACL's principal syntax without the
+// all-users names: a user becomes the owner, and a group ("user:group" or
+// ":group") also gets read access (mode 0640). Changing owners requires
+// root.
+func SetPrivateFileACL(path string, readers ...string) error {
+	var mode os.FileMode = 0600
+	for _, principal := range readers {
+		uid, gid := -1, -1
+		name, group, _ := strings.Cut(principal, ":")
+		if name != "" {
+			u, err := user.Lookup(name)
+			if err != nil {
+				return err
+			}
+			uid, _ = strconv.Atoi(u.Uid)
+		}
+		if group != "" {
+			g, err := user.LookupGroup(group)
+			if err != nil {
+				return err
+			}
+			gid, _ = strconv.Atoi(g.Gid)
+			mode = 0640
+		}
+		if uid < 0 && gid < 0 {
+			return fmt.Errorf("invalid principal %q", principal)
+		}
+		if err := os.Chown(path, uid, gid); err != nil {
+			return err
+		}
+	}
+	return os.Chmod(path, mode)
+}
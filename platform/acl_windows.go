@@ -0,0 +1,148 @@
+//go:build windows
+
+package platform
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/sys/windows"
+)
+
+// wellKnownSIDs are the SIDs of built-in accounts and groups, whose names
+// are translated on non-English Windows ("Benutzer", "Utilisateurs").
+var wellKnownSIDs = map[string]string{
+	"users":               "S-1-5-32-545",
+	"administrators":      "S-1-5-32-544",
+	"authenticated users": "S-1-5-11",
+	"everyone":            "S-1-1-0",
+	"system":              "S-1-5-18",
+	"local service":       "S-1-5-19",
+	"network service":     "S-1-5-20",
+}
+
+// Access rights of each Access level, as icacls grants them.
+var accessMasks = map[Access]windows.ACCESS_MASK{
+	AccessRead:   windows.FILE_GENERIC_READ | windows.FILE_GENERIC_EXECUTE,
+	AccessModify: windows.FILE_GENERIC_READ | windows.FILE_GENERIC_WRITE | windows.FILE_GENERIC_EXECUTE | windows.DELETE,
+	AccessFull:   fileAllAccess,
+}
+
+// fileAllAccess is FILE_ALL_ACCESS.
+const fileAllAccess = windows.STANDARD_RIGHTS_REQUIRED | windows.SYNCHRONIZE | 0x1FF
+
+// SetDirectoryACL grants principal access to a directory and everything in
+// it, now and created later, like icacls path /grant principal:(OI)(CI)M.
+// Existing permissions are kept. principal is an account or group name
+// ("DOMAIN\user", "NT SERVICE\MyService"), a SID ("S-1-5-32-545") or one of
+// the built-in names "Users", "Administrators", "Authenticated Users",
+// "Everyone", "SYSTEM", "Local Service" and "Network Service", which work on
+// any display language. Changing permissions usually requires elevation.
+func SetDirectoryACL(path, principal string, access Access) error {
+	mask, ok := accessMasks[access]
+	if !ok {
+		return fmt.Errorf("invalid access %d", access)
+	}
+	sid, err := principalSID(principal)
+	if err != nil {
+		return err
+	}
+
+	sd, err := windows.GetNamedSecurityInfo(path, windows.SE_FILE_OBJECT, windows.DACL_SECURITY_INFORMATION)
+	if err != nil {
+		return fmt.Errorf("read permissions of %s: %w", path, err)
+	}
+	dacl, _, err := sd.DACL()
+	if err != nil {
+		return fmt.Errorf("read permissions of %s: %w", path, err)
+	}
+	acl, err := windows.ACLFromEntries([]windows.EXPLICIT_ACCESS{{
+		AccessPermissions: mask,
+		AccessMode:        windows.GRANT_ACCESS,
+		Inheritance:       windows.SUB_CONTAINERS_AND_OBJECTS_INHERIT,
+		Trustee: windows.TRUSTEE{
+			TrusteeForm:  windows.TRUSTEE_IS_SID,
+			TrusteeType:  windows.TRUSTEE_IS_UNKNOWN,
+			TrusteeValue: windows.TrusteeValueFromSID(sid),
+		},
+	}}, dacl)
+	if err != nil {
+		return fmt.Errorf("build permissions: %w", err)
+	}
+	// Windows applies the inheritable entry to the existing contents too
+	if err := windows.SetNamedSecurityInfo(path, windows.SE_FILE_OBJECT, windows.DACL_SECURITY_INFORMATION,
+		nil, nil, acl, nil); err != nil {
+		return fmt.Errorf("set permissions of %s: %w", path, err)
+	}
+	return nil
+}
+
+// SetPrivateFileACL replaces the permissions of a file with a protected
+// DACL that doesn't inherit from its directory: full access for SYSTEM,
+// Administrators and the current user, and read access for readers, e.g.
+// the account of a service that uses the file. readers take the same names
+// as SetDirectoryACL's principal. Use it for private keys and other secrets
+// written to shared directories such as C:\ProgramData, which grant Users
+// read access.
+func SetPrivateFileACL(path string, readers ...string) error {
+	owners := []string{wellKnownSIDs["system"], wellKnownSIDs["administrators"]}
+	if user, err := windows.GetCurrentProcessToken().GetTokenUser(); err == nil {
+		owners = append(owners, user.User.Sid.String())
+	}
+
+	var entries []windows.EXPLICIT_ACCESS
+	add := func(principal string, mask windows.ACCESS_MASK) error {
+		sid, err := principalSID(principal)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, windows.EXPLICIT_ACCESS{
+			AccessPermissions: mask,
+			AccessMode:        windows.GRANT_ACCESS,
+			Inheritance:       windows.NO_INHERITANCE,
+			Trustee: windows.TRUSTEE{
+				TrusteeForm:  windows.TRUSTEE_IS_SID,
+				TrusteeType:  windows.TRUSTEE_IS_UNKNOWN,
+				TrusteeValue: windows.TrusteeValueFromSID(sid),
+			},
+		})
+		return nil
+	}
+	for _, p := range owners {
+		if err := add(p, fileAllAccess); err != nil {
+			return err
+		}
+	}
+	for _, p := range readers {
+		if err := add(p, windows.FILE_GENERIC_READ); err != nil {
+			return err
+		}
+	}
+
+	acl, err := windows.ACLFromEntries(entries, nil)
+	if err != nil {
+		return fmt.Errorf("build permissions: %w", err)
+	}
+	if err := windows.SetNamedSecurityInfo(path, windows.SE_FILE_OBJECT,
+		windows.DACL_SECURITY_INFORMATION|windows.PROTECTED_DACL_SECURITY_INFORMATION,
+		nil, nil, acl, nil); err != nil {
+		return fmt.Errorf("set permissions of %s: %w", path, err)
+	}
+	return nil
+}
+
+// principalSID returns the SID of an account or group name, SID string or
+// built-in name.
+func principalSID(principal string) (*windows.SID, error) {
+	if s, ok := wellKnownSIDs[strings.ToLower(principal)]; ok {
+		principal = s
+	}
+	if strings.HasPrefix(strings.ToUpper(principal), "S-1-") {
+		return windows.StringToSid(principal)
+	}
+	sid, _, _, err := windows.LookupSID("", principal)
+	if err != nil {
+		return nil, fmt.Errorf("unknown account %q: %w", principal, err)
+	}
+	return sid, nil
+}
@@ -0,0 +1,26 @@
+package platform
+
+import "runtime"
+
+// IsEmulated reports whether the installer runs under emulation, i.e. it was
+// built for a different architecture than the machine's (an amd64 build on
+// ARM64 Windows or under Rosetta, a 386 build on 64-bit Windows).
+func IsEmulated() bool {
+	return Arch() != runtime.GOARCH
+}
+
+// goarchFromMachine maps a uname machine name to a GOARCH value.
+func goarchFromMachine(machine string) string {
+	switch machine {
+	case "x86_64", "amd64":
+		return "amd64"
+	case "aarch64", "arm64":
+		return "arm64"
+	case "i386", "i486", "i586", "i686", "x86":
+		return "386"
+	}
+	if len(machine) >= 3 && machine[:3] == "arm" {
+		return "arm"
+	}
+	return machine
+}
@@ -0,0 +1,20 @@
+//go:build darwin
+
+package platform
+
+import (
+	"runtime"
+
+	"golang.org/x/sys/unix"
+)
+
+// Arch returns the Mac's CPU architecture as a GOARCH value ("amd64" or
+// "arm64"). An amd64 build running under Rosetta 2 reports "arm64".
+func Arch() string {
+	if runtime.GOARCH == "amd64" {
+		if translated, err := unix.SysctlUint32("sysctl.proc_translated"); err == nil && translated == 1 {
+			return "arm64"
+		}
+	}
+	return runtime.GOARCH
+}
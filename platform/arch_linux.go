@@ -0,0 +1,20 @@
+//go:build linux
+
+package platform
+
+import (
+	"runtime"
+
+	"golang.org/x/sys/unix"
+)
+
+// Arch returns the machine's CPU architecture as a GOARCH value ("amd64",
+// "arm64", "arm", "386"), from the kernel rather than the build, so a 386
+// build on a 64-bit kernel reports "amd64".
+func Arch() string {
+	var uts unix.Utsname
+	if err := unix.Uname(&uts); err != nil {
+		return runtime.GOARCH
+	}
+	return goarchFromMachine(unix.ByteSliceToString(uts.Machine[:]))
+}
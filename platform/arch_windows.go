@@ -0,0 +1,38 @@
+//go:build windows
+
+package platform
+
+import (
+	"debug/pe"
+	"runtime"
+
+	"golang.org/x/sys/windows"
+)
+
+// Arch returns the machine's native CPU architecture as a GOARCH value
+// ("amd64", "arm64", "386"), which differs from runtime.GOARCH when the
+// installer runs emulated, e.g. an amd64 build on ARM64 Windows. Use it to
+// pick the payload to install.
+func Arch() string {
+	var processMachine, nativeMachine uint16
+	if err := windows.IsWow64Process2(windows.CurrentProcess(), &processMachine, &nativeMachine); err == nil {
+		switch nativeMachine {
+		case pe.IMAGE_FILE_MACHINE_AMD64:
+			return "amd64"
+		case pe.IMAGE_FILE_MACHINE_ARM64:
+			return "arm64"
+		case pe.IMAGE_FILE_MACHINE_I386:
+			return "386"
+		case pe.IMAGE_FILE_MACHINE_ARMNT:
+			return "arm"
+		}
+	}
+
+	// IsWow64Process2 needs Windows 10 1709; before that, ARM64 Windows
+	// didn't run amd64 code, so WOW64 means a 32-bit process on amd64.
+	var wow64 bool
+	if err := windows.IsWow64Process(windows.CurrentProcess(), &wow64); err == nil && wow64 {
+		return "amd64"
+	}
+	return runtime.GOARCH
+}
@@ -0,0 +1,82 @@
+//go:build darwin
+
+package platform
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// EnableAutostart starts the application when the current user logs in,
+// using a LaunchAgent plist with RunAtLoad in ~/Library/LaunchAgents.
+// The appName is used as the launchd label and should be reverse-DNS
+// (e.g. com.company.app).
+func EnableAutostart(appName, exePath, args string) error {
+	path, err := autostartPlistPath(appName)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("create LaunchAgents directory: %w", err)
+	}
+
+	var programArgs strings.Builder
+	for _, arg := range programArguments(exePath, args) {
+		programArgs.WriteString("        <string>" + html.EscapeString(arg) + "</string>\n")
+	}
+
+	content := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+    <key>Label</key>
+    <string>%s</string>
+    <key>ProgramArguments</key>
+    <array>
+%s    </array>
+    <key>RunAtLoad</key>
+    <true/>
+</dict>
+</plist>
+`, html.EscapeString(appName), programArgs.String())
+
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("write LaunchAgent plist: %w", err)
+	}
+	return nil
+}
+
+// DisableAutostart removes the application's LaunchAgent plist.
+// Returns nil if it doesn't exist.
+func DisableAutostart(appName string) error {
+	path, err := autostartPlistPath(appName)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("remove LaunchAgent plist: %w", err)
+	}
+	return nil
+}
+
+// IsAutostartEnabled reports whether the application has a LaunchAgent plist.
+func IsAutostartEnabled(appName string) (bool, error) {
+	path, err := autostartPlistPath(appName)
+	if err != nil {
+		return false, err
+	}
+	_, err = os.Stat(path)
+	return err == nil, nil
+}
+
+// autostartPlistPath returns the LaunchAgent plist path for an app.
+func autostartPlistPath(appName string) (string, error) {
+	dir, err := LaunchAgentsPath()
+	if err != nil {
+		return "", fmt.Errorf("get LaunchAgents path: %w", err)
+	}
+	return filepath.Join(dir, appName+".plist"), nil
+}
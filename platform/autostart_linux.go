@@ -0,0 +1,81 @@
+//go:build linux
+
+package platform
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// EnableAutostart starts the application when the current user logs in,
+// using an XDG autostart .desktop file in ~/.config/autostart.
+func EnableAutostart(appName, exePath, args string) error {
+	path, err := autostartFilePath(appName)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("create autostart directory: %w", err)
+	}
+
+	command := desktopExecQuote(exePath)
+	if args != "" {
+		command += " " + args
+	}
+
+	content := fmt.Sprintf(`[Desktop Entry]
+Type=Application
+Name=%s
+Exec=%s
+X-GNOME-Autostart-enabled=true
+`, appName, command)
+
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("write autostart file: %w", err)
+	}
+	return nil
+}
+
+// DisableAutostart removes the application's autostart .desktop file.
+// Returns nil if it doesn't exist.
+func DisableAutostart(appName string) error {
+	path, err := autostartFilePath(appName)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("remove autostart file: %w", err)
+	}
+	return nil
+}
+
+// IsAutostartEnabled reports whether the application has an autostart .desktop file.
+func IsAutostartEnabled(appName string) (bool, error) {
+	path, err := autostartFilePath(appName)
+	if err != nil {
+		return false, err
+	}
+	_, err = os.Stat(path)
+	return err == nil, nil
+}
+
+// autostartFilePath returns the XDG autostart file path for an app.
+func autostartFilePath(appName string) (string, error) {
+	dir, err := UserConfigPath()
+	if err != nil {
+		return "", fmt.Errorf("get config path: %w", err)
+	}
+	return filepath.Join(dir, "autostart", appName+".desktop"), nil
+}
+
+// desktopExecQuote quotes a path for a .desktop Exec key if it contains
+// characters that the Desktop Entry spec reserves.
+func desktopExecQuote(s string) string {
+	if !strings.ContainsAny(s, " \t\"'\\$`") {
+		return s
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "`", "\\`", `$`, `\$`)
+	return `"` + r.Replace(s) + `"`
+}
@@ -0,0 +1,91 @@
+//go:build windows
+
+package platform
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/sys/windows/registry"
+)
+
+const runKeyPath = `Software\Microsoft\Windows\CurrentVersion\Run`
+
+// EnableAutostart starts the application when the current user logs in,
+// using the per-user Run registry key.
+func EnableAutostart(appName, exePath, args string) error {
+	key, _, err := registry.CreateKey(registry.CURRENT_USER, runKeyPath, registry.SET_VALUE)
+	if err != nil {
+		return fmt.Errorf("open Run key: %w", err)
+	}
+	defer key.Close()
+
+	command := `"` + exePath + `"`
+	if args != "" {
+		command += " " + args
+	}
+	if err := key.SetStringValue(appName, command); err != nil {
+		return fmt.Errorf("write Run value: %w", err)
+	}
+	return nil
+}
+
+// EnableStartupFolderAutostart starts the application at login through a
+// shortcut in the user's Startup folder instead of the Run key. Users can see
+// and remove these entries in Explorer, and some policies disable the Run key.
+func EnableStartupFolderAutostart(appName, exePath, args string) error {
+	lnkPath, err := startupShortcutPath(appName)
+	if err != nil {
+		return err
+	}
+	return CreateShortcut(lnkPath, Shortcut{Target: exePath, Arguments: args})
+}
+
+// DisableAutostart removes the application's Run key entry and Startup folder
+// shortcut. Returns nil if neither exists.
+func DisableAutostart(appName string) error {
+	key, err := registry.OpenKey(registry.CURRENT_USER, runKeyPath, registry.SET_VALUE)
+	if err == nil {
+		err = key.DeleteValue(appName)
+		key.Close()
+		if err != nil && err != registry.ErrNotExist {
+			return fmt.Errorf("delete Run value: %w", err)
+		}
+	}
+
+	lnkPath, err := startupShortcutPath(appName)
+	if err != nil {
+		return err
+	}
+	return DeleteShortcut(lnkPath)
+}
+
+// IsAutostartEnabled reports whether the application has a Run key entry or
+// Startup folder shortcut for the current user.
+func IsAutostartEnabled(appName string) (bool, error) {
+	key, err := registry.OpenKey(registry.CURRENT_USER, runKeyPath, registry.QUERY_VALUE)
+	if err == nil {
+		_, _, err = key.GetStringValue(appName)
+		key.Close()
+		if err == nil {
+			return true, nil
+		}
+	}
+
+	lnkPath, err := startupShortcutPath(appName)
+	if err != nil {
+		return false, err
+	}
+	_, err = os.Stat(lnkPath)
+	return err == nil, nil
+}
+
+// startupShortcutPath returns the Startup folder shortcut path for an app.
+func startupShortcutPath(appName string) (string, error) {
+	startup, err := UserStartupPath()
+	if err != nil {
+		return "", fmt.Errorf("get startup folder path: %w", err)
+	}
+	return filepath.Join(startup, appName+".lnk"), nil
+}
@@ -0,0 +1,98 @@
+package platform
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// ErrNoCertificate is returned for files that contain no X.509 certificate.
+var ErrNoCertificate = errors.New("no certificate found")
+
+// Certificate is an X.509 certificate read by ReadCertificate, with the
+// details to show the user before importing it.
+type Certificate struct {
+	Subject     string    // Common name, or the full subject if it has none
+	Issuer      string    // Common name of the issuer, or its full name
+	NotBefore   time.Time // Start of the validity period
+	NotAfter    time.Time // Expiry
+	Fingerprint string    // SHA-256 of the certificate, hex pairs separated by colons
+	IsCA        bool      // Whether the certificate may sign other certificates
+	SelfSigned  bool      // Whether subject and issuer are the same (a root certificate)
+	Raw         []byte    // DER encoding, for x509.ParseCertificate
+}
+
+// Expired reports whether the certificate is outside its validity period.
+func (c *Certificate) Expired() bool {
+	now := time.Now()
+	return now.Before(c.NotBefore) || now.After(c.NotAfter)
+}
+
+// ReadCertificate reads the first certificate from a PEM or DER file
+// (.pem, .crt, .cer, .der).
+func ReadCertificate(path string) (*Certificate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCertificate(data)
+}
+
+// ParseCertificate parses the first certificate in PEM or DER data. PEM
+// blocks other than certificates, such as private keys, are skipped.
+func ParseCertificate(data []byte) (*Certificate, error) {
+	der := data
+	if bytes.Contains(data, []byte("-----BEGIN")) {
+		der = nil
+		for rest := data; der == nil; {
+			var block *pem.Block
+			block, rest = pem.Decode(rest)
+			if block == nil {
+				return nil, ErrNoCertificate
+			}
+			if block.Type == "CERTIFICATE" {
+				der = block.Bytes
+			}
+		}
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNoCertificate, err)
+	}
+	return &Certificate{
+		Subject:     certificateName(cert.Subject.CommonName, cert.Subject.String()),
+		Issuer:      certificateName(cert.Issuer.CommonName, cert.Issuer.String()),
+		NotBefore:   cert.NotBefore,
+		NotAfter:    cert.NotAfter,
+		Fingerprint: CertificateFingerprint(cert.Raw),
+		IsCA:        cert.IsCA,
+		SelfSigned:  bytes.Equal(cert.RawSubject, cert.RawIssuer),
+		Raw:         cert.Raw,
+	}, nil
+}
+
+// CertificateFingerprint returns the SHA-256 fingerprint of a DER encoded
+// certificate as upper-case hex pairs separated by colons, the form browsers
+// and certificate viewers show.
+func CertificateFingerprint(der []byte) string {
+	sum := sha256.Sum256(der)
+	pairs := make([]string, len(sum))
+	for i, b := range sum {
+		pairs[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(pairs, ":")
+}
+
+// certificateName returns the common name, or the full name if it has none.
+func certificateName(commonName, full string) string {
+	if commonName != "" {
+		return commonName
+	}
+	return full
+}
@@ -0,0 +1,48 @@
+//go:build darwin
+
+package platform
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// systemKeychain is the keychain whose certificates all users trust.
+const systemKeychain = "/Library/Keychains/System.keychain"
+
+// ImportCertificate adds the certificate in a PEM or DER file to the System
+// keychain and marks it trusted for all users: self-signed certificates as
+// roots, others as intermediates. Requires root (see EnsureElevated).
+func ImportCertificate(path string) error {
+	cert, err := ReadCertificate(path)
+	if err != nil {
+		return err
+	}
+
+	// Pass security the parsed certificate alone, not a bundle or key file
+	f, err := os.CreateTemp("", "certificate-*.cer")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	_, err = f.Write(cert.Raw)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	result := "trustAsRoot"
+	if cert.SelfSigned {
+		result = "trustRoot"
+	}
+	output, err := exec.Command("security", "add-trusted-cert", "-d", "-r", result,
+		"-k", systemKeychain, f.Name()).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("security add-trusted-cert: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+//go:build linux
+
+package platform
+
+import (
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// trustStores are the system trust store layouts of the major distributions:
+// the folder for extra CA certificates and the command that rebuilds the
+// trusted bundle from it.
+var trustStores = []struct {
+	dir     string
+	command []string
+}{
+	{"/usr/local/share/ca-certificates", []string{"update-ca-certificates"}},           // Debian, Ubuntu
+	{"/etc/pki/ca-trust/source/anchors", []string{"update-ca-trust", "extract"}},       // Fedora, RHEL, Arch
+	{"/etc/pki/trust/anchors", []string{"update-ca-certificates"}},                     // openSUSE
+	{"/etc/ca-certificates/trust-source/anchors", []string{"trust", "extract-compat"}}, // Older Arch
+}
+
+// ImportCertificate adds the certificate in a PEM or DER file to the system
+// trust store (ca-certificates or p11-kit) and rebuilds the trusted bundle
+// that OpenSSL, curl and most programs read. Requires root.
+// Browsers that keep their own certificate store, such as Firefox, don't
+// use it.
+func ImportCertificate(path string) error {
+	cert, err := ReadCertificate(path)
+	if err != nil {
+		return err
+	}
+
+	for _, store := range trustStores {
+		if _, err := os.Stat(store.dir); err != nil {
+			continue
+		}
+		if _, err := exec.LookPath(store.command[0]); err != nil {
+			continue
+		}
+
+		// update-ca-certificates only reads files ending in .crt
+		name := certificateFileName(cert.Subject) + "-" + strings.ReplaceAll(cert.Fingerprint[:11], ":", "") + ".crt"
+		data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+		if err := os.WriteFile(filepath.Join(store.dir, name), data, 0644); err != nil {
+			return err
+		}
+		output, err := exec.Command(store.command[0], store.command[1:]...).CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("%s: %s", strings.Join(store.command, " "), strings.TrimSpace(string(output)))
+		}
+		return nil
+	}
+	return errors.New("no system trust store found (install ca-certificates)")
+}
+
+// certificateFileName returns a subject as a file name of letters, digits,
+// dots and dashes.
+func certificateFileName(subject string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-':
+			return r
+		}
+		return '_'
+	}, subject)
+	if len(name) > 64 {
+		name = name[:64]
+	}
+	return name
+}
@@ -0,0 +1,49 @@
+//go:build windows
+
+package platform
+
+import (
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// ImportCertificate adds the certificate in a PEM or DER file to the
+// machine's certificate store, so that all users and services trust it:
+// self-signed certificates go to the Trusted Root Certification Authorities,
+// others to the Intermediate Certification Authorities. Requires elevation.
+// Importing a certificate that is already in the store replaces it.
+func ImportCertificate(path string) error {
+	cert, err := ReadCertificate(path)
+	if err != nil {
+		return err
+	}
+
+	storeName := "CA"
+	if cert.SelfSigned {
+		storeName = "ROOT"
+	}
+	storeName16, err := windows.UTF16PtrFromString(storeName)
+	if err != nil {
+		return err
+	}
+	store, err := windows.CertOpenStore(windows.CERT_STORE_PROV_SYSTEM, 0, 0,
+		windows.CERT_SYSTEM_STORE_LOCAL_MACHINE, uintptr(unsafe.Pointer(storeName16)))
+	if err != nil {
+		return fmt.Errorf("open %s certificate store: %w", storeName, err)
+	}
+	defer windows.CertCloseStore(store, 0)
+
+	ctx, err := windows.CertCreateCertificateContext(windows.X509_ASN_ENCODING|windows.PKCS_7_ASN_ENCODING,
+		&cert.Raw[0], uint32(len(cert.Raw)))
+	if err != nil {
+		return fmt.Errorf("read certificate: %w", err)
+	}
+	defer windows.CertFreeCertificateContext(ctx)
+
+	if err := windows.CertAddCertificateContextToStore(store, ctx, windows.CERT_STORE_ADD_REPLACE_EXISTING, nil); err != nil {
+		return fmt.Errorf("add certificate to %s store: %w", storeName, err)
+	}
+	return nil
+}
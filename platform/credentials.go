@@ -0,0 +1,12 @@
+package platform
+
+import "errors"
+
+// Credential validation errors.
+var (
+	ErrInvalidCredentials     = errors.New("invalid account credentials")
+	ErrAccountDisabled        = errors.New("account is disabled")
+	ErrAccountLocked          = errors.New("account is locked out")
+	ErrPasswordExpired        = errors.New("password has expired or must be changed")
+	ErrCredentialsUnsupported = errors.New("credential validation not supported on this system")
+)
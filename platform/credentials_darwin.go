@@ -0,0 +1,24 @@
+//go:build darwin
+
+package platform
+
+import (
+	"errors"
+	"os/exec"
+)
+
+// ValidateCredentials checks a user name and password against the system's
+// accounts, local or from a directory service, with dscl -authonly. dscl
+// takes the password as an argument, so it is briefly visible in the process
+// list. domain is ignored.
+func ValidateCredentials(user, password, domain string) error {
+	err := exec.Command("dscl", "/Search", "-authonly", user, password).Run()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return ErrInvalidCredentials
+		}
+		return ErrCredentialsUnsupported
+	}
+	return nil
+}
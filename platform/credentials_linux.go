@@ -0,0 +1,52 @@
+//go:build linux
+
+package platform
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	osuser "os/user"
+	"strings"
+)
+
+// ValidateCredentials checks a user name and password against the system's
+// accounts. On Linux it uses pam_unix's unix_chkpwd helper, so only local
+// accounts in /etc/passwd and /etc/shadow are checked; accounts from the rest
+// of the PAM stack (SSSD, LDAP, Kerberos) are reported as invalid. The helper
+// only checks other users' passwords for root, so without root
+// ErrCredentialsUnsupported is returned for any account but the caller's
+// own. domain is ignored.
+func ValidateCredentials(user, password, domain string) error {
+	if os.Geteuid() != 0 {
+		current, err := osuser.Current()
+		if err != nil || current.Username != user {
+			return ErrCredentialsUnsupported
+		}
+	}
+
+	helper, err := exec.LookPath("unix_chkpwd")
+	if err != nil {
+		for _, path := range []string{"/usr/sbin/unix_chkpwd", "/sbin/unix_chkpwd"} {
+			if helper, err = exec.LookPath(path); err == nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		return ErrCredentialsUnsupported
+	}
+
+	// The password is read from stdin, NUL-terminated, and never appears in
+	// the process list.
+	cmd := exec.Command(helper, user, "nonull")
+	cmd.Stdin = strings.NewReader(password + "\x00")
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return ErrInvalidCredentials
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+//go:build windows
+
+package platform
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var procLogonUserW = advapi32.NewProc("LogonUserW")
+
+const (
+	logon32LogonNetwork    = 3
+	logon32ProviderDefault = 0
+
+	errorAccountRestriction  = windows.Errno(1327)
+	errorPasswordExpired     = windows.Errno(1330)
+	errorAccountDisabled     = windows.Errno(1331)
+	errorLogonTypeNotGranted = windows.Errno(1385)
+	errorPasswordMustChange  = windows.Errno(1907)
+	errorAccountLockedOut    = windows.Errno(1909)
+	errorAccountExpired      = windows.Errno(1793)
+	errorNoLogonServers      = windows.Errno(1311)
+	errorTrustedRelationship = windows.Errno(1789)
+)
+
+// ValidateWindowsCredentials checks a user name and password with LogonUser,
+// e.g. for the account a service will run as, so a wrong password is
+// reported on the account page rather than when the service fails to start.
+// user may be "name", "DOMAIN\name" or "name@domain"; an empty domain means
+// the user's own domain, or the local machine for "." and local accounts.
+//
+// A network logon is used, which doesn't load a profile. Accounts that
+// are valid but not allowed that logon type are accepted.
+func ValidateWindowsCredentials(user, password, domain string) error {
+	if domain == "" {
+		if d, name, ok := strings.Cut(user, `\`); ok {
+			domain, user = d, name
+		}
+	}
+
+	userPtr, err := windows.UTF16PtrFromString(user)
+	if err != nil {
+		return err
+	}
+	passwordPtr, err := windows.UTF16PtrFromString(password)
+	if err != nil {
+		return err
+	}
+	var domainPtr *uint16
+	if domain != "" {
+		if domainPtr, err = windows.UTF16PtrFromString(domain); err != nil {
+			return err
+		}
+	}
+
+	var token windows.Token
+	r, _, callErr := procLogonUserW.Call(
+		uintptr(unsafe.Pointer(userPtr)),
+		uintptr(unsafe.Pointer(domainPtr)),
+		uintptr(unsafe.Pointer(passwordPtr)),
+		logon32LogonNetwork,
+		logon32ProviderDefault,
+		uintptr(unsafe.Pointer(&token)),
+	)
+	if r != 0 {
+		token.Close()
+		return nil
+	}
+
+	var errno windows.Errno
+	if !errors.As(callErr, &errno) {
+		return fmt.Errorf("LogonUser: %w", callErr)
+	}
+	switch errno {
+	case errorLogonTypeNotGranted:
+		return nil // Password is right; the account just can't log on over the network
+	case windows.ERROR_LOGON_FAILURE:
+		return ErrInvalidCredentials
+	case errorAccountDisabled, errorAccountRestriction, errorAccountExpired:
+		return fmt.Errorf("%w: %v", ErrAccountDisabled, errno)
+	case errorAccountLockedOut:
+		return ErrAccountLocked
+	case errorPasswordExpired, errorPasswordMustChange:
+		return ErrPasswordExpired
+	case errorNoLogonServers, errorTrustedRelationship:
+		return fmt.Errorf("cannot reach the domain to check the password: %w", errno)
+	}
+	return fmt.Errorf("LogonUser: %w", errno)
+}
+
+// ValidateCredentials checks a user name and password against the system's
+// accounts. On Windows it is ValidateWindowsCredentials.
+func ValidateCredentials(user, password, domain string) error {
+	return ValidateWindowsCredentials(user, password, domain)
+}
@@ -44,8 +44,8 @@ var (
 //  1. IShellDispatch2::ShellExecute via COM — asks the running Explorer
 //     shell to launch the process (cross-process COM). The child inherits
 //     Explorer's non-elevated token and runs outside the caller's job.
-//  2. Scheduled task — creates a one-shot task via schtasks.exe with
-//     /rl limited. Task Scheduler runs the process in its own context,
+//  2. Scheduled task — registers a one-shot task through the Task
+//     Scheduler COM API at the limited run level. Task Scheduler runs the process in its own context,
 //     outside the caller's job object and at non-elevated privilege.
 //  3. Shell-token approach — borrows Explorer's token via
 //     CreateProcessWithTokenW. De-elevates but does NOT escape the
@@ -83,39 +83,34 @@ func LaunchDeElevated(exePath string) (uint32, error) {
 // Task Scheduler creates the process in the service's own context — outside
 // the caller's job object — with the user's limited (non-elevated) token.
 func launchViaScheduledTask(exePath string) error {
-	taskName := fmt.Sprintf("UnisonLaunch_%d", os.Getpid())
-
-	schtasks := filepath.Join(os.Getenv("WINDIR"), "System32", "schtasks.exe")
-
-	// Create a one-time task. /rl limited = standard user privileges.
-	if err := runHidden(schtasks, "/create",
-		"/tn", taskName,
-		"/tr", `"`+exePath+`"`,
-		"/sc", "once",
-		"/st", "00:00",
-		"/f",
-		"/rl", "limited",
-		"/it",
-	); err != nil {
+	return runOneShotTask(ScheduledTask{
+		Name:           fmt.Sprintf("UnisonLaunch_%d", os.Getpid()),
+		Executable:     exePath,
+		RunOnBatteries: true,
+	})
+}
+
+// runOneShotTask registers a trigger-less task, runs it once and deletes
+// the definition. Without RunAs the task uses the interactive user's limited
+// (non-elevated) token.
+func runOneShotTask(task ScheduledTask) error {
+	if err := CreateScheduledTask(task); err != nil {
 		return fmt.Errorf("create task: %w", err)
 	}
-	// Battery settings are an optimization — don't fail the launch if
-	// PowerShell is unavailable (execution policy, locked-down enterprise).
-	_ = setTaskBatteryFriendly(taskName)
 
 	// Grant any process the right to set foreground window. Without this,
 	// the app launched by Task Scheduler would appear behind other windows.
 	AllowSetForegroundForAnyProcess()
 
 	// Run immediately.
-	if err := runHidden(schtasks, "/run", "/tn", taskName); err != nil {
-		runHidden(schtasks, "/delete", "/tn", taskName, "/f")
+	if err := RunScheduledTask(task.Name); err != nil {
+		DeleteScheduledTask(task.Name)
 		return fmt.Errorf("run task: %w", err)
 	}
 
 	// Brief wait for Task Scheduler to start the process, then delete.
 	time.Sleep(1 * time.Second)
-	runHidden(schtasks, "/delete", "/tn", taskName, "/f")
+	DeleteScheduledTask(task.Name)
 
 	return nil
 }
@@ -135,22 +130,6 @@ func runHidden(name string, args ...string) error {
 	return nil
 }
 
-func setTaskBatteryFriendly(taskName string) error {
-	powershell := filepath.Join(os.Getenv("WINDIR"), "System32", "WindowsPowerShell", "v1.0", "powershell.exe")
-	escapedTaskName := strings.ReplaceAll(taskName, "'", "''")
-	script := fmt.Sprintf(
-		"$s=New-ScheduledTaskSettingsSet -AllowStartIfOnBatteries -DontStopIfGoingOnBatteries -StartWhenAvailable; Set-ScheduledTask -TaskName '%s' -Settings $s | Out-Null",
-		escapedTaskName,
-	)
-	return runHidden(
-		powershell,
-		"-NoProfile",
-		"-NonInteractive",
-		"-ExecutionPolicy", "Bypass",
-		"-Command", script,
-	)
-}
-
 // shellExecuteViaExplorer uses the IShellDispatch2::ShellExecute COM
 // technique to ask the running Explorer shell to launch an executable.
 // The process is created by Explorer, so it runs at normal privilege
@@ -0,0 +1,35 @@
+package platform
+
+import "errors"
+
+// ErrDefenderUnsupported is returned by the Defender functions on platforms
+// other than Windows.
+var ErrDefenderUnsupported = errors.New("Microsoft Defender is only available on Windows")
+
+// AntivirusProduct is an antivirus product registered with Windows Security
+// Center.
+type AntivirusProduct struct {
+	Name     string // Display name, e.g. "Microsoft Defender Antivirus"
+	Enabled  bool   // Real-time protection is on
+	UpToDate bool   // Signatures are up to date
+	Defender bool   // The product is Microsoft Defender
+}
+
+// ThirdPartyAntivirus returns the enabled antivirus products other than
+// Microsoft Defender. These don't honor Defender exclusions, so the user has
+// to exclude the install directory in them, if file copies are slow or files
+// are quarantined. Returns nil on Windows Server, which has no Security
+// Center, and on other platforms.
+func ThirdPartyAntivirus() []AntivirusProduct {
+	products, err := ListAntivirusProducts()
+	if err != nil {
+		return nil
+	}
+	var result []AntivirusProduct
+	for _, p := range products {
+		if p.Enabled && !p.Defender {
+			result = append(result, p)
+		}
+	}
+	return result
+}
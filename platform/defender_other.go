@@ -0,0 +1,18 @@
+//go:build !windows
+
+package platform
+
+// AddDefenderExclusion returns ErrDefenderUnsupported.
+func AddDefenderExclusion(path string) error {
+	return ErrDefenderUnsupported
+}
+
+// RemoveDefenderExclusion returns ErrDefenderUnsupported.
+func RemoveDefenderExclusion(path string) error {
+	return ErrDefenderUnsupported
+}
+
+// ListAntivirusProducts returns nil: there is no Security Center.
+func ListAntivirusProducts() ([]AntivirusProduct, error) {
+	return nil, nil
+}
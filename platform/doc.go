@@ -12,14 +12,38 @@
 // The package provides the following functionality:
 //
 //   - Clipboard: Copy text to the system clipboard (Windows)
-//   - Elevation: UAC elevation handling (Windows)
+//   - Elevation: Relaunch with administrator rights (UAC, osascript, pkexec)
 //   - Single Instance: Prevent multiple instances (Windows)
 //   - App Registration: Register/unregister apps in Add/Remove Programs (Windows)
 //   - Paths: Get common system paths (Windows)
 //   - Process: Find and kill processes by name (Windows)
 //   - Self-Delete: Schedule executable deletion after exit (Windows)
 //   - Shortcuts: Create and delete shortcuts (Windows)
+//   - Autostart: Start an app at login (Windows Run key/Startup folder, XDG autostart, LaunchAgents)
+//   - Code Signing: Verify Authenticode (Windows) and codesign/Gatekeeper (macOS) signatures
 //   - Service Management: Install/uninstall/start/stop system services (Windows/Linux/macOS)
+//   - Scheduled Tasks: Create/run/query/delete Task Scheduler tasks (Windows)
+//   - Sessions: Detect non-interactive sessions (SYSTEM, session 0, SSH, no display)
+//   - Credentials: Check a user name and password (LogonUser, unix_chkpwd, dscl)
+//   - Data Protection: Encrypt data for the current user (DPAPI, Windows)
+//   - Secret Storage: Keep tokens and keys in Credential Manager, Keychain or the Secret Service
+//   - Machine ID: Hashed stable computer identifier (MachineGuid, machine-id, IOPlatformUUID)
+//   - System Info: Memory, free disk space, GPUs and .NET versions
+//   - Environment: Native CPU architecture (incl. emulation), VM and container detection
+//   - Certificates: Preview certificates and import them into the system trust store (cert store, System keychain, ca-certificates)
+//   - Hosts File: Add and remove host name mappings with a backup and atomic replace
+//   - Antivirus: Microsoft Defender exclusions and detection of other antivirus products (Windows)
+//   - Mark of the Web: Detect and remove the downloaded-file mark (Windows Zone.Identifier, macOS quarantine)
+//   - Managed Devices: Domain join and MDM enrollment detection, Group Policy values
+//   - Logged-on Users: Interactive sessions of all users, local and remote, and launching apps in one (LaunchInSession)
+//   - Job Objects: Kill-on-close jobs for helper processes and starting processes outside the caller's job
+//   - Process Trees: Kill a process with every process it started (KillProcessTree)
+//   - Permissions: Grant users or groups access to a directory tree (ACLs on Windows, owners and modes elsewhere)
+//   - Links: Symbolic links with privilege detection, and directory junctions (Windows)
+//   - Minidumps: Dump the current process for crash analysis (Windows)
+//   - System Events: Recent warnings and errors from the event log, journal or unified log (SystemEvents)
+//   - Tray Icons: Notification area icon with tooltip and menu (Windows)
+//   - Power: Keep the computer awake during long operations (PreventSleep, AllowSleep)
 //
 // # Example Usage
 //
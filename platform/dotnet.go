@@ -0,0 +1,30 @@
+package platform
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// DotNetRuntime is an installed .NET (Core) runtime.
+type DotNetRuntime struct {
+	Name    string // e.g. "Microsoft.NETCore.App", "Microsoft.WindowsDesktop.App"
+	Version string // e.g. "8.0.4"
+}
+
+// DotNetRuntimes returns the .NET runtimes known to the dotnet host on PATH,
+// or nil if .NET is not installed.
+func DotNetRuntimes() []DotNetRuntime {
+	out, err := exec.Command("dotnet", "--list-runtimes").Output()
+	if err != nil {
+		return nil
+	}
+	var runtimes []DotNetRuntime
+	for _, line := range strings.Split(string(out), "\n") {
+		// Microsoft.NETCore.App 8.0.4 [C:\Program Files\dotnet\shared\Microsoft.NETCore.App]
+		fields := strings.Fields(line)
+		if len(fields) >= 2 {
+			runtimes = append(runtimes, DotNetRuntime{Name: fields[0], Version: fields[1]})
+		}
+	}
+	return runtimes
+}
@@ -0,0 +1,8 @@
+//go:build !windows
+
+package platform
+
+// DotNetFrameworkVersion returns "" on non-Windows platforms.
+func DotNetFrameworkVersion() string {
+	return ""
+}
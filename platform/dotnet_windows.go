@@ -0,0 +1,46 @@
+//go:build windows
+
+package platform
+
+import "golang.org/x/sys/windows/registry"
+
+// dotNetFrameworkReleases maps the minimum Release value of each .NET
+// Framework 4.5+ version, newest first.
+var dotNetFrameworkReleases = []struct {
+	release uint64
+	version string
+}{
+	{533320, "4.8.1"},
+	{528040, "4.8"},
+	{461808, "4.7.2"},
+	{461308, "4.7.1"},
+	{460798, "4.7"},
+	{394802, "4.6.2"},
+	{394254, "4.6.1"},
+	{393295, "4.6"},
+	{379893, "4.5.2"},
+	{378675, "4.5.1"},
+	{378389, "4.5"},
+}
+
+// DotNetFrameworkVersion returns the installed .NET Framework 4.x version,
+// e.g. "4.8.1", or "" if none (or one older than 4.5) is installed.
+func DotNetFrameworkVersion() string {
+	key, err := registry.OpenKey(registry.LOCAL_MACHINE,
+		`SOFTWARE\Microsoft\NET Framework Setup\NDP\v4\Full`, registry.QUERY_VALUE|registry.WOW64_64KEY)
+	if err != nil {
+		return ""
+	}
+	defer key.Close()
+
+	release, _, err := key.GetIntegerValue("Release")
+	if err != nil {
+		return ""
+	}
+	for _, r := range dotNetFrameworkReleases {
+		if release >= r.release {
+			return r.version
+		}
+	}
+	return ""
+}
@@ -0,0 +1,64 @@
+//go:build linux
+
+package platform
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+)
+
+// ErrElevationDeclined indicates the user rejected the authentication prompt.
+var ErrElevationDeclined = errors.New("administrator elevation declined")
+
+// IsElevated checks if the current process is running as root.
+func IsElevated() bool {
+	return os.Geteuid() == 0
+}
+
+// EnsureElevated checks if the current process is running as root. If not, it
+// relaunches the executable through pkexec (the desktop's polkit
+// authentication dialog), waits for it and exits with its exit code.
+// Returns ErrElevationDeclined if the user dismisses the dialog.
+func EnsureElevated() error {
+	if IsElevated() {
+		return nil
+	}
+
+	exePath, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	pkexec, err := exec.LookPath("pkexec")
+	if err != nil {
+		return errors.New("root privileges required: run with sudo")
+	}
+
+	// pkexec starts with a clean environment; pass on what a GUI app needs
+	args := []string{"env"}
+	for _, name := range []string{"DISPLAY", "XAUTHORITY", "WAYLAND_DISPLAY", "XDG_RUNTIME_DIR", "LANG"} {
+		if value, ok := os.LookupEnv(name); ok {
+			args = append(args, name+"="+value)
+		}
+	}
+	args = append(args, exePath)
+	args = append(args, os.Args[1:]...)
+
+	cmd := exec.Command(pkexec, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return err
+		}
+		// 126: dialog dismissed, 127: not authorized
+		if code := exitErr.ExitCode(); code == 126 || code == 127 {
+			return ErrElevationDeclined
+		}
+		os.Exit(exitErr.ExitCode())
+	}
+	os.Exit(0)
+	return nil
+}
@@ -0,0 +1,41 @@
+//go:build darwin
+
+package platform
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// SystemEvents returns up to lines of the errors and faults written to the
+// unified log in the last hour, oldest first, for diagnostics.
+func SystemEvents(lines int) ([]string, error) {
+	if lines <= 0 {
+		lines = 100
+	}
+
+	// Reading the unified log is slow; an hour of errors is plenty.
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "log", "show", "--last", "1h", "--style", "syslog",
+		"--predicate", "messageType == error OR messageType == fault")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("read unified log: %w", err)
+	}
+
+	var result []string
+	for _, line := range strings.Split(strings.TrimRight(string(output), "\n"), "\n") {
+		// The first line is a header ("Timestamp  (process)[PID]").
+		if line != "" && !strings.HasPrefix(line, "Timestamp") {
+			result = append(result, line)
+		}
+	}
+	if len(result) > lines {
+		result = result[len(result)-lines:]
+	}
+	return result, nil
+}
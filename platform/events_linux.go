@@ -0,0 +1,28 @@
+//go:build linux
+
+package platform
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SystemEvents returns up to lines of recent warnings and errors from the
+// systemd journal, oldest first, for diagnostics.
+func SystemEvents(lines int) ([]string, error) {
+	if lines <= 0 {
+		lines = 100
+	}
+
+	cmd := journalctl(false, "-p", "warning", "-n", fmt.Sprint(lines), "--no-pager", "-o", "short-iso")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("read journal: %w", err)
+	}
+
+	text := strings.TrimRight(string(output), "\n")
+	if text == "" || text == "-- No entries --" {
+		return nil, nil
+	}
+	return strings.Split(text, "\n"), nil
+}
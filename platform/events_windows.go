@@ -0,0 +1,35 @@
+//go:build windows
+
+package platform
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SystemEvents returns up to lines of recent warnings and errors from the
+// Application and System event logs, oldest first, for diagnostics.
+func SystemEvents(lines int) ([]string, error) {
+	if lines <= 0 {
+		lines = 100
+	}
+
+	const query = "*[System[(Level=1 or Level=2 or Level=3)]]"
+	appEvents, appErr := queryEventLog("Application", query, lines)
+	sysEvents, sysErr := queryEventLog("System", query, lines)
+	if appErr != nil && sysErr != nil {
+		return nil, fmt.Errorf("query event log: %w", appErr)
+	}
+
+	events := append(appEvents, sysEvents...)
+	sort.SliceStable(events, func(i, j int) bool { return events[i].date < events[j].date })
+	if len(events) > lines {
+		events = events[len(events)-lines:]
+	}
+
+	result := make([]string, 0, len(events))
+	for _, e := range events {
+		result = append(result, fmt.Sprintf("%s %s %s: %s", e.date, e.level, e.source, e.message))
+	}
+	return result, nil
+}
@@ -0,0 +1,225 @@
+package platform
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// ErrHostsConflict is returned by AddHostsEntry when a host name is already
+// mapped to a different address of the same family (IPv4 or IPv6), which
+// would take precedence over the new entry.
+var ErrHostsConflict = errors.New("host name is mapped to another address")
+
+// HasHostsEntry reports whether the hosts file maps hostname to ip.
+func HasHostsEntry(ip, hostname string) (bool, error) {
+	data, err := os.ReadFile(HostsFilePath())
+	if err != nil {
+		return false, err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		lineIP, names := parseHostsLine(line)
+		if sameIP(lineIP, ip) && slices.ContainsFunc(names, equalFoldFunc(hostname)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// AddHostsEntry maps hostnames to ip in the system hosts file. Names already
+// mapped to ip are left alone, so calling it again changes nothing; a name
+// mapped to another address fails with ErrHostsConflict. The previous file
+// is kept as hosts.bak next to it, and the new one replaces it in a single
+// rename. Requires administrator (root) rights.
+func AddHostsEntry(ip string, hostnames ...string) error {
+	if net.ParseIP(ip) == nil {
+		return fmt.Errorf("invalid IP address %q", ip)
+	}
+	return editHostsFile(HostsFilePath(), func(lines []string) ([]string, error) {
+		return addHostsLines(lines, ip, hostnames)
+	})
+}
+
+// RemoveHostsEntry removes the mappings of hostnames to ip from the system
+// hosts file. Lines left without names are deleted; other addresses and
+// comments are kept. Like AddHostsEntry it keeps a backup, writes atomically
+// and requires administrator (root) rights.
+func RemoveHostsEntry(ip string, hostnames ...string) error {
+	return editHostsFile(HostsFilePath(), func(lines []string) ([]string, error) {
+		return removeHostsLines(lines, ip, hostnames), nil
+	})
+}
+
+// addHostsLines returns lines with a line mapping the hostnames that aren't
+// mapped yet to ip, or nil if all are.
+func addHostsLines(lines []string, ip string, hostnames []string) ([]string, error) {
+	missing := slices.Clone(hostnames)
+	for _, line := range lines {
+		lineIP, names := parseHostsLine(line)
+		for _, name := range names {
+			if !slices.ContainsFunc(missing, equalFoldFunc(name)) {
+				continue
+			}
+			if !sameIP(lineIP, ip) && sameIPFamily(lineIP, ip) {
+				return nil, fmt.Errorf("%w: %s is %s", ErrHostsConflict, name, lineIP)
+			}
+			missing = slices.DeleteFunc(missing, equalFoldFunc(name))
+		}
+	}
+	if len(missing) == 0 {
+		return nil, nil
+	}
+	return append(lines, ip+"\t"+strings.Join(missing, " ")), nil
+}
+
+// removeHostsLines returns lines without the mappings of hostnames to ip, or
+// nil if there are none.
+func removeHostsLines(lines []string, ip string, hostnames []string) []string {
+	var result []string
+	changed := false
+	for _, line := range lines {
+		lineIP, names := parseHostsLine(line)
+		if !sameIP(lineIP, ip) {
+			result = append(result, line)
+			continue
+		}
+		kept := slices.DeleteFunc(slices.Clone(names), func(name string) bool {
+			return slices.ContainsFunc(hostnames, equalFoldFunc(name))
+		})
+		switch {
+		case len(kept) == len(names):
+			result = append(result, line)
+		case len(kept) > 0:
+			result = append(result, lineIP+"\t"+strings.Join(kept, " ")+hostsComment(line))
+			changed = true
+		default:
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	if result == nil {
+		result = []string{}
+	}
+	return result
+}
+
+// editHostsFile applies edit to the lines of a hosts file and writes the
+// result, unless edit returns nil lines for no change. Line endings of the
+// file are kept.
+func editHostsFile(path string, edit func(lines []string) ([]string, error)) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	eol := "\n"
+	if strings.Contains(string(data), "\r\n") {
+		eol = "\r\n"
+	}
+	text := strings.TrimSuffix(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	var lines []string
+	if text != "" {
+		lines = strings.Split(text, "\n")
+	}
+
+	lines, err = edit(lines)
+	if err != nil || lines == nil {
+		return err
+	}
+	if err := copyHostsFile(path, filepath.Join(filepath.Dir(path), "hosts.bak")); err != nil {
+		return fmt.Errorf("back up hosts file: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".hosts-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.WriteString(strings.Join(lines, eol) + eol)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), info.Mode().Perm()); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// copyHostsFile copies the hosts file to dst, replacing it.
+func copyHostsFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// parseHostsLine returns the address and names of a hosts file line, or ""
+// for blank and comment lines.
+func parseHostsLine(line string) (ip string, names []string) {
+	if i := strings.IndexByte(line, '#'); i >= 0 {
+		line = line[:i]
+	}
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return "", nil
+	}
+	return fields[0], fields[1:]
+}
+
+// hostsComment returns the trailing comment of a hosts file line preceded by
+// a space, or "".
+func hostsComment(line string) string {
+	i := strings.IndexByte(line, '#')
+	if i < 0 {
+		return ""
+	}
+	return " " + line[i:]
+}
+
+// sameIP reports whether two addresses are equal, e.g. "::1" and
+// "0:0:0:0:0:0:0:1".
+func sameIP(a, b string) bool {
+	ipA, ipB := net.ParseIP(a), net.ParseIP(b)
+	if ipA == nil || ipB == nil {
+		return a != "" && a == b
+	}
+	return ipA.Equal(ipB)
+}
+
+// sameIPFamily reports whether two addresses are both IPv4 or both IPv6.
+func sameIPFamily(a, b string) bool {
+	ipA, ipB := net.ParseIP(a), net.ParseIP(b)
+	return ipA != nil && ipB != nil && (ipA.To4() == nil) == (ipB.To4() == nil)
+}
+
+// equalFoldFunc returns a function reporting whether a string equals s,
+// ignoring case, for slices.ContainsFunc and DeleteFunc.
+func equalFoldFunc(s string) func(string) bool {
+	return func(t string) bool {
+		return strings.EqualFold(s, t)
+	}
+}
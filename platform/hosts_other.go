@@ -0,0 +1,8 @@
+//go:build !windows
+
+package platform
+
+// HostsFilePath returns the path of the system hosts file, /etc/hosts.
+func HostsFilePath() string {
+	return "/etc/hosts"
+}
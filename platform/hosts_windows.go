@@ -0,0 +1,18 @@
+//go:build windows
+
+package platform
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// HostsFilePath returns the path of the system hosts file,
+// %SystemRoot%\System32\drivers\etc\hosts.
+func HostsFilePath() string {
+	root := os.Getenv("SystemRoot")
+	if root == "" {
+		root = `C:\Windows`
+	}
+	return filepath.Join(root, "System32", "drivers", "etc", "hosts")
+}
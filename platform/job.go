@@ -0,0 +1,24 @@
+package platform
+
+import "errors"
+
+// ErrJobUnsupported is returned by the Job functions on platforms other
+// than Windows.
+var ErrJobUnsupported = errors.New("job objects are only available on Windows")
+
+// Job is a Windows job object that terminates its processes when closed, so
+// helper programs an installer starts can't outlive it, even if the
+// installer crashes or is killed. Processes started by a process in the job
+// join it too. Create one with NewJob.
+type Job struct {
+	handle uintptr
+}
+
+// JobInfo describes the job object a process runs in, as returned by
+// CurrentJob. Terminals, IDEs, CI runners and self-extractors often run
+// their children in a job that ends them when it closes.
+type JobInfo struct {
+	KillOnClose      bool // Processes are terminated when the job closes
+	BreakawayAllowed bool // Children may leave the job (see StartDetached)
+	SilentBreakaway  bool // Children start outside the job without asking
+}
@@ -0,0 +1,51 @@
+//go:build !windows
+
+package platform
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// NewJob returns ErrJobUnsupported: job objects are Windows-specific.
+func NewJob() (*Job, error) {
+	return nil, ErrJobUnsupported
+}
+
+// Assign returns ErrJobUnsupported.
+func (j *Job) Assign(pid int) error {
+	return ErrJobUnsupported
+}
+
+// Start returns ErrJobUnsupported without starting cmd.
+func (j *Job) Start(cmd *exec.Cmd) error {
+	return ErrJobUnsupported
+}
+
+// Terminate returns ErrJobUnsupported.
+func (j *Job) Terminate(exitCode uint32) error {
+	return ErrJobUnsupported
+}
+
+// Close does nothing.
+func (j *Job) Close() error {
+	return nil
+}
+
+// CurrentJob returns false: job objects are Windows-specific.
+func CurrentJob() (JobInfo, bool) {
+	return JobInfo{}, false
+}
+
+// StartDetached starts cmd in a new session, so it keeps running when the
+// installer's terminal closes.
+func StartDetached(cmd *exec.Cmd) (detached bool, err error) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setsid = true
+	if err := cmd.Start(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
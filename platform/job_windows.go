@@ -0,0 +1,187 @@
+//go:build windows
+
+package platform
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// errJobClosed is returned by Job methods after Close.
+var errJobClosed = errors.New("job is closed")
+
+// NewJob creates a job object that terminates its processes when it is
+// closed, explicitly with Close or by Windows when the installer exits.
+func NewJob() (*Job, error) {
+	handle, err := windows.CreateJobObject(nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create job object: %w", err)
+	}
+	info := windows.JOBOBJECT_EXTENDED_LIMIT_INFORMATION{}
+	info.BasicLimitInformation.LimitFlags = windows.JOB_OBJECT_LIMIT_KILL_ON_JOB_CLOSE
+	if _, err := windows.SetInformationJobObject(handle, windows.JobObjectExtendedLimitInformation,
+		uintptr(unsafe.Pointer(&info)), uint32(unsafe.Sizeof(info))); err != nil {
+		windows.CloseHandle(handle)
+		return nil, fmt.Errorf("set job limits: %w", err)
+	}
+	return &Job{handle: uintptr(handle)}, nil
+}
+
+// Assign adds a running process to the job. Processes it started before
+// don't join; use Start to add a process before it runs any code.
+func (j *Job) Assign(pid int) error {
+	if j.handle == 0 {
+		return errJobClosed
+	}
+	process, err := windows.OpenProcess(windows.PROCESS_SET_QUOTA|windows.PROCESS_TERMINATE, false, uint32(pid))
+	if err != nil {
+		return fmt.Errorf("open process %d: %w", pid, err)
+	}
+	defer windows.CloseHandle(process)
+	if err := windows.AssignProcessToJobObject(windows.Handle(j.handle), process); err != nil {
+		return fmt.Errorf("assign process %d to job: %w", pid, err)
+	}
+	return nil
+}
+
+// Start starts cmd and adds it to the job. The process is created suspended
+// and only resumed once it is in the job, so everything it starts joins too.
+// If it can't be added, the process is killed and an error returned, so no
+// helper runs unsupervised.
+func (j *Job) Start(cmd *exec.Cmd) error {
+	if j.handle == 0 {
+		return errJobClosed
+	}
+	cmd.SysProcAttr = withCreationFlags(cmd.SysProcAttr, windows.CREATE_SUSPENDED)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	err := j.Assign(cmd.Process.Pid)
+	if err == nil {
+		err = resumeProcess(uint32(cmd.Process.Pid))
+	}
+	if err != nil {
+		cmd.Process.Kill()
+		cmd.Wait()
+		return err
+	}
+	return nil
+}
+
+// withCreationFlags returns a copy of attr with flags added, leaving the
+// caller's SysProcAttr unchanged.
+func withCreationFlags(attr *syscall.SysProcAttr, flags uint32) *syscall.SysProcAttr {
+	var c syscall.SysProcAttr
+	if attr != nil {
+		c = *attr
+	}
+	c.CreationFlags |= flags
+	return &c
+}
+
+// resumeProcess resumes the threads of a process created with
+// CREATE_SUSPENDED. exec.Cmd doesn't keep the main thread's handle, so the
+// threads are found in a snapshot.
+func resumeProcess(pid uint32) error {
+	snapshot, err := windows.CreateToolhelp32Snapshot(windows.TH32CS_SNAPTHREAD, 0)
+	if err != nil {
+		return fmt.Errorf("snapshot threads: %w", err)
+	}
+	defer windows.CloseHandle(snapshot)
+
+	entry := windows.ThreadEntry32{Size: uint32(unsafe.Sizeof(windows.ThreadEntry32{}))}
+	resumed := false
+	for err = windows.Thread32First(snapshot, &entry); err == nil; err = windows.Thread32Next(snapshot, &entry) {
+		if entry.OwnerProcessID != pid {
+			continue
+		}
+		thread, err := windows.OpenThread(windows.THREAD_SUSPEND_RESUME, false, entry.ThreadID)
+		if err != nil {
+			return fmt.Errorf("open thread %d: %w", entry.ThreadID, err)
+		}
+		_, err = windows.ResumeThread(thread)
+		windows.CloseHandle(thread)
+		if err != nil {
+			return fmt.Errorf("resume thread %d: %w", entry.ThreadID, err)
+		}
+		resumed = true
+	}
+	if !resumed {
+		return fmt.Errorf("resume process %d: no threads found", pid)
+	}
+	return nil
+}
+
+// Terminate ends all processes in the job with exitCode. The job stays
+// usable.
+func (j *Job) Terminate(exitCode uint32) error {
+	if j.handle == 0 {
+		return errJobClosed
+	}
+	return windows.TerminateJobObject(windows.Handle(j.handle), exitCode)
+}
+
+// Close closes the job, which terminates the processes still in it.
+func (j *Job) Close() error {
+	if j.handle == 0 {
+		return nil
+	}
+	err := windows.CloseHandle(windows.Handle(j.handle))
+	j.handle = 0
+	return err
+}
+
+// CurrentJob returns the limits of the job object the current process runs
+// in, and false if it runs in none.
+func CurrentJob() (JobInfo, bool) {
+	var info windows.JOBOBJECT_EXTENDED_LIMIT_INFORMATION
+	// A nil job handle queries the caller's job; it fails outside of one
+	err := windows.QueryInformationJobObject(0, windows.JobObjectExtendedLimitInformation,
+		uintptr(unsafe.Pointer(&info)), uint32(unsafe.Sizeof(info)), nil)
+	if err != nil {
+		return JobInfo{}, false
+	}
+	flags := info.BasicLimitInformation.LimitFlags
+	return JobInfo{
+		KillOnClose:      flags&windows.JOB_OBJECT_LIMIT_KILL_ON_JOB_CLOSE != 0,
+		BreakawayAllowed: flags&windows.JOB_OBJECT_LIMIT_BREAKAWAY_OK != 0,
+		SilentBreakaway:  flags&windows.JOB_OBJECT_LIMIT_SILENT_BREAKAWAY_OK != 0,
+	}, true
+}
+
+// StartDetached starts cmd outside the caller's job object, so it keeps
+// running when the job closes, e.g. an app launched at the end of an
+// installer that a self-extractor started in a kill-on-close job. Inside a
+// job it first tries CREATE_BREAKAWAY_FROM_JOB; if that is refused, by the
+// caller's job or by an outer one in a nested chain, cmd starts inside the
+// job and detached is false. Outside a job it is a plain cmd.Start.
+func StartDetached(cmd *exec.Cmd) (detached bool, err error) {
+	info, inJob := CurrentJob()
+	if !inJob || info.SilentBreakaway {
+		if err := cmd.Start(); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	// A Cmd can only be started once, so keep an unstarted copy for the retry.
+	retry := *cmd
+	cmd.SysProcAttr = withCreationFlags(cmd.SysProcAttr, windows.CREATE_BREAKAWAY_FROM_JOB)
+	err = cmd.Start()
+	if err == nil {
+		return true, nil
+	}
+	if !errors.Is(err, windows.ERROR_ACCESS_DENIED) {
+		return false, err
+	}
+	*cmd = retry
+	if err := cmd.Start(); err != nil {
+		return false, err
+	}
+	return false, nil
+}
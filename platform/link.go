@@ -0,0 +1,23 @@
+package platform
+
+import (
+	"errors"
+	"os"
+)
+
+// ErrSymlinkPrivilege is returned by CreateSymlink on Windows when the
+// process may not create symbolic links: that requires Developer Mode or an
+// elevated administrator. Directory junctions (see CreateJunction) and hard
+// links don't.
+var ErrSymlinkPrivilege = errors.New("creating symbolic links requires Developer Mode or administrator rights")
+
+// CanCreateSymlinks reports whether CreateSymlink will work, by creating
+// and removing a test link in the temporary directory.
+func CanCreateSymlinks() bool {
+	dir, err := os.MkdirTemp("", "symlink-test-*")
+	if err != nil {
+		return false
+	}
+	defer os.RemoveAll(dir)
+	return CreateSymlink(dir+string(os.PathSeparator)+"link", dir) == nil
+}
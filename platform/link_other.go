@@ -0,0 +1,32 @@
+//go:build !windows
+
+package platform
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// CreateSymlink creates a symbolic link at link pointing to target, a file
+// or directory. A relative target is relative to the link's directory.
+func CreateSymlink(link, target string) error {
+	return os.Symlink(target, link)
+}
+
+// CreateJunction creates a symbolic link with an absolute target: junctions
+// are Windows-specific, and symbolic links need no privileges elsewhere.
+func CreateJunction(link, target string) error {
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(filepath.Dir(link), target)
+	}
+	target, err := filepath.Abs(target)
+	if err != nil {
+		return err
+	}
+	return os.Symlink(target, link)
+}
+
+// IsDeveloperModeEnabled returns false; Developer Mode is Windows-specific.
+func IsDeveloperModeEnabled() bool {
+	return false
+}
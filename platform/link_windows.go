@@ -0,0 +1,107 @@
+//go:build windows
+
+package platform
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"unicode/utf16"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/registry"
+)
+
+// CreateSymlink creates a symbolic link at link pointing to target, a file
+// or directory. A relative target is relative to the link's directory. Fails
+// with ErrSymlinkPrivilege unless Developer Mode is on or the process is
+// elevated.
+func CreateSymlink(link, target string) error {
+	err := os.Symlink(target, link)
+	if errors.Is(err, windows.ERROR_PRIVILEGE_NOT_HELD) {
+		return fmt.Errorf("%w: %s", ErrSymlinkPrivilege, link)
+	}
+	return err
+}
+
+// CreateJunction creates a directory junction at link pointing to the
+// directory target. Junctions work like directory symbolic links but need
+// no privileges; they can't point to files or network shares, and a
+// relative target is made absolute, so the junction breaks if the folders
+// are moved.
+func CreateJunction(link, target string) error {
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(filepath.Dir(link), target)
+	}
+	target, err := filepath.Abs(target)
+	if err != nil {
+		return err
+	}
+	if info, err := os.Stat(target); err != nil {
+		return err
+	} else if !info.IsDir() {
+		return fmt.Errorf("junction target %s is not a directory", target)
+	}
+
+	if err := os.Mkdir(link, 0755); err != nil {
+		return err
+	}
+	if err := setJunctionTarget(link, target); err != nil {
+		os.Remove(link)
+		return &os.LinkError{Op: "junction", Old: target, New: link, Err: err}
+	}
+	return nil
+}
+
+// setJunctionTarget turns the empty directory link into a mount point
+// reparse point for target.
+func setJunctionTarget(link, target string) error {
+	name, err := windows.UTF16PtrFromString(link)
+	if err != nil {
+		return err
+	}
+	handle, err := windows.CreateFile(name, windows.GENERIC_WRITE, 0, nil, windows.OPEN_EXISTING,
+		windows.FILE_FLAG_OPEN_REPARSE_POINT|windows.FILE_FLAG_BACKUP_SEMANTICS, 0)
+	if err != nil {
+		return err
+	}
+	defer windows.CloseHandle(handle)
+
+	// REPARSE_DATA_BUFFER with MountPointReparseBuffer: the NT path to
+	// substitute and the path to display, each NUL-terminated
+	substitute := utf16.Encode([]rune(`\??\` + target))
+	display := utf16.Encode([]rune(target))
+	paths := append(append(substitute, 0), append(display, 0)...)
+
+	const headerSize = 8 // Offsets and lengths of the two names
+	buf := make([]byte, 8+headerSize+len(paths)*2)
+	le := binary.LittleEndian
+	le.PutUint32(buf[0:], windows.IO_REPARSE_TAG_MOUNT_POINT)
+	le.PutUint16(buf[4:], uint16(headerSize+len(paths)*2)) // ReparseDataLength
+	le.PutUint16(buf[8:], 0)                               // SubstituteNameOffset
+	le.PutUint16(buf[10:], uint16(len(substitute)*2))      // SubstituteNameLength
+	le.PutUint16(buf[12:], uint16((len(substitute)+1)*2))  // PrintNameOffset
+	le.PutUint16(buf[14:], uint16(len(display)*2))         // PrintNameLength
+	for i, c := range paths {
+		le.PutUint16(buf[16+i*2:], c)
+	}
+
+	var returned uint32
+	return windows.DeviceIoControl(handle, windows.FSCTL_SET_REPARSE_POINT,
+		&buf[0], uint32(len(buf)), nil, 0, &returned, nil)
+}
+
+// IsDeveloperModeEnabled reports whether Windows Developer Mode is on, which
+// lets non-elevated processes create symbolic links.
+func IsDeveloperModeEnabled() bool {
+	key, err := registry.OpenKey(registry.LOCAL_MACHINE,
+		`SOFTWARE\Microsoft\Windows\CurrentVersion\AppModelUnlock`, registry.QUERY_VALUE)
+	if err != nil {
+		return false
+	}
+	defer key.Close()
+	enabled, _, err := key.GetIntegerValue("AllowDevelopmentWithoutDevLicense")
+	return err == nil && enabled == 1
+}
@@ -0,0 +1,65 @@
+//go:build !windows
+
+package platform
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// PreferredLanguages returns the user's UI languages in order of preference,
+// as BCP 47 tags such as "de-DE" or "zh-Hant-TW". On macOS it reads the
+// AppleLanguages preference; otherwise (and as a fallback) it uses the
+// LANGUAGE, LC_ALL, LC_MESSAGES and LANG environment variables.
+func PreferredLanguages() []string {
+	var langs []string
+	if runtime.GOOS == "darwin" {
+		langs = appleLanguages()
+	}
+
+	// LANGUAGE is a colon-separated priority list (GNU gettext)
+	for _, l := range strings.Split(os.Getenv("LANGUAGE"), ":") {
+		langs = appendLocale(langs, l)
+	}
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := os.Getenv(name); v != "" {
+			langs = appendLocale(langs, v)
+			break
+		}
+	}
+	return langs
+}
+
+// appendLocale converts a POSIX locale such as "pt_BR.UTF-8" or
+// "sr_RS@latin" to a BCP 47 tag and appends it. "C" and "POSIX" are skipped.
+func appendLocale(langs []string, locale string) []string {
+	locale, _, _ = strings.Cut(locale, ".")
+	locale, _, _ = strings.Cut(locale, "@")
+	if locale == "" || locale == "C" || locale == "POSIX" {
+		return langs
+	}
+	return append(langs, strings.ReplaceAll(locale, "_", "-"))
+}
+
+// appleLanguages reads the macOS AppleLanguages preference, which prints as
+//
+//	(
+//	    "en-US",
+//	    de
+//	)
+func appleLanguages() []string {
+	out, err := exec.Command("defaults", "read", "-g", "AppleLanguages").Output()
+	if err != nil {
+		return nil
+	}
+	var langs []string
+	for _, line := range strings.Split(string(out), "\n") {
+		lang := strings.Trim(strings.TrimSpace(line), `",`)
+		if lang != "" && lang != "(" && lang != ")" {
+			langs = append(langs, lang)
+		}
+	}
+	return langs
+}
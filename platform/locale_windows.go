@@ -0,0 +1,16 @@
+//go:build windows
+
+package platform
+
+import "golang.org/x/sys/windows"
+
+// PreferredLanguages returns the user's UI languages in order of preference,
+// as BCP 47 tags such as "de-DE" or "zh-Hant-TW". It uses the Windows display
+// language list (GetUserPreferredUILanguages).
+func PreferredLanguages() []string {
+	langs, err := windows.GetUserPreferredUILanguages(windows.MUI_LANGUAGE_NAME)
+	if err != nil {
+		return nil
+	}
+	return langs
+}
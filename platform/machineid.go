@@ -0,0 +1,58 @@
+package platform
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// ErrMachineIDUnavailable is returned when the system has no machine
+// identifier that can be read.
+var ErrMachineIDUnavailable = errors.New("machine ID not available")
+
+// MachineID returns a stable identifier for this computer, for license
+// binding or de-duplicating telemetry. It is a SHA-256 hash (64 hex
+// characters) of the system's own identifier, so the raw value isn't
+// disclosed, and it stays the same across reinstalls of the app.
+//
+// The source is MachineGuid on Windows, /etc/machine-id on Linux and
+// IOPlatformUUID on macOS. They change when the OS is reinstalled and may be
+// shared by cloned VMs that were not generalized.
+func MachineID() (string, error) {
+	raw, err := RawMachineID()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// MachineIDFor returns MachineID keyed with appID (an HMAC-SHA256), so two
+// apps get unrelated IDs for the same computer and can't correlate their
+// users.
+func MachineIDFor(appID string) (string, error) {
+	raw, err := RawMachineID()
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, []byte(appID))
+	mac.Write([]byte(raw))
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// RawMachineID returns the system's machine identifier unhashed, lowercased
+// and trimmed. Prefer MachineID or MachineIDFor for anything sent off the
+// computer.
+func RawMachineID() (string, error) {
+	id, err := machineID()
+	if err != nil {
+		return "", err
+	}
+	id = strings.ToLower(strings.TrimSpace(id))
+	if id == "" {
+		return "", ErrMachineIDUnavailable
+	}
+	return id, nil
+}
@@ -0,0 +1,26 @@
+//go:build darwin
+
+package platform
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// machineID reads the hardware UUID (IOPlatformUUID) from the I/O Registry.
+func machineID() (string, error) {
+	out, err := exec.Command("ioreg", "-rd1", "-c", "IOPlatformExpertDevice").Output()
+	if err != nil {
+		return "", ErrMachineIDUnavailable
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		if !strings.Contains(line, `"IOPlatformUUID"`) {
+			continue
+		}
+		// "IOPlatformUUID" = "564D...-...."
+		if _, value, ok := strings.Cut(line, "="); ok {
+			return strings.Trim(strings.TrimSpace(value), `"`), nil
+		}
+	}
+	return "", ErrMachineIDUnavailable
+}
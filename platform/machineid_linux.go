@@ -0,0 +1,20 @@
+//go:build linux
+
+package platform
+
+import (
+	"os"
+	"strings"
+)
+
+// machineID reads the systemd machine ID, falling back to the D-Bus copy on
+// systems without systemd.
+func machineID() (string, error) {
+	for _, path := range []string{"/etc/machine-id", "/var/lib/dbus/machine-id"} {
+		data, err := os.ReadFile(path)
+		if err == nil && strings.TrimSpace(string(data)) != "" {
+			return string(data), nil
+		}
+	}
+	return "", ErrMachineIDUnavailable
+}
@@ -0,0 +1,26 @@
+//go:build windows
+
+package platform
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/windows/registry"
+)
+
+// machineID reads MachineGuid, which Windows Setup generates per
+// installation. The 64-bit view is used so 32-bit builds read the same value.
+func machineID() (string, error) {
+	key, err := registry.OpenKey(registry.LOCAL_MACHINE, `SOFTWARE\Microsoft\Cryptography`,
+		registry.QUERY_VALUE|registry.WOW64_64KEY)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrMachineIDUnavailable, err)
+	}
+	defer key.Close()
+
+	guid, _, err := key.GetStringValue("MachineGuid")
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrMachineIDUnavailable, err)
+	}
+	return guid, nil
+}
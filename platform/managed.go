@@ -0,0 +1,10 @@
+package platform
+
+// IsManagedDevice reports whether an organization manages the computer,
+// through a directory domain (see IsDomainJoined) or mobile device
+// management (see IsMDMManaged). Installers can then default to settings an
+// administrator is likely to want, such as no automatic updates or
+// telemetry, and expect policies to override the user's choices.
+func IsManagedDevice() bool {
+	return IsDomainJoined() || IsMDMManaged()
+}
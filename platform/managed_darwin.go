@@ -0,0 +1,30 @@
+//go:build darwin
+
+package platform
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// IsDomainJoined reports whether the Mac is bound to an Active Directory
+// domain.
+func IsDomainJoined() bool {
+	output, err := exec.Command("dsconfigad", "-show").Output()
+	return err == nil && strings.Contains(string(output), "Active Directory Domain")
+}
+
+// IsMDMManaged reports whether the Mac is enrolled in mobile device
+// management, such as Jamf or Intune.
+func IsMDMManaged() bool {
+	output, err := exec.Command("profiles", "status", "-type", "enrollment").Output()
+	if err != nil {
+		return false
+	}
+	for _, line := range strings.Split(string(output), "\n") {
+		if strings.HasPrefix(line, "MDM enrollment:") {
+			return strings.HasPrefix(strings.TrimSpace(strings.TrimPrefix(line, "MDM enrollment:")), "Yes")
+		}
+	}
+	return false
+}
@@ -0,0 +1,21 @@
+//go:build linux
+
+package platform
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// IsDomainJoined reports whether the computer is joined to an Active
+// Directory or FreeIPA domain with realmd.
+func IsDomainJoined() bool {
+	output, err := exec.Command("realm", "list", "--name-only").Output()
+	return err == nil && strings.TrimSpace(string(output)) != ""
+}
+
+// IsMDMManaged returns false; Linux has no standard device management
+// enrollment.
+func IsMDMManaged() bool {
+	return false
+}
@@ -0,0 +1,62 @@
+//go:build windows
+
+package platform
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/registry"
+)
+
+// IsDomainJoined reports whether the computer is joined to an Active
+// Directory domain or to Microsoft Entra ID (Azure AD). Workgroup computers
+// and personal devices that only registered a work account are not.
+func IsDomainJoined() bool {
+	var name *uint16
+	var status uint32
+	if windows.NetGetJoinInformation(nil, &name, &status) == nil {
+		windows.NetApiBufferFree((*byte)(unsafe.Pointer(name)))
+		if status == windows.NetSetupDomainName {
+			return true
+		}
+	}
+
+	key, err := registry.OpenKey(registry.LOCAL_MACHINE,
+		`SYSTEM\CurrentControlSet\Control\CloudDomainJoin\JoinInfo`, registry.ENUMERATE_SUB_KEYS)
+	if err != nil {
+		return false
+	}
+	defer key.Close()
+	joins, err := key.ReadSubKeyNames(1)
+	return err == nil && len(joins) > 0
+}
+
+// IsMDMManaged reports whether the computer is enrolled in mobile device
+// management, such as Intune.
+func IsMDMManaged() bool {
+	enrollments, err := registry.OpenKey(registry.LOCAL_MACHINE,
+		`SOFTWARE\Microsoft\Enrollments`, registry.ENUMERATE_SUB_KEYS)
+	if err != nil {
+		return false
+	}
+	defer enrollments.Close()
+	ids, err := enrollments.ReadSubKeyNames(-1)
+	if err != nil {
+		return false
+	}
+
+	for _, id := range ids {
+		key, err := registry.OpenKey(enrollments, id, registry.QUERY_VALUE)
+		if err != nil {
+			continue
+		}
+		provider, _, _ := key.GetStringValue("ProviderID")
+		state, _, _ := key.GetIntegerValue("EnrollmentState")
+		key.Close()
+		if provider != "" && state == 1 { // 1 = enrolled
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,7 @@
+package platform
+
+import "errors"
+
+// ErrMiniDumpUnsupported is returned by WriteMiniDump where the platform has
+// no minidump format.
+var ErrMiniDumpUnsupported = errors.New("minidumps not supported on this platform")
@@ -0,0 +1,9 @@
+//go:build !windows
+
+package platform
+
+// WriteMiniDump writes a minidump of the current process to path. It is only
+// available on Windows.
+func WriteMiniDump(path string) error {
+	return ErrMiniDumpUnsupported
+}
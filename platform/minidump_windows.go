@@ -0,0 +1,52 @@
+//go:build windows
+
+package platform
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	dbghelp               = windows.NewLazySystemDLL("dbghelp.dll")
+	procMiniDumpWriteDump = dbghelp.NewProc("MiniDumpWriteDump")
+)
+
+// MINIDUMP_TYPE flags: thread, module and handle information, without the
+// full process memory, keeps dumps small enough to attach to a ticket.
+const (
+	miniDumpWithHandleData        = 0x00000004
+	miniDumpWithUnloadedModules   = 0x00000020
+	miniDumpWithProcessThreadData = 0x00000100
+	miniDumpWithThreadInfo        = 0x00001000
+)
+
+// WriteMiniDump writes a minidump of the current process to path, for
+// debugging a crash with WinDbg or Visual Studio. The dump holds the
+// threads, stacks and loaded modules, not the full process memory.
+func WriteMiniDump(path string) error {
+	if err := procMiniDumpWriteDump.Find(); err != nil {
+		return ErrMiniDumpUnsupported
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	const dumpType = miniDumpWithHandleData | miniDumpWithUnloadedModules |
+		miniDumpWithProcessThreadData | miniDumpWithThreadInfo
+	r, _, callErr := procMiniDumpWriteDump.Call(
+		uintptr(windows.CurrentProcess()),
+		uintptr(windows.GetCurrentProcessId()),
+		f.Fd(),
+		dumpType,
+		0, 0, 0,
+	)
+	closeErr := f.Close()
+	if r == 0 {
+		os.Remove(path)
+		return fmt.Errorf("MiniDumpWriteDump: %w", callErr)
+	}
+	return closeErr
+}
@@ -0,0 +1,41 @@
+package platform
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// RemoveMotW removes the Mark of the Web from a file, or from a directory
+// and everything in it: the Zone.Identifier stream on Windows, the
+// com.apple.quarantine attribute on macOS. Files that came in the same
+// download as the installer carry it, and SmartScreen, Smart App Control or
+// Gatekeeper then block them when the installer launches them. Files
+// without the mark are left alone. Does nothing on Linux.
+func RemoveMotW(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return removeMotW(path)
+	}
+	return filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		return removeMotW(p)
+	})
+}
+
+// ExecutableHasMotW reports whether the running executable carries the Mark
+// of the Web (see HasMotW). If so, files that were downloaded or extracted
+// with it probably do as well and may be blocked; check them with HasMotW
+// or installer.ShowBlockedFiles before launching them.
+func ExecutableHasMotW() bool {
+	exe, err := os.Executable()
+	if err != nil {
+		return false
+	}
+	return HasMotW(exe)
+}
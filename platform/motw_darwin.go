@@ -0,0 +1,30 @@
+//go:build darwin
+
+package platform
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// quarantineAttribute is the extended attribute macOS sets on downloaded
+// files, its equivalent of the Mark of the Web.
+const quarantineAttribute = "com.apple.quarantine"
+
+// HasMotW reports whether a file or bundle carries the quarantine attribute,
+// so that Gatekeeper checks it before it runs.
+func HasMotW(path string) bool {
+	_, err := unix.Getxattr(path, quarantineAttribute, nil)
+	return err == nil
+}
+
+// removeMotW deletes the quarantine attribute of a file or directory.
+func removeMotW(path string) error {
+	err := unix.Removexattr(path, quarantineAttribute)
+	if err == nil || errors.Is(err, unix.ENOATTR) {
+		return nil
+	}
+	return &os.PathError{Op: "removexattr", Path: path, Err: err}
+}
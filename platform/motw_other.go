@@ -0,0 +1,14 @@
+//go:build !windows && !darwin
+
+package platform
+
+// HasMotW reports whether a file carries the Mark of the Web. Linux has no
+// such mark, so it always returns false.
+func HasMotW(path string) bool {
+	return false
+}
+
+// removeMotW does nothing: Linux has no Mark of the Web.
+func removeMotW(path string) error {
+	return nil
+}
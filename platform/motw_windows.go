@@ -0,0 +1,57 @@
+//go:build windows
+
+package platform
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/windows"
+)
+
+// zoneIdentifierStream is the alternate data stream holding the Mark of the
+// Web, appended to a file name.
+const zoneIdentifierStream = ":Zone.Identifier"
+
+// zoneInternet is the URL security zone of downloaded files; zones above it
+// (Restricted Sites) are blocked as well.
+const zoneInternet = 3
+
+// HasMotW reports whether a file carries the Mark of the Web of the Internet
+// or Restricted Sites zone, so that SmartScreen checks it before it runs.
+// Marks of the local, intranet and trusted zones don't count.
+func HasMotW(path string) bool {
+	data, err := os.ReadFile(path + zoneIdentifierStream)
+	if err != nil {
+		return false
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(strings.TrimSpace(scanner.Text()), "=")
+		if ok && strings.EqualFold(key, "ZoneId") {
+			zone, err := strconv.Atoi(value)
+			return err == nil && zone >= zoneInternet
+		}
+	}
+	return false
+}
+
+// removeMotW deletes the Zone.Identifier stream of a file or directory.
+func removeMotW(path string) error {
+	name, err := windows.UTF16PtrFromString(path + zoneIdentifierStream)
+	if err != nil {
+		return err
+	}
+	err = windows.DeleteFile(name)
+	if errors.Is(err, windows.ERROR_FILE_NOT_FOUND) || errors.Is(err, windows.ERROR_PATH_NOT_FOUND) {
+		return nil
+	}
+	if err != nil {
+		return &os.PathError{Op: "remove", Path: path + zoneIdentifierStream, Err: err}
+	}
+	return nil
+}
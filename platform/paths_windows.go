@@ -32,6 +32,12 @@ func UserDesktopPath() (string, error) {
 	return windows.KnownFolderPath(windows.FOLDERID_Desktop, 0)
 }
 
+// UserStartupPath returns the path to the current user's Startup folder.
+// Example: C:\Users\<user>\AppData\Roaming\Microsoft\Windows\Start Menu\Programs\Startup
+func UserStartupPath() (string, error) {
+	return windows.KnownFolderPath(windows.FOLDERID_Startup, 0)
+}
+
 // ProgramFilesPath returns the path to the Program Files folder.
 // Example: C:\Program Files
 func ProgramFilesPath() string {
@@ -0,0 +1,15 @@
+//go:build !windows
+
+package platform
+
+// PolicyString returns false: policies are stored in the registry on Windows
+// only.
+func PolicyString(key, name string) (value string, ok bool) {
+	return "", false
+}
+
+// PolicyInt returns false: policies are stored in the registry on Windows
+// only.
+func PolicyInt(key, name string) (value uint64, ok bool) {
+	return 0, false
+}
@@ -0,0 +1,41 @@
+//go:build windows
+
+package platform
+
+import "golang.org/x/sys/windows/registry"
+
+// PolicyString returns a string value set by Group Policy or MDM under
+// SOFTWARE\Policies\<key> (e.g. key "Contoso\MyApp"). The machine policy in
+// HKEY_LOCAL_MACHINE takes precedence over the user policy in
+// HKEY_CURRENT_USER. ok is false if neither sets the value.
+func PolicyString(key, name string) (value string, ok bool) {
+	for _, root := range []registry.Key{registry.LOCAL_MACHINE, registry.CURRENT_USER} {
+		k, err := registry.OpenKey(root, `SOFTWARE\Policies\`+key, registry.QUERY_VALUE)
+		if err != nil {
+			continue
+		}
+		value, _, err = k.GetStringValue(name)
+		k.Close()
+		if err == nil {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// PolicyInt returns a DWORD or QWORD value set by Group Policy or MDM, like
+// PolicyString. Policies usually store switches as 1 (on) or 0 (off).
+func PolicyInt(key, name string) (value uint64, ok bool) {
+	for _, root := range []registry.Key{registry.LOCAL_MACHINE, registry.CURRENT_USER} {
+		k, err := registry.OpenKey(root, `SOFTWARE\Policies\`+key, registry.QUERY_VALUE)
+		if err != nil {
+			continue
+		}
+		value, _, err = k.GetIntegerValue(name)
+		k.Close()
+		if err == nil {
+			return value, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,44 @@
+package platform
+
+import "sync"
+
+var (
+	sleepMu    sync.Mutex
+	sleepCount int
+)
+
+// PreventSleep keeps the computer from going to sleep while the user is
+// idle, e.g. so a laptop doesn't suspend in the middle of copying files. The
+// display may still turn off. On Linux, reason is shown by
+// systemd-inhibit --list.
+//
+// Calls nest: the computer may sleep again once every PreventSleep has been
+// matched by an AllowSleep. webflow progress pages, and so
+// installer.RunSteps, do this while their work runs.
+//
+// Windows uses SetThreadExecutionState, Linux systemd-inhibit and macOS an
+// IOPMAssertion held by caffeinate.
+func PreventSleep(reason string) error {
+	sleepMu.Lock()
+	defer sleepMu.Unlock()
+	if sleepCount == 0 {
+		if err := preventSleep(reason); err != nil {
+			return err
+		}
+	}
+	sleepCount++
+	return nil
+}
+
+// AllowSleep undoes a PreventSleep call.
+func AllowSleep() {
+	sleepMu.Lock()
+	defer sleepMu.Unlock()
+	if sleepCount == 0 {
+		return
+	}
+	sleepCount--
+	if sleepCount == 0 {
+		allowSleep()
+	}
+}
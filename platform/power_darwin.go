@@ -0,0 +1,31 @@
+//go:build darwin
+
+package platform
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+)
+
+// sleepAssertion is the caffeinate process holding the power assertion.
+var sleepAssertion *exec.Cmd
+
+// preventSleep runs caffeinate, which holds a PreventUserIdleSystemSleep
+// assertion until it is killed or this process exits. The assertion is named
+// after caffeinate, not reason.
+func preventSleep(reason string) error {
+	cmd := exec.Command("caffeinate", "-i", "-w", strconv.Itoa(os.Getpid()))
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("caffeinate: %w", err)
+	}
+	sleepAssertion = cmd
+	return nil
+}
+
+func allowSleep() {
+	sleepAssertion.Process.Kill()
+	go sleepAssertion.Wait()
+	sleepAssertion = nil
+}
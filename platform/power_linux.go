@@ -0,0 +1,50 @@
+//go:build linux
+
+package platform
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// sleepInhibitor is the systemd-inhibit process holding the sleep lock.
+var sleepInhibitor struct {
+	cmd   *exec.Cmd
+	stdin io.Closer
+}
+
+// preventSleep runs systemd-inhibit with cat as its command: closing cat's
+// input, or this process exiting, ends both and releases the lock.
+func preventSleep(reason string) error {
+	who := "installer"
+	if exe, err := os.Executable(); err == nil {
+		who = filepath.Base(exe)
+	}
+	cmd := exec.Command("systemd-inhibit",
+		"--what=sleep:idle",
+		"--who="+who,
+		"--why="+reason,
+		"--mode=block",
+		"cat")
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		stdin.Close()
+		return fmt.Errorf("systemd-inhibit: %w", err)
+	}
+	sleepInhibitor.cmd = cmd
+	sleepInhibitor.stdin = stdin
+	return nil
+}
+
+func allowSleep() {
+	sleepInhibitor.stdin.Close()
+	go sleepInhibitor.cmd.Wait()
+	sleepInhibitor.cmd = nil
+	sleepInhibitor.stdin = nil
+}
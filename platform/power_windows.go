@@ -0,0 +1,47 @@
+//go:build windows
+
+package platform
+
+import (
+	"fmt"
+	"runtime"
+)
+
+var procSetThreadExecutionState = modkernel32.NewProc("SetThreadExecutionState")
+
+const (
+	esSystemRequired = 0x00000001
+	esContinuous     = 0x80000000
+)
+
+// sleepStop ends the thread holding the execution state.
+var sleepStop chan struct{}
+
+// preventSleep sets the execution state on a thread of its own, as the
+// state belongs to the calling thread and goroutines move between threads.
+// The reason is not shown anywhere on Windows.
+func preventSleep(reason string) error {
+	started := make(chan error, 1)
+	stop := make(chan struct{})
+	go func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		if r, _, err := procSetThreadExecutionState.Call(esContinuous | esSystemRequired); r == 0 {
+			started <- fmt.Errorf("SetThreadExecutionState: %w", err)
+			return
+		}
+		started <- nil
+		<-stop
+		procSetThreadExecutionState.Call(esContinuous)
+	}()
+	if err := <-started; err != nil {
+		return err
+	}
+	sleepStop = stop
+	return nil
+}
+
+func allowSleep() {
+	close(sleepStop)
+	sleepStop = nil
+}
@@ -0,0 +1,44 @@
+package platform
+
+import (
+	"fmt"
+	"os"
+)
+
+// checkProcessTreeRoot rejects pids KillProcessTree must never walk: 0, which
+// signals the caller's own process group and is the parent of everything in
+// some process tables, init (pid 1), whose descendants are the whole system,
+// and the calling process itself.
+func checkProcessTreeRoot(pid uint32) error {
+	if pid <= 1 || int(pid) == os.Getpid() {
+		return fmt.Errorf("refusing to kill process tree of pid %d", pid)
+	}
+	return nil
+}
+
+// processDescendants returns the descendants of root, parents before their
+// children, given each process's parent.
+func processDescendants(root uint32, parentOf map[uint32]uint32) []uint32 {
+	children := make(map[uint32][]uint32)
+	for pid, ppid := range parentOf {
+		if pid != ppid {
+			children[ppid] = append(children[ppid], pid)
+		}
+	}
+	var result []uint32
+	seen := map[uint32]bool{root: true}
+	queue := []uint32{root}
+	for len(queue) > 0 {
+		pid := queue[0]
+		queue = queue[1:]
+		for _, child := range children[pid] {
+			if seen[child] {
+				continue
+			}
+			seen[child] = true
+			result = append(result, child)
+			queue = append(queue, child)
+		}
+	}
+	return result
+}
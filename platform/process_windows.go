@@ -150,3 +150,72 @@ func KillProcessByName(exeName string) error {
 	}
 	return lastErr
 }
+
+// KillProcessTree terminates a process and all processes it started,
+// directly or indirectly, found by walking a process snapshot. Processes are
+// terminated parents first, so none can start new children meanwhile.
+// Descendants that exit on their own are ignored; an error is returned only
+// if pid itself can't be terminated. pid 0 (the idle process), pid 1 and the
+// calling process are rejected.
+func KillProcessTree(pid uint32) error {
+	if err := checkProcessTreeRoot(pid); err != nil {
+		return err
+	}
+	descendants := processDescendants(pid, processParents())
+	err := KillProcess(pid)
+	for _, child := range descendants {
+		KillProcess(child)
+	}
+	return err
+}
+
+// processParents returns the parent of every running process. A snapshot's
+// parent PIDs may be stale: when a parent exits its PID can be reused, and
+// the new process would look like the parent of the old one's orphans. A
+// process is only counted as a child when it was created after its parent.
+func processParents() map[uint32]uint32 {
+	parents := make(map[uint32]uint32)
+	snapshot, _, _ := procCreateToolhelp32Snapshot.Call(th32csSnapProcess, 0)
+	if snapshot == uintptr(syscall.InvalidHandle) {
+		return parents
+	}
+	defer syscall.CloseHandle(syscall.Handle(snapshot))
+
+	var entry processEntry32W
+	entry.Size = uint32(unsafe.Sizeof(entry))
+	ret, _, _ := procProcess32FirstW.Call(snapshot, uintptr(unsafe.Pointer(&entry)))
+	for ret != 0 {
+		parents[entry.ProcessID] = entry.ParentProcessID
+		ret, _, _ = procProcess32NextW.Call(snapshot, uintptr(unsafe.Pointer(&entry)))
+	}
+
+	created := make(map[uint32]int64, len(parents))
+	for pid := range parents {
+		if t, ok := processCreationTime(pid); ok {
+			created[pid] = t
+		}
+	}
+	for pid, ppid := range parents {
+		child, ok1 := created[pid]
+		parent, ok2 := created[ppid]
+		if !ok1 || !ok2 || child < parent {
+			delete(parents, pid)
+		}
+	}
+	return parents
+}
+
+// processCreationTime returns when pid was created, in 100ns units.
+func processCreationTime(pid uint32) (int64, bool) {
+	handle, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, pid)
+	if err != nil {
+		return 0, false
+	}
+	defer windows.CloseHandle(handle)
+
+	var creation, exit, kernel, user windows.Filetime
+	if err := windows.GetProcessTimes(handle, &creation, &exit, &kernel, &user); err != nil {
+		return 0, false
+	}
+	return creation.Nanoseconds() / 100, true
+}
@@ -0,0 +1,18 @@
+//go:build darwin
+
+package platform
+
+import "golang.org/x/sys/unix"
+
+// processParents returns the parent of every running process.
+func processParents() map[uint32]uint32 {
+	parents := make(map[uint32]uint32)
+	procs, err := unix.SysctlKinfoProcSlice("kern.proc.all")
+	if err != nil {
+		return parents
+	}
+	for _, p := range procs {
+		parents[uint32(p.Proc.P_pid)] = uint32(p.Eproc.Ppid)
+	}
+	return parents
+}
@@ -0,0 +1,42 @@
+//go:build linux
+
+package platform
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// processParents returns the parent of every running process.
+func processParents() map[uint32]uint32 {
+	parents := make(map[uint32]uint32)
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return parents
+	}
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		stat, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+		if err != nil {
+			continue // Process may have exited
+		}
+		// "pid (comm) state ppid ...", where comm may contain spaces and parentheses
+		i := strings.LastIndexByte(string(stat), ')')
+		if i < 0 {
+			continue
+		}
+		fields := strings.Fields(string(stat[i+1:]))
+		if len(fields) < 2 {
+			continue
+		}
+		if ppid, err := strconv.Atoi(fields[1]); err == nil {
+			parents[uint32(pid)] = uint32(ppid)
+		}
+	}
+	return parents
+}
@@ -0,0 +1,32 @@
+//go:build linux || darwin
+
+package platform
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// KillProcessTree kills a process and all processes it started, directly or
+// indirectly, with SIGKILL: its process group if it leads one, and every
+// descendant found in the process table, including those that started their
+// own group. Descendants that exit on their own are ignored; an error is
+// returned only if pid itself can't be killed. pid 0, pid 1 and the calling
+// process are rejected.
+func KillProcessTree(pid uint32) error {
+	if err := checkProcessTreeRoot(pid); err != nil {
+		return err
+	}
+	descendants := processDescendants(pid, processParents())
+	if pgid, err := syscall.Getpgid(int(pid)); err == nil && pgid == int(pid) {
+		syscall.Kill(-pgid, syscall.SIGKILL)
+	}
+	err := syscall.Kill(int(pid), syscall.SIGKILL)
+	for _, child := range descendants {
+		syscall.Kill(int(child), syscall.SIGKILL)
+	}
+	if err != nil && err != syscall.ESRCH {
+		return fmt.Errorf("kill process %d: %w", pid, err)
+	}
+	return nil
+}
@@ -0,0 +1,7 @@
+package platform
+
+import "errors"
+
+// ErrProtectUnsupported is returned by ProtectData and UnprotectData where the
+// platform has no data protection service.
+var ErrProtectUnsupported = errors.New("data protection not supported on this platform")
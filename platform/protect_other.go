@@ -0,0 +1,14 @@
+//go:build !windows
+
+package platform
+
+// ProtectData encrypts data so only the current user on this machine can
+// decrypt it with UnprotectData. It is only available on Windows (DPAPI).
+func ProtectData(data []byte) ([]byte, error) {
+	return nil, ErrProtectUnsupported
+}
+
+// UnprotectData decrypts data encrypted by ProtectData.
+func UnprotectData(data []byte) ([]byte, error) {
+	return nil, ErrProtectUnsupported
+}
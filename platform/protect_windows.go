@@ -0,0 +1,52 @@
+//go:build windows
+
+package platform
+
+import (
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// ProtectData encrypts data so only the current user on this machine can
+// decrypt it with UnprotectData, e.g. a password kept in an answers file
+// across a reboot. On Windows it uses DPAPI (CryptProtectData); the key
+// is managed by Windows and tied to the user's logon credentials.
+func ProtectData(data []byte) ([]byte, error) {
+	var out windows.DataBlob
+	if err := windows.CryptProtectData(newDataBlob(data), nil, nil, 0, nil, windows.CRYPTPROTECT_UI_FORBIDDEN, &out); err != nil {
+		return nil, fmt.Errorf("CryptProtectData: %w", err)
+	}
+	return takeDataBlob(&out), nil
+}
+
+// UnprotectData decrypts data encrypted by ProtectData.
+func UnprotectData(data []byte) ([]byte, error) {
+	var out windows.DataBlob
+	if err := windows.CryptUnprotectData(newDataBlob(data), nil, nil, 0, nil, windows.CRYPTPROTECT_UI_FORBIDDEN, &out); err != nil {
+		return nil, fmt.Errorf("CryptUnprotectData: %w", err)
+	}
+	return takeDataBlob(&out), nil
+}
+
+func newDataBlob(data []byte) *windows.DataBlob {
+	if len(data) == 0 {
+		return &windows.DataBlob{}
+	}
+	return &windows.DataBlob{Size: uint32(len(data)), Data: &data[0]}
+}
+
+// takeDataBlob copies a DPAPI output blob into Go memory, then zeroes and
+// frees the original.
+func takeDataBlob(blob *windows.DataBlob) []byte {
+	if blob.Data == nil {
+		return nil
+	}
+	src := unsafe.Slice(blob.Data, blob.Size)
+	out := make([]byte, len(src))
+	copy(out, src)
+	clear(src)
+	windows.LocalFree(windows.Handle(unsafe.Pointer(blob.Data)))
+	return out
+}
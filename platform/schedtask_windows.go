@@ -0,0 +1,484 @@
+//go:build windows
+
+package platform
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/go-ole/go-ole"
+	"github.com/go-ole/go-ole/oleutil"
+)
+
+// ErrTaskNotFound is returned when a scheduled task doesn't exist.
+var ErrTaskNotFound = errors.New("scheduled task not found")
+
+// TaskTriggerType selects when a scheduled task runs.
+type TaskTriggerType int
+
+const (
+	TaskTriggerOnce  TaskTriggerType = iota // Run once at Start
+	TaskTriggerDaily                        // Run every day at Start's time of day
+	TaskTriggerBoot                         // Run at system startup
+	TaskTriggerLogon                        // Run when a user logs on
+)
+
+// TaskTrigger describes one trigger of a scheduled task.
+type TaskTrigger struct {
+	Type  TaskTriggerType
+	Start time.Time     // Start time for Once and Daily triggers (default now)
+	Delay time.Duration // Delay after boot or logon before the task runs
+	User  string        // Logon triggers: only for this user (DOMAIN\user); empty means any user
+}
+
+// ScheduledTask describes a Task Scheduler task.
+type ScheduledTask struct {
+	Name        string // Task name, optionally with folders (e.g. `MyCompany\Updater`) (required)
+	Description string // Description shown in Task Scheduler
+	Executable  string // Full path to the executable (required)
+	Args        string // Command-line arguments
+	WorkingDir  string // Working directory (optional)
+	Triggers    []TaskTrigger
+
+	// Account settings
+	RunAs             string // "" = current user (interactive), "SYSTEM", or DOMAIN\user
+	Password          string // Password for RunAs; without one the task runs only while the user is logged on
+	HighestPrivileges bool   // Run with the account's full (elevated) token
+
+	// Behavior
+	RunOnBatteries bool          // Start and keep running on battery power
+	TimeLimit      time.Duration // Stop the task after this long (0 = no limit)
+}
+
+// ScheduledTaskInfo holds the runtime state of a registered task.
+type ScheduledTaskInfo struct {
+	State          string    // "disabled", "queued", "ready", "running" or "unknown"
+	LastRunTime    time.Time // Zero if the task has never run
+	NextRunTime    time.Time // Zero if no run is scheduled
+	LastTaskResult uint32    // Exit code or HRESULT of the last run
+}
+
+// Task Scheduler 2.0 constants (taskschd.h).
+const (
+	taskTriggerTime    = 1
+	taskTriggerDaily   = 2
+	taskTriggerBoot    = 8
+	taskTriggerLogon   = 9
+	taskActionExec     = 0
+	taskCreateOrUpdate = 6
+
+	taskLogonPassword         = 1
+	taskLogonInteractiveToken = 3
+	taskLogonServiceAccount   = 5
+
+	taskRunLevelLUA     = 0
+	taskRunLevelHighest = 1
+
+	taskTimeFormat = "2006-01-02T15:04:05"
+)
+
+// CreateScheduledTask registers a task with Task Scheduler, replacing any
+// existing task with the same name.
+func CreateScheduledTask(task ScheduledTask) error {
+	if task.Name == "" {
+		return fmt.Errorf("task name is required")
+	}
+	if task.Executable == "" {
+		return fmt.Errorf("executable path is required")
+	}
+
+	return withTaskService(func(service *ole.IDispatch) error {
+		folderPath, name := splitTaskName(task.Name)
+		folder, err := getOrCreateTaskFolder(service, folderPath)
+		if err != nil {
+			return err
+		}
+		defer folder.Release()
+
+		defVariant, err := oleutil.CallMethod(service, "NewTask", 0)
+		if err != nil {
+			return fmt.Errorf("create task definition: %s", oleErrorString(err))
+		}
+		def := defVariant.ToIDispatch()
+		defer def.Release()
+
+		if err := configureTaskDefinition(def, task); err != nil {
+			return err
+		}
+
+		// Choose logon type from the account settings
+		var userID, password any
+		logonType := taskLogonInteractiveToken
+		switch {
+		case strings.EqualFold(task.RunAs, "SYSTEM"):
+			userID, logonType = "SYSTEM", taskLogonServiceAccount
+		case task.RunAs != "" && task.Password != "":
+			userID, password, logonType = task.RunAs, task.Password, taskLogonPassword
+		case task.RunAs != "":
+			userID = task.RunAs
+		}
+
+		registered, err := oleutil.CallMethod(folder, "RegisterTaskDefinition",
+			name, def, taskCreateOrUpdate, userID, password, logonType, nil)
+		if err != nil {
+			return fmt.Errorf("register task: %s", oleErrorString(err))
+		}
+		registered.ToIDispatch().Release()
+		return nil
+	})
+}
+
+// DeleteScheduledTask removes a task. Returns nil if the task doesn't exist.
+func DeleteScheduledTask(name string) error {
+	return withTaskService(func(service *ole.IDispatch) error {
+		folderPath, taskName := splitTaskName(name)
+		folderVariant, err := oleutil.CallMethod(service, "GetFolder", folderPath)
+		if err != nil {
+			return nil // Folder doesn't exist, so neither does the task
+		}
+		folder := folderVariant.ToIDispatch()
+		defer folder.Release()
+
+		if _, err := oleutil.CallMethod(folder, "DeleteTask", taskName, 0); err != nil {
+			if isTaskNotFound(err) {
+				return nil
+			}
+			return fmt.Errorf("delete task: %s", oleErrorString(err))
+		}
+		return nil
+	})
+}
+
+// RunScheduledTask starts a registered task immediately.
+func RunScheduledTask(name string) error {
+	return withRegisteredTask(name, func(task *ole.IDispatch) error {
+		running, err := oleutil.CallMethod(task, "Run", nil)
+		if err != nil {
+			return fmt.Errorf("run task: %s", oleErrorString(err))
+		}
+		running.ToIDispatch().Release()
+		return nil
+	})
+}
+
+// ScheduledTaskExists returns true if a task with the given name is registered.
+func ScheduledTaskExists(name string) (bool, error) {
+	err := withRegisteredTask(name, func(*ole.IDispatch) error { return nil })
+	if errors.Is(err, ErrTaskNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// QueryScheduledTask returns the state and run history of a task.
+// Returns ErrTaskNotFound if the task doesn't exist.
+func QueryScheduledTask(name string) (ScheduledTaskInfo, error) {
+	var info ScheduledTaskInfo
+	err := withRegisteredTask(name, func(task *ole.IDispatch) error {
+		if v, err := oleutil.GetProperty(task, "State"); err == nil {
+			info.State = taskStateString(v.Val)
+		}
+		if v, err := oleutil.GetProperty(task, "LastTaskResult"); err == nil {
+			info.LastTaskResult = uint32(v.Val)
+		}
+		info.LastRunTime = taskTimeProperty(task, "LastRunTime")
+		info.NextRunTime = taskTimeProperty(task, "NextRunTime")
+		return nil
+	})
+	return info, err
+}
+
+// withTaskService connects to the Task Scheduler service on a locked,
+// COM-initialized thread and calls fn with the ITaskService object.
+func withTaskService(fn func(service *ole.IDispatch) error) error {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	if err := ole.CoInitializeEx(0, ole.COINIT_APARTMENTTHREADED); err != nil {
+		if oleErr, ok := err.(*ole.OleError); ok {
+			code := oleErr.Code()
+			if code != 0 && code != 1 { // S_OK=0, S_FALSE=1
+				return fmt.Errorf("COM initialization failed: %s", oleErrorString(err))
+			}
+		}
+	}
+	defer ole.CoUninitialize()
+
+	unknown, err := oleutil.CreateObject("Schedule.Service")
+	if err != nil {
+		return fmt.Errorf("cannot create Schedule.Service object: %s", oleErrorString(err))
+	}
+	defer unknown.Release()
+
+	service, err := unknown.QueryInterface(ole.IID_IDispatch)
+	if err != nil {
+		return fmt.Errorf("cannot get task service interface: %s", oleErrorString(err))
+	}
+	defer service.Release()
+
+	if _, err := oleutil.CallMethod(service, "Connect"); err != nil {
+		return fmt.Errorf("connect to task scheduler: %s", oleErrorString(err))
+	}
+
+	return fn(service)
+}
+
+// withRegisteredTask looks up a registered task and calls fn with it.
+func withRegisteredTask(name string, fn func(task *ole.IDispatch) error) error {
+	return withTaskService(func(service *ole.IDispatch) error {
+		folderPath, taskName := splitTaskName(name)
+		folderVariant, err := oleutil.CallMethod(service, "GetFolder", folderPath)
+		if err != nil {
+			return ErrTaskNotFound
+		}
+		folder := folderVariant.ToIDispatch()
+		defer folder.Release()
+
+		taskVariant, err := oleutil.CallMethod(folder, "GetTask", taskName)
+		if err != nil {
+			if isTaskNotFound(err) {
+				return ErrTaskNotFound
+			}
+			return fmt.Errorf("get task: %s", oleErrorString(err))
+		}
+		task := taskVariant.ToIDispatch()
+		defer task.Release()
+
+		return fn(task)
+	})
+}
+
+// configureTaskDefinition fills in an ITaskDefinition from task.
+func configureTaskDefinition(def *ole.IDispatch, task ScheduledTask) error {
+	if task.Description != "" {
+		regInfo, err := getDispatchProperty(def, "RegistrationInfo")
+		if err != nil {
+			return err
+		}
+		defer regInfo.Release()
+		if _, err := oleutil.PutProperty(regInfo, "Description", task.Description); err != nil {
+			return fmt.Errorf("cannot set description: %s", oleErrorString(err))
+		}
+	}
+
+	// Principal: run level
+	principal, err := getDispatchProperty(def, "Principal")
+	if err != nil {
+		return err
+	}
+	defer principal.Release()
+	runLevel := taskRunLevelLUA
+	if task.HighestPrivileges {
+		runLevel = taskRunLevelHighest
+	}
+	if _, err := oleutil.PutProperty(principal, "RunLevel", runLevel); err != nil {
+		return fmt.Errorf("cannot set run level: %s", oleErrorString(err))
+	}
+
+	// Settings: power and time limit
+	settings, err := getDispatchProperty(def, "Settings")
+	if err != nil {
+		return err
+	}
+	defer settings.Release()
+	props := map[string]any{
+		"StartWhenAvailable":         true,
+		"DisallowStartIfOnBatteries": !task.RunOnBatteries,
+		"StopIfGoingOnBatteries":     !task.RunOnBatteries,
+		"ExecutionTimeLimit":         taskDuration(task.TimeLimit),
+	}
+	for prop, value := range props {
+		if _, err := oleutil.PutProperty(settings, prop, value); err != nil {
+			return fmt.Errorf("cannot set %s: %s", prop, oleErrorString(err))
+		}
+	}
+
+	// Triggers
+	triggers, err := getDispatchProperty(def, "Triggers")
+	if err != nil {
+		return err
+	}
+	defer triggers.Release()
+	for _, t := range task.Triggers {
+		if err := addTaskTrigger(triggers, t); err != nil {
+			return err
+		}
+	}
+
+	// Action: start the executable
+	actions, err := getDispatchProperty(def, "Actions")
+	if err != nil {
+		return err
+	}
+	defer actions.Release()
+	actionVariant, err := oleutil.CallMethod(actions, "Create", taskActionExec)
+	if err != nil {
+		return fmt.Errorf("cannot create action: %s", oleErrorString(err))
+	}
+	action := actionVariant.ToIDispatch()
+	defer action.Release()
+
+	if _, err := oleutil.PutProperty(action, "Path", task.Executable); err != nil {
+		return fmt.Errorf("cannot set executable: %s", oleErrorString(err))
+	}
+	if task.Args != "" {
+		if _, err := oleutil.PutProperty(action, "Arguments", task.Args); err != nil {
+			return fmt.Errorf("cannot set arguments: %s", oleErrorString(err))
+		}
+	}
+	if task.WorkingDir != "" {
+		if _, err := oleutil.PutProperty(action, "WorkingDirectory", task.WorkingDir); err != nil {
+			return fmt.Errorf("cannot set working directory: %s", oleErrorString(err))
+		}
+	}
+
+	return nil
+}
+
+// addTaskTrigger creates one ITrigger in the trigger collection.
+func addTaskTrigger(triggers *ole.IDispatch, t TaskTrigger) error {
+	var triggerType int
+	switch t.Type {
+	case TaskTriggerOnce:
+		triggerType = taskTriggerTime
+	case TaskTriggerDaily:
+		triggerType = taskTriggerDaily
+	case TaskTriggerBoot:
+		triggerType = taskTriggerBoot
+	case TaskTriggerLogon:
+		triggerType = taskTriggerLogon
+	default:
+		return fmt.Errorf("unknown trigger type %d", t.Type)
+	}
+
+	triggerVariant, err := oleutil.CallMethod(triggers, "Create", triggerType)
+	if err != nil {
+		return fmt.Errorf("cannot create trigger: %s", oleErrorString(err))
+	}
+	trigger := triggerVariant.ToIDispatch()
+	defer trigger.Release()
+
+	start := t.Start
+	if start.IsZero() && (t.Type == TaskTriggerOnce || t.Type == TaskTriggerDaily) {
+		start = time.Now()
+	}
+	if !start.IsZero() {
+		if _, err := oleutil.PutProperty(trigger, "StartBoundary", start.Format(taskTimeFormat)); err != nil {
+			return fmt.Errorf("cannot set trigger start: %s", oleErrorString(err))
+		}
+	}
+
+	switch t.Type {
+	case TaskTriggerDaily:
+		if _, err := oleutil.PutProperty(trigger, "DaysInterval", 1); err != nil {
+			return fmt.Errorf("cannot set trigger interval: %s", oleErrorString(err))
+		}
+	case TaskTriggerBoot, TaskTriggerLogon:
+		if t.Delay > 0 {
+			if _, err := oleutil.PutProperty(trigger, "Delay", taskDuration(t.Delay)); err != nil {
+				return fmt.Errorf("cannot set trigger delay: %s", oleErrorString(err))
+			}
+		}
+		if t.Type == TaskTriggerLogon && t.User != "" {
+			if _, err := oleutil.PutProperty(trigger, "UserId", t.User); err != nil {
+				return fmt.Errorf("cannot set trigger user: %s", oleErrorString(err))
+			}
+		}
+	}
+
+	return nil
+}
+
+// getDispatchProperty reads a property holding a COM object.
+func getDispatchProperty(obj *ole.IDispatch, name string) (*ole.IDispatch, error) {
+	v, err := oleutil.GetProperty(obj, name)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get %s: %s", name, oleErrorString(err))
+	}
+	return v.ToIDispatch(), nil
+}
+
+// getOrCreateTaskFolder returns the ITaskFolder at path, creating it if needed.
+func getOrCreateTaskFolder(service *ole.IDispatch, path string) (*ole.IDispatch, error) {
+	if folder, err := oleutil.CallMethod(service, "GetFolder", path); err == nil {
+		return folder.ToIDispatch(), nil
+	}
+
+	rootVariant, err := oleutil.CallMethod(service, "GetFolder", `\`)
+	if err != nil {
+		return nil, fmt.Errorf("get root task folder: %s", oleErrorString(err))
+	}
+	root := rootVariant.ToIDispatch()
+	defer root.Release()
+
+	folder, err := oleutil.CallMethod(root, "CreateFolder", path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create task folder: %s", oleErrorString(err))
+	}
+	return folder.ToIDispatch(), nil
+}
+
+// splitTaskName splits `Folder\Sub\Task` into the folder path (`\Folder\Sub`)
+// and task name. Tasks without a folder live in the root folder (`\`).
+func splitTaskName(name string) (folder, task string) {
+	name = strings.Trim(name, `\`)
+	i := strings.LastIndex(name, `\`)
+	if i < 0 {
+		return `\`, name
+	}
+	return `\` + name[:i], name[i+1:]
+}
+
+// isTaskNotFound reports whether err is ERROR_FILE_NOT_FOUND as an HRESULT,
+// which Task Scheduler returns for missing tasks and folders.
+func isTaskNotFound(err error) bool {
+	var oleErr *ole.OleError
+	if !errors.As(err, &oleErr) {
+		return false
+	}
+	code := uint32(oleErr.Code())
+	if code == 0x80020009 { // DISP_E_EXCEPTION: the real HRESULT is in the exception info
+		if exc, ok := oleErr.SubError().(ole.EXCEPINFO); ok {
+			code = uint32(exc.SCODE())
+		}
+	}
+	return code == 0x80070002
+}
+
+// taskDuration formats d as an ISO 8601 duration ("PT0S" means no limit).
+func taskDuration(d time.Duration) string {
+	return fmt.Sprintf("PT%dS", int64(d.Seconds()))
+}
+
+// taskTimeProperty reads a DATE property, returning the zero time if unset.
+func taskTimeProperty(task *ole.IDispatch, name string) time.Time {
+	v, err := oleutil.GetProperty(task, name)
+	if err != nil {
+		return time.Time{}
+	}
+	t, ok := v.Value().(time.Time)
+	// Task Scheduler reports "never" as 1899-12-30 (DATE zero)
+	if !ok || t.Year() < 1900 {
+		return time.Time{}
+	}
+	return t
+}
+
+// taskStateString maps TASK_STATE values to status strings.
+func taskStateString(state int64) string {
+	switch state {
+	case 1:
+		return "disabled"
+	case 2:
+		return "queued"
+	case 3:
+		return "ready"
+	case 4:
+		return "running"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,9 @@
+package platform
+
+import "errors"
+
+// Secret storage errors.
+var (
+	ErrSecretNotFound    = errors.New("secret not found")
+	ErrSecretUnsupported = errors.New("no secret store available")
+)
@@ -0,0 +1,105 @@
+//go:build darwin
+
+package platform
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// errSecItemNotFound is the security tool's exit status for a missing item.
+const errSecItemNotFound = 44
+
+// StoreSecret saves a secret, such as a license key or an API token, in the
+// user's secret store under service and account, replacing any previous one.
+// On macOS this is a generic password in the login Keychain.
+//
+// Example:
+//
+//	platform.StoreSecret("MyApp", "license", key)
+//	key, err := platform.RetrieveSecret("MyApp", "license")
+func StoreSecret(service, account, secret string) error {
+	if strings.ContainsAny(service+account, "\"\n") {
+		return fmt.Errorf("store secret: service and account must not contain quotes or newlines")
+	}
+	// Commands on stdin keep the secret out of the process list
+	cmd := exec.Command("security", "-i")
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("add-generic-password -U -s \"%s\" -a \"%s\" -X %s\n",
+		service, account, hex.EncodeToString([]byte(secret))))
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("store secret: %s", strings.TrimSpace(string(out)))
+	}
+	// security -i reports failed commands only in its output; read back instead
+	if stored, err := RetrieveSecret(service, account); err != nil || stored != secret {
+		return fmt.Errorf("store secret: keychain item was not updated")
+	}
+	return nil
+}
+
+// RetrieveSecret returns a secret saved by StoreSecret, or ErrSecretNotFound.
+func RetrieveSecret(service, account string) (string, error) {
+	// -w prints secrets with non-ASCII characters as bare hex, which can't be
+	// told apart from a secret made of hex digits; -g marks hex with 0x.
+	var stderr bytes.Buffer
+	cmd := exec.Command("security", "find-generic-password", "-s", service, "-a", account, "-g")
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == errSecItemNotFound {
+			return "", ErrSecretNotFound
+		}
+		return "", fmt.Errorf("retrieve secret: %w", err)
+	}
+	return parseKeychainPassword(stderr.String())
+}
+
+// parseKeychainPassword extracts the secret from the "password:" line that
+// security find-generic-password -g writes to stderr. It has one of the forms
+//
+//	password: "text"
+//	password: 0x48C3A46C6C6F  "H\303\244llo"
+//	password:
+//
+// where the hex form is used for anything but printable ASCII.
+func parseKeychainPassword(output string) (string, error) {
+	for _, line := range strings.Split(output, "\n") {
+		rest, ok := strings.CutPrefix(line, "password:")
+		if !ok {
+			continue
+		}
+		rest = strings.TrimLeft(rest, " ")
+		switch {
+		case rest == "":
+			return "", nil
+		case strings.HasPrefix(rest, "0x"):
+			digits, _, _ := strings.Cut(rest[2:], " ")
+			secret, err := hex.DecodeString(digits)
+			if err != nil {
+				return "", fmt.Errorf("retrieve secret: %w", err)
+			}
+			return string(secret), nil
+		case len(rest) >= 2 && rest[0] == '"' && rest[len(rest)-1] == '"':
+			return rest[1 : len(rest)-1], nil
+		}
+		return "", fmt.Errorf("retrieve secret: unexpected password format")
+	}
+	return "", fmt.Errorf("retrieve secret: no password in output")
+}
+
+// DeleteSecret removes a secret saved by StoreSecret. Deleting a secret that
+// doesn't exist is not an error.
+func DeleteSecret(service, account string) error {
+	err := exec.Command("security", "delete-generic-password", "-s", service, "-a", account).Run()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == errSecItemNotFound {
+			return nil
+		}
+		return fmt.Errorf("delete secret: %w", err)
+	}
+	return nil
+}
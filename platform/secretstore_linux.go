@@ -0,0 +1,62 @@
+//go:build linux
+
+package platform
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// StoreSecret saves a secret, such as a license key or an API token, in the
+// user's secret store under service and account, replacing any previous one.
+// On Linux this is the Secret Service (GNOME Keyring, KWallet) through
+// libsecret's secret-tool; ErrSecretUnsupported is returned without it.
+//
+// Example:
+//
+//	platform.StoreSecret("MyApp", "license", key)
+//	key, err := platform.RetrieveSecret("MyApp", "license")
+func StoreSecret(service, account, secret string) error {
+	tool, err := exec.LookPath("secret-tool")
+	if err != nil {
+		return ErrSecretUnsupported
+	}
+	cmd := exec.Command(tool, "store", "--label="+service+" ("+account+")", "service", service, "account", account)
+	cmd.Stdin = strings.NewReader(secret) // Never on the command line
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("store secret: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// RetrieveSecret returns a secret saved by StoreSecret, or ErrSecretNotFound.
+func RetrieveSecret(service, account string) (string, error) {
+	tool, err := exec.LookPath("secret-tool")
+	if err != nil {
+		return "", ErrSecretUnsupported
+	}
+	out, err := exec.Command(tool, "lookup", "service", service, "account", account).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(out) == 0 {
+			return "", ErrSecretNotFound
+		}
+		return "", fmt.Errorf("retrieve secret: %w", err)
+	}
+	return string(out), nil
+}
+
+// DeleteSecret removes a secret saved by StoreSecret. Deleting a secret that
+// doesn't exist is not an error.
+func DeleteSecret(service, account string) error {
+	tool, err := exec.LookPath("secret-tool")
+	if err != nil {
+		return ErrSecretUnsupported
+	}
+	if err := exec.Command(tool, "clear", "service", service, "account", account).Run(); err != nil {
+		return fmt.Errorf("delete secret: %w", err)
+	}
+	return nil
+}
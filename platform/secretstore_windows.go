@@ -0,0 +1,120 @@
+//go:build windows
+
+package platform
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	procCredWriteW  = advapi32.NewProc("CredWriteW")
+	procCredReadW   = advapi32.NewProc("CredReadW")
+	procCredDeleteW = advapi32.NewProc("CredDeleteW")
+	procCredFree    = advapi32.NewProc("CredFree")
+)
+
+const (
+	credTypeGeneric         = 1
+	credPersistLocalMachine = 2
+	credMaxBlobSize         = 5 * 512
+)
+
+// credential mirrors CREDENTIALW.
+type credential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        windows.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+// StoreSecret saves a secret, such as a license key or an API token, in the
+// user's secret store under service and account, replacing any previous one.
+// On Windows this is Credential Manager (a generic credential named
+// "service/account"), which encrypts it with DPAPI.
+//
+// Example:
+//
+//	platform.StoreSecret("MyApp", "license", key)
+//	key, err := platform.RetrieveSecret("MyApp", "license")
+func StoreSecret(service, account, secret string) error {
+	if len(secret) > credMaxBlobSize {
+		return fmt.Errorf("store secret: %d bytes exceeds the Credential Manager limit of %d", len(secret), credMaxBlobSize)
+	}
+	target, err := windows.UTF16PtrFromString(service + "/" + account)
+	if err != nil {
+		return err
+	}
+	user, err := windows.UTF16PtrFromString(account)
+	if err != nil {
+		return err
+	}
+	blob := []byte(secret)
+	defer clear(blob)
+
+	cred := credential{
+		Type:               credTypeGeneric,
+		TargetName:         target,
+		CredentialBlobSize: uint32(len(blob)),
+		Persist:            credPersistLocalMachine,
+		UserName:           user,
+	}
+	if len(blob) > 0 {
+		cred.CredentialBlob = &blob[0]
+	}
+	if r, _, err := procCredWriteW.Call(uintptr(unsafe.Pointer(&cred)), 0); r == 0 {
+		return fmt.Errorf("CredWrite: %w", err)
+	}
+	return nil
+}
+
+// RetrieveSecret returns a secret saved by StoreSecret, or ErrSecretNotFound.
+func RetrieveSecret(service, account string) (string, error) {
+	target, err := windows.UTF16PtrFromString(service + "/" + account)
+	if err != nil {
+		return "", err
+	}
+	var cred *credential
+	if r, _, err := procCredReadW.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0, uintptr(unsafe.Pointer(&cred))); r == 0 {
+		if errors.Is(err, windows.ERROR_NOT_FOUND) {
+			return "", ErrSecretNotFound
+		}
+		return "", fmt.Errorf("CredRead: %w", err)
+	}
+	defer procCredFree.Call(uintptr(unsafe.Pointer(cred)))
+
+	if cred.CredentialBlob == nil {
+		return "", nil
+	}
+	blob := unsafe.Slice(cred.CredentialBlob, cred.CredentialBlobSize)
+	secret := string(blob)
+	clear(blob)
+	return secret, nil
+}
+
+// DeleteSecret removes a secret saved by StoreSecret. Deleting a secret that
+// doesn't exist is not an error.
+func DeleteSecret(service, account string) error {
+	target, err := windows.UTF16PtrFromString(service + "/" + account)
+	if err != nil {
+		return err
+	}
+	if r, _, err := procCredDeleteW.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0); r == 0 {
+		if errors.Is(err, windows.ERROR_NOT_FOUND) {
+			return nil
+		}
+		return fmt.Errorf("CredDelete: %w", err)
+	}
+	return nil
+}
@@ -12,7 +12,6 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
-	"syscall"
 	"time"
 	"unsafe"
 
@@ -140,28 +139,19 @@ func RunFirstPhase() error {
 	// Forward original args (excluding any phase flags)
 	args = append(args, FilterSecondPhaseArgs()...)
 
-	// Spawn Phase 2. Use CREATE_BREAKAWAY_FROM_JOB to detach from the
-	// caller's job object. Without this, the child process gets killed when
-	// Phase 1 exits if the job has JOB_OBJECT_LIMIT_KILL_ON_JOB_CLOSE set
-	// (common when launched from Windows Terminal or other process managers).
-	// If the job doesn't allow breakaway, fall back to normal CreateProcess.
-	// Phase 2 uses NTFS ADS POSIX delete to remove the original exe BEFORE
-	// signaling Phase 1, so being killed by the job is harmless.
+	// Spawn Phase 2 outside the caller's job object. Without this, the child
+	// process gets killed when Phase 1 exits if the job has
+	// JOB_OBJECT_LIMIT_KILL_ON_JOB_CLOSE set (common when launched from
+	// Windows Terminal or other process managers). If the job doesn't allow
+	// breakaway, StartDetached starts it inside the job: Phase 2 uses NTFS ADS
+	// POSIX delete to remove the original exe BEFORE signaling Phase 1, so
+	// being killed by the job is harmless.
 	cmd := exec.Command(tempExe, args...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	cmd.SysProcAttr = &syscall.SysProcAttr{
-		CreationFlags: windows.CREATE_BREAKAWAY_FROM_JOB,
-	}
-	if err := cmd.Start(); err != nil {
-		// Retry without CREATE_BREAKAWAY_FROM_JOB
-		cmd = exec.Command(tempExe, args...)
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-		if err := cmd.Start(); err != nil {
-			os.RemoveAll(tempDir)
-			return fmt.Errorf("start phase 2: %w", err)
-		}
+	if _, err := StartDetached(cmd); err != nil {
+		os.RemoveAll(tempDir)
+		return fmt.Errorf("start phase 2: %w", err)
 	}
 
 	// Open Phase 2 process handle with SYNCHRONIZE access for waiting
@@ -0,0 +1,68 @@
+package platform
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RestartPolicy controls whether the service manager restarts a service after it exits.
+type RestartPolicy int
+
+const (
+	// RestartDefault keeps the platform default: restart on failure for
+	// systemd and the Windows SCM, always restart (KeepAlive) for launchd.
+	RestartDefault RestartPolicy = iota
+	// RestartOnFailure restarts the service only when it exits with an error.
+	RestartOnFailure
+	// RestartAlways restarts the service whenever it exits.
+	RestartAlways
+	// RestartNever leaves the service stopped after it exits.
+	RestartNever
+)
+
+// ServiceLimits holds resource limits applied to a service process.
+// Zero values leave the platform default in place.
+type ServiceLimits struct {
+	OpenFiles  uint64 // Maximum open file descriptors (systemd LimitNOFILE, launchd NumberOfFiles)
+	Processes  uint64 // Maximum processes for the service user (systemd LimitNPROC, launchd NumberOfProcesses)
+	MemoryMax  uint64 // Memory ceiling in bytes (systemd MemoryMax, launchd ResidentSetSize)
+	CPUPercent uint32 // CPU quota as a percentage of one core (systemd CPUQuota only)
+}
+
+// SocketActivation describes listening sockets that the service manager opens
+// on behalf of the service, starting it on the first connection.
+type SocketActivation struct {
+	ListenStream   []string // TCP ports/addresses or Unix socket paths (e.g. "8080", "/run/myapp.sock")
+	ListenDatagram []string // UDP ports/addresses or Unix datagram socket paths
+}
+
+// serviceSeconds converts d to the whole seconds unit files take, rounding
+// up so a sub-second delay doesn't become 0. Zero or negative d gives def.
+func serviceSeconds(d time.Duration, def int) int {
+	if d <= 0 {
+		return def
+	}
+	return int((d + time.Second - 1) / time.Second)
+}
+
+// validateServiceValues rejects user, group and environment values that
+// would break out of their line in a generated unit file and add
+// directives of their own.
+func validateServiceValues(user, group string, env map[string]string) error {
+	if strings.ContainsAny(user, "\r\n") {
+		return fmt.Errorf("invalid service user %q", user)
+	}
+	if strings.ContainsAny(group, "\r\n") {
+		return fmt.Errorf("invalid service group %q", group)
+	}
+	for k, v := range env {
+		if k == "" || strings.ContainsAny(k, "=\r\n") {
+			return fmt.Errorf("invalid environment variable name %q", k)
+		}
+		if strings.ContainsAny(v, "\r\n") {
+			return fmt.Errorf("invalid value for environment variable %s: contains a line break", k)
+		}
+	}
+	return nil
+}
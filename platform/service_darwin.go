@@ -3,11 +3,13 @@
 package platform
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 	"text/template"
 	"time"
@@ -31,6 +33,29 @@ type ServiceConfig struct {
 	Executable  string // Full path to the executable (required)
 	Args        string // Command-line arguments passed at startup
 	StartType   uint32 // Ignored on macOS
+
+	// Restart and supervision
+	RestartPolicy RestartPolicy // KeepAlive setting (default always)
+	RestartDelay  time.Duration // ThrottleInterval, rounded up to whole seconds (default 5s)
+	Notify        bool          // Ignored on macOS
+	WatchdogSec   time.Duration // Ignored on macOS
+
+	// Process identity, environment and limits
+	User        string            // UserName to run as (default root)
+	Group       string            // GroupName to run as
+	Environment map[string]string // EnvironmentVariables
+	Limits      ServiceLimits     // Soft/HardResourceLimits (CPUPercent is ignored)
+
+	// systemd-only unit scope and activation, ignored on macOS
+	UserService bool
+	Socket      *SocketActivation
+
+	// Windows-only account and startup settings, ignored on macOS
+	RunAs              string
+	Password           string
+	Dependencies       []string
+	DelayedAutoStart   bool
+	PreShutdownTimeout time.Duration
 }
 
 // launchdPlistTemplate is the template for generating launchd plist files.
@@ -43,25 +68,86 @@ const launchdPlistTemplate = `<?xml version="1.0" encoding="UTF-8"?>
     <string>{{.Label}}</string>
     <key>ProgramArguments</key>
     <array>
-{{range .Args}}        <string>{{.}}</string>
+{{range .Args}}        <string>{{html .}}</string>
 {{end}}    </array>
     <key>RunAtLoad</key>
     <true/>
     <key>KeepAlive</key>
-    <true/>
+{{.KeepAlive}}
     <key>ThrottleInterval</key>
-    <integer>5</integer>
+    <integer>{{.ThrottleInterval}}</integer>
+{{- if .UserName}}
+    <key>UserName</key>
+    <string>{{html .UserName}}</string>
+{{- end}}
+{{- if .GroupName}}
+    <key>GroupName</key>
+    <string>{{html .GroupName}}</string>
+{{- end}}
+{{- if .Environment}}
+    <key>EnvironmentVariables</key>
+    <dict>
+{{- range $k, $v := .Environment}}
+        <key>{{html $k}}</key>
+        <string>{{html $v}}</string>
+{{- end}}
+    </dict>
+{{- end}}
+{{- if .HasLimits}}
+    <key>SoftResourceLimits</key>
+    <dict>{{template "limits" .Limits}}
+    </dict>
+    <key>HardResourceLimits</key>
+    <dict>{{template "limits" .Limits}}
+    </dict>
+{{- end}}
     <key>StandardOutPath</key>
     <string>/var/log/{{.Label}}.log</string>
     <key>StandardErrorPath</key>
     <string>/var/log/{{.Label}}.err</string>
 </dict>
 </plist>
+{{- define "limits"}}
+{{- if .OpenFiles}}
+        <key>NumberOfFiles</key>
+        <integer>{{.OpenFiles}}</integer>
+{{- end}}
+{{- if .Processes}}
+        <key>NumberOfProcesses</key>
+        <integer>{{.Processes}}</integer>
+{{- end}}
+{{- if .MemoryMax}}
+        <key>ResidentSetSize</key>
+        <integer>{{.MemoryMax}}</integer>
+{{- end}}
+{{- end}}
 `
 
 type launchdPlistData struct {
-	Label string
-	Args  []string
+	Label            string
+	Args             []string
+	KeepAlive        string
+	ThrottleInterval int
+	UserName         string
+	GroupName        string
+	Environment      map[string]string
+	Limits           ServiceLimits
+	HasLimits        bool
+}
+
+// launchdKeepAlive maps a RestartPolicy to the plist KeepAlive value.
+func launchdKeepAlive(policy RestartPolicy) string {
+	switch policy {
+	case RestartOnFailure:
+		return `    <dict>
+        <key>SuccessfulExit</key>
+        <false/>
+    </dict>`
+	case RestartNever:
+		return "    <false/>"
+	default:
+		return "    <true/>"
+	}
 }
 
 // plistFilePath returns the path to the launchd plist file for a service.
@@ -193,6 +279,47 @@ func StopService(name string) error {
 	return fmt.Errorf("timeout waiting for service to stop")
 }
 
+// ServiceLogs returns up to lines of recent output from the service's
+// StandardOutPath and StandardErrorPath log files. Standard error lines
+// come last, prefixed with "stderr: ", since they usually explain a failure.
+func ServiceLogs(name string, lines int) ([]string, error) {
+	if lines <= 0 {
+		lines = 50
+	}
+
+	stdout, errOut := readLogTail("/var/log/"+name+".log", lines)
+	stderr, errErr := readLogTail("/var/log/"+name+".err", lines)
+	if errOut != nil && errErr != nil {
+		return nil, fmt.Errorf("read service logs: %w", errOut)
+	}
+
+	result := stdout
+	for _, line := range stderr {
+		result = append(result, "stderr: "+line)
+	}
+	if len(result) > lines {
+		result = result[len(result)-lines:]
+	}
+	return result, nil
+}
+
+// readLogTail returns the last n lines of a log file.
+func readLogTail(path string, n int) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	text := strings.TrimRight(string(data), "\n")
+	if text == "" {
+		return nil, nil
+	}
+	all := strings.Split(text, "\n")
+	if len(all) > n {
+		all = all[len(all)-n:]
+	}
+	return all, nil
+}
+
 // InstallService installs a new launchd service.
 func InstallService(name, displayName, exePath, args string) error {
 	return InstallServiceWithConfig(ServiceConfig{
@@ -213,6 +340,10 @@ func InstallServiceWithConfig(cfg ServiceConfig) error {
 		return fmt.Errorf("executable path is required")
 	}
 
+	if err := validateServiceValues(cfg.User, cfg.Group, cfg.Environment); err != nil {
+		return err
+	}
+
 	// Check if already installed
 	exists, _ := ServiceExists(cfg.Name)
 	if exists {
@@ -220,11 +351,7 @@ func InstallServiceWithConfig(cfg ServiceConfig) error {
 	}
 
 	// Build program arguments
-	args := []string{cfg.Executable}
-	if cfg.Args != "" {
-		// Simple split by spaces (doesn't handle quoted strings)
-		args = append(args, strings.Fields(cfg.Args)...)
-	}
+	args := programArguments(cfg.Executable, cfg.Args)
 
 	// Generate plist content
 	tmpl, err := template.New("plist").Parse(launchdPlistTemplate)
@@ -232,9 +359,17 @@ func InstallServiceWithConfig(cfg ServiceConfig) error {
 		return fmt.Errorf("parse plist template: %w", err)
 	}
 
+	limits := cfg.Limits
 	data := launchdPlistData{
-		Label: cfg.Name,
-		Args:  args,
+		Label:            cfg.Name,
+		Args:             args,
+		KeepAlive:        launchdKeepAlive(cfg.RestartPolicy),
+		ThrottleInterval: serviceSeconds(cfg.RestartDelay, 5),
+		UserName:         cfg.User,
+		GroupName:        cfg.Group,
+		Environment:      cfg.Environment,
+		Limits:           limits,
+		HasLimits:        limits.OpenFiles > 0 || limits.Processes > 0 || limits.MemoryMax > 0,
 	}
 
 	var content strings.Builder
@@ -260,6 +395,51 @@ func InstallServiceWithConfig(cfg ServiceConfig) error {
 	return nil
 }
 
+// UpdateServiceExecutable replaces the ProgramArguments of an installed plist.
+// Returns false without changes if the plist already uses the given command.
+// Takes effect the next time the service is loaded.
+func UpdateServiceExecutable(name, exePath, args string) (bool, error) {
+	exists, _ := ServiceExists(name)
+	if !exists {
+		return false, ErrNotInstalled
+	}
+
+	plistPath := plistFilePath(name)
+	output, err := exec.Command("plutil", "-extract", "ProgramArguments", "json", "-o", "-", plistPath).Output()
+	if err != nil {
+		return false, fmt.Errorf("read plist file: %w", err)
+	}
+
+	var current []string
+	if err := json.Unmarshal(output, &current); err != nil {
+		return false, fmt.Errorf("parse ProgramArguments: %w", err)
+	}
+
+	desired := programArguments(exePath, args)
+	if slices.Equal(current, desired) {
+		return false, nil
+	}
+
+	encoded, err := json.Marshal(desired)
+	if err != nil {
+		return false, err
+	}
+	if err := runWithPrivileges("plutil", "-replace", "ProgramArguments", "-json", string(encoded), plistPath); err != nil {
+		return false, fmt.Errorf("write plist file: %w", err)
+	}
+	return true, nil
+}
+
+// programArguments builds the launchd ProgramArguments array.
+func programArguments(exePath, args string) []string {
+	result := []string{exePath}
+	if args != "" {
+		// Simple split by spaces (doesn't handle quoted strings)
+		result = append(result, strings.Fields(args)...)
+	}
+	return result
+}
+
 // UninstallService removes a launchd service.
 // Returns nil if the service doesn't exist.
 func UninstallService(name string) error {
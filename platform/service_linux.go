@@ -8,6 +8,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 	"text/template"
 	"time"
@@ -31,27 +32,141 @@ type ServiceConfig struct {
 	Executable  string // Full path to the executable (required)
 	Args        string // Command-line arguments passed at startup
 	StartType   uint32 // Ignored on Linux (services always start automatically unless disabled)
+
+	// Restart and supervision
+	RestartPolicy RestartPolicy // Restart= setting (default on-failure)
+	RestartDelay  time.Duration // RestartSec=, rounded up to whole seconds (default 5s)
+	Notify        bool          // Type=notify: the service reports readiness with sd_notify (default Type=simple)
+	WatchdogSec   time.Duration // WatchdogSec= (0 = disabled); the service must send WATCHDOG=1 with sd_notify
+
+	// Process identity, environment and limits
+	User        string            // User= to run as (default root)
+	Group       string            // Group= to run as
+	Environment map[string]string // Environment= entries
+	Limits      ServiceLimits     // LimitNOFILE=, LimitNPROC=, MemoryMax=, CPUQuota=
+
+	// Unit scope and activation
+	UserService bool              // Install to ~/.config/systemd/user and manage with systemctl --user (no root needed)
+	Socket      *SocketActivation // Generate and enable a .socket unit that starts the service on demand
+
+	// Windows-only account and startup settings, ignored on Linux
+	RunAs              string
+	Password           string
+	Dependencies       []string
+	DelayedAutoStart   bool
+	PreShutdownTimeout time.Duration
 }
 
 // systemdUnitTemplate is the template for generating systemd unit files.
-// Following go-svc patterns with Restart=on-failure configuration.
+// Following go-svc patterns: Restart=on-failure unless ServiceConfig overrides it.
 const systemdUnitTemplate = `[Unit]
 Description={{.Description}}
 After=network.target
+{{- if .Socket}}
+Requires={{.Socket}}
+After={{.Socket}}
+{{- end}}
 
 [Service]
-Type=simple
+Type={{.Type}}
 ExecStart={{.ExecStart}}
-Restart=on-failure
-RestartSec=5
+Restart={{.Restart}}
+RestartSec={{.RestartSec}}
+{{- if .WatchdogSec}}
+WatchdogSec={{.WatchdogSec}}
+NotifyAccess=main
+{{- end}}
+{{- if .User}}
+User={{.User}}
+{{- end}}
+{{- if .Group}}
+Group={{.Group}}
+{{- end}}
+{{- range .Environment}}
+Environment={{.}}
+{{- end}}
+{{- if .Limits.OpenFiles}}
+LimitNOFILE={{.Limits.OpenFiles}}
+{{- end}}
+{{- if .Limits.Processes}}
+LimitNPROC={{.Limits.Processes}}
+{{- end}}
+{{- if .Limits.MemoryMax}}
+MemoryMax={{.Limits.MemoryMax}}
+{{- end}}
+{{- if .Limits.CPUPercent}}
+CPUQuota={{.Limits.CPUPercent}}%
+{{- end}}
 
 [Install]
-WantedBy=multi-user.target
+WantedBy={{.WantedBy}}
 `
 
+// systemdSocketTemplate is the template for socket-activation units. systemd
+// pairs name.socket with name.service and passes the listening sockets to it.
+const systemdSocketTemplate = `[Unit]
+Description={{.Description}} socket
+
+[Socket]
+{{- range .ListenStream}}
+ListenStream={{.}}
+{{- end}}
+{{- range .ListenDatagram}}
+ListenDatagram={{.}}
+{{- end}}
+
+[Install]
+WantedBy=sockets.target
+`
+
+type systemdSocketData struct {
+	Description    string
+	ListenStream   []string
+	ListenDatagram []string
+}
+
 type systemdUnitData struct {
 	Description string
 	ExecStart   string
+	Type        string
+	Restart     string
+	RestartSec  int
+	WatchdogSec int
+	User        string
+	Group       string
+	Environment []string
+	Limits      ServiceLimits
+	Socket      string
+	WantedBy    string
+}
+
+// systemdRestart maps a RestartPolicy to the systemd Restart= value.
+func systemdRestart(policy RestartPolicy) string {
+	switch policy {
+	case RestartAlways:
+		return "always"
+	case RestartNever:
+		return "no"
+	default:
+		return "on-failure"
+	}
+}
+
+// systemdEnvironment formats environment variables as quoted Environment= values,
+// sorted by key so the generated unit file is stable.
+func systemdEnvironment(env map[string]string) []string {
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	entries := make([]string, 0, len(keys))
+	for _, k := range keys {
+		v := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `%`, `%%`).Replace(env[k])
+		entries = append(entries, fmt.Sprintf(`"%s=%s"`, k, v))
+	}
+	return entries
 }
 
 // unitFilePath returns the path to the systemd unit file for a service.
@@ -59,6 +174,47 @@ func unitFilePath(name string) string {
 	return filepath.Join("/etc/systemd/system", name+".service")
 }
 
+// userUnitDir returns the directory for per-user systemd units.
+func userUnitDir() (string, error) {
+	dir, err := UserConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "systemd", "user"), nil
+}
+
+// userUnitFilePath returns the path to the per-user unit file for a service,
+// or "" if the user config directory cannot be determined.
+func userUnitFilePath(name string) string {
+	dir, err := userUnitDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, name+".service")
+}
+
+// isUserService reports whether the service is installed as a per-user unit
+// rather than a system unit.
+func isUserService(name string) bool {
+	if _, err := os.Stat(unitFilePath(name)); err == nil {
+		return false
+	}
+	userPath := userUnitFilePath(name)
+	if userPath == "" {
+		return false
+	}
+	_, err := os.Stat(userPath)
+	return err == nil
+}
+
+// systemctl builds a systemctl command, targeting the user manager if user is set.
+func systemctl(user bool, args ...string) *exec.Cmd {
+	if user {
+		args = append([]string{"--user"}, args...)
+	}
+	return exec.Command("systemctl", args...)
+}
+
 // checkPrivileges verifies root access is available.
 // Following go-svc pattern: checks via id -g command.
 func checkPrivileges() error {
@@ -78,7 +234,7 @@ func ServiceExists(name string) (bool, error) {
 	unitPath := unitFilePath(name)
 	_, err := os.Stat(unitPath)
 	if os.IsNotExist(err) {
-		return false, nil
+		return isUserService(name), nil
 	}
 	if err != nil {
 		return false, err
@@ -88,7 +244,7 @@ func ServiceExists(name string) (bool, error) {
 
 // IsServiceRunning returns true if the service is currently running.
 func IsServiceRunning(name string) (bool, error) {
-	cmd := exec.Command("systemctl", "is-active", name)
+	cmd := systemctl(isUserService(name), "is-active", name)
 	output, err := cmd.Output()
 	if err != nil {
 		// is-active returns non-zero exit code if not active
@@ -105,7 +261,7 @@ func ServiceStatus(name string) (string, error) {
 		return "not installed", nil
 	}
 
-	cmd := exec.Command("systemctl", "is-active", name)
+	cmd := systemctl(isUserService(name), "is-active", name)
 	output, err := cmd.Output()
 	if err != nil {
 		return "stopped", nil
@@ -147,7 +303,7 @@ func StartService(name string) error {
 	}
 
 	// Start the service
-	cmd := exec.Command("systemctl", "start", name)
+	cmd := systemctl(isUserService(name), "start", name)
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("start service: %w", err)
 	}
@@ -181,7 +337,7 @@ func StopService(name string) error {
 	}
 
 	// Stop the service
-	cmd := exec.Command("systemctl", "stop", name)
+	cmd := systemctl(isUserService(name), "stop", name)
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("stop service: %w", err)
 	}
@@ -199,6 +355,34 @@ func StopService(name string) error {
 	return fmt.Errorf("timeout waiting for service to stop")
 }
 
+// ServiceLogs returns up to lines of the most recent journal entries for the
+// service, oldest first. Useful for showing why a service failed to start.
+func ServiceLogs(name string, lines int) ([]string, error) {
+	if lines <= 0 {
+		lines = 50
+	}
+
+	cmd := journalctl(isUserService(name), "-u", name, "-n", fmt.Sprint(lines), "--no-pager", "-o", "short-iso")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("read journal: %w", err)
+	}
+
+	text := strings.TrimRight(string(output), "\n")
+	if text == "" || text == "-- No entries --" {
+		return nil, nil
+	}
+	return strings.Split(text, "\n"), nil
+}
+
+// journalctl builds a journalctl command, reading the user journal if user is set.
+func journalctl(user bool, args ...string) *exec.Cmd {
+	if user {
+		args = append([]string{"--user"}, args...)
+	}
+	return exec.Command("journalctl", args...)
+}
+
 // InstallService installs a new systemd service.
 func InstallService(name, displayName, exePath, args string) error {
 	return InstallServiceWithConfig(ServiceConfig{
@@ -211,6 +395,7 @@ func InstallService(name, displayName, exePath, args string) error {
 
 // InstallServiceWithConfig installs a systemd service with full configuration.
 // Following go-svc patterns: requires root, runs daemon-reload and enable.
+// User services (cfg.UserService) are installed for the current user and need no root.
 func InstallServiceWithConfig(cfg ServiceConfig) error {
 	if cfg.Name == "" {
 		return fmt.Errorf("service name is required")
@@ -219,11 +404,17 @@ func InstallServiceWithConfig(cfg ServiceConfig) error {
 		return fmt.Errorf("executable path is required")
 	}
 
-	// Check privileges
-	if err := checkPrivileges(); err != nil {
+	if err := validateServiceValues(cfg.User, cfg.Group, cfg.Environment); err != nil {
 		return err
 	}
 
+	// Check privileges
+	if !cfg.UserService {
+		if err := checkPrivileges(); err != nil {
+			return err
+		}
+	}
+
 	// Check if already installed
 	exists, _ := ServiceExists(cfg.Name)
 	if exists {
@@ -231,10 +422,7 @@ func InstallServiceWithConfig(cfg ServiceConfig) error {
 	}
 
 	// Build ExecStart line
-	execStart := cfg.Executable
-	if cfg.Args != "" {
-		execStart = fmt.Sprintf("%s %s", cfg.Executable, cfg.Args)
-	}
+	execStart := buildExecStart(cfg.Executable, cfg.Args)
 
 	// Build description
 	description := cfg.Description
@@ -251,9 +439,32 @@ func InstallServiceWithConfig(cfg ServiceConfig) error {
 		return fmt.Errorf("parse unit template: %w", err)
 	}
 
+	// A Type=notify service that never calls sd_notify hangs in
+	// "activating", so the type is only changed when asked for. The
+	// watchdog works with either type once NotifyAccess allows the pings.
+	unitType := "simple"
+	if cfg.Notify {
+		unitType = "notify"
+	}
+
 	data := systemdUnitData{
 		Description: description,
 		ExecStart:   execStart,
+		Type:        unitType,
+		Restart:     systemdRestart(cfg.RestartPolicy),
+		RestartSec:  serviceSeconds(cfg.RestartDelay, 5),
+		WatchdogSec: serviceSeconds(cfg.WatchdogSec, 0),
+		User:        cfg.User,
+		Group:       cfg.Group,
+		Environment: systemdEnvironment(cfg.Environment),
+		Limits:      cfg.Limits,
+		WantedBy:    "multi-user.target",
+	}
+	if cfg.UserService {
+		data.WantedBy = "default.target"
+	}
+	if cfg.Socket != nil {
+		data.Socket = cfg.Name + ".socket"
 	}
 
 	var content strings.Builder
@@ -263,18 +474,37 @@ func InstallServiceWithConfig(cfg ServiceConfig) error {
 
 	// Write unit file
 	unitPath := unitFilePath(cfg.Name)
+	if cfg.UserService {
+		dir, err := userUnitDir()
+		if err != nil {
+			return fmt.Errorf("locate user unit directory: %w", err)
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("create user unit directory: %w", err)
+		}
+		unitPath = filepath.Join(dir, cfg.Name+".service")
+	}
 	if err := os.WriteFile(unitPath, []byte(content.String()), 0644); err != nil {
 		return fmt.Errorf("write unit file: %w", err)
 	}
 
+	// Write socket unit next to the service unit
+	enableUnit := cfg.Name
+	if cfg.Socket != nil {
+		if err := writeSocketUnit(strings.TrimSuffix(unitPath, ".service")+".socket", description, cfg.Socket); err != nil {
+			return err
+		}
+		enableUnit = cfg.Name + ".socket"
+	}
+
 	// Reload systemd daemon
-	cmd := exec.Command("systemctl", "daemon-reload")
+	cmd := systemctl(cfg.UserService, "daemon-reload")
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("reload systemd: %w", err)
 	}
 
-	// Enable the service
-	cmd = exec.Command("systemctl", "enable", cfg.Name)
+	// Enable the service, or only its socket when socket-activated
+	cmd = systemctl(cfg.UserService, "enable", enableUnit)
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("enable service: %w", err)
 	}
@@ -282,6 +512,86 @@ func InstallServiceWithConfig(cfg ServiceConfig) error {
 	return nil
 }
 
+// writeSocketUnit generates the .socket unit for socket activation.
+func writeSocketUnit(path, description string, socket *SocketActivation) error {
+	if len(socket.ListenStream) == 0 && len(socket.ListenDatagram) == 0 {
+		return fmt.Errorf("socket activation requires at least one listen address")
+	}
+
+	tmpl, err := template.New("socket").Parse(systemdSocketTemplate)
+	if err != nil {
+		return fmt.Errorf("parse socket template: %w", err)
+	}
+
+	data := systemdSocketData{
+		Description:    description,
+		ListenStream:   socket.ListenStream,
+		ListenDatagram: socket.ListenDatagram,
+	}
+
+	var content strings.Builder
+	if err := tmpl.Execute(&content, data); err != nil {
+		return fmt.Errorf("generate socket file: %w", err)
+	}
+
+	if err := os.WriteFile(path, []byte(content.String()), 0644); err != nil {
+		return fmt.Errorf("write socket file: %w", err)
+	}
+	return nil
+}
+
+// UpdateServiceExecutable rewrites the ExecStart line of an installed unit.
+// Returns false without changes if the unit already uses the given command.
+// Takes effect on the next service start.
+func UpdateServiceExecutable(name, exePath, args string) (bool, error) {
+	exists, _ := ServiceExists(name)
+	if !exists {
+		return false, ErrNotInstalled
+	}
+
+	user := isUserService(name)
+	unitPath := unitFilePath(name)
+	if user {
+		unitPath = userUnitFilePath(name)
+	} else if err := checkPrivileges(); err != nil {
+		return false, err
+	}
+
+	data, err := os.ReadFile(unitPath)
+	if err != nil {
+		return false, fmt.Errorf("read unit file: %w", err)
+	}
+
+	execStart := "ExecStart=" + buildExecStart(exePath, args)
+	lines := strings.Split(string(data), "\n")
+	changed := false
+	for i, line := range lines {
+		if strings.HasPrefix(line, "ExecStart=") && line != execStart {
+			lines[i] = execStart
+			changed = true
+		}
+	}
+	if !changed {
+		return false, nil
+	}
+
+	if err := os.WriteFile(unitPath, []byte(strings.Join(lines, "\n")), 0644); err != nil {
+		return false, fmt.Errorf("write unit file: %w", err)
+	}
+	if err := systemctl(user, "daemon-reload").Run(); err != nil {
+		return false, fmt.Errorf("reload systemd: %w", err)
+	}
+	return true, nil
+}
+
+// buildExecStart builds the ExecStart command line.
+func buildExecStart(exePath, args string) string {
+	if args == "" {
+		return exePath
+	}
+	return fmt.Sprintf("%s %s", exePath, args)
+}
+
 // UninstallService removes a systemd service.
 // Returns nil if the service doesn't exist.
 func UninstallService(name string) error {
@@ -290,26 +600,43 @@ func UninstallService(name string) error {
 		return nil
 	}
 
+	user := isUserService(name)
+	unitPath := unitFilePath(name)
+	if user {
+		unitPath = userUnitFilePath(name)
+	}
+	socketPath := strings.TrimSuffix(unitPath, ".service") + ".socket"
+
 	// Check privileges
-	if err := checkPrivileges(); err != nil {
-		return err
+	if !user {
+		if err := checkPrivileges(); err != nil {
+			return err
+		}
+	}
+
+	// Stop the socket first so it can't reactivate the service
+	if _, err := os.Stat(socketPath); err == nil {
+		systemctl(user, "stop", name+".socket").Run()
+		systemctl(user, "disable", name+".socket").Run()
 	}
 
 	// Stop the service first if running
 	StopService(name)
 
 	// Disable the service
-	cmd := exec.Command("systemctl", "disable", name)
+	cmd := systemctl(user, "disable", name)
 	cmd.Run() // Ignore error - service might not be enabled
 
-	// Remove unit file
-	unitPath := unitFilePath(name)
+	// Remove unit files
 	if err := os.Remove(unitPath); err != nil && !os.IsNotExist(err) {
 		return fmt.Errorf("remove unit file: %w", err)
 	}
+	if err := os.Remove(socketPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("remove socket file: %w", err)
+	}
 
 	// Reload systemd daemon
-	cmd = exec.Command("systemctl", "daemon-reload")
+	cmd = systemctl(user, "daemon-reload")
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("reload systemd: %w", err)
 	}
@@ -3,10 +3,19 @@
 package platform
 
 import (
+	"bytes"
+	"encoding/xml"
 	"errors"
 	"fmt"
+	"io"
+	"os/exec"
+	"sort"
+	"strings"
+	"syscall"
 	"time"
+	"unsafe"
 
+	"golang.org/x/sys/windows"
 	"golang.org/x/sys/windows/registry"
 	"golang.org/x/sys/windows/svc"
 	"golang.org/x/sys/windows/svc/mgr"
@@ -21,6 +30,13 @@ var (
 	ErrInsufficientPrivileges = errors.New("insufficient privileges")
 )
 
+// Built-in accounts for ServiceConfig.RunAs. These accounts have no password.
+const (
+	ServiceAccountLocalSystem    = "LocalSystem"
+	ServiceAccountLocalService   = `NT AUTHORITY\LocalService`
+	ServiceAccountNetworkService = `NT AUTHORITY\NetworkService`
+)
+
 // ServiceConfig holds parameters for installing a Windows service.
 // This follows patterns from github.com/adnsv/go-svc.
 type ServiceConfig struct {
@@ -30,11 +46,36 @@ type ServiceConfig struct {
 	Executable  string // Full path to the executable (required)
 	Args        string // Command-line arguments passed at startup
 	StartType   uint32 // Start type: mgr.StartAutomatic (default), mgr.StartManual, mgr.StartDisabled
+
+	// Account settings
+	RunAs    string // Account to run as: ServiceAccount* constant or DOMAIN\user, .\user, user@domain, NT SERVICE\name (default LocalSystem)
+	Password string // Password for a named RunAs account (ignored for built-in accounts)
+
+	// Startup and shutdown behavior
+	Dependencies       []string      // Services that must be running before this one starts
+	DelayedAutoStart   bool          // Start shortly after other automatic services (StartAutomatic only)
+	PreShutdownTimeout time.Duration // Time allowed for SERVICE_CONTROL_PRESHUTDOWN handling (0 = system default)
+
+	// Restart and supervision
+	RestartPolicy RestartPolicy // Recovery actions (default restart on failure)
+	RestartDelay  time.Duration // Delay before the first three restarts (default 5s)
+	Notify        bool          // Ignored on Windows
+	WatchdogSec   time.Duration // Ignored on Windows
+
+	// Process environment
+	User        string            // Ignored on Windows (use RunAs)
+	Group       string            // Ignored on Windows
+	Environment map[string]string // Stored in the service's Environment registry value
+	Limits      ServiceLimits     // Ignored on Windows
+
+	// systemd-only unit scope and activation, ignored on Windows
+	UserService bool
+	Socket      *SocketActivation
 }
 
 // ServiceExists returns true if a Windows service with the given name exists.
 func ServiceExists(name string) (bool, error) {
-	m, err := mgr.Connect()
+	m, err := connectServiceManager()
 	if err != nil {
 		return false, fmt.Errorf("connect to service manager: %w", err)
 	}
@@ -50,7 +91,7 @@ func ServiceExists(name string) (bool, error) {
 
 // IsServiceRunning returns true if the service exists and is running.
 func IsServiceRunning(name string) (bool, error) {
-	m, err := mgr.Connect()
+	m, err := connectServiceManager()
 	if err != nil {
 		return false, fmt.Errorf("connect to service manager: %w", err)
 	}
@@ -73,7 +114,7 @@ func IsServiceRunning(name string) (bool, error) {
 // ServiceStatus returns a string describing the current state of a service.
 // Returns "not installed" if the service doesn't exist.
 func ServiceStatus(name string) (string, error) {
-	m, err := mgr.Connect()
+	m, err := connectServiceManager()
 	if err != nil {
 		return "", fmt.Errorf("connect to service manager: %w", err)
 	}
@@ -113,7 +154,7 @@ func ServiceStatus(name string) (string, error) {
 // StartService starts the service and waits for it to enter the running state.
 // Returns nil if the service is already running.
 func StartService(name string) error {
-	m, err := mgr.Connect()
+	m, err := connectServiceManager()
 	if err != nil {
 		return fmt.Errorf("connect to service manager: %w", err)
 	}
@@ -147,7 +188,7 @@ func StartService(name string) error {
 // StopService stops the service and waits for it to enter the stopped state.
 // Returns nil if the service is already stopped or doesn't exist.
 func StopService(name string) error {
-	m, err := mgr.Connect()
+	m, err := connectServiceManager()
 	if err != nil {
 		return fmt.Errorf("connect to service manager: %w", err)
 	}
@@ -179,6 +220,156 @@ func StopService(name string) error {
 	return waitForServiceState(s, svc.Stopped, getServiceTimeout())
 }
 
+// ServiceLogs returns up to lines of recent Event Log entries for the service,
+// oldest first. It includes events the service wrote to the Application log
+// under its own source name and Service Control Manager events from the
+// System log that mention the service (start failures, unexpected exits).
+func ServiceLogs(name string, lines int) ([]string, error) {
+	if lines <= 0 {
+		lines = 50
+	}
+
+	displayName := name
+	if m, err := connectServiceManager(); err == nil {
+		if s, err := m.OpenService(name); err == nil {
+			if cfg, err := s.Config(); err == nil && cfg.DisplayName != "" {
+				displayName = cfg.DisplayName
+			}
+			s.Close()
+		}
+		m.Disconnect()
+	}
+
+	nameLit, err := xpathLiteral(name)
+	if err != nil {
+		return nil, err
+	}
+	displayLit, err := xpathLiteral(displayName)
+	if err != nil {
+		return nil, err
+	}
+	appQuery := fmt.Sprintf("*[System[Provider[@Name=%s]]]", nameLit)
+	scmQuery := fmt.Sprintf("*[System[Provider[@Name='Service Control Manager']] and EventData[Data=%s or Data=%s]]",
+		nameLit, displayLit)
+
+	appEvents, appErr := queryEventLog("Application", appQuery, lines)
+	scmEvents, scmErr := queryEventLog("System", scmQuery, lines)
+	if appErr != nil && scmErr != nil {
+		return nil, fmt.Errorf("query event log: %w", appErr)
+	}
+
+	events := append(appEvents, scmEvents...)
+	sort.SliceStable(events, func(i, j int) bool { return events[i].date < events[j].date })
+	if len(events) > lines {
+		events = events[len(events)-lines:]
+	}
+
+	result := make([]string, 0, len(events))
+	for _, e := range events {
+		result = append(result, fmt.Sprintf("%s %s: %s", e.date, e.level, e.message))
+	}
+	return result, nil
+}
+
+type eventLogEntry struct {
+	date    string // ISO 8601, sortable
+	level   string
+	source  string
+	message string
+}
+
+// eventXML is the part of an event's rendered XML that ServiceLogs shows.
+// Element names are the same on every display language, unlike the labels
+// of wevtutil's text format.
+type eventXML struct {
+	System struct {
+		Provider struct {
+			Name string `xml:"Name,attr"`
+		}
+		Level       int
+		TimeCreated struct {
+			SystemTime string `xml:"SystemTime,attr"`
+		}
+	}
+	EventData struct {
+		Data []string
+	}
+	RenderingInfo struct {
+		Message string
+		Level   string
+	}
+}
+
+// eventLevelNames are the standard event levels, for events whose provider
+// renders no level name.
+var eventLevelNames = map[int]string{
+	1: "Critical",
+	2: "Error",
+	3: "Warning",
+	4: "Information",
+	5: "Verbose",
+}
+
+// queryEventLog runs wevtutil and parses its rendered XML output into
+// entries. The output is a sequence of Event elements without a root.
+func queryEventLog(logName, query string, count int) ([]eventLogEntry, error) {
+	cmd := exec.Command("wevtutil", "qe", logName, "/q:"+query, fmt.Sprintf("/c:%d", count), "/rd:true", "/f:RenderedXml")
+	cmd.SysProcAttr = &syscall.SysProcAttr{HideWindow: true}
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []eventLogEntry
+	dec := xml.NewDecoder(bytes.NewReader(output))
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return entries, fmt.Errorf("parse events: %w", err)
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "Event" {
+			continue
+		}
+		var ev eventXML
+		if err := dec.DecodeElement(&ev, &start); err != nil {
+			return entries, fmt.Errorf("parse events: %w", err)
+		}
+
+		level := strings.TrimSpace(ev.RenderingInfo.Level)
+		if level == "" {
+			level = eventLevelNames[ev.System.Level]
+		}
+		message := strings.TrimSpace(ev.RenderingInfo.Message)
+		if message == "" {
+			message = strings.Join(ev.EventData.Data, " ")
+		}
+		entries = append(entries, eventLogEntry{
+			date:    ev.System.TimeCreated.SystemTime,
+			level:   level,
+			source:  ev.System.Provider.Name,
+			message: strings.Join(strings.Fields(message), " "),
+		})
+	}
+	return entries, nil
+}
+
+// xpathLiteral quotes s as an XPath string literal. The event log's XPath
+// subset has no concat(), so a value containing both quote characters
+// can't be expressed and is rejected.
+func xpathLiteral(s string) (string, error) {
+	if !strings.Contains(s, "'") {
+		return "'" + s + "'", nil
+	}
+	if !strings.Contains(s, `"`) {
+		return `"` + s + `"`, nil
+	}
+	return "", fmt.Errorf("service name %q contains both quote characters", s)
+}
+
 // InstallService installs a new Windows service.
 // The service is created with automatic start type.
 func InstallService(name, displayName, exePath, args string) error {
@@ -201,7 +392,7 @@ func InstallServiceWithConfig(cfg ServiceConfig) error {
 		return fmt.Errorf("executable path is required")
 	}
 
-	m, err := mgr.Connect()
+	m, err := connectServiceManager()
 	if err != nil {
 		return fmt.Errorf("connect to service manager: %w", err)
 	}
@@ -214,6 +405,14 @@ func InstallServiceWithConfig(cfg ServiceConfig) error {
 		return ErrAlreadyInstalled
 	}
 
+	// Validate named account credentials up front; CreateService accepts a
+	// wrong password and the failure only surfaces when the service starts.
+	if !isBuiltinServiceAccount(cfg.RunAs) {
+		if err := validateServiceCredentials(cfg.RunAs, cfg.Password); err != nil {
+			return err
+		}
+	}
+
 	// Determine start type
 	startType := cfg.StartType
 	if startType == 0 {
@@ -222,15 +421,26 @@ func InstallServiceWithConfig(cfg ServiceConfig) error {
 
 	// Build service config
 	config := mgr.Config{
-		DisplayName: cfg.DisplayName,
-		Description: cfg.Description,
-		StartType:   startType,
+		DisplayName:      cfg.DisplayName,
+		Description:      cfg.Description,
+		StartType:        startType,
+		Dependencies:     cfg.Dependencies,
+		DelayedAutoStart: cfg.DelayedAutoStart && startType == mgr.StartAutomatic,
+		ServiceStartName: cfg.RunAs, // Empty means LocalSystem
+	}
+	if !isBuiltinServiceAccount(cfg.RunAs) {
+		config.Password = cfg.Password
+		// CreateService doesn't grant "Log on as a service"; without it the
+		// first start fails with ERROR_SERVICE_LOGON_FAILED.
+		if err := grantServiceLogonRight(cfg.RunAs); err != nil {
+			return fmt.Errorf("grant log on as a service to %s: %w", cfg.RunAs, err)
+		}
 	}
 
 	// Build binary path with arguments
 	binPath := cfg.Executable
 	if cfg.Args != "" {
-		binPath = fmt.Sprintf(`"%s" %s`, cfg.Executable, cfg.Args)
+		binPath = serviceBinaryPath(cfg.Executable, cfg.Args)
 	}
 
 	s, err = m.CreateService(cfg.Name, binPath, config)
@@ -239,13 +449,35 @@ func InstallServiceWithConfig(cfg ServiceConfig) error {
 	}
 	defer s.Close()
 
+	if cfg.PreShutdownTimeout > 0 {
+		if err := setPreShutdownTimeout(s, cfg.PreShutdownTimeout); err != nil {
+			s.Delete()
+			return fmt.Errorf("set pre-shutdown timeout: %w", err)
+		}
+	}
+
+	if len(cfg.Environment) > 0 {
+		if err := setServiceEnvironment(cfg.Name, cfg.Environment); err != nil {
+			s.Delete()
+			return fmt.Errorf("set service environment: %w", err)
+		}
+	}
+
+	if cfg.RestartPolicy == RestartNever {
+		return nil
+	}
+
 	// Configure automatic recovery (restart on failure)
 	// Following go-svc pattern: restart after 5s for first 3 failures, then 60s
+	delay := 5 * time.Second
+	if cfg.RestartDelay > 0 {
+		delay = cfg.RestartDelay
+	}
 	recoveryActions := []mgr.RecoveryAction{
-		{Type: mgr.ServiceRestart, Delay: 5 * time.Second},
-		{Type: mgr.ServiceRestart, Delay: 5 * time.Second},
-		{Type: mgr.ServiceRestart, Delay: 5 * time.Second},
-		{Type: mgr.ServiceRestart, Delay: 60 * time.Second},
+		{Type: mgr.ServiceRestart, Delay: delay},
+		{Type: mgr.ServiceRestart, Delay: delay},
+		{Type: mgr.ServiceRestart, Delay: delay},
+		{Type: mgr.ServiceRestart, Delay: max(delay, 60*time.Second)},
 	}
 	err = s.SetRecoveryActions(recoveryActions, uint32((24*time.Hour).Seconds())) // Reset failure count after 24h
 	if err != nil {
@@ -253,13 +485,68 @@ func InstallServiceWithConfig(cfg ServiceConfig) error {
 		_ = err
 	}
 
+	// The SCM only runs recovery actions on crashes; for RestartAlways also
+	// treat a stop with a non-zero exit code as a failure.
+	if cfg.RestartPolicy == RestartAlways {
+		_ = s.SetRecoveryActionsOnNonCrashFailures(true)
+	}
+
 	return nil
 }
 
+// UpdateServiceExecutable points an installed service at a new executable
+// path and arguments. Returns false without changes if the service already
+// uses them. Takes effect on the next service start.
+func UpdateServiceExecutable(name, exePath, args string) (bool, error) {
+	m, err := connectServiceManager()
+	if err != nil {
+		return false, fmt.Errorf("connect to service manager: %w", err)
+	}
+	defer m.Disconnect()
+
+	s, err := m.OpenService(name)
+	if err != nil {
+		return false, ErrNotInstalled
+	}
+	defer s.Close()
+
+	config, err := s.Config()
+	if err != nil {
+		return false, fmt.Errorf("query service config: %w", err)
+	}
+
+	binPath := serviceBinaryPath(exePath, args)
+	if normalizeBinaryPath(config.BinaryPathName) == normalizeBinaryPath(binPath) {
+		return false, nil
+	}
+
+	config.BinaryPathName = binPath
+	if err := s.UpdateConfig(config); err != nil {
+		return false, fmt.Errorf("update service config: %w", err)
+	}
+	return true, nil
+}
+
+// serviceBinaryPath builds the service command line with a quoted executable.
+func serviceBinaryPath(exePath, args string) string {
+	if args == "" {
+		return fmt.Sprintf(`"%s"`, exePath)
+	}
+	return fmt.Sprintf(`"%s" %s`, exePath, args)
+}
+
+// normalizeBinaryPath strips quoting and case so command lines written by
+// different tools compare equal.
+func normalizeBinaryPath(s string) string {
+	s = strings.ReplaceAll(s, `\"`, "")
+	s = strings.ReplaceAll(s, `"`, "")
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
 // UninstallService removes a Windows service.
 // Returns nil if the service doesn't exist.
 func UninstallService(name string) error {
-	m, err := mgr.Connect()
+	m, err := connectServiceManager()
 	if err != nil {
 		return fmt.Errorf("connect to service manager: %w", err)
 	}
@@ -287,6 +574,133 @@ func UninstallService(name string) error {
 	return nil
 }
 
+// isBuiltinServiceAccount reports whether account is one of the password-less
+// built-in service accounts. An empty account means LocalSystem. Virtual
+// accounts (NT SERVICE\<name>) are managed by the system and have no password
+// either.
+func isBuiltinServiceAccount(account string) bool {
+	lower := strings.ToLower(account)
+	switch lower {
+	case "", "localsystem", `.\localsystem`, `nt authority\system`,
+		`nt authority\localservice`, `nt authority\networkservice`:
+		return true
+	}
+	return strings.HasPrefix(lower, `nt service\`)
+}
+
+// validateServiceCredentials checks the account name and password with
+// ValidateWindowsCredentials. Group managed service accounts (names ending
+// in $) have no password and are not checked. When no domain controller can
+// be reached the password can't be checked either; the install goes ahead
+// and the SCM reports a wrong password when the service starts. Other
+// errors, such as a locked or disabled account, are returned unchanged.
+func validateServiceCredentials(account, password string) error {
+	if strings.HasSuffix(account, "$") {
+		return nil
+	}
+	err := ValidateWindowsCredentials(account, password, "")
+	if errors.Is(err, errorNoLogonServers) || errors.Is(err, errorTrustedRelationship) {
+		return nil
+	}
+	return err
+}
+
+var (
+	procLsaOpenPolicy       = advapi32.NewProc("LsaOpenPolicy")
+	procLsaAddAccountRights = advapi32.NewProc("LsaAddAccountRights")
+	procLsaClose            = advapi32.NewProc("LsaClose")
+)
+
+const (
+	policyCreateAccount = 0x00000010
+	policyLookupNames   = 0x00000800
+)
+
+// lsaObjectAttributes mirrors LSA_OBJECT_ATTRIBUTES; LsaOpenPolicy requires
+// it zeroed apart from Length.
+type lsaObjectAttributes struct {
+	Length                   uint32
+	RootDirectory            windows.Handle
+	ObjectName               *windows.NTUnicodeString
+	Attributes               uint32
+	SecurityDescriptor       uintptr
+	SecurityQualityOfService uintptr
+}
+
+// grantServiceLogonRight gives account the SeServiceLogonRight privilege
+// ("Log on as a service") through the local security policy. Granting a
+// right the account already holds is not an error.
+func grantServiceLogonRight(account string) error {
+	name := account
+	if rest, ok := strings.CutPrefix(name, `.\`); ok {
+		name = rest
+	}
+	sid, _, _, err := windows.LookupSID("", name)
+	if err != nil {
+		return fmt.Errorf("look up account: %w", err)
+	}
+
+	attrs := lsaObjectAttributes{Length: uint32(unsafe.Sizeof(lsaObjectAttributes{}))}
+	var policy windows.Handle
+	r, _, _ := procLsaOpenPolicy.Call(0, uintptr(unsafe.Pointer(&attrs)),
+		policyCreateAccount|policyLookupNames, uintptr(unsafe.Pointer(&policy)))
+	if status := windows.NTStatus(r); status != windows.STATUS_SUCCESS {
+		if status.Errno() == windows.ERROR_ACCESS_DENIED {
+			return ErrInsufficientPrivileges
+		}
+		return fmt.Errorf("LsaOpenPolicy: %w", status.Errno())
+	}
+	defer procLsaClose.Call(uintptr(policy))
+
+	right, err := windows.NewNTUnicodeString("SeServiceLogonRight")
+	if err != nil {
+		return err
+	}
+	r, _, _ = procLsaAddAccountRights.Call(uintptr(policy), uintptr(unsafe.Pointer(sid)),
+		uintptr(unsafe.Pointer(right)), 1)
+	if status := windows.NTStatus(r); status != windows.STATUS_SUCCESS {
+		return fmt.Errorf("LsaAddAccountRights: %w", status.Errno())
+	}
+	return nil
+}
+
+// setPreShutdownTimeout configures how long the SCM waits for the service
+// to handle SERVICE_CONTROL_PRESHUTDOWN.
+func setPreShutdownTimeout(s *mgr.Service, timeout time.Duration) error {
+	info := struct{ Timeout uint32 }{Timeout: uint32(timeout.Milliseconds())}
+	return windows.ChangeServiceConfig2(s.Handle, windows.SERVICE_CONFIG_PRESHUTDOWN_INFO, (*byte)(unsafe.Pointer(&info)))
+}
+
+// setServiceEnvironment writes environment variables for the service process.
+// The SCM merges the REG_MULTI_SZ Environment value under the service key into
+// the environment of the started process.
+func setServiceEnvironment(name string, env map[string]string) error {
+	key, err := registry.OpenKey(registry.LOCAL_MACHINE,
+		`SYSTEM\CurrentControlSet\Services\`+name,
+		registry.SET_VALUE)
+	if err != nil {
+		return err
+	}
+	defer key.Close()
+
+	entries := make([]string, 0, len(env))
+	for k, v := range env {
+		entries = append(entries, k+"="+v)
+	}
+	sort.Strings(entries)
+	return key.SetStringsValue("Environment", entries)
+}
+
+// connectServiceManager connects to the SCM, reporting access denied as
+// ErrInsufficientPrivileges so callers can prompt for elevation.
+func connectServiceManager() (*mgr.Mgr, error) {
+	m, err := mgr.Connect()
+	if errors.Is(err, windows.ERROR_ACCESS_DENIED) {
+		return nil, ErrInsufficientPrivileges
+	}
+	return m, err
+}
+
 // waitForServiceState waits for a service to reach the target state.
 func waitForServiceState(s *mgr.Service, target svc.State, timeout time.Duration) error {
 	deadline := time.Now().Add(timeout)
@@ -3,6 +3,7 @@
 package platform
 
 import (
+	"os"
 	"os/exec"
 )
 
@@ -17,3 +18,35 @@ func LaunchAsSessionUser(exePath string) (uint32, error) {
 	cmd.Process.Release()
 	return pid, nil
 }
+
+// LaunchInSession starts the process directly; sessions are Windows-specific.
+func LaunchInSession(exePath string, sessionID uint32) (uint32, error) {
+	return LaunchAsSessionUser(exePath)
+}
+
+// IsNonInteractiveSession reports whether the process runs where no user can
+// see or answer a window: over SSH, or as a launchd daemon outside any login
+// session. Installers should switch to silent mode instead of creating a
+// window.
+func IsNonInteractiveSession() bool {
+	return NonInteractiveReason() != ""
+}
+
+// NonInteractiveReason describes why the session is non-interactive, or
+// returns "" if it is interactive.
+func NonInteractiveReason() string {
+	if os.Getenv("SSH_CONNECTION") != "" || os.Getenv("SSH_CLIENT") != "" {
+		return "running over SSH"
+	}
+	// Daemons are started by launchd as root with no GUI session
+	if os.Getuid() == 0 && os.Getppid() == 1 {
+		return "running as a launchd daemon"
+	}
+	return ""
+}
+
+// IsRemoteSession returns false; screen sharing on macOS mirrors the local
+// display, which keeps its GPU.
+func IsRemoteSession() bool {
+	return false
+}
@@ -3,6 +3,7 @@
 package platform
 
 import (
+	"os"
 	"os/exec"
 )
 
@@ -17,3 +18,34 @@ func LaunchAsSessionUser(exePath string) (uint32, error) {
 	cmd.Process.Release()
 	return pid, nil
 }
+
+// LaunchInSession starts the process directly; sessions are Windows-specific.
+func LaunchInSession(exePath string, sessionID uint32) (uint32, error) {
+	return LaunchAsSessionUser(exePath)
+}
+
+// IsNonInteractiveSession reports whether the process runs where no user can
+// see or answer a window: without an X11 or Wayland display, e.g. over SSH or
+// from a system service. Installers should switch to silent mode instead of
+// creating a window.
+func IsNonInteractiveSession() bool {
+	return NonInteractiveReason() != ""
+}
+
+// NonInteractiveReason describes why the session is non-interactive, or
+// returns "" if it is interactive.
+func NonInteractiveReason() string {
+	if os.Getenv("DISPLAY") != "" || os.Getenv("WAYLAND_DISPLAY") != "" {
+		return ""
+	}
+	if os.Getenv("SSH_CONNECTION") != "" || os.Getenv("SSH_CLIENT") != "" {
+		return "running over SSH without a forwarded display"
+	}
+	return "no X11 or Wayland display"
+}
+
+// IsRemoteSession reports whether windows are shown on a remote display:
+// an X11 display forwarded over SSH.
+func IsRemoteSession() bool {
+	return os.Getenv("SSH_CONNECTION") != "" && os.Getenv("DISPLAY") != ""
+}
@@ -12,19 +12,26 @@ import (
 	"golang.org/x/sys/windows"
 )
 
-// LaunchAsSessionUser launches an executable in the active console user's
-// desktop session at the user's normal (non-elevated) privilege level.
+// LaunchAsSessionUser launches an executable in the desktop session of the
+// user who started the installer, at the user's normal (non-elevated)
+// privilege level.
 //
 // The function picks the right strategy based on the caller's identity:
 //   - SYSTEM (e.g., a Windows service or its child process): uses WTS
-//     approach (WTSQueryUserToken + CreateProcessAsUser) which correctly
-//     targets the desktop user's session. LaunchDeElevated is skipped
-//     because its schtasks approach would create the task as SYSTEM
-//     (no /ru flag → inherits caller identity), launching the app in
+//     approach (see LaunchInSession) in the caller's own session, or, from
+//     session 0, the console session, or the only active session if the
+//     console has no user (e.g., a single Remote Desktop user). LaunchDeElevated
+//     is skipped because its scheduled task approach would register the task
+//     as SYSTEM (no RunAs → inherits caller identity), launching the app in
 //     session 0 instead of the user's desktop.
 //   - Elevated admin (e.g., UAC-elevated installer): uses LaunchDeElevated
 //     which borrows the Explorer shell token to de-elevate.
-//   - Non-elevated: falls through to WTS as a last resort.
+//   - Non-elevated: falls through to WTS in the caller's own session as a
+//     last resort.
+//
+// With Fast User Switching or Remote Desktop the console can belong to
+// another user; the caller's session is preferred, so the app opens for
+// whoever ran the installer. Use LaunchInSession to pick another session.
 //
 // Returns the PID of the launched process.
 func LaunchAsSessionUser(exePath string) (uint32, error) {
@@ -37,14 +44,21 @@ func LaunchAsSessionUser(exePath string) (uint32, error) {
 		}
 	}
 
-	// WTS approach (works from SYSTEM — requires SE_TCB_NAME privilege).
-
-	// Get the active console session (the one with the physical keyboard/monitor).
-	sessionID := windows.WTSGetActiveConsoleSessionId()
-	if sessionID == 0xFFFFFFFF {
-		return 0, fmt.Errorf("no active console session")
+	sessionID, err := launchSessionID()
+	if err != nil {
+		return 0, err
 	}
+	return LaunchInSession(exePath, sessionID)
+}
 
+// LaunchInSession launches an executable in a Windows session as the user
+// signed in to it, e.g. a session found with ListLoggedOnUsers. Requires the
+// SE_TCB_NAME privilege, which SYSTEM has: call it from a service or a
+// process it started.
+//
+// Returns the PID of the launched process, or 0 if it was started by the
+// scheduled task fallback.
+func LaunchInSession(exePath string, sessionID uint32) (uint32, error) {
 	// Obtain the session user's token.
 	var userToken windows.Token
 	if err := windows.WTSQueryUserToken(sessionID, &userToken); err != nil {
@@ -139,17 +153,17 @@ func LaunchAsSessionUser(exePath string) (uint32, error) {
 			return pid, nil
 		}
 
-		// CreateProcessAsUser failed. Fall back to schtasks with the session
-		// user's identity — the same proven approach used by LaunchDeElevated
-		// (launchViaScheduledTask) but with /ru <user> /it so the task runs
-		// in the user's interactive session rather than as the caller (SYSTEM).
-		if schtasksErr := launchViaScheduledTaskForUser(exePath, primaryToken); schtasksErr != nil {
-			return 0, fmt.Errorf("CreateProcessAsUser: %w; schtasks fallback: %w", err, schtasksErr)
+		// CreateProcessAsUser failed. Fall back to a scheduled task with the
+		// session user's identity — the same proven approach used by
+		// LaunchDeElevated (launchViaScheduledTask) but registered for the user
+		// so it runs in their interactive session rather than as the caller (SYSTEM).
+		if taskErr := launchViaScheduledTaskForUser(exePath, primaryToken); taskErr != nil {
+			return 0, fmt.Errorf("CreateProcessAsUser: %w; scheduled task fallback: %w", err, taskErr)
 		}
 		// Task Scheduler can report success while the process never materializes.
 		// Confirm the process appears before reporting success to the caller.
 		if !waitForProcessByName(filepath.Base(exePath), 8*time.Second) {
-			return 0, fmt.Errorf("schtasks fallback did not start process %q", filepath.Base(exePath))
+			return 0, fmt.Errorf("scheduled task fallback did not start process %q", filepath.Base(exePath))
 		}
 		return 0, nil
 	}
@@ -206,11 +220,11 @@ func waitForProcessByName(exeName string, timeout time.Duration) bool {
 
 // launchViaScheduledTaskForUser creates and immediately runs a one-shot
 // scheduled task targeting a specific user's interactive session. This mirrors
-// launchViaScheduledTask in deelevate_windows.go but specifies the user via
-// /ru and /it so the task runs in their desktop session (not as the caller).
-// The /it flag uses the user's interactive token — no password is needed.
+// launchViaScheduledTask in deelevate_windows.go but registers the task for
+// the user so it runs in their desktop session (not as the caller).
+// The task uses the user's interactive token — no password is needed.
 func launchViaScheduledTaskForUser(exePath string, userToken windows.Token) error {
-	// Look up the account name from the token so we can pass it to schtasks /ru.
+	// Look up the account name from the token to register the task for it.
 	tokenUser, err := userToken.GetTokenUser()
 	if err != nil {
 		return fmt.Errorf("get token user: %w", err)
@@ -219,40 +233,55 @@ func launchViaScheduledTaskForUser(exePath string, userToken windows.Token) erro
 	if err != nil {
 		return fmt.Errorf("lookup account: %w", err)
 	}
-	ruArg := account
+	runAs := account
 	if domain != "" {
-		ruArg = domain + `\` + account
+		runAs = domain + `\` + account
 	}
 
-	taskName := fmt.Sprintf("UnisonLaunch_%d", os.Getpid())
-	schtasks := filepath.Join(os.Getenv("WINDIR"), "System32", "schtasks.exe")
-
-	if err := runHidden(schtasks, "/create",
-		"/tn", taskName,
-		"/tr", `"`+exePath+`"`,
-		"/sc", "once",
-		"/st", "00:00",
-		"/ru", ruArg,
-		"/it",
-		"/f",
-	); err != nil {
-		return fmt.Errorf("create task: %w", err)
-	}
-	// Battery settings are an optimization — don't fail the launch if
-	// PowerShell is unavailable (execution policy, locked-down enterprise).
-	_ = setTaskBatteryFriendly(taskName)
-
-	AllowSetForegroundForAnyProcess()
+	return runOneShotTask(ScheduledTask{
+		Name:           fmt.Sprintf("UnisonLaunch_%d", os.Getpid()),
+		Executable:     exePath,
+		RunAs:          runAs,
+		RunOnBatteries: true,
+	})
+}
 
-	if err := runHidden(schtasks, "/run", "/tn", taskName); err != nil {
-		runHidden(schtasks, "/delete", "/tn", taskName, "/f")
-		return fmt.Errorf("run task: %w", err)
+// launchSessionID returns the session LaunchAsSessionUser launches in: the
+// caller's own, unless it runs in session 0 as a service. Then it is the
+// console session if a user is signed in there, or the only session with an
+// active user.
+func launchSessionID() (uint32, error) {
+	var own uint32
+	if err := windows.ProcessIdToSessionId(windows.GetCurrentProcessId(), &own); err == nil && own != 0 {
+		return own, nil
 	}
 
-	// Brief wait for Task Scheduler to start the process, then delete.
-	time.Sleep(1 * time.Second)
-	runHidden(schtasks, "/delete", "/tn", taskName, "/f")
-	return nil
+	console := windows.WTSGetActiveConsoleSessionId()
+	users, err := ListLoggedOnUsers()
+	if err != nil {
+		if console == 0xFFFFFFFF {
+			return 0, fmt.Errorf("no active console session")
+		}
+		return console, nil
+	}
+	var active []uint32
+	for _, u := range users {
+		if !u.Active {
+			continue
+		}
+		if u.SessionID == console {
+			return console, nil
+		}
+		active = append(active, u.SessionID)
+	}
+	switch len(active) {
+	case 0:
+		return 0, fmt.Errorf("no user is signed in")
+	case 1:
+		return active[0], nil
+	default:
+		return 0, fmt.Errorf("%d users are signed in remotely and none at the console; use LaunchInSession", len(active))
+	}
 }
 
 // isRunningAsSystem reports whether the current process is running as
@@ -277,3 +306,74 @@ func isRunningAsSystem() bool {
 
 	return windows.EqualSid(user.User.Sid, systemSID)
 }
+
+var (
+	procGetProcessWindowStation  = user32.NewProc("GetProcessWindowStation")
+	procGetUserObjectInformation = user32.NewProc("GetUserObjectInformationW")
+	procGetSystemMetrics         = user32.NewProc("GetSystemMetrics")
+)
+
+// IsNonInteractiveSession reports whether the process runs where no user can
+// see or answer a window, e.g. deployed by SCCM/Intune as SYSTEM, as a
+// service in session 0, or over SSH. Installers should switch to silent mode
+// instead of creating a window.
+func IsNonInteractiveSession() bool {
+	return NonInteractiveReason() != ""
+}
+
+// NonInteractiveReason describes why the session is non-interactive, or
+// returns "" if it is interactive.
+func NonInteractiveReason() string {
+	var sessionID uint32
+	if err := windows.ProcessIdToSessionId(windows.GetCurrentProcessId(), &sessionID); err == nil && sessionID == 0 {
+		return "running in session 0"
+	}
+	if isRunningAsSystem() {
+		return "running as SYSTEM"
+	}
+	if !hasVisibleWindowStation() {
+		return "no interactive desktop"
+	}
+	if os.Getenv("SSH_CONNECTION") != "" || os.Getenv("SSH_CLIENT") != "" {
+		return "running over SSH"
+	}
+	return ""
+}
+
+// hasVisibleWindowStation reports whether the process's window station is
+// visible to a user (WSF_VISIBLE). Non-interactive stations such as
+// Service-0x0-3e7$ have no display.
+func hasVisibleWindowStation() bool {
+	const uoiFlags = 1
+	const wsfVisible = 0x0001
+
+	station, _, _ := procGetProcessWindowStation.Call()
+	if station == 0 {
+		return false
+	}
+	var flags struct {
+		Inherit  int32
+		Reserved int32
+		Flags    uint32
+	}
+	var needed uint32
+	r, _, _ := procGetUserObjectInformation.Call(
+		station,
+		uoiFlags,
+		uintptr(unsafe.Pointer(&flags)),
+		unsafe.Sizeof(flags),
+		uintptr(unsafe.Pointer(&needed)),
+	)
+	if r == 0 {
+		return true // Unknown; don't block the UI on a failed query
+	}
+	return flags.Flags&wsfVisible != 0
+}
+
+// IsRemoteSession reports whether the process runs in a Remote Desktop
+// session, where the window is drawn without GPU acceleration.
+func IsRemoteSession() bool {
+	const smRemoteSession = 0x1000
+	ret, _, _ := procGetSystemMetrics.Call(smRemoteSession)
+	return ret != 0
+}
@@ -0,0 +1,11 @@
+package platform
+
+import "errors"
+
+// Code signature verification errors.
+var (
+	ErrNotSigned            = errors.New("file is not signed")
+	ErrSignatureInvalid     = errors.New("signature is invalid or untrusted")
+	ErrSignerMismatch       = errors.New("file is signed by an unexpected publisher")
+	ErrSignatureUnsupported = errors.New("code signature verification not supported on this platform")
+)
@@ -0,0 +1,63 @@
+//go:build darwin
+
+package platform
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// VerifyCodeSignature checks a file's code signature with codesign and its
+// Gatekeeper assessment with spctl. If expectedSubject is non-empty, it must
+// match the signing certificate's common name (e.g. "Developer ID
+// Application: Crafted Tech GmbH (ABCDE12345)") or its Team ID.
+//
+// Returns ErrNotSigned, ErrSignatureInvalid or ErrSignerMismatch on failure.
+func VerifyCodeSignature(path, expectedSubject string) error {
+	output, err := exec.Command("codesign", "--verify", "--deep", "--strict", path).CombinedOutput()
+	if err != nil {
+		if strings.Contains(string(output), "not signed") {
+			return ErrNotSigned
+		}
+		return fmt.Errorf("%w: %s", ErrSignatureInvalid, strings.TrimSpace(string(output)))
+	}
+
+	// Gatekeeper: checks notarization and Developer ID trust.
+	// Plain executables are assessed as "open" type, bundles as "exec".
+	assessType := "exec"
+	if !strings.HasSuffix(strings.TrimSuffix(path, "/"), ".app") {
+		assessType = "open"
+	}
+	args := []string{"--assess", "--type", assessType}
+	if assessType == "open" {
+		args = append(args, "--context", "context:primary-signature")
+	}
+	output, err = exec.Command("spctl", append(args, path)...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrSignatureInvalid, strings.TrimSpace(string(output)))
+	}
+
+	if expectedSubject == "" {
+		return nil
+	}
+
+	// codesign -dvv prints signing details to stderr
+	output, err = exec.Command("codesign", "-dvv", path).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("read signing details: %w", err)
+	}
+	var authority, teamID string
+	for _, line := range strings.Split(string(output), "\n") {
+		if v, ok := strings.CutPrefix(line, "Authority="); ok && authority == "" {
+			authority = v // First Authority line is the leaf certificate
+		}
+		if v, ok := strings.CutPrefix(line, "TeamIdentifier="); ok {
+			teamID = v
+		}
+	}
+	if !strings.EqualFold(authority, expectedSubject) && !strings.EqualFold(teamID, expectedSubject) {
+		return fmt.Errorf("%w: %q", ErrSignerMismatch, authority)
+	}
+	return nil
+}
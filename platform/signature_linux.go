@@ -0,0 +1,9 @@
+//go:build linux
+
+package platform
+
+// VerifyCodeSignature is not supported on Linux, which has no system-wide
+// code signing for executables. Use detached signatures instead.
+func VerifyCodeSignature(path, expectedSubject string) error {
+	return ErrSignatureUnsupported
+}
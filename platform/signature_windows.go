@@ -0,0 +1,108 @@
+//go:build windows
+
+package platform
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	wintrust                           = windows.NewLazySystemDLL("wintrust.dll")
+	procWTHelperProvDataFromStateData  = wintrust.NewProc("WTHelperProvDataFromStateData")
+	procWTHelperGetProvSignerFromChain = wintrust.NewProc("WTHelperGetProvSignerFromChain")
+	procWTHelperGetProvCertFromChain   = wintrust.NewProc("WTHelperGetProvCertFromChain")
+)
+
+// cryptProviderCert mirrors the leading fields of CRYPT_PROVIDER_CERT.
+type cryptProviderCert struct {
+	size uint32
+	cert *windows.CertContext
+}
+
+// VerifyAuthenticode checks the Authenticode signature of a file with
+// WinVerifyTrust, including certificate chain and revocation checks.
+// If expectedSubject is non-empty, the signing certificate's subject name
+// (e.g. "Crafted Tech GmbH") must match it exactly (case-insensitive).
+//
+// Returns ErrNotSigned, ErrSignatureInvalid or ErrSignerMismatch on failure.
+func VerifyAuthenticode(path, expectedSubject string) error {
+	path16, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return err
+	}
+
+	data := &windows.WinTrustData{
+		Size:             uint32(unsafe.Sizeof(windows.WinTrustData{})),
+		UIChoice:         windows.WTD_UI_NONE,
+		RevocationChecks: windows.WTD_REVOKE_WHOLECHAIN,
+		UnionChoice:      windows.WTD_CHOICE_FILE,
+		StateAction:      windows.WTD_STATEACTION_VERIFY,
+		FileOrCatalogOrBlobOrSgnrOrCert: unsafe.Pointer(&windows.WinTrustFileInfo{
+			Size:     uint32(unsafe.Sizeof(windows.WinTrustFileInfo{})),
+			FilePath: path16,
+		}),
+	}
+	verifyErr := windows.WinVerifyTrustEx(windows.InvalidHWND, &windows.WINTRUST_ACTION_GENERIC_VERIFY_V2, data)
+	defer func() {
+		data.StateAction = windows.WTD_STATEACTION_CLOSE
+		windows.WinVerifyTrustEx(windows.InvalidHWND, &windows.WINTRUST_ACTION_GENERIC_VERIFY_V2, data)
+	}()
+
+	if verifyErr != nil {
+		if errors.Is(verifyErr, windows.Errno(windows.TRUST_E_NOSIGNATURE)) {
+			return ErrNotSigned
+		}
+		return fmt.Errorf("%w: %v", ErrSignatureInvalid, verifyErr)
+	}
+
+	if expectedSubject == "" {
+		return nil
+	}
+
+	subject, err := signerSubject(data.StateData)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(subject, expectedSubject) {
+		return fmt.Errorf("%w: %q", ErrSignerMismatch, subject)
+	}
+	return nil
+}
+
+// VerifyCodeSignature checks that a file carries a valid, trusted code
+// signature from expectedSubject (any trusted signer if empty).
+// On Windows this is VerifyAuthenticode.
+func VerifyCodeSignature(path, expectedSubject string) error {
+	return VerifyAuthenticode(path, expectedSubject)
+}
+
+// signerSubject returns the simple display name of the leaf signing
+// certificate from WinVerifyTrust state data.
+func signerSubject(stateData windows.Handle) (string, error) {
+	provData, _, _ := procWTHelperProvDataFromStateData.Call(uintptr(stateData))
+	if provData == 0 {
+		return "", fmt.Errorf("read signature provider data")
+	}
+	signer, _, _ := procWTHelperGetProvSignerFromChain.Call(provData, 0, 0, 0)
+	if signer == 0 {
+		return "", fmt.Errorf("read signer information")
+	}
+	certPtr, _, _ := procWTHelperGetProvCertFromChain.Call(signer, 0)
+	if certPtr == 0 {
+		return "", fmt.Errorf("read signing certificate")
+	}
+	cert := *(**cryptProviderCert)(unsafe.Pointer(&certPtr))
+
+	size := windows.CertGetNameString(cert.cert, windows.CERT_NAME_SIMPLE_DISPLAY_TYPE, 0, nil, nil, 0)
+	if size <= 1 {
+		return "", fmt.Errorf("read certificate subject")
+	}
+	buf := make([]uint16, size)
+	windows.CertGetNameString(cert.cert, windows.CERT_NAME_SIMPLE_DISPLAY_TYPE, 0, nil, &buf[0], size)
+	return windows.UTF16ToString(buf), nil
+}
@@ -0,0 +1,25 @@
+package platform
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// existingParent returns path or its nearest ancestor that exists, so the
+// free space of an install directory can be checked before it is created.
+func existingParent(path string) (string, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	for {
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return path, nil
+		}
+		path = parent
+	}
+}
@@ -0,0 +1,50 @@
+//go:build darwin
+
+package platform
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// TotalMemory returns the amount of physical memory in bytes.
+func TotalMemory() (uint64, error) {
+	mem, err := unix.SysctlUint64("hw.memsize")
+	if err != nil {
+		return 0, fmt.Errorf("sysctl hw.memsize: %w", err)
+	}
+	return mem, nil
+}
+
+// FreeDiskSpace returns the bytes available to unprivileged users on the
+// volume containing path. path need not exist yet; its nearest existing
+// parent is used.
+func FreeDiskSpace(path string) (uint64, error) {
+	dir, err := existingParent(path)
+	if err != nil {
+		return 0, err
+	}
+	var stat unix.Statfs_t
+	if err := unix.Statfs(dir, &stat); err != nil {
+		return 0, fmt.Errorf("statfs %s: %w", dir, err)
+	}
+	return stat.Bavail * uint64(stat.Bsize), nil
+}
+
+// GPUNames returns the chipset names of the Mac's GPUs, e.g. "Apple M2".
+func GPUNames() []string {
+	out, err := exec.Command("system_profiler", "SPDisplaysDataType").Output()
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if name, ok := strings.CutPrefix(strings.TrimSpace(line), "Chipset Model:"); ok {
+			names = append(names, strings.TrimSpace(name))
+		}
+	}
+	return names
+}
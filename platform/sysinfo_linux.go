@@ -0,0 +1,65 @@
+//go:build linux
+
+package platform
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// TotalMemory returns the amount of physical memory in bytes.
+func TotalMemory() (uint64, error) {
+	var info unix.Sysinfo_t
+	if err := unix.Sysinfo(&info); err != nil {
+		return 0, fmt.Errorf("sysinfo: %w", err)
+	}
+	return uint64(info.Totalram) * uint64(info.Unit), nil
+}
+
+// FreeDiskSpace returns the bytes available to unprivileged users on the file
+// system containing path. path need not exist yet; its nearest existing parent
+// is used.
+func FreeDiskSpace(path string) (uint64, error) {
+	dir, err := existingParent(path)
+	if err != nil {
+		return 0, err
+	}
+	var stat unix.Statfs_t
+	if err := unix.Statfs(dir, &stat); err != nil {
+		return 0, fmt.Errorf("statfs %s: %w", dir, err)
+	}
+	return stat.Bavail * uint64(stat.Bsize), nil
+}
+
+// gpuVendors names the PCI vendors of hardware GPUs.
+var gpuVendors = map[string]string{
+	"0x10de": "NVIDIA",
+	"0x1002": "AMD",
+	"0x8086": "Intel",
+	"0x5143": "Qualcomm",
+	"0x14e4": "Broadcom",
+}
+
+// GPUNames returns the vendors of the GPUs the kernel has a DRM driver for,
+// e.g. "NVIDIA". Virtual adapters (QEMU, VMware, VirtualBox) are left out.
+func GPUNames() []string {
+	cards, _ := filepath.Glob("/sys/class/drm/card[0-9]*/device/vendor")
+	var names []string
+	for _, path := range cards {
+		if strings.Contains(filepath.Base(filepath.Dir(filepath.Dir(path))), "-") {
+			continue // a connector such as card0-HDMI-A-1
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if name, ok := gpuVendors[strings.TrimSpace(string(data))]; ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,94 @@
+//go:build windows
+
+package platform
+
+import (
+	"fmt"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/registry"
+)
+
+var procGlobalMemoryStatusEx = modkernel32.NewProc("GlobalMemoryStatusEx")
+
+// memoryStatusEx mirrors MEMORYSTATUSEX.
+type memoryStatusEx struct {
+	length               uint32
+	memoryLoad           uint32
+	totalPhys            uint64
+	availPhys            uint64
+	totalPageFile        uint64
+	availPageFile        uint64
+	totalVirtual         uint64
+	availVirtual         uint64
+	availExtendedVirtual uint64
+}
+
+// TotalMemory returns the amount of physical memory in bytes.
+func TotalMemory() (uint64, error) {
+	status := memoryStatusEx{length: uint32(unsafe.Sizeof(memoryStatusEx{}))}
+	ret, _, err := procGlobalMemoryStatusEx.Call(uintptr(unsafe.Pointer(&status)))
+	if ret == 0 {
+		return 0, fmt.Errorf("GlobalMemoryStatusEx: %w", err)
+	}
+	return status.totalPhys, nil
+}
+
+// FreeDiskSpace returns the bytes available to the current user on the volume
+// containing path. path need not exist yet; its nearest existing parent is used.
+func FreeDiskSpace(path string) (uint64, error) {
+	dir, err := existingParent(path)
+	if err != nil {
+		return 0, err
+	}
+	p, err := windows.UTF16PtrFromString(dir)
+	if err != nil {
+		return 0, err
+	}
+	var free, total, totalFree uint64
+	if err := windows.GetDiskFreeSpaceEx(p, &free, &total, &totalFree); err != nil {
+		return 0, fmt.Errorf("GetDiskFreeSpaceEx %s: %w", dir, err)
+	}
+	return free, nil
+}
+
+// displayAdapterClass is the device setup class of display adapters.
+const displayAdapterClass = `SYSTEM\CurrentControlSet\Control\Class\{4d36e968-e325-11ce-bfc1-08002be10318}`
+
+// GPUNames returns the names of the installed display adapters, leaving out
+// Windows' software adapters (Basic Display, Remote Display).
+func GPUNames() []string {
+	key, err := registry.OpenKey(registry.LOCAL_MACHINE, displayAdapterClass, registry.ENUMERATE_SUB_KEYS)
+	if err != nil {
+		return nil
+	}
+	defer key.Close()
+
+	subkeys, err := key.ReadSubKeyNames(-1)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, sub := range subkeys {
+		adapter, err := registry.OpenKey(key, sub, registry.QUERY_VALUE)
+		if err != nil {
+			continue
+		}
+		name, _, err := adapter.GetStringValue("DriverDesc")
+		adapter.Close()
+		if err != nil || name == "" || isSoftwareAdapter(name) {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// isSoftwareAdapter reports whether a display adapter renders without a GPU.
+func isSoftwareAdapter(name string) bool {
+	name = strings.ToLower(name)
+	return strings.Contains(name, "basic display") || strings.Contains(name, "basic render") ||
+		strings.Contains(name, "remote display")
+}
@@ -0,0 +1,10 @@
+//go:build darwin
+
+package platform
+
+// TextScaleFactor returns the user's text size preference as a factor of the
+// default size (1.0 = 100%). macOS has no system-wide text size for apps
+// (WebKit follows the display scaling on its own), so it is always 1.
+func TextScaleFactor() float64 {
+	return 1
+}
@@ -0,0 +1,24 @@
+//go:build linux
+
+package platform
+
+import (
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// TextScaleFactor returns the user's text size preference as a factor of the
+// default size (1.0 = 100%). On Linux this is GNOME's text scaling factor
+// (Accessibility > Large Text), or 1 on other desktops.
+func TextScaleFactor() float64 {
+	out, err := exec.Command("gsettings", "get", "org.gnome.desktop.interface", "text-scaling-factor").Output()
+	if err != nil {
+		return 1
+	}
+	factor, err := strconv.ParseFloat(strings.TrimSpace(string(out)), 64)
+	if err != nil || factor < 0.5 || factor > 3 {
+		return 1
+	}
+	return factor
+}
@@ -0,0 +1,22 @@
+//go:build windows
+
+package platform
+
+import "golang.org/x/sys/windows/registry"
+
+// TextScaleFactor returns the user's text size preference as a factor of the
+// default size (1.0 = 100%). On Windows this is Settings > Accessibility >
+// Text size, which WebView2 doesn't apply by itself.
+func TextScaleFactor() float64 {
+	key, err := registry.OpenKey(registry.CURRENT_USER, `Software\Microsoft\Accessibility`, registry.QUERY_VALUE)
+	if err != nil {
+		return 1
+	}
+	defer key.Close()
+
+	percent, _, err := key.GetIntegerValue("TextScaleFactor")
+	if err != nil || percent < 100 || percent > 225 {
+		return 1
+	}
+	return float64(percent) / 100
+}
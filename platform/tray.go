@@ -0,0 +1,22 @@
+package platform
+
+import "errors"
+
+// ErrTrayUnsupported is returned by NewTrayIcon where the platform has no
+// notification area icon support.
+var ErrTrayUnsupported = errors.New("tray icons not supported on this platform")
+
+// TrayIconConfig describes a notification area (system tray) icon.
+type TrayIconConfig struct {
+	Icon    []byte         // PNG image (default: the application icon)
+	Tooltip string         // Text shown when hovering over the icon
+	Items   []TrayMenuItem // Menu opened by a right click
+	OnClick func()         // Called on a left click (default: open the menu)
+}
+
+// TrayMenuItem is an entry of a tray icon's menu.
+type TrayMenuItem struct {
+	Label    string // Menu text; "" for a separator
+	Disabled bool   // Shown grayed out
+	OnClick  func() // Called on its own goroutine when the item is chosen
+}
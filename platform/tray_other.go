@@ -0,0 +1,19 @@
+//go:build !windows
+
+package platform
+
+// TrayIcon is an icon in the notification area. Tray icons are only
+// available on Windows.
+type TrayIcon struct{}
+
+// NewTrayIcon adds an icon to the notification area. It is only available on
+// Windows and returns ErrTrayUnsupported elsewhere.
+func NewTrayIcon(cfg TrayIconConfig) (*TrayIcon, error) {
+	return nil, ErrTrayUnsupported
+}
+
+// SetTooltip changes the text shown when hovering over the icon.
+func (t *TrayIcon) SetTooltip(tooltip string) {}
+
+// Close removes the icon.
+func (t *TrayIcon) Close() {}
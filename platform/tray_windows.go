@@ -0,0 +1,331 @@
+//go:build windows
+
+package platform
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	shell32                      = windows.NewLazySystemDLL("shell32.dll")
+	procShellNotifyIconW         = shell32.NewProc("Shell_NotifyIconW")
+	procCreatePopupMenu          = user32.NewProc("CreatePopupMenu")
+	procAppendMenuW              = user32.NewProc("AppendMenuW")
+	procTrackPopupMenu           = user32.NewProc("TrackPopupMenu")
+	procDestroyMenu              = user32.NewProc("DestroyMenu")
+	procGetCursorPos             = user32.NewProc("GetCursorPos")
+	procSetForegroundWindow      = user32.NewProc("SetForegroundWindow")
+	procCreateIconFromResourceEx = user32.NewProc("CreateIconFromResourceEx")
+	procDestroyIcon              = user32.NewProc("DestroyIcon")
+	procLoadIconW                = user32.NewProc("LoadIconW")
+)
+
+const (
+	nimAdd    = 0x0
+	nimModify = 0x1
+	nimDelete = 0x2
+
+	nifMessage = 0x1
+	nifIcon    = 0x2
+	nifTip     = 0x4
+
+	wmNull         = 0x0000
+	wmDestroy      = 0x0002
+	wmClose        = 0x0010
+	wmLButtonUp    = 0x0202
+	wmRButtonUp    = 0x0205
+	wmTrayCallback = 0x8000 + 1 // WM_APP + 1
+
+	mfString    = 0x0000
+	mfGrayed    = 0x0001
+	mfSeparator = 0x0800
+
+	tpmRightButton = 0x0002
+	tpmNoNotify    = 0x0080
+	tpmReturnCmd   = 0x0100
+
+	smCxSmIcon     = 49
+	smCySmIcon     = 50
+	idiApplication = 32512
+)
+
+// notifyIconData is NOTIFYICONDATAW.
+type notifyIconData struct {
+	cbSize           uint32
+	hWnd             windows.HWND
+	uID              uint32
+	uFlags           uint32
+	uCallbackMessage uint32
+	hIcon            windows.Handle
+	szTip            [128]uint16
+	dwState          uint32
+	dwStateMask      uint32
+	szInfo           [256]uint16
+	uVersion         uint32
+	szInfoTitle      [64]uint16
+	dwInfoFlags      uint32
+	guidItem         windows.GUID
+	hBalloonIcon     windows.Handle
+}
+
+// TrayIcon is an icon in the notification area, with a tooltip and a menu.
+// It runs its own message loop, so it works while the caller is busy.
+type TrayIcon struct {
+	cfg     TrayIconConfig
+	hwnd    windows.HWND
+	icon    windows.Handle
+	ownIcon bool
+	done    chan struct{}
+
+	mu      sync.Mutex
+	tooltip string
+	closed  bool
+}
+
+var (
+	trayClassOnce sync.Once
+	trayClassErr  error
+	trayClassName = windows.StringToUTF16Ptr("WebflowTrayIcon")
+
+	trayIconsMu sync.Mutex
+	trayIcons   = map[windows.HWND]*TrayIcon{}
+)
+
+// NewTrayIcon adds an icon to the notification area. Close removes it.
+//
+// Example:
+//
+//	tray, err := platform.NewTrayIcon(platform.TrayIconConfig{
+//	    Icon:    iconPNG,
+//	    Tooltip: "Acme Setup",
+//	    Items:   []platform.TrayMenuItem{{Label: "Cancel", OnClick: cancel}},
+//	})
+//	if err == nil {
+//	    defer tray.Close()
+//	}
+func NewTrayIcon(cfg TrayIconConfig) (*TrayIcon, error) {
+	t := &TrayIcon{cfg: cfg, tooltip: cfg.Tooltip, done: make(chan struct{})}
+	ready := make(chan error, 1)
+	go t.run(ready)
+	if err := <-ready; err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// SetTooltip changes the text shown when hovering over the icon. Text beyond
+// 127 characters is cut off.
+func (t *TrayIcon) SetTooltip(tooltip string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return
+	}
+	t.tooltip = tooltip
+	nid := t.data(nifTip)
+	procShellNotifyIconW.Call(nimModify, uintptr(unsafe.Pointer(&nid)))
+}
+
+// Close removes the icon.
+func (t *TrayIcon) Close() {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		return
+	}
+	t.closed = true
+	nid := t.data(0)
+	procShellNotifyIconW.Call(nimDelete, uintptr(unsafe.Pointer(&nid)))
+	t.mu.Unlock()
+
+	procPostMessageW.Call(uintptr(t.hwnd), wmClose, 0, 0)
+	<-t.done
+	if t.ownIcon {
+		procDestroyIcon.Call(uintptr(t.icon))
+	}
+}
+
+// run creates the icon's window and runs its message loop until Close.
+// Window messages are delivered to the thread that created the window, so
+// the goroutine stays on it.
+func (t *TrayIcon) run(ready chan<- error) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	defer close(t.done)
+
+	trayClassOnce.Do(func() {
+		wc := wndClassW{
+			lpfnWndProc:   windows.NewCallback(trayWindowProc),
+			lpszClassName: trayClassName,
+		}
+		if r, _, err := procRegisterClassW.Call(uintptr(unsafe.Pointer(&wc))); r == 0 {
+			trayClassErr = fmt.Errorf("register tray window class: %w", err)
+		}
+	})
+	if trayClassErr != nil {
+		ready <- trayClassErr
+		return
+	}
+	hwnd, _, err := procCreateWindowExW.Call(
+		0,
+		uintptr(unsafe.Pointer(trayClassName)),
+		0,
+		wsOverlapped,
+		0, 0, 0, 0,
+		0, 0, 0, 0,
+	)
+	if hwnd == 0 {
+		ready <- fmt.Errorf("create tray window: %w", err)
+		return
+	}
+	t.hwnd = windows.HWND(hwnd)
+	trayIconsMu.Lock()
+	trayIcons[t.hwnd] = t
+	trayIconsMu.Unlock()
+
+	t.icon, t.ownIcon = loadTrayIcon(t.cfg.Icon)
+	t.mu.Lock()
+	nid := t.data(nifMessage | nifIcon | nifTip)
+	t.mu.Unlock()
+	if r, _, err := procShellNotifyIconW.Call(nimAdd, uintptr(unsafe.Pointer(&nid))); r == 0 {
+		procDestroyWindow.Call(hwnd)
+		if t.ownIcon {
+			procDestroyIcon.Call(uintptr(t.icon))
+		}
+		ready <- fmt.Errorf("Shell_NotifyIcon: %w", err)
+		return
+	}
+	ready <- nil
+
+	var m msg
+	for {
+		r, _, _ := procGetMessageW.Call(uintptr(unsafe.Pointer(&m)), 0, 0, 0)
+		if r == 0 || int32(r) == -1 {
+			return
+		}
+		procTranslateMessage.Call(uintptr(unsafe.Pointer(&m)))
+		procDispatchMessageW.Call(uintptr(unsafe.Pointer(&m)))
+	}
+}
+
+// data returns the NOTIFYICONDATA for the icon with flags. t.mu must be held.
+func (t *TrayIcon) data(flags uint32) notifyIconData {
+	nid := notifyIconData{
+		hWnd:             t.hwnd,
+		uID:              1,
+		uFlags:           flags,
+		uCallbackMessage: wmTrayCallback,
+		hIcon:            t.icon,
+	}
+	nid.cbSize = uint32(unsafe.Sizeof(nid))
+	tip, _ := windows.UTF16FromString(t.tooltip)
+	copy(nid.szTip[:len(nid.szTip)-1], tip)
+	return nid
+}
+
+// showMenu opens the icon's menu at the mouse position and runs the chosen
+// item.
+func (t *TrayIcon) showMenu() {
+	if len(t.cfg.Items) == 0 {
+		return
+	}
+	menu, _, _ := procCreatePopupMenu.Call()
+	if menu == 0 {
+		return
+	}
+	defer procDestroyMenu.Call(menu)
+	for i, item := range t.cfg.Items {
+		flags := uintptr(mfString)
+		if item.Label == "" {
+			flags = mfSeparator
+		}
+		if item.Disabled {
+			flags |= mfGrayed
+		}
+		label, _ := windows.UTF16PtrFromString(item.Label)
+		procAppendMenuW.Call(menu, flags, uintptr(i+1), uintptr(unsafe.Pointer(label)))
+	}
+
+	var pt struct{ x, y int32 }
+	procGetCursorPos.Call(uintptr(unsafe.Pointer(&pt)))
+	// The menu only closes on a click elsewhere if the window is in the
+	// foreground (see the TrackPopupMenu documentation).
+	procSetForegroundWindow.Call(uintptr(t.hwnd))
+	cmd, _, _ := procTrackPopupMenu.Call(menu, tpmReturnCmd|tpmRightButton|tpmNoNotify,
+		uintptr(pt.x), uintptr(pt.y), 0, uintptr(t.hwnd), 0)
+	procPostMessageW.Call(uintptr(t.hwnd), wmNull, 0, 0)
+
+	if i := int(cmd) - 1; i >= 0 && i < len(t.cfg.Items) && t.cfg.Items[i].OnClick != nil {
+		go t.cfg.Items[i].OnClick()
+	}
+}
+
+// trayWindowProc handles the messages of tray icon windows.
+func trayWindowProc(hwnd uintptr, message uint32, wParam, lParam uintptr) uintptr {
+	trayIconsMu.Lock()
+	t := trayIcons[windows.HWND(hwnd)]
+	trayIconsMu.Unlock()
+
+	switch message {
+	case wmTrayCallback:
+		if t == nil {
+			return 0
+		}
+		switch lParam & 0xffff {
+		case wmLButtonUp:
+			if t.cfg.OnClick != nil {
+				go t.cfg.OnClick()
+			} else {
+				t.showMenu()
+			}
+		case wmRButtonUp:
+			t.showMenu()
+		}
+		return 0
+	case wmClose:
+		procDestroyWindow.Call(hwnd)
+		return 0
+	case wmDestroy:
+		trayIconsMu.Lock()
+		delete(trayIcons, windows.HWND(hwnd))
+		trayIconsMu.Unlock()
+		procPostQuitMessage.Call(0)
+		return 0
+	}
+	r, _, _ := procDefWindowProcW.Call(hwnd, uintptr(message), wParam, lParam)
+	return r
+}
+
+// loadTrayIcon creates a small icon from PNG data, or returns the default
+// application icon if there is none or it cannot be read. own reports
+// whether the icon must be destroyed.
+func loadTrayIcon(png []byte) (icon windows.Handle, own bool) {
+	if len(png) > 0 {
+		cx, _, _ := procGetSystemMetrics.Call(smCxSmIcon)
+		cy, _, _ := procGetSystemMetrics.Call(smCySmIcon)
+		h, _, _ := procCreateIconFromResourceEx.Call(
+			uintptr(unsafe.Pointer(&png[0])),
+			uintptr(len(png)),
+			1,          // icon, not cursor
+			0x00030000, // format version
+			cx, cy,
+			0,
+		)
+		if h != 0 {
+			return windows.Handle(h), true
+		}
+	}
+	h, _, _ := procLoadIconW.Call(0, idiApplication)
+	return windows.Handle(h), false
+}
@@ -0,0 +1,29 @@
+package platform
+
+// LoggedOnUser is an interactive session on the computer.
+type LoggedOnUser struct {
+	Username  string // Login name; "DOMAIN\user" on Windows
+	SessionID uint32 // Windows session ID (0 on other platforms)
+	Terminal  string // Window station or terminal, e.g. "Console", "RDP-Tcp#2", "tty2", "pts/0"
+	Remote    bool   // Signed in over Remote Desktop or SSH
+	Active    bool   // false for disconnected Windows sessions, whose programs keep running
+	Current   bool   // The session (Windows) or user (other platforms) this process runs for
+}
+
+// OtherLoggedOnUsers returns the sessions of ListLoggedOnUsers other than
+// the installer's own. Restarting services and replacing per-machine files
+// can disrupt programs these users are running, so warn before doing so.
+// Returns nil if the sessions can't be listed.
+func OtherLoggedOnUsers() []LoggedOnUser {
+	users, err := ListLoggedOnUsers()
+	if err != nil {
+		return nil
+	}
+	var others []LoggedOnUser
+	for _, u := range users {
+		if !u.Current {
+			others = append(others, u)
+		}
+	}
+	return others
+}
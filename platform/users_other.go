@@ -0,0 +1,59 @@
+//go:build !windows
+
+package platform
+
+import (
+	"os"
+	"os/exec"
+	"os/user"
+	"strings"
+)
+
+// ListLoggedOnUsers returns the terminal and desktop logins listed by who,
+// one per terminal, so a user can appear more than once. Current marks the
+// logins of the user running the installer (the one who ran sudo, if
+// elevated).
+func ListLoggedOnUsers() ([]LoggedOnUser, error) {
+	output, err := exec.Command("who").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	self := os.Getenv("SUDO_USER")
+	if self == "" {
+		if u, err := user.Current(); err == nil {
+			self = u.Username
+		}
+	}
+
+	var users []LoggedOnUser
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		users = append(users, LoggedOnUser{
+			Username: fields[0],
+			Terminal: fields[1],
+			Remote:   isRemoteHost(whoHost(line)),
+			Active:   true,
+			Current:  fields[0] == self,
+		})
+	}
+	return users, nil
+}
+
+// whoHost returns the parenthesized host at the end of a who line, or "".
+func whoHost(line string) string {
+	start, end := strings.LastIndexByte(line, '('), strings.LastIndexByte(line, ')')
+	if start < 0 || end < start {
+		return ""
+	}
+	return line[start+1 : end]
+}
+
+// isRemoteHost reports whether a who host is a remote machine rather than a
+// local X display (":0") or virtual terminal ("tty2").
+func isRemoteHost(host string) bool {
+	return host != "" && !strings.HasPrefix(host, ":") && !strings.HasPrefix(host, "tty")
+}
@@ -0,0 +1,85 @@
+//go:build windows
+
+package platform
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	wtsapi32                        = windows.NewLazySystemDLL("wtsapi32.dll")
+	procWTSQuerySessionInformationW = wtsapi32.NewProc("WTSQuerySessionInformationW")
+)
+
+// WTS_INFO_CLASS values for WTSQuerySessionInformation.
+const (
+	wtsUserName           = 5
+	wtsDomainName         = 7
+	wtsClientProtocolType = 16
+)
+
+// ListLoggedOnUsers returns the sessions with a signed-in user, connected or
+// disconnected, including Remote Desktop sessions.
+func ListLoggedOnUsers() ([]LoggedOnUser, error) {
+	var info *windows.WTS_SESSION_INFO
+	var count uint32
+	if err := windows.WTSEnumerateSessions(0, 0, 1, &info, &count); err != nil {
+		return nil, err
+	}
+	defer windows.WTSFreeMemory(uintptr(unsafe.Pointer(info)))
+
+	var current uint32
+	windows.ProcessIdToSessionId(windows.GetCurrentProcessId(), &current)
+
+	var users []LoggedOnUser
+	for _, s := range unsafe.Slice(info, count) {
+		if s.State != windows.WTSActive && s.State != windows.WTSDisconnected {
+			continue
+		}
+		name := wtsSessionString(s.SessionID, wtsUserName)
+		if name == "" {
+			continue // Session 0, or a logon screen
+		}
+		if domain := wtsSessionString(s.SessionID, wtsDomainName); domain != "" {
+			name = domain + `\` + name
+		}
+		users = append(users, LoggedOnUser{
+			Username:  name,
+			SessionID: s.SessionID,
+			Terminal:  windows.UTF16PtrToString(s.WindowStationName),
+			Remote:    wtsSessionProtocol(s.SessionID) != 0,
+			Active:    s.State == windows.WTSActive,
+			Current:   s.SessionID == current,
+		})
+	}
+	return users, nil
+}
+
+// wtsSessionString returns a string property of a session, or "".
+func wtsSessionString(sessionID uint32, class uintptr) string {
+	var buf *uint16
+	var size uint32
+	r, _, _ := procWTSQuerySessionInformationW.Call(0, uintptr(sessionID), class,
+		uintptr(unsafe.Pointer(&buf)), uintptr(unsafe.Pointer(&size)))
+	if r == 0 || buf == nil {
+		return ""
+	}
+	defer windows.WTSFreeMemory(uintptr(unsafe.Pointer(buf)))
+	return windows.UTF16PtrToString(buf)
+}
+
+// wtsSessionProtocol returns the client protocol of a session: 0 for the
+// console, 2 for Remote Desktop.
+func wtsSessionProtocol(sessionID uint32) uint16 {
+	var buf *uint16
+	var size uint32
+	r, _, _ := procWTSQuerySessionInformationW.Call(0, uintptr(sessionID), wtsClientProtocolType,
+		uintptr(unsafe.Pointer(&buf)), uintptr(unsafe.Pointer(&size)))
+	if r == 0 || buf == nil {
+		return 0
+	}
+	defer windows.WTSFreeMemory(uintptr(unsafe.Pointer(buf)))
+	return *buf
+}
@@ -0,0 +1,25 @@
+package platform
+
+import "strings"
+
+// vmVendors are substrings of the system manufacturer or product name
+// reported by hypervisors' virtual firmware.
+var vmVendors = []string{
+	"vmware", "virtualbox", "innotek", "qemu", "kvm", "xen", "parallels",
+	"bochs", "bhyve", "virtual machine", "amazon ec2", "google compute engine",
+	"openstack", "digitalocean",
+}
+
+// isVMVendor reports whether a firmware manufacturer or product name belongs
+// to a virtual machine.
+func isVMVendor(names ...string) bool {
+	for _, name := range names {
+		name = strings.ToLower(name)
+		for _, vendor := range vmVendors {
+			if strings.Contains(name, vendor) {
+				return true
+			}
+		}
+	}
+	return false
+}
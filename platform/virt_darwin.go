@@ -0,0 +1,17 @@
+//go:build darwin
+
+package platform
+
+import "golang.org/x/sys/unix"
+
+// IsRunningInVM reports whether macOS runs in a virtual machine
+// (Virtualization.framework, Parallels, VMware Fusion).
+func IsRunningInVM() bool {
+	present, err := unix.SysctlUint32("kern.hv_vmm_present")
+	return err == nil && present == 1
+}
+
+// IsRunningInContainer returns false; macOS has no containers.
+func IsRunningInContainer() bool {
+	return false
+}
@@ -0,0 +1,55 @@
+//go:build linux
+
+package platform
+
+import (
+	"os"
+	"strings"
+)
+
+// IsRunningInVM reports whether Linux runs in a virtual machine, judged by
+// the DMI manufacturer and product name, or the CPU's hypervisor flag where
+// there is no DMI (e.g. some ARM clouds).
+func IsRunningInVM() bool {
+	vendor, _ := os.ReadFile("/sys/class/dmi/id/sys_vendor")
+	product, _ := os.ReadFile("/sys/class/dmi/id/product_name")
+	if isVMVendor(string(vendor), string(product)) {
+		return true
+	}
+	if len(vendor) > 0 {
+		return false
+	}
+	cpuinfo, err := os.ReadFile("/proc/cpuinfo")
+	if err != nil {
+		return false
+	}
+	for _, line := range strings.Split(string(cpuinfo), "\n") {
+		if strings.HasPrefix(line, "flags") {
+			return strings.Contains(line+" ", " hypervisor ")
+		}
+	}
+	return false
+}
+
+// IsRunningInContainer reports whether the process runs in a container
+// (Docker, Podman, containerd/Kubernetes, LXC or systemd-nspawn).
+func IsRunningInContainer() bool {
+	if os.Getenv("container") != "" {
+		return true // set by Podman, LXC and systemd-nspawn
+	}
+	for _, marker := range []string{"/.dockerenv", "/run/.containerenv"} {
+		if _, err := os.Stat(marker); err == nil {
+			return true
+		}
+	}
+	cgroup, err := os.ReadFile("/proc/1/cgroup")
+	if err != nil {
+		return false
+	}
+	for _, name := range []string{"docker", "kubepods", "containerd", "lxc", "libpod"} {
+		if strings.Contains(string(cgroup), name) {
+			return true
+		}
+	}
+	return false
+}
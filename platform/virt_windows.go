@@ -0,0 +1,33 @@
+//go:build windows
+
+package platform
+
+import "golang.org/x/sys/windows/registry"
+
+// IsRunningInVM reports whether Windows runs in a virtual machine (Hyper-V,
+// VMware, VirtualBox, QEMU/KVM, Xen, Parallels or a cloud VM), judged by the
+// firmware's manufacturer and product name.
+func IsRunningInVM() bool {
+	key, err := registry.OpenKey(registry.LOCAL_MACHINE, `HARDWARE\DESCRIPTION\System\BIOS`, registry.QUERY_VALUE)
+	if err != nil {
+		return false
+	}
+	defer key.Close()
+
+	manufacturer, _, _ := key.GetStringValue("SystemManufacturer")
+	product, _, _ := key.GetStringValue("SystemProductName")
+	return isVMVendor(manufacturer, product)
+}
+
+// IsRunningInContainer reports whether the process runs in a Windows
+// container (process- or Hyper-V-isolated).
+func IsRunningInContainer() bool {
+	key, err := registry.OpenKey(registry.LOCAL_MACHINE, `SYSTEM\CurrentControlSet\Control`, registry.QUERY_VALUE)
+	if err != nil {
+		return false
+	}
+	defer key.Close()
+
+	_, _, err = key.GetIntegerValue("ContainerType")
+	return err == nil
+}
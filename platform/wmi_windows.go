@@ -0,0 +1,103 @@
+//go:build windows
+
+package platform
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+
+	"github.com/go-ole/go-ole"
+	"github.com/go-ole/go-ole/oleutil"
+)
+
+// wmiQuery runs a WQL query in a WMI namespace (e.g. `root\SecurityCenter2`)
+// on a locked, COM-initialized thread and calls fn with each result object.
+func wmiQuery(namespace, query string, fn func(item *ole.IDispatch) error) error {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	if err := ole.CoInitializeEx(0, ole.COINIT_APARTMENTTHREADED); err != nil {
+		if oleErr, ok := err.(*ole.OleError); ok {
+			code := oleErr.Code()
+			if code != 0 && code != 1 { // S_OK=0, S_FALSE=1
+				return fmt.Errorf("COM initialization failed: %s", oleErrorString(err))
+			}
+		}
+	}
+	defer ole.CoUninitialize()
+
+	unknown, err := oleutil.CreateObject("WbemScripting.SWbemLocator")
+	if err != nil {
+		return fmt.Errorf("cannot create WMI locator: %s", oleErrorString(err))
+	}
+	defer unknown.Release()
+
+	locator, err := unknown.QueryInterface(ole.IID_IDispatch)
+	if err != nil {
+		return fmt.Errorf("cannot get WMI locator interface: %s", oleErrorString(err))
+	}
+	defer locator.Release()
+
+	servicesVariant, err := oleutil.CallMethod(locator, "ConnectServer", nil, namespace)
+	if err != nil {
+		return fmt.Errorf("connect to WMI namespace %s: %s", namespace, oleErrorString(err))
+	}
+	services := servicesVariant.ToIDispatch()
+	defer services.Release()
+
+	resultVariant, err := oleutil.CallMethod(services, "ExecQuery", query)
+	if err != nil {
+		return fmt.Errorf("WMI query: %s", oleErrorString(err))
+	}
+	result := resultVariant.ToIDispatch()
+	defer result.Release()
+
+	return oleutil.ForEach(result, func(v *ole.VARIANT) error {
+		item := v.ToIDispatch()
+		defer item.Release()
+		return fn(item)
+	})
+}
+
+// wmiString returns a string property of a WMI object, or "" if it is null.
+func wmiString(item *ole.IDispatch, name string) string {
+	v, err := oleutil.GetProperty(item, name)
+	if err != nil {
+		return ""
+	}
+	defer v.Clear()
+	if s, ok := v.Value().(string); ok {
+		return s
+	}
+	return ""
+}
+
+// wmiInt returns an integer property of a WMI object, or 0 if it is null.
+func wmiInt(item *ole.IDispatch, name string) int64 {
+	v, err := oleutil.GetProperty(item, name)
+	if err != nil {
+		return 0
+	}
+	defer v.Clear()
+	switch n := v.Value().(type) {
+	case int32:
+		return int64(n)
+	case uint32:
+		return int64(n)
+	case int64:
+		return n
+	case uint64:
+		return int64(n)
+	case int16:
+		return int64(n)
+	case uint16:
+		return int64(n)
+	case uint8:
+		return int64(n)
+	case string: // WMI returns 64-bit integers as strings
+		i, _ := strconv.ParseInt(n, 10, 64)
+		return i
+	}
+	return 0
+}
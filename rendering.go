@@ -0,0 +1,52 @@
+package webflow
+
+import "github.com/crafted-tech/webflow/platform"
+
+// RenderingInfo describes how the window is drawn, for diagnostics and
+// support logs. When the WebView is likely to draw without GPU acceleration,
+// pages reduce animations and transitions, which otherwise show as flicker,
+// blank areas or garbled frames in some VMs and Remote Desktop sessions.
+type RenderingInfo struct {
+	Renderer      string // WebGL renderer reported by the WebView, e.g. "ANGLE (Intel, ...)"; "" until the first page has loaded or without WebGL
+	Software      bool   // The WebView has no WebGL or renders in software (SwiftShader, llvmpipe, Basic Render Driver)
+	RemoteSession bool   // The window is shown over Remote Desktop or a forwarded X11 display
+	VM            bool   // The computer is a virtual machine
+	ReducedMotion bool   // Animations are reduced because of one of the above
+}
+
+// detectRendering checks the session for conditions that rule out GPU
+// rendering. The WebView's renderer is reported later by the first page.
+func detectRendering() RenderingInfo {
+	info := RenderingInfo{
+		RemoteSession: platform.IsRemoteSession(),
+		VM:            platform.IsRunningInVM(),
+	}
+	info.ReducedMotion = info.RemoteSession || info.VM
+	return info
+}
+
+// RenderingInfo returns how the window is drawn. Include it in diagnostics
+// when users report blank or garbled windows.
+//
+// Example:
+//
+//	info := f.RenderingInfo()
+//	log.Info("renderer=%q software=%v remote=%v vm=%v", info.Renderer, info.Software, info.RemoteSession, info.VM)
+func (f *Flow) RenderingInfo() RenderingInfo {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.rendering
+}
+
+// handleRenderingInfo records the renderer reported by runtime.js.
+func (f *Flow) handleRenderingInfo(resp messageResponse) {
+	renderer, _ := resp.Data["renderer"].(string)
+	software, _ := resp.Data["software"].(bool)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rendering.Renderer = renderer
+	f.rendering.Software = software
+	f.rendering.ReducedMotion = software || f.rendering.RemoteSession || f.rendering.VM
+	f.rendererReported = true
+}
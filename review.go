@@ -0,0 +1,230 @@
+package webflow
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	reviewChunkLines  = 500  // Lines a review page loads at a time
+	reviewInlineLines = 5000 // Content up to this many lines is embedded in the page
+)
+
+// reviewSource is the content of a review page too large to embed in the
+// page. The page shows only the lines around its viewport and loads them
+// in chunks (see handleReviewChunk).
+type reviewSource struct {
+	language string   // Highlighting of the lines (see highlightLine)
+	lines    []string // Content held in memory, or nil
+
+	file    *os.File // Temporary copy of a reader's content, or nil
+	offsets []int64  // Start of each line in file, followed by the end of the last
+}
+
+// newReviewSource returns the source for content of more than
+// reviewInlineLines lines, or nil if the content can be embedded.
+func newReviewSource(content string) *reviewSource {
+	lines := splitLines(content)
+	if len(lines) <= reviewInlineLines {
+		return nil
+	}
+	return &reviewSource{lines: lines}
+}
+
+// readReviewSource copies r to a temporary file and indexes its lines, so
+// the content doesn't need to fit in memory.
+func readReviewSource(r io.Reader) (*reviewSource, error) {
+	file, err := os.CreateTemp("", "review-*.txt")
+	if err != nil {
+		return nil, err
+	}
+	src := &reviewSource{file: file}
+
+	br := bufio.NewReaderSize(r, 64*1024)
+	w := bufio.NewWriterSize(file, 64*1024)
+	var offset int64
+	lineStart := true
+	for {
+		// ReadSlice returns long lines in several parts
+		part, err := br.ReadSlice('\n')
+		if len(part) > 0 {
+			if lineStart {
+				src.offsets = append(src.offsets, offset)
+			}
+			if _, err := w.Write(part); err != nil {
+				src.Close()
+				return nil, err
+			}
+			offset += int64(len(part))
+			lineStart = part[len(part)-1] == '\n'
+		}
+		if errors.Is(err, bufio.ErrBufferFull) {
+			continue
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			src.Close()
+			return nil, err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		src.Close()
+		return nil, err
+	}
+	src.offsets = append(src.offsets, offset)
+	return src, nil
+}
+
+// Len returns the number of lines.
+func (s *reviewSource) Len() int {
+	if s.file == nil {
+		return len(s.lines)
+	}
+	return len(s.offsets) - 1
+}
+
+// Lines returns up to n lines from line start.
+func (s *reviewSource) Lines(start, n int) ([]string, error) {
+	end := min(start+n, s.Len())
+	if start < 0 || start >= end {
+		return nil, nil
+	}
+	if s.file == nil {
+		return s.lines[start:end], nil
+	}
+
+	buf := make([]byte, s.offsets[end]-s.offsets[start])
+	if _, err := s.file.ReadAt(buf, s.offsets[start]); err != nil {
+		return nil, err
+	}
+	lines := make([]string, 0, end-start)
+	for i := start; i < end; i++ {
+		line := string(buf[s.offsets[i]-s.offsets[start] : s.offsets[i+1]-s.offsets[start]])
+		line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
+// WriteTo writes the whole content to w.
+func (s *reviewSource) WriteTo(w io.Writer) (int64, error) {
+	if s.file == nil {
+		n, err := io.WriteString(w, strings.Join(s.lines, "\n")+"\n")
+		return int64(n), err
+	}
+	return io.Copy(w, io.NewSectionReader(s.file, 0, s.offsets[len(s.offsets)-1]))
+}
+
+// String returns the whole content.
+func (s *reviewSource) String() string {
+	var b strings.Builder
+	s.WriteTo(&b)
+	return b.String()
+}
+
+// Close removes the temporary copy of a reader's content.
+func (s *reviewSource) Close() error {
+	if s.file == nil {
+		return nil
+	}
+	s.file.Close()
+	return os.Remove(s.file.Name())
+}
+
+// handleReviewChunk sends the page a chunk of the current review page's
+// lines, highlighted as its content is.
+func (f *Flow) handleReviewChunk(resp messageResponse) {
+	f.mu.Lock()
+	src := f.review
+	f.mu.Unlock()
+	chunk, ok := resp.Data["chunk"].(float64)
+	if src == nil || !ok {
+		return
+	}
+
+	go func() {
+		lines, err := src.Lines(int(chunk)*reviewChunkLines, reviewChunkLines)
+		if err != nil {
+			return
+		}
+		rendered := make([]string, len(lines))
+		for i, line := range lines {
+			rendered[i] = highlightLine(src.language, line)
+		}
+		data, _ := json.Marshal(rendered)
+		f.wv.EvaluateScript(`window.reviewChunk(` + strconv.Itoa(int(chunk)) + `, ` + string(data) + `);`)
+	}()
+}
+
+// handleReviewFind searches the current review page's lines for the page's
+// find bar, which can't search lines that aren't loaded. It sends the page the
+// next matching line after (or before) the given one, wrapping around, with
+// its position among all matching lines.
+func (f *Flow) handleReviewFind(resp messageResponse) {
+	f.mu.Lock()
+	src := f.review
+	f.mu.Unlock()
+	query, _ := resp.Data["query"].(string)
+	from, _ := resp.Data["line"].(float64)
+	backward, _ := resp.Data["backward"].(bool)
+	if src == nil {
+		return
+	}
+
+	go func() {
+		query = strings.ToLower(query)
+		var matches []int
+		for start := 0; query != "" && start < src.Len(); start += reviewChunkLines {
+			lines, err := src.Lines(start, reviewChunkLines)
+			if err != nil {
+				return
+			}
+			for i, line := range lines {
+				if strings.Contains(strings.ToLower(line), query) {
+					matches = append(matches, start+i)
+				}
+			}
+		}
+
+		// Index of the first match after from, or of the last before it
+		found := -1
+		if len(matches) > 0 {
+			i, _ := slices.BinarySearch(matches, int(from)+1)
+			if backward {
+				i, _ = slices.BinarySearch(matches, int(from))
+				i--
+			}
+			found = (i + len(matches)) % len(matches)
+		}
+		line := -1
+		if found >= 0 {
+			line = matches[found]
+		}
+		f.wv.EvaluateScript(`window.reviewFound(` + jsonString(query) + `, ` + strconv.Itoa(line) + `, ` +
+			strconv.Itoa(found+1) + `, ` + strconv.Itoa(len(matches)) + `);`)
+	}()
+}
+
+// saveReview writes the content of a review page to path.
+func saveReview(path, content string, src *reviewSource) error {
+	if src == nil || src.file == nil {
+		return os.WriteFile(path, []byte(content), 0644)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := src.WriteTo(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,158 @@
+package webflow
+
+import (
+	"fmt"
+	"html"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+// RichText is message content with a small subset of HTML: <a href>, <b>,
+// <strong>, <i>, <em>, <code> and <br>. Anything else is escaped, so text from
+// translations or config files can be shown without risk of script injection.
+// Links accept http, https and mailto URLs only and open in the system browser
+// (see WithOnLinkClicked).
+//
+// Example:
+//
+//	f.ShowMessage("Done", webflow.RichText(`Read the <a href="https://example.com/notes">release notes</a> or run <code>myapp --help</code>.`))
+type RichText string
+
+// richTextTags are the elements allowed in RichText.
+var richTextTags = map[string]bool{
+	"a": true, "b": true, "strong": true, "i": true, "em": true, "code": true, "br": true,
+}
+
+var (
+	richTextTagRe  = regexp.MustCompile(`<[^<>]*>`)
+	richTextNameRe = regexp.MustCompile(`^<(/?)([a-zA-Z][a-zA-Z0-9]*)[\s/>]`)
+	richTextHrefRe = regexp.MustCompile(`(?i)\bhref\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+)
+
+// SanitizeRichText returns HTML containing only the RichText whitelist.
+// Disallowed tags are dropped (their text is kept, escaped), attributes other
+// than a safe href are removed, and unclosed tags are closed at the end.
+// Text between < and > that isn't a tag, as in "1 < 2 and 3 > 2", is kept,
+// escaped.
+func SanitizeRichText(s string) string {
+	var buf strings.Builder
+	var open []string
+
+	writeText := func(text string) {
+		// Unescape first so existing entities like &amp; aren't double-escaped
+		buf.WriteString(html.EscapeString(html.UnescapeString(text)))
+	}
+
+	last := 0
+	for _, loc := range richTextTagRe.FindAllStringIndex(s, -1) {
+		writeText(s[last:loc[0]])
+		last = loc[1]
+
+		tag := s[loc[0]:loc[1]]
+		m := richTextNameRe.FindStringSubmatch(tag)
+		if m == nil {
+			writeText(tag)
+			continue
+		}
+		closing, name := m[1] == "/", strings.ToLower(m[2])
+		if !richTextTags[name] {
+			continue
+		}
+
+		switch {
+		case name == "br":
+			buf.WriteString("<br>")
+		case closing:
+			// Close back to the matching open tag; stray closers are dropped
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] == name {
+					for _, n := range reverse(open[i:]) {
+						buf.WriteString("</" + n + ">")
+					}
+					open = open[:i]
+					break
+				}
+			}
+		case name == "a":
+			href := ""
+			if hm := richTextHrefRe.FindStringSubmatch(tag); hm != nil {
+				href = html.UnescapeString(hm[1] + hm[2])
+			}
+			if !isSafeLink(href) {
+				buf.WriteString(`<a class="rich-link">`)
+			} else {
+				buf.WriteString(`<a class="rich-link" href="` + html.EscapeString(href) + `">`)
+			}
+			open = append(open, name)
+		default:
+			buf.WriteString("<" + name + ">")
+			open = append(open, name)
+		}
+	}
+	writeText(s[last:])
+
+	for _, n := range reverse(open) {
+		buf.WriteString("</" + n + ">")
+	}
+	return buf.String()
+}
+
+// isSafeLink reports whether a link target may be opened from RichText.
+func isSafeLink(href string) bool {
+	lower := strings.ToLower(strings.TrimSpace(href))
+	return strings.HasPrefix(lower, "https://") ||
+		strings.HasPrefix(lower, "http://") ||
+		strings.HasPrefix(lower, "mailto:")
+}
+
+func reverse(s []string) []string {
+	r := make([]string, len(s))
+	for i, v := range s {
+		r[len(s)-1-i] = v
+	}
+	return r
+}
+
+// renderRichText renders sanitized rich text as a message paragraph.
+func renderRichText(text RichText) string {
+	formatted := strings.ReplaceAll(SanitizeRichText(string(text)), "\n", "<br>")
+	return `            <p class="flow-message">` + formatted + `</p>
+`
+}
+
+// OpenURL opens a URL in the system's default browser or mail client.
+// Only http, https and mailto URLs are accepted.
+func OpenURL(url string) error {
+	if !isSafeLink(url) {
+		return fmt.Errorf("unsupported URL scheme: %s", url)
+	}
+
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	case "darwin":
+		cmd = exec.Command("open", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go cmd.Wait()
+	return nil
+}
+
+// handleOpenLink handles an open_link message sent when a link in the page is clicked.
+func (f *Flow) handleOpenLink(resp messageResponse) {
+	url, _ := resp.Data["url"].(string)
+	if url == "" {
+		return
+	}
+	if f.config.OnLinkClicked != nil && f.config.OnLinkClicked(url) {
+		return
+	}
+	OpenURL(url)
+}
@@ -0,0 +1,100 @@
+package webflow
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// redacted is what a SecretString shows instead of its value.
+const redacted = "[redacted]"
+
+// SecretString holds a password or other secret from a form. It prints as
+// "[redacted]" with fmt, log/slog and encoding/json, so it can't end up in
+// logs or debug output by accident, and Close overwrites its memory.
+//
+// The value arrives from the page as a Go string, which can't be cleared;
+// Secret removes it from the response so the SecretString is the only
+// reference the app keeps.
+//
+// Example:
+//
+//	resp := f.ShowForm("Service Account", fields)
+//	password := webflow.Secret(resp, "password")
+//	defer password.Close()
+//	err := platform.ValidateCredentials(user, password.Reveal(), "")
+type SecretString struct {
+	b []byte
+}
+
+// NewSecretString returns a SecretString holding a copy of s.
+func NewSecretString(s string) *SecretString {
+	return &SecretString{b: []byte(s)}
+}
+
+// Secret returns the value of a field of a form response as a SecretString
+// and deletes it from the response. It returns an empty SecretString if the
+// response has no such field.
+func Secret(resp any, id string) *SecretString {
+	data, ok := resp.(map[string]any)
+	if !ok {
+		return &SecretString{}
+	}
+	s, _ := data[id].(string)
+	delete(data, id)
+	return NewSecretString(s)
+}
+
+// Reveal returns the secret as a string, for APIs that need one. The string
+// is a copy that Close can't clear; prefer Bytes where possible.
+func (s SecretString) Reveal() string {
+	return string(s.b)
+}
+
+// Bytes returns the secret's memory, valid until Close.
+func (s SecretString) Bytes() []byte {
+	return s.b
+}
+
+// Len returns the length of the secret in bytes.
+func (s SecretString) Len() int {
+	return len(s.b)
+}
+
+// IsEmpty reports whether the secret is empty.
+func (s SecretString) IsEmpty() bool {
+	return len(s.b) == 0
+}
+
+// Close overwrites the secret with zeros and empties it.
+func (s *SecretString) Close() {
+	clear(s.b)
+	s.b = nil
+}
+
+// String returns "[redacted]".
+func (SecretString) String() string {
+	return redacted
+}
+
+// Format prints "[redacted]" for every verb, including %x and %#v.
+func (SecretString) Format(f fmt.State, verb rune) {
+	fmt.Fprint(f, redacted)
+}
+
+// LogValue keeps the secret out of log/slog output.
+func (SecretString) LogValue() slog.Value {
+	return slog.StringValue(redacted)
+}
+
+// MarshalJSON encodes the secret as "[redacted]", so it is never written to
+// session recordings, status files or other JSON output.
+func (SecretString) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + redacted + `"`), nil
+}
+
+// UnmarshalText sets the secret, so BindForm can fill SecretString fields.
+func (s *SecretString) UnmarshalText(text []byte) error {
+	clear(s.b)
+	s.b = append([]byte(nil), text...)
+	return nil
+}
@@ -0,0 +1,210 @@
+package webflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"strings"
+)
+
+// Session is a recording of the pages a Flow showed and how the user answered
+// them, written by WithRecording. A session can be replayed in tests (see
+// webflowtest.FakeFlow.RespondSession) or turned into an answers file for
+// unattended installs with WriteAnswers ("record once, deploy silently").
+//
+// Password field values are never recorded.
+type Session struct {
+	Entries []SessionEntry `json:"entries"`
+}
+
+// SessionEntry is one answered page.
+type SessionEntry struct {
+	Page   string         `json:"page"`             // Page ID (see WithPageID)
+	Title  string         `json:"title,omitempty"`  // Page title as shown, for reading the file
+	Button string         `json:"button,omitempty"` // Button clicked
+	Closed bool           `json:"closed,omitempty"` // The window was closed instead
+	Data   map[string]any `json:"data,omitempty"`   // Form values and selections sent with the click
+}
+
+// WithRecording records every page answered through the Flow to a session
+// file at path. The file is rewritten after each page, so it survives the
+// installer being killed midway.
+//
+// Example:
+//
+//	// setup.exe --record session.json
+//	f, _ := webflow.New(webflow.WithRecording(*recordPath))
+func WithRecording(path string) Option {
+	return func(c *Config) {
+		c.RecordPath = path
+	}
+}
+
+// WithPageID sets a stable identifier for the page, used in recorded sessions
+// and answer files instead of the (translated) title.
+func WithPageID(id string) PageOption {
+	return func(c *PageConfig) {
+		c.ID = id
+	}
+}
+
+// LoadSession reads a session file written by WithRecording.
+func LoadSession(path string) (*Session, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read session: %w", err)
+	}
+	var s Session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parse session: %w", err)
+	}
+	return &s, nil
+}
+
+// Save writes the session to path.
+func (s *Session) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("write session: %w", err)
+	}
+	return nil
+}
+
+// Answers returns the final answer for each page ID: its form values and
+// selections, plus "_button" when the page was left with a button other than
+// Next. Back clicks are navigation rather than answers and are left out; when
+// a page was answered more than once, the last answer wins.
+func (s *Session) Answers() map[string]map[string]any {
+	answers := make(map[string]map[string]any)
+	for _, e := range s.Entries {
+		if e.Closed || e.Button == ButtonBack {
+			continue
+		}
+		answer := maps.Clone(e.Data)
+		if answer == nil {
+			answer = make(map[string]any)
+		}
+		if e.Button != ButtonNext && e.Button != "" {
+			answer["_button"] = e.Button
+		}
+		answers[e.Page] = answer
+	}
+	return answers
+}
+
+// WriteAnswers writes the session's answers (see Answers) as an answers file:
+//
+//	{
+//	  "pages": {
+//	    "install-type": {"_selected_index": 1},
+//	    "settings": {"port": "8080", "autostart": true}
+//	  }
+//	}
+//
+// Password fields are not recorded, so add them by hand if the install needs them.
+func (s *Session) WriteAnswers(path string) error {
+	data, err := json.MarshalIndent(map[string]any{"pages": s.Answers()}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("write answers: %w", err)
+	}
+	return nil
+}
+
+// pageID returns the identifier of a page for sessions and answer files:
+// the ID set with WithPageID, else the title.
+func pageID(page Page) string {
+	if page.ID != "" {
+		return page.ID
+	}
+	if page.Title != "" {
+		return page.Title
+	}
+	switch c := page.Content.(type) {
+	case WelcomeConfig:
+		return c.Title
+	case AlertConfig:
+		return c.Title
+	}
+	return ""
+}
+
+// recordPage appends an answered page to the recording, if enabled.
+// Language changes are not recorded: the page is shown again and answered then.
+func (f *Flow) recordPage(page Page, msg messageResponse) {
+	if f.config.RecordPath == "" || msg.Type == "change_language" {
+		return
+	}
+
+	entry := SessionEntry{
+		Page:   pageID(page),
+		Title:  page.Title,
+		Button: msg.Button,
+		Closed: msg.Type == "window_close",
+		Data:   maps.Clone(msg.Data),
+	}
+	scrubPasswords(pageFields(page), entry.Data)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.session.Entries = append(f.session.Entries, entry)
+	f.session.Save(f.config.RecordPath) // Best effort; a recording problem must not break the wizard
+}
+
+// scrubPasswords removes the values of password fields from data, including
+// those in the rows of FieldGroups, whether the rows are reported by row
+// field ID ("group.0.field") or as a list.
+func scrubPasswords(fields []FormField, data map[string]any) {
+	for _, field := range fields {
+		switch field.Type {
+		case FieldPassword:
+			delete(data, field.ID)
+		case FieldGroup:
+			for key := range data {
+				rest, ok := strings.CutPrefix(key, field.ID+".")
+				if !ok {
+					continue
+				}
+				if _, id, ok := strings.Cut(rest, "."); ok && isPasswordField(field.Fields, id) {
+					delete(data, key)
+				}
+			}
+			switch rows := data[field.ID].(type) {
+			case []any:
+				scrubbed := make([]any, len(rows))
+				for i, row := range rows {
+					if m, ok := row.(map[string]any); ok {
+						m = maps.Clone(m)
+						scrubPasswords(field.Fields, m)
+						row = m
+					}
+					scrubbed[i] = row
+				}
+				data[field.ID] = scrubbed
+			case []map[string]any:
+				scrubbed := make([]map[string]any, len(rows))
+				for i, row := range rows {
+					scrubbed[i] = maps.Clone(row)
+					scrubPasswords(field.Fields, scrubbed[i])
+				}
+				data[field.ID] = scrubbed
+			}
+		}
+	}
+}
+
+// isPasswordField reports whether the field with id is a password field.
+func isPasswordField(fields []FormField, id string) bool {
+	for _, field := range fields {
+		if field.ID == id {
+			return field.Type == FieldPassword
+		}
+	}
+	return false
+}
@@ -0,0 +1,275 @@
+package webflow
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// settingsApply is the ID of the Apply button of a settings page.
+const settingsApply = "settings_apply"
+
+// settingsTabKey is the form value runtime.js sends with the selected tab.
+const settingsTabKey = "_settings_tab"
+
+// SettingsTab is one tab of a settings page.
+type SettingsTab struct {
+	Title  string      // Tab label
+	Fields []FormField // Fields shown on the tab; IDs must be unique across tabs
+}
+
+// SettingsConfig configures ShowSettings.
+type SettingsConfig struct {
+	Tabs []SettingsTab
+	Tab  int // Initially selected tab
+
+	// OnApply, if set, saves the values of all tabs when the user clicks
+	// Apply or OK. If it returns an error, the error is shown and the page
+	// stays open with the user's changes.
+	OnApply func(values map[string]any) error
+
+	alert string         // Error from OnApply, shown above the tabs
+	saved map[string]any // Values before the user's edits, when the fields show the edits
+}
+
+// ShowSettings displays a preferences page with tabs: [Apply] [OK] [Cancel].
+// Apply saves the changes through OnApply and keeps the page open; it is
+// enabled only while there are unsaved changes, which are also marked on
+// their tabs. OK saves and closes the page, Cancel discards the changes.
+//
+// Returns:
+//   - map[string]any with the values of all tabs (keyed by field ID) after OK
+//   - Navigation (Close) for Cancel or closing the window
+//
+// Example:
+//
+//	f.ShowSettings("Preferences", webflow.SettingsConfig{
+//	    Tabs: []webflow.SettingsTab{
+//	        {Title: "General", Fields: generalFields},
+//	        {Title: "Network", Fields: networkFields},
+//	    },
+//	    OnApply: func(values map[string]any) error {
+//	        return saveConfig(values)
+//	    },
+//	})
+func (f *Flow) ShowSettings(title string, cfg SettingsConfig, opts ...PageOption) any {
+	hasButtonBar := false
+	for _, opt := range opts {
+		pcfg := PageConfig{}
+		opt(&pcfg)
+		if pcfg.ButtonBar != nil {
+			hasButtonBar = true
+			break
+		}
+	}
+	if !hasButtonBar {
+		opts = append(opts, WithButtonBar(settingsButtonBar(cfg.OnApply != nil)))
+	}
+
+	for {
+		page := applyPageConfig(title, cfg, opts)
+		msg := f.showPageInternal(page)
+
+		values := msg.Data
+		if values == nil {
+			values = make(map[string]any)
+		}
+		tab, _ := values[settingsTabKey].(float64)
+		delete(values, settingsTabKey)
+		cfg.normalizeValues(values)
+
+		switch msg.Button {
+		case ButtonNext:
+			if cfg.OnApply != nil {
+				if err := cfg.OnApply(values); err != nil {
+					if cfg.saved == nil {
+						cfg.saved = cfg.fieldValues()
+					}
+					cfg = cfg.withValues(values, int(tab))
+					cfg.alert = err.Error()
+					continue
+				}
+			}
+			return values
+		case ButtonBack:
+			return Back
+		case ButtonClose, ButtonCancel, "":
+			return Close
+		default:
+			values["_button"] = msg.Button
+			return values
+		}
+	}
+}
+
+// settingsButtonBar returns [Apply] [OK] [Cancel]. Apply starts disabled
+// and is enabled by runtime.js once a value changes.
+func settingsButtonBar(apply bool) ButtonBar {
+	bb := ButtonBar{
+		Next:  NewButton(TKey("button.ok"), ButtonNext).WithPrimary(),
+		Close: NewButton(TKey("button.cancel"), ButtonCancel),
+	}
+	if apply {
+		bb.Back = &Button{Label: TKey("button.apply"), ID: settingsApply, action: true}
+	}
+	return bb
+}
+
+// withValues returns a copy of cfg showing values instead of the fields'
+// defaults, with tab selected.
+func (cfg SettingsConfig) withValues(values map[string]any, tab int) SettingsConfig {
+	tabs := make([]SettingsTab, len(cfg.Tabs))
+	for i, t := range cfg.Tabs {
+		fields := make([]FormField, len(t.Fields))
+		for j, field := range t.Fields {
+			if v, ok := values[field.ID]; ok && field.Type != FieldPassword {
+				field.Default = v
+			}
+			fields[j] = field
+		}
+		tabs[i] = SettingsTab{Title: t.Title, Fields: fields}
+	}
+	cfg.Tabs = tabs
+	cfg.Tab = tab
+	return cfg
+}
+
+// fieldValues returns the values the fields show initially, as runtime.js
+// reads them from the inputs.
+func (cfg SettingsConfig) fieldValues() map[string]any {
+	values := make(map[string]any)
+	for _, tab := range cfg.Tabs {
+		for _, field := range tab.Fields {
+			switch {
+			case field.ID == "" || field.Type == FieldInfo || field.Type == FieldGroup:
+			case field.Type == FieldCheckbox:
+				values[field.ID] = field.Default == true
+			case field.Type == FieldSlider:
+				values[field.ID] = field.sliderValue()
+			case field.Type == FieldFileDrop:
+				values[field.ID] = strings.Join(field.dropPaths(), "\n")
+			case field.Type == FieldColor && field.Default != nil:
+				values[field.ID], _ = NormalizeColor(fmt.Sprintf("%v", field.Default))
+			case field.Default != nil:
+				values[field.ID] = fmt.Sprintf("%v", field.Default)
+			case field.Type == FieldSelect && len(field.Options) > 0:
+				values[field.ID] = field.Options[0]
+			default:
+				values[field.ID] = ""
+			}
+		}
+	}
+	return values
+}
+
+// normalizeValues applies the fields' input masks to values.
+func (cfg SettingsConfig) normalizeValues(values map[string]any) {
+	for _, tab := range cfg.Tabs {
+		normalizeValues(tab.Fields, values)
+	}
+}
+
+// applySettings runs OnApply for a click on Apply and tells the page whether
+// the values were saved.
+func (f *Flow) applySettings(cfg SettingsConfig, values map[string]any) {
+	delete(values, settingsTabKey)
+	cfg.normalizeValues(values)
+	if err := cfg.OnApply(values); err != nil {
+		f.wv.EvaluateScript(`window.settingsApplyFailed(` + jsonString(err.Error()) + `, ` + jsonString(GetIcon("error")) + `);`)
+		return
+	}
+	f.wv.EvaluateScript(`window.settingsApplied();`)
+}
+
+// EditSettings shows a settings page for a struct: fields are filled from
+// *settings and read back as BindForm does, using the same `flow:"field_id"`
+// tags, into a copy of *settings: fields not on the page and secrets left
+// blank keep their value. Apply and OK pass the edited copy to save; when it
+// succeeds, *settings is updated. It reports whether the page was closed with OK.
+//
+// Example:
+//
+//	type prefs struct {
+//	    Server    string        `flow:"server,required"`
+//	    Timeout   time.Duration `flow:"timeout"`
+//	    Autostart bool          `flow:"autostart"`
+//	}
+//
+//	webflow.EditSettings(f, "Preferences", tabs, &current, func(p prefs) error {
+//	    return savePrefs(p)
+//	})
+func EditSettings[T any](f *Flow, title string, tabs []SettingsTab, settings *T, save func(T) error, opts ...PageOption) bool {
+	cfg := SettingsConfig{Tabs: tabs}.withValues(structValues(settings), 0)
+	cfg.OnApply = func(values map[string]any) error {
+		v := *settings
+		if err := bindValues(values, reflect.ValueOf(&v).Elem()); err != nil {
+			return err
+		}
+		if err := save(v); err != nil {
+			return err
+		}
+		*settings = v
+		return nil
+	}
+	_, ok := f.ShowSettings(title, cfg, opts...).(map[string]any)
+	return ok
+}
+
+var secretType = reflect.TypeOf(SecretString{})
+
+// structValues returns the fields of a struct, or pointer to one, keyed by
+// their form field ID (see BindForm), for use as form defaults. Secrets and
+// nil pointers are left out.
+func structValues(s any) map[string]any {
+	values := make(map[string]any)
+	v := reflect.ValueOf(s)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return values
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return values
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		id, _, _ := strings.Cut(sf.Tag.Get("flow"), ",")
+		if id == "-" {
+			continue
+		}
+		if id == "" {
+			id = sf.Name
+		}
+
+		fv := v.Field(i)
+		if fv.Kind() == reflect.Pointer {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+		}
+		if fv.Type() == secretType {
+			continue
+		}
+		m, ok := fv.Interface().(encoding.TextMarshaler)
+		if !ok && fv.CanAddr() {
+			m, ok = fv.Addr().Interface().(encoding.TextMarshaler)
+		}
+		if ok {
+			text, err := m.MarshalText()
+			if err == nil {
+				values[id] = string(text)
+			}
+			continue
+		}
+		values[id] = fv.Interface()
+	}
+	return values
+}
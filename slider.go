@@ -0,0 +1,32 @@
+package webflow
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// sliderRange returns the Min, Max and Step of a FieldSlider with defaults
+// applied.
+func (field FormField) sliderRange() (lo, hi, step float64) {
+	lo, hi, step = field.Min, field.Max, field.Step
+	if hi == 0 && lo < 100 {
+		hi = 100
+	}
+	if step <= 0 {
+		step = 1
+	}
+	return lo, hi, step
+}
+
+// sliderValue returns the initial value of a FieldSlider as the page reports
+// it: Default within the range, or Min.
+func (field FormField) sliderValue() string {
+	lo, hi, _ := field.sliderRange()
+	value := lo
+	if field.Default != nil {
+		if v, err := strconv.ParseFloat(fmt.Sprintf("%v", field.Default), 64); err == nil {
+			value = min(max(v, lo), hi)
+		}
+	}
+	return formatFloat(value)
+}
@@ -0,0 +1,79 @@
+package webflow
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"strings"
+)
+
+// eventPumper is an optional interface for processing pending window events
+// outside Run, so a page loaded before the event loop starts gets painted.
+type eventPumper interface {
+	ProcessEvents()
+}
+
+// ShowSplash shows a lightweight splash screen with a logo (SVG or PNG bytes,
+// may be nil) and a message, and returns immediately. The page has no
+// stylesheet or scripts, so it appears while the app does its slow startup
+// work; the next Show* call replaces it.
+//
+// Example:
+//
+//	f, _ := webflow.New(webflow.WithTitle("My App Setup"))
+//	f.ShowSplash(logoSVG, "Preparing setup...")
+//	info := detectInstalledVersion() // Slow
+//	f.ShowWelcome(webflow.WelcomeConfig{...})
+func (f *Flow) ShowSplash(logo []byte, message string) {
+	if f.closed.Load() {
+		return
+	}
+
+	f.wv.LoadHTML(renderSplash(logo, message, f.darkMode, f.textDirection(f.Language())))
+	f.wv.Show()
+	if p, ok := f.wv.(eventPumper); ok {
+		p.ProcessEvents()
+	}
+}
+
+// renderSplash renders the self-contained splash page.
+func renderSplash(logo []byte, message string, dark bool, dir TextDirection) string {
+	background, foreground := "#ffffff", "#404040"
+	if dark {
+		background, foreground = "#242424", "#e6e6e6"
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(fmt.Sprintf(`<!DOCTYPE html>
+<html dir="%s">
+<head>
+<meta charset="UTF-8">
+<style>
+html, body { height: 100%%; margin: 0; }
+body { display: flex; flex-direction: column; align-items: center; justify-content: center; gap: 1.25rem;
+       background: %s; color: %s; font: 14px system-ui, -apple-system, "Segoe UI", Roboto, sans-serif; cursor: progress; }
+.splash-logo { height: 64px; }
+.splash-logo svg, .splash-logo img { height: 100%%; width: auto; }
+</style>
+</head>
+<body>
+`, dir, background, foreground))
+
+	if len(logo) > 0 {
+		logoData := string(logo)
+		if strings.HasPrefix(logoData, "<svg") || strings.HasPrefix(logoData, "<?xml") {
+			buf.WriteString(`<div class="splash-logo">` + logoData + `</div>
+`)
+		} else {
+			buf.WriteString(`<div class="splash-logo"><img src="data:image/png;base64,` + encodeBase64(logo) + `" alt=""></div>
+`)
+		}
+	}
+	if message != "" {
+		buf.WriteString(`<div role="status">` + html.EscapeString(message) + `</div>
+`)
+	}
+	buf.WriteString(`</body>
+</html>`)
+	return buf.String()
+}
@@ -3,8 +3,10 @@ package webflow
 import (
 	"bytes"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"html"
+	"strconv"
 	"strings"
 )
 
@@ -12,22 +14,59 @@ import (
 // Uses currentColor to inherit text color from CSS.
 const selectChevron = `<svg class="select-chevron" xmlns="http://www.w3.org/2000/svg" width="12" height="12" viewBox="0 0 12 12" aria-hidden="true"><path fill="currentColor" d="M3 4L6 8L9 4z"/></svg>`
 
-// renderPage generates the complete HTML for a flow page.
-// Translation is performed immediately by T()/TF() - no frontend translation needed.
-// Call SetLanguage() before calling this function to ensure correct language.
-func renderPage(page Page, darkMode bool, primaryLight, primaryDark string) string {
-	// T() and TF() translate strings immediately using the package-level currentLanguage.
-	// The frontend still needs i18n.js for the language selector to display language names.
+// renderPage renders a page in the Flow's language and theme.
+func (f *Flow) renderPage(page Page) string {
+	f.reloadTranslations()
+	tr := f.translator()
+	// Keep the package-level T()/TF() in step for pages the app builds with them
+	setCurrentTranslator(tr)
+	f.setButtonActions(page)
+	f.mu.Lock()
+	reducedMotion, rendererChecked := f.rendering.ReducedMotion, f.rendererReported
+	f.mu.Unlock()
+	return renderPage(page, tr, pageStyle{
+		dark:         f.darkMode,
+		primaryLight: f.primaryColorLight,
+		primaryDark:  f.primaryColorDark,
+		dir:          f.textDirection(tr.lang),
+		contextMenu:  f.config.ContextMenu,
+		fontScale:    f.FontScale(),
+		windowMode:   f.WindowMode(),
+		baseScale:    baseFontScale(f.config),
+
+		reducedMotion:   reducedMotion,
+		rendererChecked: rendererChecked,
+	})
+}
 
+// pageStyle holds the window-wide settings pages are rendered with.
+type pageStyle struct {
+	dark         bool
+	primaryLight string            // HSL primary color override for light mode
+	primaryDark  string            // HSL primary color override for dark mode
+	dir          TextDirection     // Document direction; RTL mirrors the layout through CSS
+	contextMenu  []ContextMenuItem // nil = browser menu, empty = none (see WithContextMenu)
+	fontScale    float64           // Text size factor, 1 = default
+	baseScale    float64           // Text size Ctrl+0 returns to
+	windowMode   WindowMode        // Window presentation, for frameless drag areas and kiosk shortcuts
+
+	reducedMotion   bool // Turn off animations, e.g. when rendering without a GPU (see RenderingInfo)
+	rendererChecked bool // The WebView's renderer is known; runtime.js needn't probe WebGL again
+}
+
+// renderPage generates the complete HTML for a flow page.
+// Library strings are translated immediately with tr - no frontend translation needed.
+func renderPage(page Page, tr translator, style pageStyle) string {
 	var buf bytes.Buffer
 
 	theme := "light"
-	if darkMode {
+	if style.dark {
 		theme = "dark"
 	}
 
 	// Build CSS with optional color overrides
 	css := cssContent
+	primaryLight, primaryDark := style.primaryLight, style.primaryDark
 	if primaryLight != "" || primaryDark != "" {
 		var colorCSS strings.Builder
 		colorCSS.WriteString("\n:root {")
@@ -45,8 +84,32 @@ func renderPage(page Page, darkMode bool, primaryLight, primaryDark string) stri
 		css += colorCSS.String()
 	}
 
+	// Window-wide behavior read by runtime.js
+	htmlAttrs := ""
+	if page.OnFieldChange != nil {
+		htmlAttrs += ` data-field-change`
+	}
+	if mode := contextMenuMode(style.contextMenu); mode != "" {
+		htmlAttrs += ` data-context-menu="` + mode + `"`
+	}
+	if style.windowMode != WindowNormal {
+		htmlAttrs += ` data-window-mode="` + style.windowMode.attr() + `"`
+	}
+	if style.fontScale > 0 && style.fontScale != 1 {
+		htmlAttrs += fmt.Sprintf(` style="--font-scale: %.2f"`, style.fontScale)
+	}
+	if style.baseScale > 0 {
+		htmlAttrs += fmt.Sprintf(` data-base-font-scale="%.2f"`, style.baseScale)
+	}
+	if style.reducedMotion {
+		htmlAttrs += ` data-reduced-motion`
+	}
+	if style.rendererChecked {
+		htmlAttrs += ` data-renderer-checked`
+	}
+
 	buf.WriteString(`<!DOCTYPE html>
-<html lang="en" data-theme="` + theme + `">
+<html lang="` + html.EscapeString(tr.lang) + `" dir="` + string(style.dir) + `" data-theme="` + theme + `"` + htmlAttrs + `>
 <head>
     <meta charset="UTF-8">
     <meta name="viewport" content="width=device-width, initial-scale=1.0">
@@ -113,7 +176,7 @@ func renderPage(page Page, darkMode bool, primaryLight, primaryDark string) stri
 `)
 
 	// Content
-	contentHTML, needsPassthrough := renderContent(page.Content)
+	contentHTML, needsPassthrough := renderContent(page.Content, tr)
 	contentClass := "flow-content"
 	if needsPassthrough {
 		contentClass += " flow-content-passthrough"
@@ -125,10 +188,16 @@ func renderPage(page Page, darkMode bool, primaryLight, primaryDark string) stri
 `)
 
 	// Footer with buttons - prefer ButtonBar over legacy Buttons array
-	buf.WriteString(renderButtonBar(page))
+	buf.WriteString(renderButtonBar(tr.buttons(page)))
 
 	buf.WriteString(`    </div>
-    <script>` + jsContent + `</script>
+`)
+	buf.WriteString(renderContextMenu(style.contextMenu, tr))
+	// Live regions for screen reader announcements (see announce in runtime.js)
+	buf.WriteString(`    <div id="live-polite" class="sr-only" aria-live="polite" aria-atomic="true"></div>
+    <div id="live-assertive" class="sr-only" aria-live="assertive" aria-atomic="true"></div>
+`)
+	buf.WriteString(`    <script>` + jsContent + `</script>
 </body>
 </html>`)
 
@@ -169,7 +238,7 @@ func renderIcon(icon string) string {
 // renderContent renders the page content based on its type.
 // Returns (html, needsPassthrough) where needsPassthrough indicates the content
 // handles its own scrolling and the parent should use overflow:hidden.
-func renderContent(content any) (string, bool) {
+func renderContent(content any, tr translator) (string, bool) {
 	if content == nil {
 		return "", false
 	}
@@ -177,34 +246,48 @@ func renderContent(content any) (string, bool) {
 	switch c := content.(type) {
 	case string:
 		return renderMessage(c), false
+	case RichText:
+		return renderRichText(c), false
 	case []Choice:
 		return renderChoiceList(c), false
 	case MultiChoice:
-		return renderMultiChoiceList(c), false
+		return renderMultiChoiceList(c, tr), false
+	case ItemList:
+		return renderItemList(c, tr), false
 	case []MenuItem:
 		return renderMenuList(c), false
 	case []FormField:
-		return renderForm(c), false
+		return renderForm(c, tr), false
 	case ProgressConfig:
-		return renderProgress(), false
+		return renderProgress(c), false
+	case MultiProgressConfig:
+		return renderMultiProgress(c, tr), false
 	case LogConfig:
-		return renderLogView(), true
+		return renderLogView(tr), true
 	case FileListConfig:
 		return renderFileListView(), true
 	case ReviewConfig:
-		return renderReviewView(c), true
+		return renderReviewView(c, tr), true
+	case DiffConfig:
+		return renderDiff(c, tr), true
 	case WelcomeConfig:
-		return renderWelcomeView(c), false
+		return renderWelcomeView(c, tr), false
 	case LicenseConfig:
-		return renderLicenseView(c), true
+		return renderLicenseView(c, tr), true
 	case ConfirmCheckboxConfig:
 		return renderConfirmCheckboxView(c), false
 	case ConfirmTextConfig:
 		return renderConfirmTextView(c), false
 	case SummaryConfig:
-		return renderSummaryView(c), false
+		return renderSummaryView(c, tr), false
 	case AlertConfig:
 		return renderAlertView(c), false
+	case Accordion:
+		return renderAccordion(c, tr), false
+	case SettingsConfig:
+		return renderSettings(c, tr), false
+	case ConsentConfig:
+		return renderConsent(c, tr), false
 	default:
 		return "", false
 	}
@@ -253,7 +336,7 @@ func renderChoiceList(choices []Choice) string {
 }
 
 // renderMultiChoiceList renders a list of checkboxes for multi-selection.
-func renderMultiChoiceList(mc MultiChoice) string {
+func renderMultiChoiceList(mc MultiChoice, tr translator) string {
 	// Build a set of selected indices for quick lookup
 	selectedSet := make(map[int]bool)
 	for _, idx := range mc.Selected {
@@ -276,23 +359,118 @@ func renderMultiChoiceList(mc MultiChoice) string {
 		if value == "" {
 			value = choice.Label
 		}
+		rules := ""
+		if len(choice.Requires) > 0 {
+			rules += fmt.Sprintf(` data-requires="%s"`, html.EscapeString(strings.Join(choice.Requires, "\n")))
+		}
+		if len(choice.Excludes) > 0 {
+			rules += fmt.Sprintf(` data-excludes="%s"`, html.EscapeString(strings.Join(choice.Excludes, "\n")))
+		}
 		inputID := fmt.Sprintf("choice-%d", i)
 		buf.WriteString(fmt.Sprintf(`                <label class="choice-item" for="%s">
-                    <input type="checkbox" id="%s" name="choice-%d" value="%s" data-index="%d"%s%s>
+                    <input type="checkbox" id="%s" name="choice-%d" value="%s" data-index="%d"%s%s%s>
                     <span class="choice-checkbox"></span>
                     <div class="choice-content">
                         <div class="choice-label">%s</div>
-`, inputID, inputID, i, html.EscapeString(value), i, checked, autofocus, html.EscapeString(choice.Label)))
+`, inputID, inputID, i, html.EscapeString(value), i, rules, checked, autofocus, html.EscapeString(choice.Label)))
 		if choice.Description != "" {
 			buf.WriteString(fmt.Sprintf(`                        <div class="choice-description">%s</div>
 `, html.EscapeString(choice.Description)))
 		}
 		buf.WriteString(`                    </div>
-                </label>
+`)
+		if choice.SizeBytes > 0 {
+			buf.WriteString(fmt.Sprintf(`                    <span class="choice-size" data-size="%d">%s</span>
+`, choice.SizeBytes, formatBytes(tr.lang, choice.SizeBytes)))
+		}
+		buf.WriteString(`                </label>
+`)
+	}
+	buf.WriteString(`            </div>
+`)
+
+	// Live total of the selected sizes, updated by the runtime
+	var total int64
+	hasSizes := false
+	for i, choice := range mc.Choices {
+		if choice.SizeBytes > 0 {
+			hasSizes = true
+			if selectedSet[i] {
+				total += choice.SizeBytes
+			}
+		}
+	}
+	if hasSizes {
+		buf.WriteString(fmt.Sprintf(`            <div class="choice-total">
+                <span>%s</span>
+                <span id="choice-total-size" data-units="%s">%s</span>
+            </div>
+`, html.EscapeString(tr.T("components.spaceRequired")), html.EscapeString(strings.Join(byteUnits(tr.lang), ",")), formatBytes(tr.lang, total)))
+	}
+	return buf.String()
+}
+
+// renderItemList renders a checkbox list with sizes and expandable details.
+// It uses the multi-choice markup, so selection, keyboard navigation and the
+// size total work as for ShowMultiChoice.
+func renderItemList(list ItemList, tr translator) string {
+	var buf bytes.Buffer
+	buf.WriteString(fmt.Sprintf(`            <div class="form-checkbox-group item-list-all">
+                <input type="checkbox" id="item-select-all" class="form-checkbox">
+                <label class="form-label" for="item-select-all">%s</label>
+            </div>
+            <div class="choice-list choice-list-multi item-list">
+`, html.EscapeString(tr.T("itemList.selectAll"))))
+
+	var total int64
+	hasSizes := false
+	for i, item := range list.Items {
+		checked := ""
+		if item.Checked {
+			checked = " checked"
+			total += item.SizeBytes
+		}
+		inputID := fmt.Sprintf("choice-%d", i)
+		buf.WriteString(fmt.Sprintf(`                <div class="item-entry">
+                    <label class="choice-item" for="%s">
+                        <input type="checkbox" id="%s" name="choice-%d" value="%s" data-index="%d"%s>
+                        <span class="choice-checkbox"></span>
+                        <div class="choice-content">
+                            <div class="choice-label">%s</div>
+`, inputID, inputID, i, html.EscapeString(item.ID), i, checked, html.EscapeString(item.Label)))
+		if item.Description != "" {
+			buf.WriteString(fmt.Sprintf(`                            <div class="choice-description">%s</div>
+`, html.EscapeString(item.Description)))
+		}
+		buf.WriteString(`                        </div>
+`)
+		if item.SizeBytes > 0 {
+			hasSizes = true
+			buf.WriteString(fmt.Sprintf(`                        <span class="choice-size" data-size="%d">%s</span>
+`, item.SizeBytes, formatBytes(tr.lang, item.SizeBytes)))
+		}
+		buf.WriteString(`                    </label>
+`)
+		if item.Details != "" {
+			buf.WriteString(fmt.Sprintf(`                    <details class="item-details">
+                        <summary>%s</summary>
+                        <div class="item-details-body">%s</div>
+                    </details>
+`, html.EscapeString(tr.T("button.details")), html.EscapeString(item.Details)))
+		}
+		buf.WriteString(`                </div>
 `)
 	}
 	buf.WriteString(`            </div>
 `)
+
+	if hasSizes {
+		buf.WriteString(fmt.Sprintf(`            <div class="choice-total">
+                <span>%s</span>
+                <span id="choice-total-size" data-units="%s">%s</span>
+            </div>
+`, html.EscapeString(tr.T("itemList.selectedSize")), html.EscapeString(strings.Join(byteUnits(tr.lang), ",")), formatBytes(tr.lang, total)))
+	}
 	return buf.String()
 }
 
@@ -340,12 +518,12 @@ func renderMenuIcon(icon string) string {
 }
 
 // renderForm renders a form with input fields.
-func renderForm(fields []FormField) string {
+func renderForm(fields []FormField, tr translator) string {
 	var buf bytes.Buffer
 	buf.WriteString(`            <form class="flow-form">
 `)
 	for _, field := range fields {
-		buf.WriteString(renderFormField(field))
+		buf.WriteString(renderFormField(field, tr))
 	}
 	buf.WriteString(`            </form>
 `)
@@ -353,14 +531,23 @@ func renderForm(fields []FormField) string {
 }
 
 // renderFormField renders a single form field.
-func renderFormField(field FormField) string {
+func renderFormField(field FormField, tr translator) string {
 	var buf bytes.Buffer
 
+	field = field.withHostTest()
+	field.Label = tr.translate(field.Label)
+	field.Placeholder = tr.translate(field.Placeholder)
+	field.Suffix = tr.button(field.Suffix)
 	switch field.Type {
-	case FieldText, FieldPassword:
+	case FieldText, FieldPassword, FieldEmail, FieldURL, FieldHost:
 		inputType := "text"
-		if field.Type == FieldPassword {
+		switch field.Type {
+		case FieldPassword:
 			inputType = "password"
+		case FieldEmail:
+			inputType = "email"
+		case FieldURL:
+			inputType = "url"
 		}
 
 		buf.WriteString(fmt.Sprintf(`                <div class="form-group">
@@ -394,6 +581,56 @@ func renderFormField(field FormField) string {
 			autofocus = " autofocus"
 		}
 
+		// Password pairing: the confirm field names the field it repeats,
+		// runtime.js compares them as the user types
+		attrs := ""
+		if field.Type == FieldPassword && field.Confirm != "" {
+			attrs = fmt.Sprintf(` data-confirms="%s"`, html.EscapeString(field.Confirm))
+		}
+
+		// Format errors are shown by runtime.js; Verify runs in Go when the
+		// user has finished editing
+		invalidMessage := ""
+		switch field.Type {
+		case FieldEmail:
+			invalidMessage = tr.T("field.invalidEmail")
+		case FieldURL:
+			invalidMessage = tr.T("field.invalidURL")
+		case FieldHost:
+			attrs += " data-host"
+			invalidMessage = tr.T("field.invalidHost")
+		}
+		if field.InputMask != "" {
+			attrs += fmt.Sprintf(` data-mask="%s"`, html.EscapeString(string(field.InputMask)))
+			switch field.InputMask {
+			case MaskDigits:
+				attrs += ` inputmode="numeric"`
+			case MaskPhone:
+				attrs += ` inputmode="tel"`
+				invalidMessage = tr.T("field.invalidPhone")
+			case MaskIPv4:
+				attrs += ` inputmode="decimal"`
+				invalidMessage = tr.T("field.invalidIPv4")
+			case MaskMAC:
+				invalidMessage = tr.T("field.invalidMAC")
+			default:
+				attrs += fmt.Sprintf(` maxlength="%d"`, len([]rune(string(field.InputMask))))
+				invalidMessage = tr.T("field.invalidFormat")
+			}
+		}
+		if field.Pattern != "" {
+			attrs += fmt.Sprintf(` data-pattern="%s"`, html.EscapeString(field.Pattern))
+			if invalidMessage == "" {
+				invalidMessage = tr.T("field.invalidFormat")
+			}
+		}
+		if invalidMessage != "" {
+			attrs += fmt.Sprintf(` data-invalid-message="%s"`, html.EscapeString(invalidMessage))
+		}
+		if field.Verify != nil {
+			attrs += " data-verify"
+		}
+
 		// Add width class if specified
 		inputClass := "form-input"
 		if field.Width != "" {
@@ -415,7 +652,7 @@ func renderFormField(field FormField) string {
 			buf.WriteString(`                    <div class="form-input-reveal">
 `)
 			buf.WriteString(fmt.Sprintf(`                        <input type="%s" id="%s" class="%s" value="%s"%s%s%s%s>
-`, inputType, html.EscapeString(field.ID), revealInputClass, html.EscapeString(defaultVal), placeholder, required, invalidates, autofocus))
+`, inputType, html.EscapeString(field.ID), revealInputClass, html.EscapeString(defaultVal), placeholder, required, invalidates, autofocus+attrs))
 			buf.WriteString(fmt.Sprintf(`                        <button type="button" class="form-reveal-toggle" data-reveal-target="%s" onclick="window.toggleReveal(this)" title="Show password" aria-label="Show password" tabindex="-1"><span class="reveal-eye">%s</span><span class="reveal-eye-off" hidden>%s</span></button>
 `, html.EscapeString(field.ID), GetIcon("eye"), GetIcon("eye-off")))
 			buf.WriteString(`                    </div>
@@ -424,13 +661,39 @@ func renderFormField(field FormField) string {
 			buf.WriteString(`                    <div class="form-input-group">
 `)
 			buf.WriteString(fmt.Sprintf(`                        <input type="%s" id="%s" class="%s" value="%s"%s%s%s%s>
-`, inputType, html.EscapeString(field.ID), inputClass, html.EscapeString(defaultVal), placeholder, required, invalidates, autofocus))
-			buf.WriteString(renderInlineButton(field.Suffix))
+`, inputType, html.EscapeString(field.ID), inputClass, html.EscapeString(defaultVal), placeholder, required, invalidates, autofocus+attrs))
+			suffix := field.Suffix
+			if field.OnSuffix != nil {
+				withAction := *suffix
+				withAction.action = true
+				suffix = &withAction
+			}
+			buf.WriteString(renderInlineButton(suffix))
 			buf.WriteString(`                    </div>
 `)
 		default:
 			buf.WriteString(fmt.Sprintf(`                    <input type="%s" id="%s" class="%s" value="%s"%s%s%s%s>
-`, inputType, html.EscapeString(field.ID), inputClass, html.EscapeString(defaultVal), placeholder, required, invalidates, autofocus))
+`, inputType, html.EscapeString(field.ID), inputClass, html.EscapeString(defaultVal), placeholder, required, invalidates, autofocus+attrs))
+		}
+
+		if field.Type == FieldPassword && field.StrengthMeter {
+			labels, _ := json.Marshal([]string{
+				tr.T("password.weak"), tr.T("password.weak"), tr.T("password.fair"),
+				tr.T("password.good"), tr.T("password.strong"),
+			})
+			buf.WriteString(fmt.Sprintf(`                    <div class="password-strength" data-strength-for="%s" data-labels="%s">
+                        <div class="password-strength-bar"><span></span></div>
+                        <span class="password-strength-label" aria-live="polite"></span>
+                    </div>
+`, html.EscapeString(field.ID), html.EscapeString(string(labels))))
+		}
+		if field.Type == FieldPassword && field.Confirm != "" {
+			buf.WriteString(fmt.Sprintf(`                    <div class="password-mismatch" data-mismatch-for="%s" role="alert" hidden>%s</div>
+`, html.EscapeString(field.ID), html.EscapeString(tr.T("password.mismatch"))))
+		}
+		if invalidMessage != "" || field.Verify != nil {
+			buf.WriteString(fmt.Sprintf(`                    <div class="field-error" data-error-for="%s" role="alert" hidden></div>
+`, html.EscapeString(field.ID)))
 		}
 
 		buf.WriteString(`                </div>
@@ -537,6 +800,57 @@ func renderFormField(field FormField) string {
                 </div>
 `)
 
+	case FieldSlider:
+		lo, hi, step := field.sliderRange()
+		value := field.sliderValue()
+		unit := ""
+		if field.Unit != "" {
+			unit = " " + field.Unit
+		}
+		buf.WriteString(fmt.Sprintf(`                <div class="form-group">
+                    <label class="form-label" for="%s">%s</label>
+                    <div class="form-slider">
+                        <input type="range" id="%s" class="form-range" min="%s" max="%s" step="%s" value="%s" aria-valuetext="%s" data-unit="%s">
+                        <output class="form-slider-value" for="%s">%s</output>
+                    </div>
+                </div>
+`, html.EscapeString(field.ID), html.EscapeString(field.Label), html.EscapeString(field.ID),
+			formatFloat(lo), formatFloat(hi), formatFloat(step), value, html.EscapeString(value+unit), html.EscapeString(field.Unit),
+			html.EscapeString(field.ID), html.EscapeString(value+unit)))
+
+	case FieldColor:
+		// The text input holds the value; the swatch opens the system's
+		// color picker and is kept in sync by runtime.js
+		value := ""
+		if field.Default != nil {
+			value = fmt.Sprintf("%v", field.Default)
+		}
+		swatch := "#000000"
+		if c, ok := NormalizeColor(value); ok {
+			value, swatch = c, c
+		}
+		required := ""
+		if field.Required {
+			required = " required"
+		}
+		buf.WriteString(fmt.Sprintf(`                <div class="form-group">
+                    <label class="form-label" for="%s">%s</label>
+                    <div class="form-color">
+                        <input type="color" class="form-color-swatch" value="%s" data-color-for="%s" aria-label="%s">
+                        <input type="text" id="%s" class="form-input form-input-medium" value="%s" placeholder="#rrggbb" spellcheck="false" data-color data-invalid-message="%s"%s>
+                    </div>
+                    <div class="field-error" data-error-for="%s" role="alert" hidden></div>
+                </div>
+`, html.EscapeString(field.ID), html.EscapeString(field.Label), swatch, html.EscapeString(field.ID), html.EscapeString(field.Label),
+			html.EscapeString(field.ID), html.EscapeString(value), html.EscapeString(tr.T("field.invalidColor")), required,
+			html.EscapeString(field.ID)))
+
+	case FieldGroup:
+		buf.WriteString(renderFieldGroup(field, tr))
+
+	case FieldFileDrop:
+		buf.WriteString(renderFileDrop(field, tr))
+
 	case FieldInfo:
 		// FieldInfo renders as an alert/info box (read-only, no input)
 		alertType := field.AlertType
@@ -553,30 +867,97 @@ func renderFormField(field FormField) string {
 		icon := GetIcon(string(alertType))
 		escapedMsg := html.EscapeString(message)
 		formattedMsg := strings.ReplaceAll(escapedMsg, "\n", "<br>")
-		buf.WriteString(fmt.Sprintf(`                <div class="summary-alert summary-alert-%s form-field-info">
+		buf.WriteString(fmt.Sprintf(`                <div class="summary-alert summary-alert-%s form-field-info" role="%s">
                     <span class="summary-alert-icon">%s</span>
                     <span class="summary-alert-text">%s</span>
                 </div>
-`, alertType, icon, formattedMsg))
+`, alertType, alertRole(alertType), icon, formattedMsg))
 	}
 
 	return buf.String()
 }
 
-// renderProgress renders a progress bar.
-func renderProgress() string {
+// alertRole returns the ARIA role of an alert box: screen readers interrupt
+// for errors and warnings and wait for a pause for the others.
+func alertRole(alertType AlertType) string {
+	if alertType == AlertError || alertType == AlertWarning {
+		return "alert"
+	}
+	return "status"
+}
+
+// progressTimeRow is the elapsed/remaining time and speed row of a
+// progress page shown with WithProgressTime.
+const progressTimeRow = `                <div class="progress-time">
+                    <span class="progress-elapsed"></span>
+                    <svg class="progress-sparkline" viewBox="0 0 100 20" preserveAspectRatio="none" aria-hidden="true"><polyline points=""/></svg>
+                    <span class="progress-speed"></span>
+                    <span class="progress-remaining"></span>
+                </div>
+`
+
+// renderProgress renders a progress bar, optionally with a time/speed row.
+func renderProgress(cfg ProgressConfig) string {
+	timeRow := ""
+	if cfg.ShowTime {
+		timeRow = progressTimeRow
+	}
 	return `            <div class="progress-container">
-                <div class="progress-bar-wrapper">
+                <div class="progress-bar-wrapper" role="progressbar" aria-valuemin="0" aria-valuemax="100" aria-valuenow="0">
                     <div class="progress-bar" style="width: 0%"></div>
                 </div>
                 <p class="progress-status">Starting...</p>
-            </div>
+` + timeRow + `            </div>
 `
 }
 
-// renderLogView renders a live log/console view.
-func renderLogView() string {
+// renderMultiProgress renders an overall progress bar followed by one bar per name.
+func renderMultiProgress(cfg MultiProgressConfig, tr translator) string {
+	var buf bytes.Buffer
+	buf.WriteString(fmt.Sprintf(`            <div class="progress-container multiprogress-container">
+                <div class="multiprogress-overall">
+                    <div class="multiprogress-label"><span class="multiprogress-name">%s</span></div>
+                    <div class="progress-bar-wrapper" role="progressbar" aria-valuemin="0" aria-valuemax="100" aria-valuenow="0">
+                        <div class="progress-bar" id="multiprogress-overall-bar" style="width: 0%%"></div>
+                    </div>
+                    <p class="progress-status" id="multiprogress-status"></p>
+                </div>
+`, html.EscapeString(tr.T("progress.overall"))))
+	if cfg.ShowTime {
+		buf.WriteString(progressTimeRow)
+	}
+	buf.WriteString(`                <div class="multiprogress-list" id="multiprogress-list">
+`)
+	for _, name := range cfg.Bars {
+		buf.WriteString(renderMultiProgressBar(name))
+	}
+	buf.WriteString(`                </div>
+            </div>
+`)
+	return buf.String()
+}
+
+// renderMultiProgressBar renders a single named bar of a multi-progress page.
+func renderMultiProgressBar(name string) string {
+	escaped := html.EscapeString(name)
+	return fmt.Sprintf(`                    <div class="multiprogress-item" data-name="%s">
+                        <div class="multiprogress-label"><span class="multiprogress-name">%s</span><span class="multiprogress-item-status"></span></div>
+                        <div class="progress-bar-wrapper">
+                            <div class="progress-bar" style="width: 0%%"></div>
+                        </div>
+                    </div>
+`, escaped, escaped)
+}
+
+// renderLogView renders a live log/console view with a filter toolbar.
+// Filtering happens entirely in runtime.js; Copy/Save live in the ButtonBar.
+func renderLogView(tr translator) string {
 	return `            <div class="log-container">
+                <div class="log-toolbar">
+                    <input type="text" class="form-input log-filter" id="log-filter" placeholder="` + html.EscapeString(tr.T("log.filter")) + `" aria-label="` + html.EscapeString(tr.T("log.filter")) + `">
+                    <button type="button" class="btn btn-default log-severity" data-severity="log-warning" aria-pressed="false">` + html.EscapeString(tr.T("log.warnings")) + `</button>
+                    <button type="button" class="btn btn-default log-severity" data-severity="log-error" aria-pressed="false">` + html.EscapeString(tr.T("log.errors")) + `</button>
+                </div>
                 <div class="log-content" id="log-content"></div>
                 <div class="log-status" id="log-status"></div>
             </div>
@@ -595,24 +976,244 @@ func renderFileListView() string {
 
 // renderReviewView renders a text review/viewer.
 // Copy/Save buttons are rendered in the ButtonBar, not here.
-func renderReviewView(cfg ReviewConfig) string {
+// With a Language, each line is a .review-line; runtime.js joins them for Copy
+// and the line numbers are CSS counters, so they are neither copied nor selected.
+func renderReviewView(cfg ReviewConfig, tr translator) string {
 	var buf bytes.Buffer
 	buf.WriteString(`            <div class="review-container">
 `)
-	if cfg.Subtitle != "" {
-		buf.WriteString(fmt.Sprintf(`                <div class="review-subtitle">%s</div>
+	buf.WriteString(renderFindBar(tr))
+	if cfg.Language == "" {
+		if cfg.Subtitle != "" {
+			buf.WriteString(fmt.Sprintf(`                <div class="review-subtitle">%s</div>
 `, html.EscapeString(cfg.Subtitle)))
-	}
-	buf.WriteString(fmt.Sprintf(`                <div class="review-content">%s</div>
+		}
+		buf.WriteString(fmt.Sprintf(`                <div class="review-content">%s</div>
             </div>
 `, html.EscapeString(cfg.Content)))
+		return buf.String()
+	}
+
+	if cfg.lines > 0 {
+		// Lines are loaded in chunks by runtime.js; a spacer sized for all
+		// lines gives the scrollbar its range. Lines don't wrap, so they all
+		// have the same height.
+		buf.WriteString(fmt.Sprintf(`                <div class="review-toolbar">
+                    <div class="review-subtitle">%s</div>
+                </div>
+                <div class="review-content review-code review-nowrap" data-language="%s">
+                    <div class="review-virtual" data-lines="%d" data-chunk="%d">
+                        <div class="review-spacer"></div>
+                        <div class="review-window"></div>
+                    </div>
+                </div>
+            </div>
+`, html.EscapeString(cfg.Subtitle), html.EscapeString(cfg.Language), cfg.lines, reviewChunkLines))
+		return buf.String()
+	}
+
+	buf.WriteString(fmt.Sprintf(`                <div class="review-toolbar">
+                    <div class="review-subtitle">%s</div>
+                    <button type="button" class="btn btn-default review-wrap" aria-pressed="true">%s</button>
+                </div>
+                <div class="review-content review-code" data-language="%s">`,
+		html.EscapeString(cfg.Subtitle), html.EscapeString(tr.T("review.wordWrap")), html.EscapeString(cfg.Language)))
+	for _, line := range splitLines(cfg.Content) {
+		buf.WriteString(`<span class="review-line">` + highlightLine(cfg.Language, line) + `</span>`)
+	}
+	buf.WriteString(`</div>
+            </div>
+`)
 	return buf.String()
 }
 
+// renderFindBar renders the find bar of license and review pages, shown by
+// runtime.js on Ctrl+F. The match count is formatted by runtime.js from the
+// data attributes.
+func renderFindBar(tr translator) string {
+	return fmt.Sprintf(`                <div class="find-bar" role="search" hidden>
+                    <input type="search" class="form-input find-input" placeholder="%s" aria-label="%s" autocomplete="off" spellcheck="false">
+                    <span class="find-count" aria-live="polite" data-format="%s" data-none="%s"></span>
+                    <button type="button" class="btn btn-default btn-icon find-prev" title="%s" aria-label="%s"><svg width="16" height="16" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" aria-hidden="true"><path d="m18 15-6-6-6 6"/></svg></button>
+                    <button type="button" class="btn btn-default btn-icon find-next" title="%s" aria-label="%s"><svg width="16" height="16" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" aria-hidden="true"><path d="m6 9 6 6 6-6"/></svg></button>
+                    <button type="button" class="btn btn-default btn-icon find-close" title="%s" aria-label="%s"><svg width="16" height="16" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" aria-hidden="true"><path d="M18 6 6 18"/><path d="m6 6 12 12"/></svg></button>
+                </div>
+`,
+		html.EscapeString(tr.T("find.placeholder")), html.EscapeString(tr.T("find.placeholder")),
+		html.EscapeString(tr.T("find.count")), html.EscapeString(tr.T("find.noMatches")),
+		html.EscapeString(tr.T("find.previous")), html.EscapeString(tr.T("find.previous")),
+		html.EscapeString(tr.T("find.next")), html.EscapeString(tr.T("find.next")),
+		html.EscapeString(tr.T("button.close")), html.EscapeString(tr.T("button.close")))
+}
+
+// renderDiff renders the changes of a DiffConfig as a table of lines, in one
+// column or side by side. Collapsed unchanged lines get a single row.
+func renderDiff(cfg DiffConfig, tr translator) string {
+	var buf bytes.Buffer
+	lines := diffLines(cfg.Before, cfg.After)
+	lang := cfg.language()
+
+	added, removed := 0, 0
+	for _, line := range lines {
+		switch line.Op {
+		case '+':
+			added++
+		case '-':
+			removed++
+		}
+	}
+
+	label := cfg.BeforeLabel
+	if cfg.AfterLabel != "" && cfg.AfterLabel != cfg.BeforeLabel {
+		if label != "" {
+			label += " → "
+		}
+		label += cfg.AfterLabel
+	}
+	buf.WriteString(fmt.Sprintf(`            <div class="diff-view">
+                <div class="diff-header">
+                    <span class="diff-label">%s</span>
+                    <span class="diff-stats"><span class="diff-stat-add">+%d</span> <span class="diff-stat-del">−%d</span></span>
+                </div>
+`, html.EscapeString(label), added, removed))
+
+	if added == 0 && removed == 0 {
+		buf.WriteString(fmt.Sprintf(`                <div class="diff-content"><p class="diff-empty">%s</p></div>
+            </div>
+`, html.EscapeString(tr.T("diff.noChanges"))))
+		return buf.String()
+	}
+
+	layout, columns := "diff-unified", 3
+	if cfg.SideBySide {
+		layout, columns = "diff-split", 4
+	}
+	buf.WriteString(fmt.Sprintf(`                <div class="diff-content">
+                    <table class="diff-table %s">
+`, layout))
+
+	context := cfg.Context
+	if context == 0 {
+		context = 3
+	}
+	visible := visibleDiffLines(lines, context)
+	for i := 0; i < len(lines); {
+		if !visible[i] {
+			n := 0
+			for i < len(lines) && !visible[i] {
+				n++
+				i++
+			}
+			buf.WriteString(fmt.Sprintf(`                        <tr class="diff-skip"><td colspan="%d">%s</td></tr>
+`, columns, html.EscapeString(tr.TF("diff.unchanged", n))))
+			continue
+		}
+		if !cfg.SideBySide || lines[i].Op == '=' {
+			buf.WriteString(renderDiffRow(lines[i], lang, cfg.SideBySide))
+			i++
+			continue
+		}
+
+		// Side by side, removed lines are paired with the added lines that
+		// follow them
+		var del, add []diffLine
+		for i < len(lines) && visible[i] && lines[i].Op == '-' {
+			del = append(del, lines[i])
+			i++
+		}
+		for i < len(lines) && visible[i] && lines[i].Op == '+' {
+			add = append(add, lines[i])
+			i++
+		}
+		for k := 0; k < max(len(del), len(add)); k++ {
+			buf.WriteString(`                        <tr class="diff-line">`)
+			if k < len(del) {
+				buf.WriteString(renderDiffCells(del[k], del[k].Old, lang))
+			} else {
+				buf.WriteString(`<td class="diff-num"></td><td class="diff-code diff-blank"></td>`)
+			}
+			if k < len(add) {
+				buf.WriteString(renderDiffCells(add[k], add[k].New, lang))
+			} else {
+				buf.WriteString(`<td class="diff-num"></td><td class="diff-code diff-blank"></td>`)
+			}
+			buf.WriteString("</tr>\n")
+		}
+	}
+
+	buf.WriteString(`                    </table>
+                </div>
+            </div>
+`)
+	return buf.String()
+}
+
+// renderDiffRow renders an unchanged line, or any line of a unified diff.
+func renderDiffRow(line diffLine, lang string, sideBySide bool) string {
+	if sideBySide {
+		return `                        <tr class="diff-line">` + renderDiffCells(line, line.Old, lang) + renderDiffCells(line, line.New, lang) + "</tr>\n"
+	}
+	return fmt.Sprintf(`                        <tr class="diff-line %s"><td class="diff-num">%s</td><td class="diff-num">%s</td><td class="diff-code"><span class="diff-marker" aria-hidden="true">%c</span>%s</td></tr>
+`, diffClass(line.Op), diffNumber(line.Old), diffNumber(line.New), diffMarker(line.Op), highlightLine(lang, line.Text))
+}
+
+// renderDiffCells renders the line number and code cells of one side of a
+// side-by-side row.
+func renderDiffCells(line diffLine, num int, lang string) string {
+	return fmt.Sprintf(`<td class="diff-num">%s</td><td class="diff-code %s"><span class="diff-marker" aria-hidden="true">%c</span>%s</td>`,
+		diffNumber(num), diffClass(line.Op), diffMarker(line.Op), highlightLine(lang, line.Text))
+}
+
+// diffClass returns the row class of a diff operation.
+func diffClass(op byte) string {
+	switch op {
+	case '+':
+		return "diff-add"
+	case '-':
+		return "diff-del"
+	}
+	return "diff-same"
+}
+
+// diffMarker returns the marker shown before a line.
+func diffMarker(op byte) rune {
+	switch op {
+	case '+':
+		return '+'
+	case '-':
+		return '−'
+	}
+	return ' '
+}
+
+// diffNumber formats a line number, blank for 0.
+func diffNumber(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
 // renderWelcomeView renders a welcome page with optional logo and language selector.
-func renderWelcomeView(cfg WelcomeConfig) string {
+func renderWelcomeView(cfg WelcomeConfig, tr translator) string {
 	var buf bytes.Buffer
 
+	if len(cfg.Background) > 0 {
+		buf.WriteString(`            <div class="welcome-background" aria-hidden="true">
+`)
+		buf.WriteString(renderThemedImages(cfg.Background, cfg.BackgroundDark, "welcome-background-image"))
+		buf.WriteString(`            </div>
+`)
+	}
+	if len(cfg.Banner) > 0 {
+		buf.WriteString(`            <div class="welcome-layout">
+                <div class="welcome-banner" aria-hidden="true">
+`)
+		buf.WriteString(renderThemedImages(cfg.Banner, cfg.BannerDark, "welcome-banner-image"))
+		buf.WriteString(`                </div>
+`)
+	}
+
 	buf.WriteString(`            <div class="welcome-container">
 `)
 	// Logo
@@ -657,17 +1258,14 @@ func renderWelcomeView(cfg WelcomeConfig) string {
 	// Language selector
 	if cfg.LanguageSelector {
 		buf.WriteString(`                <div class="welcome-language">
-                    <label class="form-label" for="language-select">` + T("welcome.languageLabel") + `</label>
+                    <label class="form-label" for="language-select">` + tr.T("welcome.languageLabel") + `</label>
                     <div class="select-wrapper">
                         <select id="language-select" class="form-input" onchange="window.changeLanguage(this.value)">
 `)
 		// Render language options (backend provides full list)
-		langMu.RLock()
-		currLang := currentLanguage
-		langMu.RUnlock()
-		for _, lang := range GetAvailableLanguages() {
+		for _, lang := range tr.languages() {
 			selected := ""
-			if lang.Code == currLang {
+			if lang.Code == tr.lang {
 				selected = " selected"
 			}
 			buf.WriteString(fmt.Sprintf(`                            <option value="%s"%s>%s</option>
@@ -682,11 +1280,40 @@ func renderWelcomeView(cfg WelcomeConfig) string {
 
 	buf.WriteString(`            </div>
 `)
+	if len(cfg.Banner) > 0 {
+		buf.WriteString(`            </div>
+`)
+	}
 	return buf.String()
 }
 
+// renderThemedImages renders an image with an optional dark-mode variant;
+// style.css shows the one matching the current theme, so theme changes
+// don't need a re-render.
+func renderThemedImages(light, dark []byte, class string) string {
+	if len(dark) == 0 {
+		return fmt.Sprintf(`                <img class="%s" src="%s" alt="">
+`, class, imageDataURI(light))
+	}
+	return fmt.Sprintf(`                <img class="%s theme-light-only" src="%s" alt="">
+                <img class="%s theme-dark-only" src="%s" alt="">
+`, class, imageDataURI(light), class, imageDataURI(dark))
+}
+
+// imageDataURI returns a data URI for SVG, JPEG or PNG image data.
+func imageDataURI(data []byte) string {
+	mime := "image/png"
+	switch {
+	case bytes.HasPrefix(data, []byte("<svg")), bytes.HasPrefix(data, []byte("<?xml")):
+		mime = "image/svg+xml"
+	case bytes.HasPrefix(data, []byte("\xff\xd8\xff")):
+		mime = "image/jpeg"
+	}
+	return "data:" + mime + ";base64," + encodeBase64(data)
+}
+
 // renderLicenseView renders a license agreement page.
-func renderLicenseView(cfg LicenseConfig) string {
+func renderLicenseView(cfg LicenseConfig, tr translator) string {
 	var buf bytes.Buffer
 
 	// Top label
@@ -695,12 +1322,19 @@ func renderLicenseView(cfg LicenseConfig) string {
 `, html.EscapeString(cfg.Label)))
 	}
 
-	// License content in a bordered scrollable area
-	buf.WriteString(fmt.Sprintf(`            <div class="license-content">%s</div>
+	// License content in a bordered scrollable area, searchable with Ctrl+F
+	buf.WriteString(renderFindBar(tr))
+	if cfg.RequireScroll {
+		buf.WriteString(fmt.Sprintf(`            <div class="license-content" data-require-scroll tabindex="0">%s</div>
+            <p class="license-scroll-hint">%s</p>
+`, html.EscapeString(cfg.Content), html.EscapeString(tr.T("license.scrollToEnd"))))
+	} else {
+		buf.WriteString(fmt.Sprintf(`            <div class="license-content">%s</div>
 `, html.EscapeString(cfg.Content)))
+	}
 
 	// Bottom instruction label (button text is embedded in translation)
-	instruction := T("license.instruction")
+	instruction := tr.T("license.instruction")
 	if instruction != "" {
 		buf.WriteString(fmt.Sprintf(`            <p class="license-instruction">%s</p>
 `, html.EscapeString(instruction)))
@@ -726,7 +1360,7 @@ func renderConfirmCheckboxView(cfg ConfirmCheckboxConfig) string {
 		icon := GetIcon("warning")
 		escapedWarn := html.EscapeString(cfg.WarningMessage)
 		formattedWarn := strings.ReplaceAll(escapedWarn, "\n", "<br>")
-		buf.WriteString(fmt.Sprintf(`            <div class="summary-alert summary-alert-warning">
+		buf.WriteString(fmt.Sprintf(`            <div class="summary-alert summary-alert-warning" role="alert">
                 <span class="summary-alert-icon">%s</span>
                 <span class="summary-alert-text">%s</span>
             </div>
@@ -763,7 +1397,7 @@ func renderConfirmTextView(cfg ConfirmTextConfig) string {
 		icon := GetIcon("warning")
 		escapedWarn := html.EscapeString(cfg.WarningMessage)
 		formattedWarn := strings.ReplaceAll(escapedWarn, "\n", "<br>")
-		buf.WriteString(fmt.Sprintf(`            <div class="summary-alert summary-alert-warning">
+		buf.WriteString(fmt.Sprintf(`            <div class="summary-alert summary-alert-warning" role="alert">
                 <span class="summary-alert-icon">%s</span>
                 <span class="summary-alert-text">%s</span>
             </div>
@@ -794,11 +1428,128 @@ func renderConfirmTextView(cfg ConfirmTextConfig) string {
 	return buf.String()
 }
 
+// renderAccordion renders collapsible sections using <details>/<summary>, so
+// expanding and collapsing works without script. Exclusive mode is handled in runtime.js.
+func renderAccordion(cfg Accordion, tr translator) string {
+	var buf bytes.Buffer
+	exclusive := ""
+	if cfg.Exclusive {
+		exclusive = ` data-exclusive="true"`
+	}
+	buf.WriteString(fmt.Sprintf(`            <div class="accordion"%s>
+`, exclusive))
+	for _, section := range cfg.Sections {
+		open := ""
+		if section.Open {
+			open = " open"
+		}
+		body, _ := renderContent(section.Content, tr)
+		buf.WriteString(fmt.Sprintf(`                <details class="accordion-section"%s>
+                    <summary class="accordion-title">%s</summary>
+                    <div class="accordion-body">
+%s                    </div>
+                </details>
+`, open, html.EscapeString(section.Title), body))
+	}
+	buf.WriteString(`            </div>
+`)
+	return buf.String()
+}
+
+// renderSettings renders a settings page: a tab strip and a panel of form
+// fields per tab. The panels share one form, so the values of hidden tabs are
+// submitted too. Switching tabs and tracking changes is done in runtime.js.
+func renderSettings(cfg SettingsConfig, tr translator) string {
+	var buf bytes.Buffer
+	selected := cfg.Tab
+	if selected < 0 || selected >= len(cfg.Tabs) {
+		selected = 0
+	}
+
+	saved := ""
+	if cfg.saved != nil {
+		data, _ := json.Marshal(cfg.saved)
+		saved = ` data-saved="` + html.EscapeString(string(data)) + `"`
+	}
+	// The alert container stays :empty without an alert, so it takes no space
+	alert := ""
+	if cfg.alert != "" {
+		alert = "\n" + renderFormField(FormField{Type: FieldInfo, Label: cfg.alert, AlertType: AlertError}, tr) + "                "
+	}
+	buf.WriteString(`            <div class="settings"` + saved + `>
+                <div id="settings-alert">` + alert + `</div>
+                <div class="settings-tabs" role="tablist">
+`)
+	for i, tab := range cfg.Tabs {
+		tabIndex := "-1"
+		if i == selected {
+			tabIndex = "0"
+		}
+		buf.WriteString(fmt.Sprintf(`                    <button type="button" class="settings-tab" role="tab" id="settings-tab-%d" aria-controls="settings-panel-%d" aria-selected="%t" tabindex="%s" data-tab="%d">%s</button>
+`, i, i, i == selected, tabIndex, i, html.EscapeString(tab.Title)))
+	}
+	buf.WriteString(`                </div>
+                <form class="flow-form settings-panels">
+`)
+	for i, tab := range cfg.Tabs {
+		hidden := ""
+		if i != selected {
+			hidden = " hidden"
+		}
+		buf.WriteString(fmt.Sprintf(`                <div class="settings-panel" role="tabpanel" id="settings-panel-%d" aria-labelledby="settings-tab-%d"%s>
+`, i, i, hidden))
+		for _, field := range tab.Fields {
+			buf.WriteString(renderFormField(field, tr))
+		}
+		buf.WriteString(`                </div>
+`)
+	}
+	buf.WriteString(`                </form>
+            </div>
+`)
+	return buf.String()
+}
+
+// renderConsent renders a consent page: a notice and a checkbox per item with
+// its description and policy link. The link is outside the label, so
+// clicking it doesn't toggle the item.
+func renderConsent(cfg ConsentConfig, tr translator) string {
+	var buf bytes.Buffer
+	buf.WriteString(fmt.Sprintf(`            <p class="flow-message">%s</p>
+            <div class="consent-list">
+`, html.EscapeString(cfg.Message)))
+	for _, item := range cfg.Items {
+		checked := ""
+		if item.Default {
+			checked = " checked"
+		}
+		buf.WriteString(fmt.Sprintf(`                <div class="consent-item">
+                    <div class="form-checkbox-group">
+                        <input type="checkbox" id="%s" class="form-checkbox" aria-describedby="%s-description"%s>
+                        <label class="form-label" for="%s">%s</label>
+                    </div>
+                    <p class="consent-description" id="%s-description">%s`,
+			html.EscapeString(item.ID), html.EscapeString(item.ID), checked,
+			html.EscapeString(item.ID), html.EscapeString(tr.translate(item.Label)),
+			html.EscapeString(item.ID), html.EscapeString(tr.translate(item.Description))))
+		if item.PolicyURL != "" {
+			buf.WriteString(fmt.Sprintf(` <a class="rich-link" href="%s">%s</a>`,
+				html.EscapeString(item.PolicyURL), html.EscapeString(tr.T("consent.policy"))))
+		}
+		buf.WriteString(`</p>
+                </div>
+`)
+	}
+	buf.WriteString(`            </div>
+`)
+	return buf.String()
+}
+
 // renderSummaryView renders a summary with labeled key-value pairs and optional checkboxes.
-// Labels can contain translation keys (with \x01 prefix) which the frontend will translate.
+// Labels can contain translation keys (see TKey), translated with tr.
 // Values are rendered as literal text.
 // Items with AlertType set are rendered as alert boxes with icons.
-func renderSummaryView(cfg SummaryConfig) string {
+func renderSummaryView(cfg SummaryConfig, tr translator) string {
 	var buf bytes.Buffer
 
 	// Separate regular items from alert items
@@ -822,7 +1573,7 @@ func renderSummaryView(cfg SummaryConfig) string {
 			formattedValue := strings.ReplaceAll(escapedValue, "\n", "<br>")
 			buf.WriteString(fmt.Sprintf(`                <dt>%s</dt>
                 <dd>%s</dd>
-`, html.EscapeString(item.Label), formattedValue))
+`, html.EscapeString(tr.translate(item.Label)), formattedValue))
 		}
 		buf.WriteString(`            </dl>
 `)
@@ -833,11 +1584,11 @@ func renderSummaryView(cfg SummaryConfig) string {
 		icon := GetIcon(string(item.AlertType))
 		escapedValue := html.EscapeString(item.Value)
 		formattedValue := strings.ReplaceAll(escapedValue, "\n", "<br>")
-		buf.WriteString(fmt.Sprintf(`            <div class="summary-alert summary-alert-%s">
+		buf.WriteString(fmt.Sprintf(`            <div class="summary-alert summary-alert-%s" role="%s">
                 <span class="summary-alert-icon">%s</span>
                 <span class="summary-alert-text">%s</span>
             </div>
-`, item.AlertType, icon, formattedValue))
+`, item.AlertType, alertRole(item.AlertType), icon, formattedValue))
 	}
 
 	// Render checkboxes if any
@@ -859,7 +1610,7 @@ func renderSummaryView(cfg SummaryConfig) string {
 				icon := GetIcon("warning")
 				escapedWarn := html.EscapeString(cb.Warning)
 				formattedWarn := strings.ReplaceAll(escapedWarn, "\n", "<br>")
-				buf.WriteString(fmt.Sprintf(`                <div class="summary-alert summary-alert-warning">
+				buf.WriteString(fmt.Sprintf(`                <div class="summary-alert summary-alert-warning" role="alert">
                     <span class="summary-alert-icon">%s</span>
                     <span class="summary-alert-text">%s</span>
                 </div>
@@ -1030,35 +1781,90 @@ func renderButton(btn *Button) string {
 		}
 	}
 
-	// Icon-only button style
-	if btn.IconOnly {
-		btnClass += " btn-icon"
-	}
+	return `            ` + renderButtonElement(btn, btnClass)
+}
 
-	// Handle disabled state
-	disabled := ""
-	if !btn.Enabled {
-		btnClass += " btn-disabled"
-		disabled = " disabled"
+// renderFieldGroup renders a FieldGroup: its rows, each with a Remove button,
+// an Add button, and the template runtime.js copies for new rows.
+func renderFieldGroup(field FormField, tr translator) string {
+	var buf bytes.Buffer
+	rows := field.groupRows()
+
+	required := ""
+	if field.Required {
+		required = ` data-required="true"`
+	}
+	buf.WriteString(fmt.Sprintf(`                <fieldset class="form-group form-repeater" data-next-row="%d" data-max-rows="%d"%s>
+                    <legend class="form-label">%s</legend>
+                    <div class="repeater-rows">
+`, len(rows), field.MaxRows, required, html.EscapeString(field.Label)))
+	for i, row := range rows {
+		buf.WriteString(renderGroupRow(field, strconv.Itoa(i), row, tr))
 	}
+	buf.WriteString(`                    </div>
+                    <template class="repeater-template">
+`)
+	buf.WriteString(renderGroupRow(field, groupRowPlaceholder, nil, tr))
+	buf.WriteString(fmt.Sprintf(`                    </template>
+                    <button type="button" class="btn btn-default repeater-add" onclick="window.addGroupRow(this)">%s</button>
+                </fieldset>
+`, html.EscapeString(tr.T("group.add"))))
+	return buf.String()
+}
 
-	// Build button content
-	var content string
-	if btn.Icon != "" {
-		if btn.IconOnly {
-			// Icon only - label becomes title for accessibility
-			content = fmt.Sprintf(`<span class="btn-icon-wrap">%s</span>`, btn.Icon)
-			return fmt.Sprintf(`            <button type="button" class="%s" data-button="%s" title="%s"%s>%s</button>
-`, btnClass, html.EscapeString(btn.ID), html.EscapeString(btn.Label), disabled, content)
-		}
-		// Icon + label
-		content = fmt.Sprintf(`<span class="btn-icon-wrap">%s</span><span>%s</span>`, btn.Icon, html.EscapeString(btn.Label))
-	} else {
-		content = html.EscapeString(btn.Label)
+// renderGroupRow renders one row of a FieldGroup.
+func renderGroupRow(field FormField, row string, values map[string]any, tr translator) string {
+	var buf bytes.Buffer
+	buf.WriteString(`                        <div class="repeater-row">
+                            <div class="repeater-fields">
+`)
+	for _, sub := range field.rowFields(row, values) {
+		buf.WriteString(renderFormField(sub, tr))
 	}
+	remove := html.EscapeString(tr.T("group.remove"))
+	buf.WriteString(fmt.Sprintf(`                            </div>
+                            <button type="button" class="btn btn-default btn-icon repeater-remove" onclick="window.removeGroupRow(this)" title="%s" aria-label="%s">%s</button>
+                        </div>
+`, remove, remove, GetIcon("trash-2")))
+	return buf.String()
+}
 
-	return fmt.Sprintf(`            <button type="button" class="%s" data-button="%s"%s>%s</button>
-`, btnClass, html.EscapeString(btn.ID), disabled, content)
+// renderFileDrop renders a FieldFileDrop: an area to drop files on, with a
+// Browse button, the list of added files, and a hidden textarea holding their
+// paths one per line (text inputs drop line breaks), which runtime.js keeps in
+// sync with the list.
+func renderFileDrop(field FormField, tr translator) string {
+	prompt := tr.T("fileDrop.prompt")
+	multiple := ""
+	if field.Multiple {
+		prompt = tr.T("fileDrop.promptMultiple")
+		multiple = " data-multiple"
+	}
+	var hints []string
+	if len(field.Extensions) > 0 {
+		hints = append(hints, tr.TF("fileDrop.types", strings.Join(field.Extensions, ", ")))
+	}
+	hints = append(hints, tr.TF("fileDrop.maxSize", formatBytes(tr.lang, field.dropMaxSize())))
+
+	id := html.EscapeString(field.ID)
+	return fmt.Sprintf(`                <div class="form-group">
+                    <span class="form-label" id="%s-label">%s</span>
+                    <div class="file-drop" data-drop-for="%s" data-extensions="%s" data-max-size="%d"%s data-wrong-type="%s" data-too-large="%s">
+                        <span class="file-drop-icon">%s</span>
+                        <span class="file-drop-text">%s</span>
+                        <button type="button" class="btn btn-default file-drop-browse" aria-labelledby="%s-label %s-browse" aria-describedby="%s-hint" id="%s-browse">%s</button>
+                        <span class="file-drop-hint" id="%s-hint">%s</span>
+                    </div>
+                    <ul class="file-drop-list" data-list-for="%s" data-remove-label="%s" data-remove-icon="%s"></ul>
+                    <textarea id="%s" hidden>%s</textarea>
+                    <div class="field-error" data-error-for="%s" role="alert" hidden></div>
+                </div>
+`, id, html.EscapeString(field.Label), id, html.EscapeString(strings.ToLower(strings.Join(field.Extensions, ","))), field.dropMaxSize(), multiple,
+		html.EscapeString(tr.T("fileDrop.wrongType")), html.EscapeString(tr.T("fileDrop.tooLarge")),
+		GetIcon("upload"), html.EscapeString(prompt),
+		id, id, id, id, html.EscapeString(tr.T("fileDrop.browse")),
+		id, html.EscapeString(strings.Join(hints, " · ")),
+		id, html.EscapeString(tr.T("group.remove")), html.EscapeString(GetIcon("trash-2")), id, html.EscapeString(strings.Join(field.dropPaths(), "\n")), id)
 }
 
 // renderInlineButton renders a button for use inside form-input-group.
@@ -1074,32 +1880,57 @@ func renderInlineButton(btn *Button) string {
 		btnClass = "btn btn-primary"
 	}
 
+	return `                        ` + renderButtonElement(btn, btnClass)
+}
+
+// renderButtonElement renders the <button> element shared by footer and
+// inline buttons: icon, label, tooltip, badge and loading state.
+func renderButtonElement(btn *Button, btnClass string) string {
 	// Icon-only button style
 	if btn.IconOnly {
 		btnClass += " btn-icon"
 	}
 
-	disabled := ""
-	if !btn.Enabled {
+	// Handle disabled state
+	attrs := ""
+	if !btn.Enabled || btn.Loading {
 		btnClass += " btn-disabled"
-		disabled = " disabled"
+		attrs += " disabled"
+	}
+	if btn.Loading {
+		btnClass += " btn-loading"
+		attrs += ` aria-busy="true"`
+	}
+	if btn.OnClick != nil || btn.action {
+		attrs += " data-action"
+	}
+
+	// Icon-only buttons use the label as title for accessibility
+	title := btn.Tooltip
+	if title == "" && btn.IconOnly && btn.Icon != "" {
+		title = btn.Label
+	}
+	if title != "" {
+		attrs += fmt.Sprintf(` title="%s"`, html.EscapeString(title))
 	}
 
 	// Build button content
 	var content string
-	if btn.Icon != "" {
-		if btn.IconOnly {
-			// Icon only - label becomes title for accessibility
-			content = fmt.Sprintf(`<span class="btn-icon-wrap">%s</span>`, btn.Icon)
-			return fmt.Sprintf(`                        <button type="button" class="%s" data-button="%s" title="%s"%s>%s</button>
-`, btnClass, html.EscapeString(btn.ID), html.EscapeString(btn.Label), disabled, content)
-		}
-		// Icon + label
-		content = fmt.Sprintf(`<span class="btn-icon-wrap">%s</span><span>%s</span>`, btn.Icon, html.EscapeString(btn.Label))
-	} else {
-		content = html.EscapeString(btn.Label)
+	if btn.Loading {
+		content = `<span class="btn-spinner" aria-hidden="true"></span>`
+	}
+	switch {
+	case btn.Icon != "" && btn.IconOnly:
+		content += fmt.Sprintf(`<span class="btn-icon-wrap">%s</span>`, btn.Icon)
+	case btn.Icon != "":
+		content += fmt.Sprintf(`<span class="btn-icon-wrap">%s</span><span>%s</span>`, btn.Icon, html.EscapeString(btn.Label))
+	default:
+		content += html.EscapeString(btn.Label)
+	}
+	if btn.Badge != "" {
+		content += fmt.Sprintf(`<span class="btn-badge">%s</span>`, html.EscapeString(btn.Badge))
 	}
 
-	return fmt.Sprintf(`                        <button type="button" class="%s" data-button="%s"%s>%s</button>
-`, btnClass, html.EscapeString(btn.ID), disabled, content)
+	return fmt.Sprintf(`<button type="button" class="%s" data-button="%s"%s>%s</button>
+`, btnClass, html.EscapeString(btn.ID), attrs, content)
 }
@@ -2,7 +2,11 @@
 // (installers, setup assistants, configuration tools, onboarding flows) using HTML rendering.
 package webflow
 
-import "github.com/crafted-tech/webframe/types"
+import (
+	"slices"
+
+	"github.com/crafted-tech/webframe/types"
+)
 
 // Navigation represents a navigation action (back, close, cancel, or custom button).
 // When a Show* method returns a Navigation value, it means the user clicked a
@@ -88,7 +92,14 @@ const (
 	FieldFile     // Browse for file
 	FieldFolder   // Browse for folder
 	FieldTextArea
-	FieldInfo // Read-only info/alert display (uses AlertType for styling)
+	FieldInfo     // Read-only info/alert display (uses AlertType for styling)
+	FieldEmail    // Text input for an email address (see ValidEmail)
+	FieldURL      // Text input for an http or https URL (see ValidURL)
+	FieldHost     // Text input for a host name or IP address with optional port, with a Test button (see CheckHost)
+	FieldSlider   // Slider from Min to Max with the current value shown next to it
+	FieldColor    // Color picker with a hex text input; returns "#rrggbb"
+	FieldGroup    // Rows of Fields the user can add and remove; returns []map[string]any
+	FieldFileDrop // Area to drop files on or browse for them; returns the path, or []string if Multiple
 )
 
 // ButtonStyle defines the visual style for a button.
@@ -108,6 +119,15 @@ type Button struct {
 	Style    ButtonStyle // Visual style (Normal, Primary, Danger)
 	Icon     string      // Optional icon SVG content (displayed before label)
 	IconOnly bool        // If true, only show icon (label used for accessibility title)
+	Tooltip  string      // Optional hover text (icon-only buttons default to Label)
+	Badge    string      // Optional small count or label shown on the button, e.g. "3" or "New"
+	Loading  bool        // Show a spinner and disable the button
+
+	// OnClick, if set, runs when the button is clicked instead of completing
+	// the page. The button shows a spinner and stays disabled until it returns.
+	OnClick func()
+
+	action bool // Clicks run a Go callback (set for FormField.OnSuffix)
 
 	// Deprecated: Use Style instead. Kept for backwards compatibility.
 	Primary bool // If true, button is styled as the primary action
@@ -152,6 +172,37 @@ func (b *Button) WithIcon(iconSVG string) *Button {
 	return &copy
 }
 
+// WithTooltip returns a copy of the button with hover text.
+func (b *Button) WithTooltip(text string) *Button {
+	copy := *b
+	copy.Tooltip = text
+	return &copy
+}
+
+// WithBadge returns a copy of the button with a small count or label.
+func (b *Button) WithBadge(badge string) *Button {
+	copy := *b
+	copy.Badge = badge
+	return &copy
+}
+
+// AsLoading returns a copy of the button showing a spinner, disabled.
+func (b *Button) AsLoading() *Button {
+	copy := *b
+	copy.Loading = true
+	return &copy
+}
+
+// WithOnClick returns a copy of the button that runs fn when clicked, without
+// leaving the page, e.g. for a "Test connection" button. The button shows a
+// spinner until fn returns; fn runs on its own goroutine and may call Flow
+// methods that update the current page, such as SetButtonBadge.
+func (b *Button) WithOnClick(fn func()) *Button {
+	copy := *b
+	copy.OnClick = fn
+	return &copy
+}
+
 // AsIconOnly returns a copy of the button that displays only the icon.
 // The label is used as the button's title/tooltip for accessibility.
 func (b *Button) AsIconOnly() *Button {
@@ -173,82 +224,82 @@ type ButtonBar struct {
 
 // WizardFirst returns a ButtonBar for the first wizard page: [Next >] [Close].
 // No back button since going back is not possible.
-// Button labels are translation keys - they will be translated by the frontend.
+// Button labels are translation keys, translated when the page is rendered.
 func WizardFirst() ButtonBar {
 	return ButtonBar{
-		Next:  NewButton(T("button.next"), ButtonNext).WithPrimary(),
-		Close: NewButton(T("button.close"), ButtonClose),
+		Next:  NewButton(TKey("button.next"), ButtonNext).WithPrimary(),
+		Close: NewButton(TKey("button.close"), ButtonClose),
 	}
 }
 
 // WizardMiddle returns a ButtonBar for middle wizard pages: [Back] [Next >] [Close].
-// Button labels are translation keys - they will be translated by the frontend.
+// Button labels are translation keys, translated when the page is rendered.
 func WizardMiddle() ButtonBar {
 	return ButtonBar{
-		Back:  NewButton(T("button.back"), ButtonBack),
-		Next:  NewButton(T("button.next"), ButtonNext).WithPrimary(),
-		Close: NewButton(T("button.close"), ButtonClose),
+		Back:  NewButton(TKey("button.back"), ButtonBack),
+		Next:  NewButton(TKey("button.next"), ButtonNext).WithPrimary(),
+		Close: NewButton(TKey("button.close"), ButtonClose),
 	}
 }
 
 // WizardInstall returns a ButtonBar for install confirmation: [Back] [Install] [Close].
-// Button labels are translation keys - they will be translated by the frontend.
+// Button labels are translation keys, translated when the page is rendered.
 func WizardInstall() ButtonBar {
 	return ButtonBar{
-		Back:  NewButton(T("button.back"), ButtonBack),
-		Next:  NewButton(T("button.install"), ButtonNext).WithPrimary(),
-		Close: NewButton(T("button.close"), ButtonClose),
+		Back:  NewButton(TKey("button.back"), ButtonBack),
+		Next:  NewButton(TKey("button.install"), ButtonNext).WithPrimary(),
+		Close: NewButton(TKey("button.close"), ButtonClose),
 	}
 }
 
 // WizardFinish returns a ButtonBar for completion: [Finish].
-// Button labels are translation keys - they will be translated by the frontend.
+// Button labels are translation keys, translated when the page is rendered.
 func WizardFinish() ButtonBar {
 	return ButtonBar{
-		Next: NewButton(T("button.finish"), ButtonClose).WithPrimary(),
+		Next: NewButton(TKey("button.finish"), ButtonClose).WithPrimary(),
 	}
 }
 
 // WizardLicense returns a ButtonBar for license agreement: [Back] [I Agree] [Close].
-// Button labels are translation keys - they will be translated by the frontend.
+// Button labels are translation keys, translated when the page is rendered.
 func WizardLicense() ButtonBar {
 	return ButtonBar{
-		Back:  NewButton(T("button.back"), ButtonBack),
-		Next:  NewButton(T("button.iAgree"), ButtonNext).WithPrimary(),
-		Close: NewButton(T("button.close"), ButtonClose),
+		Back:  NewButton(TKey("button.back"), ButtonBack),
+		Next:  NewButton(TKey("button.iAgree"), ButtonNext).WithPrimary(),
+		Close: NewButton(TKey("button.close"), ButtonClose),
 	}
 }
 
 // WizardProgress returns a ButtonBar for progress pages: [Cancel].
-// Button labels are translation keys - they will be translated by the frontend.
+// Button labels are translation keys, translated when the page is rendered.
 func WizardProgress() ButtonBar {
 	return ButtonBar{
-		Close: NewButton(T("button.cancel"), ButtonCancel),
+		Close: NewButton(TKey("button.cancel"), ButtonCancel),
 	}
 }
 
 // SimpleOK returns a ButtonBar with just [OK].
-// Button labels are translation keys - they will be translated by the frontend.
+// Button labels are translation keys, translated when the page is rendered.
 func SimpleOK() ButtonBar {
 	return ButtonBar{
-		Next: NewButton(T("button.ok"), ButtonNext).WithPrimary(),
+		Next: NewButton(TKey("button.ok"), ButtonNext).WithPrimary(),
 	}
 }
 
 // SimpleClose returns a ButtonBar with just [Close].
-// Button labels are translation keys - they will be translated by the frontend.
+// Button labels are translation keys, translated when the page is rendered.
 func SimpleClose() ButtonBar {
 	return ButtonBar{
-		Close: NewButton(T("button.close"), ButtonClose).WithPrimary(),
+		Close: NewButton(TKey("button.close"), ButtonClose).WithPrimary(),
 	}
 }
 
 // ConfirmYesNo returns a ButtonBar for confirmation: [No] [Yes].
-// Button labels are translation keys - they will be translated by the frontend.
+// Button labels are translation keys, translated when the page is rendered.
 func ConfirmYesNo() ButtonBar {
 	return ButtonBar{
-		Back: NewButton(T("button.no"), ButtonBack),
-		Next: NewButton(T("button.yes"), ButtonNext).WithPrimary(),
+		Back: NewButton(TKey("button.no"), ButtonBack),
+		Next: NewButton(TKey("button.yes"), ButtonNext).WithPrimary(),
 	}
 }
 
@@ -269,6 +320,54 @@ type FormField struct {
 	Hidden          bool      // If true, field is initially hidden (shown when form is invalidated)
 	Focus           bool      // If true, field receives focus when form is displayed
 	RevealToggle    bool      // For FieldPassword: render a show/hide eye toggle next to the input
+	StrengthMeter   bool      // For FieldPassword: show a strength bar below the input (see PasswordStrength)
+	Confirm         string    // For FieldPassword: ID of the password field this one must repeat; Next stays disabled until they match
+	Pattern         string    // For FieldText: regular expression the whole value must match; Next stays disabled until it does
+	InputMask       InputMask // For FieldText: restricts and formats what is typed, and normalizes the returned value
+	Port            int       // For FieldHost: port the Test button connects to if the value has none (0: only resolve the name)
+	Min             float64   // For FieldSlider: lowest value
+	Max             float64   // For FieldSlider: highest value (default: 100)
+	Step            float64   // For FieldSlider: increment between values (default: 1)
+	Unit            string    // For FieldSlider: shown after the value, e.g. "MB"
+
+	// Fields, for FieldGroup, are the fields of each row; Default holds the
+	// initial rows as []map[string]any. Groups can't be nested.
+	Fields  []FormField
+	MaxRows int // For FieldGroup: most rows the user can add (0: no limit)
+
+	// For FieldFileDrop: accepted extensions such as ".lic" (any if empty),
+	// the largest file accepted in bytes (default: 10 MB), and whether
+	// several files can be added. Dropped files are copied to a temporary
+	// folder that Close removes, so copy them elsewhere to keep them.
+	Extensions []string
+	MaxSize    int64
+	Multiple   bool
+
+	// Verify, if set, checks the value in Go when the user has finished
+	// editing it, e.g. VerifyEmailDomain or VerifyURLReachable. Its error is
+	// shown below the field, and Next stays disabled until it passes.
+	// FieldEmail and FieldURL values are only verified once their format is valid.
+	Verify func(value string) error
+
+	// OnSuffix, if set, runs when the Suffix button is clicked, with the
+	// current form values, instead of submitting the form. It can update
+	// fields and show an alert below the field (see FormAction).
+	OnSuffix func(form *FormAction)
+}
+
+// AutostartFieldID is the ID of the checkbox created by AutostartField.
+const AutostartFieldID = "autostart"
+
+// AutostartField returns the standard "Start <app> when I log in" checkbox.
+// Read the result with IsCheckboxChecked(resp, AutostartFieldID) and pass it
+// to installer.StepEnableAutostart.
+func AutostartField(appName string, checked bool) FormField {
+	return FormField{
+		ID:      AutostartFieldID,
+		Type:    FieldCheckbox,
+		Label:   TKey("autostart.label", appName),
+		Default: checked,
+	}
 }
 
 // Choice represents an option in a choice list.
@@ -276,6 +375,99 @@ type Choice struct {
 	Label       string // Display text for the choice
 	Description string // Optional description/subtitle
 	Value       string // Value to return when selected
+
+	// Dependency rules for multi-selection, by Value (see ResolveChoices)
+	Requires []string // Choices selected automatically along with this one
+	Excludes []string // Choices that cannot be selected together with this one
+
+	// SizeBytes is the disk space the choice needs, shown next to it in
+	// multi-selection lists along with a live total (0 = not shown)
+	SizeBytes int64
+}
+
+// choiceValue returns the value a choice is referenced by: Value, or Label if empty.
+func choiceValue(c Choice) string {
+	if c.Value != "" {
+		return c.Value
+	}
+	return c.Label
+}
+
+// ResolveChoices applies the Requires and Excludes rules of choices to a
+// selection of indices. Selected choices pull in everything they require,
+// transitively. Choices are taken in selection order, and one that (with its
+// requirements) conflicts with an earlier one is dropped. The result is sorted.
+//
+// Example:
+//
+//	choices := []webflow.Choice{
+//	    {Label: "Core", Value: "core"},
+//	    {Label: "Dev Tools", Value: "dev", Requires: []string{"core"}},
+//	    {Label: "Lite runtime", Value: "lite", Excludes: []string{"core"}},
+//	}
+//	webflow.ResolveChoices(choices, []int{1})    // [0 1]
+//	webflow.ResolveChoices(choices, []int{1, 2}) // [0 1]: lite conflicts with core
+func ResolveChoices(choices []Choice, selected []int) []int {
+	if len(selected) == 0 {
+		return selected
+	}
+	index := make(map[string]int, len(choices))
+	for i, c := range choices {
+		index[choiceValue(c)] = i
+	}
+
+	// closure returns i and everything it requires
+	closure := func(i int) []int {
+		seen := map[int]bool{i: true}
+		queue := []int{i}
+		for k := 0; k < len(queue); k++ {
+			for _, v := range choices[queue[k]].Requires {
+				if j, ok := index[v]; ok && !seen[j] {
+					seen[j] = true
+					queue = append(queue, j)
+				}
+			}
+		}
+		return queue
+	}
+	excludes := func(a, b int) bool {
+		return slices.Contains(choices[a].Excludes, choiceValue(choices[b])) ||
+			slices.Contains(choices[b].Excludes, choiceValue(choices[a]))
+	}
+
+	accepted := make(map[int]bool)
+	for _, i := range selected {
+		if i < 0 || i >= len(choices) || accepted[i] {
+			continue
+		}
+		group := closure(i)
+		conflict := false
+		for _, a := range group {
+			for b := range accepted {
+				if excludes(a, b) {
+					conflict = true
+				}
+			}
+			for _, b := range group {
+				if excludes(a, b) {
+					conflict = true
+				}
+			}
+		}
+		if conflict {
+			continue
+		}
+		for _, a := range group {
+			accepted[a] = true
+		}
+	}
+
+	result := make([]int, 0, len(accepted))
+	for i := range accepted {
+		result = append(result, i)
+	}
+	slices.Sort(result)
+	return result
 }
 
 // MultiChoice represents a multi-selection list (checkboxes).
@@ -294,6 +486,7 @@ type MenuItem struct {
 
 // Page defines a wizard page with content and navigation buttons.
 type Page struct {
+	ID         string    // Optional stable identifier for sessions and answer files (default: Title)
 	Title      string    // Main title displayed at the top
 	Subtitle   string    // Optional subtitle/description below the title
 	Icon       string    // Icon name ("info", "warning", "error", "success") or custom SVG
@@ -305,11 +498,15 @@ type Page struct {
 	Content    any       // Content: string (message), []Choice, []FormField, or ProgressConfig
 	ButtonBar  ButtonBar // Navigation buttons with fixed positions (preferred)
 	Buttons    []Button  // Deprecated: use ButtonBar instead. Legacy button array.
+
+	// OnFieldChange is called as the user edits the page's inputs (see WithOnFieldChange).
+	OnFieldChange func(form *FormAction)
 }
 
 // ProgressConfig configures a progress page.
 type ProgressConfig struct {
-	Work func(p Progress) // Function that performs the work and reports progress
+	Work     func(p Progress) // Function that performs the work and reports progress
+	ShowTime bool             // Show elapsed/remaining time and a speed sparkline
 }
 
 // PageConfig holds configuration for pages that accept PageOption.
@@ -323,6 +520,11 @@ type PageConfig struct {
 	LogoAlign      string
 	CenterTitle    bool
 	SaveDialogOpts []DialogOption
+	Language       string
+	ProgressTime   bool
+	TrayIcon       bool
+	ID             string
+	OnFieldChange  func(form *FormAction)
 }
 
 // PageOption configures a page.
@@ -375,6 +577,28 @@ func WithLogoAlign(align string) PageOption {
 	}
 }
 
+// WithProgressTime shows elapsed time, an estimate of the remaining time and a
+// small speed graph below the progress bar. The estimate is based on the
+// rate of recent Update calls, so it settles after a few seconds of work.
+// The graph shows transfer speed, with the current value, once the work
+// reports bytes with ByteProgress, and the rate of progress before that.
+func WithProgressTime() PageOption {
+	return func(c *PageConfig) {
+		c.ProgressTime = true
+	}
+}
+
+// WithTrayIcon shows an icon in the notification area while a progress page
+// runs, so users can minimize the installer and keep working: its tooltip
+// shows the title and progress, a click restores the window and its menu
+// offers Cancel. It uses the window icon (see WithWindowIcon) and is only
+// available on Windows (see platform.NewTrayIcon).
+func WithTrayIcon() PageOption {
+	return func(c *PageConfig) {
+		c.TrayIcon = true
+	}
+}
+
 // WithSaveDialogOptions sets the save file dialog options for review pages.
 // When provided, these options override the default save dialog behavior.
 func WithSaveDialogOptions(opts ...DialogOption) PageOption {
@@ -383,6 +607,14 @@ func WithSaveDialogOptions(opts ...DialogOption) PageOption {
 	}
 }
 
+// WithLanguage shows the content of a review page as code in lang, with line
+// numbers and syntax highlighting (see ReviewConfig.Language).
+func WithLanguage(lang string) PageOption {
+	return func(c *PageConfig) {
+		c.Language = lang
+	}
+}
+
 // Progress interface for updating progress during long-running operations.
 type Progress interface {
 	// Update sets the current progress percentage (0-100) and status message.
@@ -391,6 +623,41 @@ type Progress interface {
 	Cancelled() bool
 }
 
+// ByteProgress is a Progress that also takes the bytes copied or downloaded
+// so far, for the speed shown by WithProgressTime. The Progress of
+// ShowProgress implements it:
+//
+//	if bp, ok := p.(webflow.ByteProgress); ok {
+//	    bp.SetBytes(copied)
+//	}
+type ByteProgress interface {
+	Progress
+	// SetBytes sets the number of bytes processed so far.
+	SetBytes(done int64)
+}
+
+// MultiProgress provides methods for updating several independent progress bars,
+// e.g. parallel downloads. All methods are safe to call from multiple goroutines.
+type MultiProgress interface {
+	// Update sets the percentage (0-100) and status line of the named bar.
+	// Names not listed in MultiProgressConfig.Bars are ignored.
+	// The overall bar shows the average of all bars.
+	Update(name string, percent float64, status string)
+
+	// SetStatus updates the overall status text below the overall bar.
+	SetStatus(status string)
+
+	// Cancelled returns true if the user has requested cancellation.
+	Cancelled() bool
+}
+
+// MultiProgressConfig configures a page with several concurrent progress bars.
+type MultiProgressConfig struct {
+	Bars     []string              // Bar names in display order; also the names passed to Update
+	Work     func(p MultiProgress) // Function that performs the work and reports progress
+	ShowTime bool                  // Show elapsed/remaining time and a progress-rate sparkline for the overall bar
+}
+
 // LogStyle defines the visual style for log lines.
 type LogStyle int
 
@@ -404,7 +671,8 @@ const (
 
 // LogWriter provides methods for writing to a live log/console view.
 type LogWriter interface {
-	// WriteLine appends a line to the scrolling view.
+	// WriteLine appends a line to the scrolling view. ANSI color codes are
+	// removed and translated to the matching LogStyle (see ParseANSI).
 	WriteLine(text string)
 
 	// WriteLineStyled appends a styled line to the scrolling view.
@@ -468,6 +736,13 @@ type ReviewConfig struct {
 	OnCopy   func() // Callback when Copy is clicked
 	OnSave   func() // Callback when Save is clicked
 	Subtitle string // Optional subtitle (e.g., file path)
+
+	// Language, if set, shows the content as code: with line numbers, a word
+	// wrap toggle and, for LangJSON, LangYAML and LangINI, syntax
+	// highlighting. Use LangText for plain text such as logs.
+	Language string
+
+	lines int // Number of lines when they are loaded in chunks (see ShowReviewReader)
 }
 
 // WelcomeConfig configures a welcome page with optional logo and language selector.
@@ -477,6 +752,17 @@ type WelcomeConfig struct {
 	Title            string // Main title
 	Message          string // Welcome message (can include continue instructions if desired)
 	LanguageSelector bool   // Show language selector
+
+	// Banner is a tall image (PNG, JPEG or SVG) shown in a left panel, like
+	// classic installer wizards. It is cropped to fill the panel, so keep the
+	// important part centered.
+	Banner     []byte
+	BannerDark []byte // Banner for dark mode (default: Banner)
+
+	// Background is a full-bleed hero image behind the welcome content,
+	// scaled to cover the window and dimmed so the text stays readable.
+	Background     []byte
+	BackgroundDark []byte // Background for dark mode (default: Background)
 }
 
 // LicenseConfig configures a license agreement page.
@@ -484,6 +770,10 @@ type LicenseConfig struct {
 	Title   string // Page title (e.g., "License Agreement")
 	Label   string // Instruction text above the license
 	Content string // License text content
+
+	// RequireScroll keeps "I Agree" disabled until the user has scrolled to
+	// the end of the license text.
+	RequireScroll bool
 }
 
 // ConfirmCheckboxConfig configures a confirmation dialog with a required checkbox.
@@ -555,6 +845,27 @@ type SummaryConfig struct {
 	Checkboxes []SummaryCheckbox // Optional acknowledgment checkboxes
 }
 
+// AccordionSection is one collapsible section of an Accordion.
+type AccordionSection struct {
+	Title   string // Header text, always visible
+	Content any    // Body: string (message) or any other content type, e.g. SummaryConfig
+	Open    bool   // Initially expanded
+}
+
+// Accordion displays a list of collapsible sections, e.g. "Advanced options"
+// or third-party notices. Use it as content for ShowMessage.
+//
+// Example:
+//
+//	f.ShowMessage("Ready to Install", webflow.Accordion{Sections: []webflow.AccordionSection{
+//	    {Title: "Summary", Content: summary, Open: true},
+//	    {Title: "Third-party notices", Content: notices},
+//	}})
+type Accordion struct {
+	Sections  []AccordionSection
+	Exclusive bool // Opening a section collapses the others
+}
+
 // Dialog types re-exported from webframe/types for convenience.
 // These are used with OpenFile, OpenFiles, SaveFile, and PickFolder methods.
 type (
@@ -0,0 +1,119 @@
+package webflow
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// emailPattern is the format FieldEmail accepts: no spaces, one @ and a
+// domain with at least one dot.
+//
+// Must match emailPattern in runtime.js.
+var emailPattern = regexp.MustCompile(`^[^\s@]+@[^\s@.]+(\.[^\s@.]+)+$`)
+
+// verifyTimeout limits the network checks of VerifyEmailDomain and
+// VerifyURLReachable.
+const verifyTimeout = 10 * time.Second
+
+// ValidEmail reports whether s has the format of an email address, as
+// FieldEmail checks it while the user types.
+func ValidEmail(s string) bool {
+	return emailPattern.MatchString(s)
+}
+
+// ValidURL reports whether s is an absolute http or https URL, as FieldURL
+// checks it while the user types.
+//
+// Must match validURL in runtime.js.
+func ValidURL(s string) bool {
+	u, err := url.Parse(s)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Hostname() != ""
+}
+
+// VerifyEmailDomain checks that the domain of an email address can receive
+// mail: it has an MX record or, failing that, an address. Use it as
+// FormField.Verify of a FieldEmail.
+func VerifyEmailDomain(email string) error {
+	_, domain, ok := strings.Cut(email, "@")
+	if !ok || domain == "" {
+		return &LocalizedError{Key: "field.invalidEmail"}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), verifyTimeout)
+	defer cancel()
+
+	if mx, err := net.DefaultResolver.LookupMX(ctx, domain); err == nil && len(mx) > 0 {
+		return nil
+	}
+	if addrs, err := net.DefaultResolver.LookupHost(ctx, domain); err == nil && len(addrs) > 0 {
+		return nil
+	}
+	return &LocalizedError{Key: "field.emailDomainNotFound", Args: []any{domain}}
+}
+
+// VerifyURLReachable checks that a URL answers a HEAD request (or a GET, for
+// servers that don't allow HEAD) with a status below 400. Use it as
+// FormField.Verify of a FieldURL.
+func VerifyURLReachable(rawURL string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), verifyTimeout)
+	defer cancel()
+
+	status, err := requestStatus(ctx, http.MethodHead, rawURL)
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented) {
+		status, err = requestStatus(ctx, http.MethodGet, rawURL)
+	}
+	if err != nil {
+		return &LocalizedError{Key: "field.urlUnreachable", Args: []any{rawURL}}
+	}
+	if status >= 400 {
+		return &LocalizedError{Key: "field.urlStatus", Args: []any{strconv.Itoa(status)}}
+	}
+	return nil
+}
+
+// requestStatus sends a request without a body and returns the status code.
+func requestStatus(ctx context.Context, method, rawURL string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+// handleFieldVerify runs the FormField.Verify of a field whose value the user
+// has finished editing and sends the page the result.
+func (f *Flow) handleFieldVerify(resp messageResponse) {
+	f.mu.Lock()
+	verify := f.fieldVerify[resp.Button]
+	f.mu.Unlock()
+	if verify == nil {
+		_, checks := f.rowActions(resp.Button)
+		verify = checks[resp.Button]
+	}
+	value, _ := resp.Data["value"].(string)
+	if verify == nil {
+		return
+	}
+
+	go func() {
+		message := ""
+		if err := verify(value); err != nil {
+			message = f.ErrorText(err)
+		}
+		f.wv.EvaluateScript(`window.fieldVerified(` + jsonString(resp.Button) + `, ` +
+			jsonString(value) + `, ` + jsonString(message) + `);`)
+	}()
+}
@@ -0,0 +1,345 @@
+// Package webflowtest provides a fake Flow for unit-testing wizard logic and
+// installer flows without a display or WebView runtime.
+//
+// FakeFlow wraps a real *webflow.Flow whose window backend is replaced by an
+// in-memory fake, so every Show* method and every helper taking a
+// *webflow.Flow (such as installer.RunSteps) works unchanged. Each page that
+// waits for the user consumes the next scripted Response; progress and log
+// pages run their work to completion without one, unless the next response
+// is CancelWork. Every page shown is recorded for assertions.
+//
+// Example:
+//
+//	func TestWizard(t *testing.T) {
+//	    f := webflowtest.New(t)
+//	    f.Respond(
+//	        webflowtest.Next(),                                    // Welcome
+//	        webflowtest.NextWith(map[string]any{"port": "8080"}),  // Settings form
+//	        webflowtest.Choose(1),                                 // Install type
+//	    )
+//	    if err := runWizard(f.Flow); err != nil {
+//	        t.Fatal(err)
+//	    }
+//	    if got := f.Titles(); !slices.Equal(got, []string{"Welcome", "Settings", "Install Type", "Installing"}) {
+//	        t.Errorf("pages = %q", got)
+//	    }
+//	}
+package webflowtest
+
+import (
+	"encoding/json"
+	"html"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/crafted-tech/webflow"
+	"github.com/crafted-tech/webframe/types"
+)
+
+// Response is a scripted user action answering one page.
+type Response struct {
+	Button string         // Button ID, e.g. webflow.ButtonNext
+	Data   map[string]any // Form data sent with the click
+	close  bool           // Simulate the window's X button instead of a click
+	work   bool           // Consumed by progress and log pages while their work runs
+}
+
+// Next clicks the Next (or OK) button.
+func Next() Response {
+	return Response{Button: webflow.ButtonNext}
+}
+
+// NextWith clicks Next with form values keyed by field ID. Checkbox values
+// are bools, other fields strings.
+func NextWith(data map[string]any) Response {
+	return Response{Button: webflow.ButtonNext, Data: data}
+}
+
+// Back clicks the Back button.
+func Back() Response {
+	return Response{Button: webflow.ButtonBack}
+}
+
+// Cancel clicks the Cancel button of a page that waits for the user. Use
+// CancelWork to cancel a running install.
+func Cancel() Response {
+	return Response{Button: webflow.ButtonCancel}
+}
+
+// CancelWork clicks the Cancel button of the next progress or log page as
+// soon as its work starts, e.g. to cancel installer.RunSteps. The work keeps
+// running until it checks Cancelled. A page that waits for the user treats
+// it as Cancel.
+func CancelWork() Response {
+	return Response{Button: webflow.ButtonCancel, work: true}
+}
+
+// Choose selects the choice at index on a ShowChoice or ShowMenu page.
+func Choose(index int) Response {
+	return Response{Button: webflow.ButtonNext, Data: map[string]any{"_selected_index": index}}
+}
+
+// Select checks the choices at indices on a ShowMultiChoice page and clicks Next.
+func Select(indices ...int) Response {
+	return Response{Button: webflow.ButtonNext, Data: map[string]any{"_selected_indices": indices}}
+}
+
+// Click clicks a custom button by ID.
+func Click(button string) Response {
+	return Response{Button: button}
+}
+
+// CloseWindow closes the window with its X button. The close is delivered to
+// the Flow's OnCloseRequest hook, which must not veto it.
+func CloseWindow() Response {
+	return Response{close: true}
+}
+
+// Page is a page the Flow displayed.
+type Page struct {
+	Title string // Page title, empty for pages without one (e.g. alerts)
+	HTML  string // Full rendered document
+}
+
+// FakeFlow is a Flow driven by scripted responses. The embedded Flow is passed
+// wherever a *webflow.Flow is expected.
+type FakeFlow struct {
+	*webflow.Flow
+	backend *fakeBackend
+}
+
+// New creates a FakeFlow. opts are applied as for webflow.New, except that the
+// language defaults to English rather than the OS language. The Flow is
+// closed when the test ends, and the test fails if a page waits for a
+// response after the script is exhausted.
+func New(t testing.TB, opts ...webflow.Option) *FakeFlow {
+	t.Helper()
+	backend := &fakeBackend{t: t, quit: make(chan struct{}, 1)}
+	// English unless opts say otherwise, so tests don't depend on the machine's locale
+	opts = append([]webflow.Option{webflow.WithInitialLanguage("en")}, opts...)
+	opts = append(opts, webflow.WithBackend(func(cfg types.Config) (types.WebFrame, error) {
+		backend.onClose = cfg.OnClose
+		return backend, nil
+	}))
+
+	flow, err := webflow.New(opts...)
+	if err != nil {
+		t.Fatalf("webflowtest: create flow: %v", err)
+	}
+	t.Cleanup(flow.Close)
+	return &FakeFlow{Flow: flow, backend: backend}
+}
+
+// Respond appends responses to the script, consumed in order.
+func (f *FakeFlow) Respond(responses ...Response) {
+	f.backend.mu.Lock()
+	defer f.backend.mu.Unlock()
+	f.backend.script = append(f.backend.script, responses...)
+}
+
+// RespondSession appends the answers of a recorded session (see
+// webflow.WithRecording) to the script, replaying the user's clicks in order.
+func (f *FakeFlow) RespondSession(s *webflow.Session) {
+	responses := make([]Response, 0, len(s.Entries))
+	for _, e := range s.Entries {
+		if e.Closed {
+			responses = append(responses, CloseWindow())
+			continue
+		}
+		responses = append(responses, Response{Button: e.Button, Data: e.Data})
+	}
+	f.Respond(responses...)
+}
+
+// Remaining returns the number of scripted responses not yet consumed.
+func (f *FakeFlow) Remaining() int {
+	f.backend.mu.Lock()
+	defer f.backend.mu.Unlock()
+	return len(f.backend.script)
+}
+
+// Pages returns every page displayed so far, in order.
+func (f *FakeFlow) Pages() []Page {
+	f.backend.mu.Lock()
+	defer f.backend.mu.Unlock()
+	return append([]Page(nil), f.backend.pages...)
+}
+
+// Titles returns the titles of the pages displayed so far.
+func (f *FakeFlow) Titles() []string {
+	pages := f.Pages()
+	titles := make([]string, len(pages))
+	for i, p := range pages {
+		titles[i] = p.Title
+	}
+	return titles
+}
+
+// Scripts returns the JavaScript the Flow evaluated, such as progress updates
+// and log lines.
+func (f *FakeFlow) Scripts() []string {
+	f.backend.mu.Lock()
+	defer f.backend.mu.Unlock()
+	return append([]string(nil), f.backend.scripts...)
+}
+
+// titleRe matches the page title heading, or the welcome page's title.
+var titleRe = regexp.MustCompile(`<h1 class="flow-title"[^>]*>([^<]*)</h1>|<h2 class="welcome-title">([^<]*)</h2>`)
+
+// workPageMarkers identify pages that end when their work finishes rather
+// than on a user action (progress, multi-progress, log and file list views).
+var workPageMarkers = []string{
+	`class="progress-container`,
+	`class="log-container"`,
+	`class="filelist-container"`,
+}
+
+// fakeBackend implements types.WebFrame in memory.
+type fakeBackend struct {
+	t       testing.TB
+	onClose func()
+	handler func(string)
+	quit    chan struct{}
+
+	mu       sync.Mutex
+	script   []Response
+	pages    []Page
+	scripts  []string
+	workPage bool // Current page runs until its work calls Quit
+	answered bool // Current page has received a response
+	lastBtn  string
+}
+
+func (b *fakeBackend) IsDarkMode() bool                         { return false }
+func (b *fakeBackend) GetHeaderBarColor() types.RGBA            { return types.RGBA{} }
+func (b *fakeBackend) GetBackdropHeaderBarColor() types.RGBA    { return types.RGBA{} }
+func (b *fakeBackend) SetFrameAppearance(types.FrameAppearance) {}
+func (b *fakeBackend) OnThemeChange(func(bool))                 {}
+func (b *fakeBackend) AddMessageHandler(handler func(string))   { b.handler = handler }
+func (b *fakeBackend) Show()                                    {}
+func (b *fakeBackend) Destroy()                                 {}
+func (b *fakeBackend) EvaluateScriptAsync(script string)        { b.EvaluateScript(script) }
+
+func (b *fakeBackend) EvaluateScript(script string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.scripts = append(b.scripts, script)
+}
+
+func (b *fakeBackend) LoadHTML(doc string) {
+	title := ""
+	if m := titleRe.FindStringSubmatch(doc); m != nil {
+		title = html.UnescapeString(m[1] + m[2])
+	}
+	work := false
+	for _, marker := range workPageMarkers {
+		if strings.Contains(doc, marker) {
+			work = true
+			break
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.pages = append(b.pages, Page{Title: title, HTML: doc})
+	b.workPage = work
+	b.answered = false
+	b.lastBtn = ""
+
+	// Work cancelled on an earlier page may have quit after its page ended
+	select {
+	case <-b.quit:
+	default:
+	}
+}
+
+func (b *fakeBackend) Quit() {
+	select {
+	case b.quit <- struct{}{}:
+	default:
+	}
+}
+
+// Run answers the current page with the next scripted response. Work pages
+// block until their work finishes, unless the next response is CancelWork.
+// Pages that already got a navigating response (and a final Flow.Run)
+// return immediately.
+func (b *fakeBackend) Run() {
+	b.mu.Lock()
+	if b.workPage {
+		if b.answered || len(b.script) == 0 || !b.script[0].work {
+			b.mu.Unlock()
+			<-b.quit
+			return
+		}
+		resp := b.script[0]
+		b.script = b.script[1:]
+		b.answered = true
+		b.lastBtn = resp.Button
+		b.mu.Unlock()
+		b.send(resp)
+		return
+	}
+
+	// Custom buttons such as "details" keep the page open and run the loop again
+	needsResponse := !b.answered || !isNavigation(b.lastBtn)
+	if !needsResponse {
+		b.mu.Unlock()
+		return
+	}
+	if len(b.script) == 0 {
+		title := ""
+		if len(b.pages) > 0 {
+			title = b.pages[len(b.pages)-1].Title
+		}
+		b.mu.Unlock()
+		if b.answered {
+			return
+		}
+		b.t.Errorf("webflowtest: no scripted response for page %q", title)
+		b.onClose()
+		return
+	}
+	resp := b.script[0]
+	b.script = b.script[1:]
+	b.answered = true
+	b.lastBtn = resp.Button
+	b.mu.Unlock()
+	b.send(resp)
+}
+
+// send delivers a response to the Flow as a button click or window close.
+func (b *fakeBackend) send(resp Response) {
+	if resp.close {
+		b.onClose()
+		return
+	}
+	msg, err := json.Marshal(map[string]any{
+		"type":   "button_click",
+		"button": resp.Button,
+		"data":   resp.Data,
+	})
+	if err != nil {
+		b.t.Errorf("webflowtest: encode response: %v", err)
+		b.onClose()
+		return
+	}
+	b.handler(string(msg))
+
+	// The handler quits the loop; drop the signal so the next page starts clean
+	select {
+	case <-b.quit:
+	default:
+	}
+}
+
+// isNavigation reports whether a button ends the page rather than acting on it.
+func isNavigation(button string) bool {
+	switch button {
+	case webflow.ButtonNext, webflow.ButtonBack, webflow.ButtonClose, webflow.ButtonCancel:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package webflowtest
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/crafted-tech/webflow"
+)
+
+func TestForm(t *testing.T) {
+	f := New(t)
+	f.Respond(NextWith(map[string]any{"port": "8080", "tls": true}))
+
+	fields := []webflow.FormField{
+		{ID: "port", Type: webflow.FieldText, Label: "Port"},
+		{ID: "tls", Type: webflow.FieldCheckbox, Label: "Use TLS"},
+	}
+	resp := f.ShowForm("Settings", fields)
+	data, ok := resp.(map[string]any)
+	if !ok {
+		t.Fatalf("ShowForm = %#v, want form data", resp)
+	}
+	if data["port"] != "8080" || data["tls"] != true {
+		t.Errorf("form data = %v", data)
+	}
+	if got := f.Titles(); !slices.Equal(got, []string{"Settings"}) {
+		t.Errorf("pages = %q", got)
+	}
+	if n := f.Remaining(); n != 0 {
+		t.Errorf("%d responses left", n)
+	}
+}
+
+func TestChoice(t *testing.T) {
+	f := New(t)
+	f.Respond(Choose(1), Back())
+
+	choices := []webflow.Choice{{Label: "Typical"}, {Label: "Custom"}}
+	if resp := f.ShowChoice("Install Type", choices); resp != 1 {
+		t.Errorf("first ShowChoice = %#v, want 1", resp)
+	}
+	if resp := f.ShowChoice("Install Type", choices); !webflow.IsBack(resp) {
+		t.Errorf("second ShowChoice = %#v, want Back", resp)
+	}
+}
+
+func TestCustomButton(t *testing.T) {
+	f := New(t)
+	f.Respond(Click("test"), Next())
+
+	bar := webflow.WizardMiddle()
+	bar.Left = webflow.NewButton("Test", "test")
+	fields := []webflow.FormField{{ID: "host", Type: webflow.FieldText, Label: "Host"}}
+
+	resp := f.ShowForm("Server", fields, webflow.WithButtonBar(bar))
+	data, ok := resp.(map[string]any)
+	if !ok || data["_button"] != "test" {
+		t.Fatalf("ShowForm = %#v, want the test button", resp)
+	}
+	if _, ok := f.ShowForm("Server", fields, webflow.WithButtonBar(bar)).(map[string]any); !ok {
+		t.Error("ShowForm after the custom button did not return form data")
+	}
+}
+
+func TestProgress(t *testing.T) {
+	f := New(t)
+
+	var reported []float64
+	resp := f.ShowProgress("Installing", func(p webflow.Progress) {
+		for _, pct := range []float64{0, 50, 100} {
+			p.Update(pct, "")
+			reported = append(reported, pct)
+		}
+	})
+	if resp != nil {
+		t.Errorf("ShowProgress = %#v, want nil", resp)
+	}
+	if !slices.Equal(reported, []float64{0, 50, 100}) {
+		t.Errorf("work reported %v", reported)
+	}
+	if got := f.Titles(); !slices.Equal(got, []string{"Installing"}) {
+		t.Errorf("pages = %q", got)
+	}
+}
+
+func TestCancelWork(t *testing.T) {
+	f := New(t)
+	f.Respond(CancelWork(), Next())
+
+	cancelled := make(chan bool, 1)
+	resp := f.ShowProgress("Installing", func(p webflow.Progress) {
+		deadline := time.Now().Add(5 * time.Second)
+		for !p.Cancelled() && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+		cancelled <- p.Cancelled()
+	})
+	if resp != webflow.Cancel {
+		t.Fatalf("ShowProgress = %#v, want Cancel", resp)
+	}
+	if !<-cancelled {
+		t.Error("work did not see the cancellation")
+	}
+
+	// The next page answers with the next response, not the work's quit
+	f.ShowMessage("Cancelled", "Setup was cancelled.")
+	if n := f.Remaining(); n != 0 {
+		t.Errorf("%d responses left", n)
+	}
+}
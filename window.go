@@ -0,0 +1,327 @@
+package webflow
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/crafted-tech/webflow/platform"
+)
+
+// Window management. Each capability is an optional interface on the webframe
+// backend; methods are no-ops (or report false) where the platform backend
+// doesn't provide it, e.g. positioning under Wayland.
+
+// windowSizer is an optional interface for resizing the window.
+// Width and height use the same specs as WithSize ("40em", "600", "80%").
+type windowSizer interface {
+	SetSize(width, height string)
+}
+
+// windowCenterer is an optional interface for centering the window on its monitor.
+type windowCenterer interface {
+	Center()
+}
+
+// windowMinimizer is an optional interface for minimizing the window.
+type windowMinimizer interface {
+	Minimize()
+}
+
+// windowRestorer is an optional interface for restoring a minimized window
+// and bringing it to the front.
+type windowRestorer interface {
+	Restore()
+}
+
+// windowTopmost is an optional interface for keeping the window above others.
+type windowTopmost interface {
+	SetAlwaysOnTop(onTop bool)
+}
+
+// windowAttention is an optional interface for flashing the taskbar button or
+// bouncing the dock icon without stealing focus.
+type windowAttention interface {
+	RequestAttention()
+}
+
+// windowPositioner is an optional interface for querying the window position.
+type windowPositioner interface {
+	GetPosition() (x, y int)
+}
+
+// windowCapturer is an optional interface for capturing the rendered page as PNG.
+type windowCapturer interface {
+	CaptureScreenshot() ([]byte, error)
+}
+
+// windowFullscreener is an optional interface for covering the whole monitor
+// without window decorations or taskbar.
+type windowFullscreener interface {
+	SetFullscreen(fullscreen bool)
+}
+
+// windowDecorator is an optional interface for hiding the native window frame.
+type windowDecorator interface {
+	SetDecorated(decorated bool)
+}
+
+// WindowMode selects how the window is presented (see WithWindowMode).
+type WindowMode int
+
+const (
+	// WindowNormal is a regular window with a title bar.
+	WindowNormal WindowMode = iota
+
+	// WindowFrameless hides the native frame for a compact window; the page
+	// header acts as the drag area.
+	WindowFrameless
+
+	// WindowFullscreen covers the whole monitor. Escape does not leave it;
+	// call SetWindowMode to return to a normal window.
+	WindowFullscreen
+
+	// WindowKiosk is fullscreen, stays above other windows and ignores close
+	// requests, reload and developer shortcuts, for OEM/OOBE-style
+	// provisioning on dedicated machines. Only the app can leave it, with
+	// SetWindowMode or Close.
+	WindowKiosk
+)
+
+// ErrNotSupported is returned when the platform backend lacks a capability
+// that has no sensible no-op, such as CaptureScreenshot.
+var ErrNotSupported = errors.New("not supported by this platform backend")
+
+// SetWindowMode switches the window between normal, frameless, fullscreen and
+// kiosk presentation, e.g. to leave kiosk mode once provisioning is done:
+//
+//	f.SetWindowMode(webflow.WindowNormal)
+//	f.ShowMessage("Done", "The device is ready.")
+func (f *Flow) SetWindowMode(mode WindowMode) {
+	f.mu.Lock()
+	f.windowMode = mode
+	f.mu.Unlock()
+	f.applyWindowMode(mode)
+	f.wv.EvaluateScript(fmt.Sprintf(`document.documentElement.setAttribute('data-window-mode', '%s')`, mode.attr()))
+}
+
+// WindowMode returns the current window presentation.
+func (f *Flow) WindowMode() WindowMode {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.windowMode
+}
+
+// applyWindowMode sets the backend's frame, fullscreen and topmost state for mode.
+func (f *Flow) applyWindowMode(mode WindowMode) {
+	fullscreen := mode == WindowFullscreen || mode == WindowKiosk
+	if w, ok := f.wv.(windowDecorator); ok {
+		w.SetDecorated(mode == WindowNormal)
+	}
+	if w, ok := f.wv.(windowFullscreener); ok {
+		w.SetFullscreen(fullscreen)
+	}
+	if w, ok := f.wv.(windowTopmost); ok {
+		w.SetAlwaysOnTop(mode == WindowKiosk)
+	}
+}
+
+// attr returns the data-window-mode value used by style.css and runtime.js.
+func (m WindowMode) attr() string {
+	switch m {
+	case WindowFrameless:
+		return "frameless"
+	case WindowFullscreen:
+		return "fullscreen"
+	case WindowKiosk:
+		return "kiosk"
+	}
+	return "normal"
+}
+
+// SetSize resizes the window. Accepts the same dimension specs as WithSize,
+// e.g. to enlarge the window for a long license page:
+//
+//	f.SetSize("60em", "45em")
+//	f.Center()
+//	f.ShowLicense(cfg)
+func (f *Flow) SetSize(width, height string) {
+	if w, ok := f.wv.(windowSizer); ok {
+		w.SetSize(width, height)
+	}
+}
+
+// Center moves the window to the center of its current monitor.
+func (f *Flow) Center() {
+	if w, ok := f.wv.(windowCenterer); ok {
+		w.Center()
+	}
+}
+
+// Minimize minimizes the window to the taskbar or dock.
+func (f *Flow) Minimize() {
+	if w, ok := f.wv.(windowMinimizer); ok {
+		w.Minimize()
+	}
+}
+
+// Restore restores the window after Minimize and brings it to the front.
+func (f *Flow) Restore() {
+	if w, ok := f.wv.(windowRestorer); ok {
+		w.Restore()
+	}
+}
+
+// SetAlwaysOnTop keeps the window above other windows while onTop is true.
+// Use it sparingly, e.g. for an uninstaller prompt that must not get lost.
+func (f *Flow) SetAlwaysOnTop(onTop bool) {
+	if w, ok := f.wv.(windowTopmost); ok {
+		w.SetAlwaysOnTop(onTop)
+	}
+}
+
+// RequestAttention flashes the taskbar button (Windows), bounces the dock icon
+// (macOS) or sets the urgency hint (Linux) when the window is not focused,
+// e.g. when a long install finishes in the background.
+func (f *Flow) RequestAttention() {
+	if w, ok := f.wv.(windowAttention); ok {
+		w.RequestAttention()
+	}
+}
+
+// GetPosition returns the window's top-left position in screen coordinates.
+// ok is false if the platform backend cannot report it.
+func (f *Flow) GetPosition() (x, y int, ok bool) {
+	if w, ok := f.wv.(windowPositioner); ok {
+		x, y = w.GetPosition()
+		return x, y, true
+	}
+	return 0, 0, false
+}
+
+// NewWindow creates a secondary window, e.g. a detached live log that stays
+// open while the main wizard continues. The window inherits this Flow's
+// configuration (theme, colors, translations, language); opts override it.
+//
+// All windows share one native event loop, which only runs while the primary
+// Flow is inside a Show* call or Run. A secondary window therefore never runs
+// a loop of its own: use the non-blocking ShowLogDetached and ShowPageDetached
+// on it rather than the blocking Show* methods. Closing the primary Flow
+// closes its secondary windows.
+//
+// Example:
+//
+//	logWin, err := f.NewWindow(webflow.WithTitle("Setup Log"), webflow.WithSize("50em", "30em"))
+//	if err != nil {
+//	    return err
+//	}
+//	log := logWin.ShowLogDetached("Installation Log")
+//	log.WriteLine("Starting...")
+//	f.ShowProgress("Installing", work) // Both windows stay responsive
+func (f *Flow) NewWindow(opts ...Option) (*Flow, error) {
+	parentCfg := f.config
+	inherit := func(c *Config) { *c = parentCfg }
+
+	child, err := New(append([]Option{inherit}, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	lang := f.language
+	f.children = append(f.children, child)
+	f.mu.Unlock()
+
+	child.parent = f
+	child.language = lang
+	return child, nil
+}
+
+// ShowLogDetached displays a live log view and returns immediately with a
+// LogWriter that is safe to use from any goroutine. It is intended for
+// secondary windows (see NewWindow). Cancelled always reports false, since
+// the view has no Cancel button; closing the window doesn't stop the work.
+func (f *Flow) ShowLogDetached(title string) LogWriter {
+	buttonBar := ButtonBar{}
+	buttonBar.Actions = []*Button{
+		NewButton(f.T("button.copyToClipboard"), "log_copy").WithIcon(IconCopy).AsIconOnly(),
+		NewButton(f.T("button.saveToFile"), "log_save").WithIcon(IconDownload).AsIconOnly(),
+	}
+	f.ShowPageDetached(Page{
+		Title:     title,
+		Content:   LogConfig{},
+		ButtonBar: buttonBar,
+	})
+	return &logWriterImpl{flow: f}
+}
+
+// ShowPageDetached displays a page without waiting for user interaction.
+// Button clicks are not reported back; use it for informational content in
+// secondary windows, such as help text next to the main wizard.
+func (f *Flow) ShowPageDetached(page Page) {
+	if f.closed.Load() {
+		return
+	}
+
+	html := f.renderPage(page)
+	f.wv.LoadHTML(html)
+	f.wv.Show()
+}
+
+// FontScale returns the current text size factor (1 = default), including
+// the OS text size setting and the user's zoom.
+func (f *Flow) FontScale() float64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.fontScale
+}
+
+// SetFontScale sets the text size factor, e.g. from a saved preference, and
+// applies it to the current page.
+func (f *Flow) SetFontScale(scale float64) {
+	if scale <= 0 {
+		return
+	}
+	f.mu.Lock()
+	f.fontScale = scale
+	f.mu.Unlock()
+	f.wv.EvaluateScript(fmt.Sprintf(`document.documentElement.style.setProperty('--font-scale', '%.2f')`, scale))
+}
+
+// baseFontScale returns the configured text size factor times the OS text size.
+func baseFontScale(cfg Config) float64 {
+	scale := cfg.FontScale
+	if scale <= 0 {
+		scale = 1
+	}
+	return scale * platform.TextScaleFactor()
+}
+
+// CaptureScreenshot returns a PNG image of the page currently shown, without
+// the window frame. Use it to generate documentation screenshots (one Flow per
+// language, see WithInitialLanguage) or to attach what the user was seeing to
+// an error report. Returns ErrNotSupported if the platform backend can't capture.
+//
+// Example:
+//
+//	if err := runInstall(f); err != nil {
+//	    if png, shotErr := f.CaptureScreenshot(); shotErr == nil {
+//	        report.Attach("screen.png", png)
+//	    }
+//	}
+func (f *Flow) CaptureScreenshot() ([]byte, error) {
+	w, ok := f.wv.(windowCapturer)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+	return w.CaptureScreenshot()
+}
+
+// SaveScreenshot writes CaptureScreenshot's PNG image to path.
+func (f *Flow) SaveScreenshot(path string) error {
+	png, err := f.CaptureScreenshot()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, png, 0644)
+}